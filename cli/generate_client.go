@@ -17,10 +17,17 @@ type GenerateClientRequest struct {
 	InputFileName string
 	// Language is the programming language for the client to generate (the "--language" option)
 	Language string
+	// FieldCase renames the fields of your models in JS/Dart clients (the "--field-case" option)
+	FieldCase string
+	// Fake generates an in-memory client that responds with canned data instead (the "--fake" option)
+	Fake bool
 }
 
 // TemplateName translates the Language option into the name of the template we should use for generation.
 func (req GenerateClientRequest) TemplateName() string {
+	if req.Fake {
+		return req.fakeTemplateName()
+	}
 	switch strings.ToLower(req.Language) {
 	case "go", "":
 		return "client.go"
@@ -33,6 +40,18 @@ func (req GenerateClientRequest) TemplateName() string {
 	}
 }
 
+// fakeTemplateName is the TemplateName for "--fake" clients, which only support Go and JS.
+func (req GenerateClientRequest) fakeTemplateName() string {
+	switch strings.ToLower(req.Language) {
+	case "go", "":
+		return "client.fake.go"
+	case "js", "javascript", "node", "nodejs":
+		return "client.fake.js"
+	default:
+		return ""
+	}
+}
+
 // GenerateClient handles the registration and execution of the 'frodo client' CLI subcommand.
 type GenerateClient struct{}
 
@@ -49,6 +68,8 @@ func (c GenerateClient) Command() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&request.Language, "language", "go", "The file extension of the target language (e.g. 'go' or 'js')")
+	cmd.Flags().StringVar(&request.FieldCase, "field-case", "", "Rename model fields in JS/Dart clients to 'camel', 'snake', or 'pascal' case.")
+	cmd.Flags().BoolVar(&request.Fake, "fake", false, "Generate an in-memory client that responds with canned data for offline development (Go/JS only).")
 	cmd.Flags().StringVar(&request.Template, "template", "", "Path to a custom Go template file used to generate this artifact.")
 	cmd.Flags().BoolVar(&request.Force, "force", false, "Ignore file modification timestamps and generate the artifact no matter what.")
 	return cmd
@@ -60,13 +81,21 @@ func (c GenerateClient) Exec(request *GenerateClientRequest) error {
 	if templateName == "" {
 		return fmt.Errorf("unsupported client language")
 	}
+	if !generate.ValidFieldCase(request.FieldCase) {
+		return fmt.Errorf("unsupported field case: '%s'", request.FieldCase)
+	}
+	if request.FieldCase != generate.FieldCaseDefault && strings.HasSuffix(templateName, ".go") {
+		return fmt.Errorf("field case is not supported for Go clients")
+	}
 
 	if !request.Force && generate.UpToDate(request.InputFileName, templateName) {
 		log.Printf("Skipping '%s'. Artifact is up to date '%s'", request.InputFileName, templateName)
 		return nil
 	}
 
-	return c.generate(request, request.ToFileTemplate(templateName))
+	artifact := request.ToFileTemplate(templateName)
+	artifact.FieldCase = request.FieldCase
+	return c.generate(request, artifact)
 }
 
 // generate parses the input service definition file and creates an output client/gateway
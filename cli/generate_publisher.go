@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"log"
+
+	"github.com/bridgekit-io/frodo/generate"
+	"github.com/bridgekit-io/frodo/parser"
+	"github.com/spf13/cobra"
+)
+
+// GeneratePublisherRequest contains all of the CLI options used in the "frodo publisher" command.
+type GeneratePublisherRequest struct {
+	templateOption
+	// InputFileName is the service definition to parse/process (the "--service" option)
+	InputFileName string
+}
+
+// GeneratePublisher handles the registration and execution of the 'frodo publisher' CLI subcommand.
+type GeneratePublisher struct{}
+
+// Command creates the Cobra struct describing this CLI command and its options.
+func (c GeneratePublisher) Command() *cobra.Command {
+	request := &GeneratePublisherRequest{}
+	cmd := &cobra.Command{
+		Use:   "publisher [flags] FILENAME",
+		Short: "Creates a strongly-typed publisher that triggers your service's event subscribers directly.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			request.InputFileName = args[0]
+			crapPants(c.Exec(request))
+		},
+	}
+	cmd.Flags().StringVar(&request.Template, "template", "", "Path to a custom Go template file used to generate this artifact.")
+	cmd.Flags().BoolVar(&request.Force, "force", false, "Ignore file modification timestamps and generate the artifact no matter what.")
+	return cmd
+}
+
+// Exec takes all of the parsed CLI flags and generates the target event publisher artifact.
+func (c GeneratePublisher) Exec(request *GeneratePublisherRequest) error {
+	artifact := request.ToFileTemplate("publisher.go")
+
+	if !request.Force && generate.UpToDate(request.InputFileName, artifact.Name) {
+		log.Printf("Skipping '%s'. Artifact is up to date '%s'", request.InputFileName, artifact.Name)
+		return nil
+	}
+
+	log.Printf("Parsing service definitions: %s", request.InputFileName)
+	ctx, err := parser.ParseFile(request.InputFileName)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Generating artifact '%s'", artifact.Name)
+	return generate.File(ctx, artifact)
+}
@@ -5,10 +5,11 @@ import (
 	"net/url"
 )
 
-func New() Registry {
+// New creates a registry that handles JSON out of the box. Use options like WithStrictJSON() to customize it.
+func New(options ...RegistryOption) Registry {
 	jsonEncoder := JSONEncoder{}
 	jsonDecoder := JSONDecoder{}
-	return Registry{
+	reg := Registry{
 		defaultEncoder: jsonEncoder,
 		defaultDecoder: jsonDecoder,
 		encoders:       map[string]Encoder{"application/json": jsonEncoder},
@@ -19,6 +20,30 @@ func New() Registry {
 		valueEncoders:       map[string]ValueEncoder{"application/json": jsonEncoder},
 		valueDecoders:       map[string]ValueDecoder{"application/json": jsonDecoder},
 	}
+	for _, option := range options {
+		option(&reg)
+	}
+	return reg
+}
+
+// RegistryOption customizes the encoders/decoders in the Registry that New() creates.
+type RegistryOption func(*Registry)
+
+// WithDecoder registers the decoder that handles bodies w/ the given content type, replacing the one that
+// was there. JSON is our default format, so registering an "application/json" decoder also makes it the default.
+func WithDecoder(contentType string, decoder Decoder) RegistryOption {
+	return func(reg *Registry) {
+		reg.decoders[contentType] = decoder
+		if contentType == "application/json" {
+			reg.defaultDecoder = decoder
+		}
+	}
+}
+
+// WithStrictJSON makes the registry's JSON decoder reject bodies that contain fields the target struct
+// doesn't have, failing w/ an UnknownFieldError (see JSONDecoder.Strict).
+func WithStrictJSON() RegistryOption {
+	return WithDecoder("application/json", JSONDecoder{Strict: true})
 }
 
 // Registry helps you wrangle a collection of encoders/decoders such that you can
@@ -129,6 +154,22 @@ type ValueEncoder interface {
 	EncodeValues(value any) url.Values
 }
 
+// ValueMarshaler lets a type control the exact text it uses when it appears as a single
+// path or query value. Value encoders check for this before falling back to json.Marshaler,
+// so a type whose JSON is an object (e.g. {"H":"...","W":"..."}) can still have a sane,
+// URL-friendly representation such as "home@x.com,work@x.com".
+type ValueMarshaler interface {
+	// MarshalValue returns the raw text for this value as it should appear in a path/query string.
+	MarshalValue() string
+}
+
+// ValueUnmarshaler is the decoding counterpart to ValueMarshaler. Value decoders will use
+// this to bind path/query values to your type instead of massaging the text into JSON.
+type ValueUnmarshaler interface {
+	// UnmarshalValue parses the raw path/query text produced by MarshalValue.
+	UnmarshalValue(value string) error
+}
+
 // NopDecoder satisfies the Decoder interface, but does not actually do any work.
 type NopDecoder struct{}
 
@@ -1,6 +1,9 @@
 package codec_test
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type testStruct struct {
 	String       string
@@ -24,3 +27,43 @@ type testStructTimestamp struct {
 	Created  time.Time
 	Modified time.Time
 }
+
+type testStructSearch struct {
+	Text     string
+	Range    testStructRange
+	RangePtr *testStructRange
+}
+
+type testStructTimeRange struct {
+	Since    time.Time
+	UntilPtr *time.Time
+	Filter   struct {
+		Before time.Time
+	}
+}
+
+// testStructRange marshals to a JSON object, but uses "min-max" for path/query values.
+type testStructRange struct {
+	Min int
+	Max int
+}
+
+func (r testStructRange) MarshalValue() string {
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+func (r *testStructRange) UnmarshalValue(value string) error {
+	_, err := fmt.Sscanf(value, "%d-%d", &r.Min, &r.Max)
+	return err
+}
+
+type testStructLogin struct {
+	Email string
+	PIN   int `sensitive:"true"`
+	User  testStructLoginUser
+}
+
+type testStructLoginUser struct {
+	Secret *testStructRange `sensitive:"true"`
+	Age    int
+}
@@ -0,0 +1,197 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"time"
+
+	"github.com/bridgekit-io/frodo/internal/reflection"
+)
+
+// FormatEncoder wraps a JSON Encoder/ValueEncoder so that time.Time and time.Duration values are written using
+// the formats of your choice rather than the encoding/json defaults (RFC 3339 and integer nanoseconds). This is
+// handy when you're talking to some external API that expects, say, ISO 8601 durations everywhere, but you don't
+// want to define a custom type w/ MarshalJSON() for every single field just to change the wire format.
+//
+// Leave either format nil to keep the default for that type. Types w/ their own MarshalJSON() or MarshalValue()
+// are left alone, even if they're time.Time or time.Duration under the hood.
+type FormatEncoder struct {
+	// Encoder writes request bodies. It must produce JSON since we adjust its output.
+	Encoder
+	// ValueEncoder writes path/query values.
+	ValueEncoder
+	// TimeFormat, when not nil, converts every time.Time value to the text we should send.
+	TimeFormat func(time.Time) string
+	// DurationFormat, when not nil, converts every time.Duration value to the text we should send.
+	DurationFormat func(time.Duration) string
+}
+
+// Encode writes the value's JSON using the underlying encoder, but with the time/duration values reformatted.
+func (encoder FormatEncoder) Encode(writer io.Writer, value any) error {
+	if encoder.TimeFormat == nil && encoder.DurationFormat == nil {
+		return encoder.Encoder.Encode(writer, value)
+	}
+
+	// We let the underlying encoder do the heavy lifting, so tags like `json:"foo,omitempty"` and custom
+	// marshalers work exactly like they normally would. Then we walk its output alongside the original
+	// value, so we know which JSON values came from time/duration fields.
+	buf := &bytes.Buffer{}
+	if err := encoder.Encoder.Encode(buf, value); err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(buf)
+	decoder.UseNumber()
+
+	var data any
+	if err := decoder.Decode(&data); err != nil {
+		return fmt.Errorf("format encoder: %w", err)
+	}
+	return encoder.Encoder.Encode(writer, encoder.format(reflect.ValueOf(value), data))
+}
+
+// format returns the JSON data with any values that came from a time.Time or time.Duration reformatted.
+func (encoder FormatEncoder) format(value reflect.Value, data any) any {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return data
+		}
+		value = value.Elem()
+	}
+	if text, ok := encoder.formatValue(value); ok {
+		return text
+	}
+	if implementsMarshaler(value) {
+		return data
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if object, ok := data.(map[string]any); ok {
+			encoder.formatStruct(value, object)
+		}
+	case reflect.Slice, reflect.Array:
+		if array, ok := data.([]any); ok {
+			for i := 0; i < len(array) && i < value.Len(); i++ {
+				array[i] = encoder.format(value.Index(i), array[i])
+			}
+		}
+	case reflect.Map:
+		if object, ok := data.(map[string]any); ok && value.Type().Key().Kind() == reflect.String {
+			iter := value.MapRange()
+			for iter.Next() {
+				key := iter.Key().String()
+				if entry, ok := object[key]; ok {
+					object[key] = encoder.format(iter.Value(), entry)
+				}
+			}
+		}
+	}
+	return data
+}
+
+// formatStruct reformats the struct's fields in place. Embedded structs' fields are flattened into the same
+// JSON object, so we look for their fields in the same place.
+func (encoder FormatEncoder) formatStruct(value reflect.Value, object map[string]any) {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		fieldValue := value.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" && reflection.IsStructOrPointerTo(field.Type) {
+			if fieldValue = reflect.Indirect(fieldValue); fieldValue.IsValid() {
+				encoder.formatStruct(fieldValue, object)
+			}
+			continue
+		}
+
+		key := reflection.BindingName(field)
+		if entry, ok := object[key]; ok {
+			object[key] = encoder.format(fieldValue, entry)
+		}
+	}
+}
+
+// EncodeValues writes the path/query values using the underlying value encoder, but with the time/duration
+// values reformatted.
+func (encoder FormatEncoder) EncodeValues(value any) url.Values {
+	values := encoder.ValueEncoder.EncodeValues(value)
+	if encoder.TimeFormat == nil && encoder.DurationFormat == nil {
+		return values
+	}
+	encoder.formatValues("", reflect.ValueOf(value), values)
+	return values
+}
+
+// formatValues overwrites the time/duration values using the same "Parent.Child" keys that the JSON value
+// encoder uses, so you get the same values, just formatted differently.
+func (encoder FormatEncoder) formatValues(prefix string, value reflect.Value, out url.Values) {
+	value = reflect.Indirect(value)
+	if !value.IsValid() || value.Kind() != reflect.Struct {
+		return
+	}
+
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		fieldValue := value.Field(i)
+		if reflection.IsNil(fieldValue) || !fieldValue.CanInterface() {
+			continue
+		}
+
+		var fieldKey string
+		switch {
+		case field.Anonymous:
+			fieldKey = prefix
+		case prefix == "":
+			fieldKey = reflection.BindingName(field)
+		default:
+			fieldKey = prefix + "." + reflection.BindingName(field)
+		}
+
+		switch text, ok := encoder.formatValue(reflect.Indirect(fieldValue)); {
+		case ok:
+			out.Set(fieldKey, text)
+		case reflection.IsStructOrPointerTo(fieldValue.Type()) && !implementsMarshaler(reflect.Indirect(fieldValue)):
+			encoder.formatValues(fieldKey, fieldValue, out)
+		}
+	}
+}
+
+// formatValue returns the formatted text when the value is a time.Time/time.Duration that we have a format for.
+func (encoder FormatEncoder) formatValue(value reflect.Value) (string, bool) {
+	switch {
+	case !value.IsValid() || !value.CanInterface():
+		return "", false
+	case value.Type() == timeType && encoder.TimeFormat != nil:
+		return encoder.TimeFormat(value.Interface().(time.Time)), true
+	case value.Type() == durationType && encoder.DurationFormat != nil:
+		return encoder.DurationFormat(time.Duration(value.Int())), true
+	default:
+		return "", false
+	}
+}
+
+// implementsMarshaler returns true when the value has its own JSON or path/query format, so we shouldn't mess w/ it.
+func implementsMarshaler(value reflect.Value) bool {
+	if !value.IsValid() || !value.CanInterface() {
+		return false
+	}
+	if value.CanAddr() {
+		value = value.Addr()
+	}
+	switch value.Interface().(type) {
+	case json.Marshaler, ValueMarshaler:
+		return true
+	default:
+		return false
+	}
+}
+
+// durationType is the reflection type for time.Duration, which FormatEncoder can write using your own format.
+var durationType = reflect.TypeOf(time.Duration(0))
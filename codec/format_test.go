@@ -0,0 +1,138 @@
+//go:build unit
+
+package codec_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bridgekit-io/frodo/codec"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestFormatSuite(t *testing.T) {
+	suite.Run(t, new(FormatSuite))
+}
+
+type FormatSuite struct {
+	suite.Suite
+}
+
+type formatInner struct {
+	Elapsed time.Duration
+}
+
+type FormatEmbedded struct {
+	Expires time.Time
+}
+
+type formatCustomDuration time.Duration
+
+func (d formatCustomDuration) MarshalJSON() ([]byte, error) {
+	return []byte(`"custom"`), nil
+}
+
+type formatStruct struct {
+	FormatEmbedded
+	Name      string
+	Count     int64
+	Timeout   time.Duration `json:"timeout,omitempty"`
+	Started   time.Time
+	Finished  *time.Time
+	Skipped   *time.Time
+	Inner     formatInner
+	Durations []time.Duration
+	ByName    map[string]time.Duration
+	Custom    formatCustomDuration
+}
+
+func (suite *FormatSuite) encoder(timeFormat func(time.Time) string, durationFormat func(time.Duration) string) codec.FormatEncoder {
+	return codec.FormatEncoder{
+		Encoder:        codec.JSONEncoder{},
+		ValueEncoder:   codec.JSONEncoder{},
+		TimeFormat:     timeFormat,
+		DurationFormat: durationFormat,
+	}
+}
+
+func (suite *FormatSuite) value() *formatStruct {
+	started := time.Date(2024, 11, 15, 12, 30, 0, 0, time.UTC)
+	finished := started.Add(90 * time.Minute)
+	return &formatStruct{
+		FormatEmbedded: FormatEmbedded{Expires: started.AddDate(0, 0, 1)},
+		Name:           "Dude",
+		Count:          9007199254740993,
+		Timeout:        5 * time.Second,
+		Started:        started,
+		Finished:       &finished,
+		Inner:          formatInner{Elapsed: time.Minute},
+		Durations:      []time.Duration{time.Second, 2 * time.Second},
+		ByName:         map[string]time.Duration{"walter": time.Hour},
+		Custom:         formatCustomDuration(time.Second),
+	}
+}
+
+func (suite *FormatSuite) TestEncode() {
+	encoder := suite.encoder(
+		func(t time.Time) string { return t.Format(time.DateOnly) },
+		func(d time.Duration) string { return d.String() },
+	)
+
+	buf := &bytes.Buffer{}
+	suite.Require().NoError(encoder.Encode(buf, suite.value()))
+	suite.JSONEq(`{
+		"Expires": "2024-11-16",
+		"Name": "Dude",
+		"Count": 9007199254740993,
+		"timeout": "5s",
+		"Started": "2024-11-15",
+		"Finished": "2024-11-15",
+		"Skipped": null,
+		"Inner": {"Elapsed": "1m0s"},
+		"Durations": ["1s", "2s"],
+		"ByName": {"walter": "1h0m0s"},
+		"Custom": "custom"
+	}`, buf.String())
+}
+
+func (suite *FormatSuite) TestEncode_defaults() {
+	value := suite.value()
+	expected, err := json.Marshal(value)
+	suite.Require().NoError(err)
+
+	buf := &bytes.Buffer{}
+	suite.Require().NoError(suite.encoder(nil, nil).Encode(buf, value))
+	suite.JSONEq(string(expected), buf.String(), "No formats should behave exactly like the underlying encoder")
+
+	// Only the format that you supply is changed.
+	buf.Reset()
+	suite.Require().NoError(suite.encoder(nil, func(d time.Duration) string { return d.String() }).Encode(buf, value))
+	suite.Contains(buf.String(), `"Started":"2024-11-15T12:30:00Z"`)
+	suite.Contains(buf.String(), `"timeout":"5s"`)
+}
+
+func (suite *FormatSuite) TestEncodeValues() {
+	encoder := suite.encoder(
+		func(t time.Time) string { return t.Format(time.DateOnly) },
+		func(d time.Duration) string { return d.String() },
+	)
+
+	values := encoder.EncodeValues(suite.value())
+	suite.Equal("2024-11-16", values.Get("Expires"))
+	suite.Equal("Dude", values.Get("Name"))
+	suite.Equal("5s", values.Get("timeout"))
+	suite.Equal("2024-11-15", values.Get("Started"))
+	suite.Equal("2024-11-15", values.Get("Finished"))
+	suite.False(values.Has("Skipped"))
+	suite.Equal("1m0s", values.Get("Inner.Elapsed"))
+	suite.Equal("custom", values.Get("Custom"))
+}
+
+func (suite *FormatSuite) TestEncodeValues_defaults() {
+	values := suite.encoder(nil, nil).EncodeValues(suite.value())
+	suite.Equal("5000000000", values.Get("timeout"))
+	suite.Equal("2024-11-15T12:30:00Z", values.Get("Started"))
+	suite.Equal("60000000000", values.Get("Inner.Elapsed"))
+}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/bridgekit-io/frodo/internal/reflection"
 )
@@ -86,6 +87,12 @@ func (encoder JSONEncoder) encodeValues(prefix string, rawValue any, out url.Val
 			fieldKey = prefix + "." + reflection.BindingName(field)
 		}
 
+		// If you told us exactly how this value should look in a path/query string, use that.
+		if marshal, ok := fieldValue.(ValueMarshaler); ok {
+			out.Set(fieldKey, marshal.MarshalValue())
+			continue
+		}
+
 		// We want to honor your desired JSON formats. The only tweak we make is that we strip
 		// the outer quotes if your value marshals to a JSON string. The JSON decoder will automatically
 		// wrap string-looking values in quotes, so let the value be the raw text inside it.
@@ -139,6 +146,10 @@ func (encoder JSONEncoder) encodeValues(prefix string, rawValue any, out url.Val
 // JSON marshaling rules will overlay each one onto your 'out' value.
 type JSONDecoder struct {
 	Loose bool
+	// Strict causes Decode() to reject JSON that contains fields that don't exist on the 'out' value; the
+	// error will be a 400-style fail.BadRequest. This only affects Decode(). DecodeValues() intentionally
+	// ignores keys that don't match anything because path/query values often have nothing to do with your struct.
+	Strict bool
 }
 
 // Decode simply uses standard encoding/json to populate your 'out' value w/ JSON from the reader.
@@ -146,10 +157,69 @@ func (decoder JSONDecoder) Decode(data io.Reader, out any) error {
 	if data == nil || data == http.NoBody {
 		return nil
 	}
-	if err := json.NewDecoder(data).Decode(out); err != nil {
+
+	if !decoder.Strict {
+		if err := json.NewDecoder(data).Decode(out); err != nil {
+			return fmt.Errorf("json decoder: reader error: %w", err)
+		}
+		return nil
+	}
+
+	// We hang onto the body so that if strict decoding fails, we can tell if unknown fields were the only problem.
+	body, err := io.ReadAll(data)
+	if err != nil {
 		return fmt.Errorf("json decoder: reader error: %w", err)
 	}
-	return nil
+	jsonDecoder := json.NewDecoder(bytes.NewReader(body))
+	jsonDecoder.DisallowUnknownFields()
+
+	switch err = jsonDecoder.Decode(out); {
+	case err == nil:
+		return nil
+	case decodesLoosely(body, out):
+		return UnknownFieldError{Err: err}
+	default:
+		return fmt.Errorf("json decoder: reader error: %w", err)
+	}
+}
+
+// decodesLoosely returns true when the body decodes onto a fresh copy of the 'out' value once we stop caring about
+// unknown fields. encoding/json doesn't give us a typed error for unknown fields, so this is how we know that
+// they're what made strict decoding fail without having to pick apart the error message.
+func decodesLoosely(body []byte, out any) bool {
+	value := reflect.ValueOf(out)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return false
+		}
+		if value.Kind() == reflect.Pointer && value.Elem().Kind() != reflect.Pointer && value.Elem().Kind() != reflect.Interface {
+			return json.NewDecoder(bytes.NewReader(body)).Decode(reflect.New(value.Elem().Type()).Interface()) == nil
+		}
+		value = value.Elem()
+	}
+	return false
+}
+
+// UnknownFieldError is what a Strict JSONDecoder returns when the body contains a field that the target struct
+// doesn't have. It's a 400-style error, so the API gateway responds w/ a Bad Request rather than a 500.
+type UnknownFieldError struct {
+	// Err is the underlying encoding/json error, which names the offending field.
+	Err error
+}
+
+// Error returns the underlying error's message (e.g. `json decoder: unknown field "Typo"`).
+func (err UnknownFieldError) Error() string {
+	return "json decoder: " + strings.TrimPrefix(err.Err.Error(), "json: ")
+}
+
+// StatusCode returns 400 since this is a problem w/ the caller's request.
+func (err UnknownFieldError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// Unwrap returns the underlying encoding/json error.
+func (err UnknownFieldError) Unwrap() error {
+	return err.Err
 }
 
 // DecodeValues accepts key/value mappings like "User.ID":"123" and uses JSON-style
@@ -197,7 +267,40 @@ func (decoder JSONDecoder) DecodeValues(values url.Values, out any) error {
 		// that will most naturally unmarshal to the Go type. So if the Go data type for the "baz" field
 		// is uint16 then we'd expect this to return 'jsonTypeNumber'. If "baz" were a string then
 		// we'd expect this to return 'jsonTypeString', and so on.
-		valueType := decoder.keyToJSONType(outValue, keySegments, value[0])
+		fieldType := decoder.keyToType(outValue, keySegments)
+		valueType := decoder.keyToJSONType(fieldType, value[0])
+		paramValue := value[0]
+
+		// Your type knows how to parse its own path/query representation, so let it do that and then
+		// hand the JSON version of the result to the decoder just like any other object value.
+		if fieldType != nil && reflect.PointerTo(fieldType).Implements(valueUnmarshalerType) {
+			unmarshaledJSON, err := decoder.unmarshalValueJSON(fieldType, value[0])
+			switch {
+			case err != nil && decoder.Loose:
+				continue
+			case err != nil:
+				return fmt.Errorf("json decoder: value error: '%s'='%s': %w", key, decoder.echoValue(outValue, keySegments, value[0]), err)
+			}
+			paramValue = unmarshaledJSON
+			valueType = jsonTypeObject
+		}
+
+		// Times are structs, so they'd normally go through the object-or-string guessing game. A value like "2020"
+		// would look like a number, and the "+" in a time zone offset often arrives as a space since that's how
+		// query strings encode it. Parse the time ourselves, so it always reaches the decoder as an RFC 3339 string.
+		if fieldType == timeType {
+			timeJSON, ok, err := decoder.timeValueJSON(value[0])
+			switch {
+			case err != nil && decoder.Loose:
+				continue
+			case err != nil:
+				return fmt.Errorf("json decoder: value error: '%s'='%s': %w", key, decoder.echoValue(outValue, keySegments, value[0]), err)
+			case !ok:
+				continue
+			}
+			paramValue = timeJSON
+			valueType = jsonTypeString
+		}
 
 		// We didn't find a field path with that name (e.g. the key was "name" but there was no field called "name")
 		if valueType == jsonTypeNil {
@@ -214,7 +317,7 @@ func (decoder JSONDecoder) DecodeValues(values url.Values, out any) error {
 		// Convert the parameter "foo.bar.baz=4" into {"foo":{"bar":{"baz":4}}} so that the standard
 		// JSON decoder can work its magic to apply that to 'out' properly.
 		ctx.buf.Reset()
-		decoder.writeParamJSON(ctx.buf, keySegments, value[0], valueType)
+		decoder.writeParamJSON(ctx.buf, keySegments, paramValue, valueType)
 
 		// Now that we have a close-enough JSON representation of your parameter, let the standard
 		// JSON decoder do its magic.
@@ -225,7 +328,7 @@ func (decoder JSONDecoder) DecodeValues(values url.Values, out any) error {
 			// We wrote this field successfully. Move on.
 		case err != nil:
 			// Not in "Loose" mode, so cause the entire decoding to fail.
-			return fmt.Errorf("json decoder: value error: '%s'='%s': %w", key, value[0], err)
+			return fmt.Errorf("json decoder: value error: '%s'='%s': %w", key, decoder.echoValue(outValue, keySegments, value[0]), err)
 		}
 	}
 	return nil
@@ -288,31 +391,103 @@ const (
 	jsonTypeArray  = jsonType(5)
 )
 
-// keyToJSONType looks at your parameter key (e.g. "foo.bar.baz") and your value (e.g. "12345"), and
-// indicates how we should format the value when creating binding JSON. It will use reflection to
-// traverse the Go attributes foo, then bar, then baz, and return the most appropriate JSON type
-// for the go type. For instance if "baz" is a uint16, the most appropriate jsonType is jsonTypeNumber.
-func (decoder JSONDecoder) keyToJSONType(outValue reflect.Value, key []string, value string) jsonType {
-	keyLength := len(key)
-	if keyLength < 1 {
-		return jsonTypeNil
+// keyToType looks at your parameter key (e.g. "foo.bar.baz") and uses reflection to traverse the Go
+// attributes foo, then bar, then baz, returning the (non-pointer) type of that nested "baz" field. It
+// returns nil if there is no field path with that name.
+func (decoder JSONDecoder) keyToType(outValue reflect.Value, key []string) reflect.Type {
+	if len(key) < 1 {
+		return nil
 	}
 	if outValue.Kind() != reflect.Struct {
-		return jsonTypeNil
+		return nil
+	}
+
+	actualType := reflection.FlattenPointerType(outValue.Type())
+	for i := 0; i < len(key); i++ {
+		field, ok := reflection.FindField(actualType, key[i])
+		if !ok {
+			return nil
+		}
+		actualType = reflection.FlattenPointerType(field.Type)
+	}
+	return actualType
+}
+
+// echoValue returns the raw parameter value so that we can include it in error messages. If any field along
+// the key's path is tagged `sensitive:"true"`, however, you'll get "***" instead so that we never echo back
+// things like passwords.
+func (decoder JSONDecoder) echoValue(outValue reflect.Value, key []string, value string) string {
+	if outValue.Kind() != reflect.Struct {
+		return value
 	}
 
-	// Follow the path of attributes described by the key, so if the key was "foo.bar.baz" then look up
-	// "foo" on the out value, then the "bar" attribute on that type, then the "baz" attribute on that type.
-	// Once we exit the loop, 'actualType' should be the type of that nested "baz" field, and we can
-	// determine the correct JSON type from there.
 	actualType := reflection.FlattenPointerType(outValue.Type())
-	for i := 0; i < keyLength; i++ {
+	for i := 0; i < len(key); i++ {
 		field, ok := reflection.FindField(actualType, key[i])
 		if !ok {
-			return jsonTypeNil
+			return value
+		}
+		if reflection.IsSensitive(field) {
+			return "***"
 		}
 		actualType = reflection.FlattenPointerType(field.Type)
 	}
+	return value
+}
+
+// unmarshalValueJSON feeds the raw parameter value to the field type's UnmarshalValue() and returns
+// the JSON for the resulting value so that it can be bound using the standard JSON decoding rules.
+func (decoder JSONDecoder) unmarshalValueJSON(fieldType reflect.Type, value string) (string, error) {
+	fieldValue := reflect.New(fieldType)
+	if err := fieldValue.Interface().(ValueUnmarshaler).UnmarshalValue(value); err != nil {
+		return "", err
+	}
+	fieldJSON, err := json.Marshal(fieldValue.Interface())
+	if err != nil {
+		return "", err
+	}
+	return string(fieldJSON), nil
+}
+
+// timeLayouts are the formats that we accept for time.Time values in the path/query string, in the order that we
+// try them. RFC 3339 is the format that times have in JSON, but callers often just want to supply a date.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// timeValueJSON parses a path/query value for a time.Time field, returning the quoted RFC 3339 JSON string that the
+// standard decoder knows how to unmarshal. Times w/o a time zone are UTC. The 'ok' value is false for empty values,
+// since there's no time to bind; the field keeps whatever value it already had.
+func (decoder JSONDecoder) timeValueJSON(value string) (string, bool, error) {
+	value = strings.TrimSpace(strings.Trim(value, `"`))
+	if value == "" {
+		return "", false, nil
+	}
+
+	// An unescaped "+" in a query string decodes as a space, so "15:04:05+07:00" shows up as "15:04:05 07:00".
+	candidates := []string{value}
+	if strings.Contains(value, " ") {
+		candidates = append(candidates, strings.ReplaceAll(value, " ", "+"))
+	}
+	for _, candidate := range candidates {
+		for _, layout := range timeLayouts {
+			if t, err := time.ParseInLocation(layout, candidate, time.UTC); err == nil {
+				return `"` + t.Format(time.RFC3339Nano) + `"`, true, nil
+			}
+		}
+	}
+	return "", false, fmt.Errorf("invalid time '%s': use an RFC 3339 time like '2006-01-02T15:04:05Z'", value)
+}
+
+// keyToJSONType looks at the Go type of the field your parameter key resolved to (see keyToType) and your
+// value (e.g. "12345"), and indicates how we should format the value when creating binding JSON. For instance
+// if the field is a uint16, the most appropriate jsonType is jsonTypeNumber.
+func (decoder JSONDecoder) keyToJSONType(actualType reflect.Type, value string) jsonType {
+	if actualType == nil {
+		return jsonTypeNil
+	}
 
 	// Now that we have the Go type for the field that will ultimately be populated by this parameter/value,
 	// we need to do a quick double check. The field's Go type might be a type alias for an int64 so the
@@ -413,3 +588,9 @@ type jsonBindingContext struct {
 	buf     *bytes.Buffer
 	decoder *json.Decoder
 }
+
+// timeType is the reflection type for time.Time, which we parse specially when binding path/query values.
+var timeType = reflect.TypeOf(time.Time{})
+
+// valueUnmarshalerType is the reflection type for the ValueUnmarshaler interface.
+var valueUnmarshalerType = reflect.TypeOf((*ValueUnmarshaler)(nil)).Elem()
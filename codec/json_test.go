@@ -5,12 +5,14 @@ package codec_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/bridgekit-io/frodo/codec"
+	"github.com/bridgekit-io/frodo/fail"
 	"github.com/bridgekit-io/frodo/internal/testext"
 	"github.com/stretchr/testify/suite"
 )
@@ -136,6 +138,67 @@ func (suite *JSONSuite) TestDecode_remapping() {
 	suite.Equal("The Dude", value.Name)
 }
 
+func (suite *JSONSuite) TestDecode_strict() {
+	var value testStructUser
+
+	// By default, we're lenient about fields that don't exist on the struct.
+	decoder := codec.JSONDecoder{}
+	suite.NoError(decoder.Decode(bytes.NewBufferString(`{"ID":"Frodo", "Typo":"The Dude"}`), &value))
+	suite.Equal("Frodo", value.ID)
+
+	decoder = codec.JSONDecoder{Strict: true}
+	suite.NoError(decoder.Decode(bytes.NewBufferString(`{"ID":"Frodo", "goes_by":"The Dude"}`), &value))
+	suite.Equal("The Dude", value.Name)
+
+	err := decoder.Decode(bytes.NewBufferString(`{"ID":"Frodo", "Typo":"The Dude"}`), &value)
+	suite.Require().Error(err)
+	suite.True(fail.IsBadRequest(err), "Unknown fields should result in a 400")
+	suite.Contains(err.Error(), "Typo")
+	unknownErr := codec.UnknownFieldError{}
+	suite.True(errors.As(err, &unknownErr), "Should be an UnknownFieldError")
+
+	// Other errors should be reported the same way as non-strict decoding.
+	err = decoder.Decode(bytes.NewBufferString(`{"ID":`), &value)
+	suite.Require().Error(err)
+	suite.False(fail.IsBadRequest(err))
+	suite.False(errors.As(err, &unknownErr))
+
+	err = decoder.Decode(bytes.NewBufferString(`{"ID":5, "Typo":"The Dude"}`), &value)
+	suite.Require().Error(err)
+	suite.False(errors.As(err, &unknownErr), "Unknown fields aren't the only problem")
+}
+
+// The gateway decodes onto an 'any' that holds a pointer to your request struct.
+func (suite *JSONSuite) TestDecode_strictInterface() {
+	var value any = &testStructUser{}
+
+	err := codec.JSONDecoder{Strict: true}.Decode(bytes.NewBufferString(`{"ID":"Frodo", "Typo":"The Dude"}`), &value)
+	unknownErr := codec.UnknownFieldError{}
+	suite.True(errors.As(err, &unknownErr), "Should be an UnknownFieldError")
+	suite.Equal("Frodo", value.(*testStructUser).ID)
+}
+
+func (suite *JSONSuite) TestRegistry_strictJSON() {
+	body := `{"ID":"Frodo", "Typo":"The Dude"}`
+
+	var value testStructUser
+	suite.NoError(codec.New().Decoder("application/json").Decode(bytes.NewBufferString(body), &value))
+
+	registry := codec.New(codec.WithStrictJSON())
+	suite.True(fail.IsBadRequest(registry.Decoder("application/json").Decode(bytes.NewBufferString(body), &value)))
+	suite.True(fail.IsBadRequest(registry.DefaultDecoder().Decode(bytes.NewBufferString(body), &value)))
+	suite.True(fail.IsBadRequest(registry.Decoder("text/plain").Decode(bytes.NewBufferString(body), &value)), "Should fall back to the strict default")
+}
+
+func (suite *JSONSuite) TestDecodeValues_strict() {
+	// Strict mode only applies to bodies, not path/query values.
+	decoder := codec.JSONDecoder{Strict: true}
+
+	var value testStructUser
+	suite.NoError(decoder.DecodeValues(map[string][]string{"ID": {"Frodo"}, "Typo": {"The Dude"}}, &value))
+	suite.Equal("Frodo", value.ID)
+}
+
 func (suite *JSONSuite) TestEncoder_contentType() {
 	encoder := codec.JSONEncoder{}
 	suite.Equal("application/json", encoder.ContentType())
@@ -328,3 +391,113 @@ func (suite *JSONSuite) TestDecodeEncodeValues() {
 	suite.Require().NotNil(out.InTimePtr)
 	suite.Equal(inTimePtr, *out.InTimePtr)
 }
+
+// Types that implement ValueMarshaler/ValueUnmarshaler should control their own path/query
+// representation rather than having their JSON crammed into the value.
+func (suite *JSONSuite) TestEncodeDecodeValues_valueMarshaler() {
+	values := codec.JSONEncoder{}.EncodeValues(testStructSearch{
+		Text:     "Dude",
+		Range:    testStructRange{Min: 1, Max: 10},
+		RangePtr: &testStructRange{Min: 5, Max: 7},
+	})
+	suite.Equal("Dude", values.Get("Text"))
+	suite.Equal("1-10", values.Get("Range"))
+	suite.Equal("5-7", values.Get("RangePtr"))
+
+	out := testStructSearch{}
+	suite.Require().NoError(codec.JSONDecoder{}.DecodeValues(values, &out))
+	suite.Equal("Dude", out.Text)
+	suite.Equal(testStructRange{Min: 1, Max: 10}, out.Range)
+	suite.Require().NotNil(out.RangePtr)
+	suite.Equal(testStructRange{Min: 5, Max: 7}, *out.RangePtr)
+
+	// The sample's MarshalToObject has an object-style JSON format, so make sure it's query-friendly, too.
+	values = codec.JSONEncoder{}.EncodeValues(testext.SampleUser{
+		MarshalToObject: testext.MarshalToObject{Home: "home@object.com", Work: "work@object.com"},
+	})
+	suite.Equal("home@object.com,work@object.com", values.Get("MarshalToObject"))
+}
+
+func (suite *JSONSuite) TestDecodeValues_valueUnmarshalerError() {
+	values := map[string][]string{
+		"Text":  {"Dude"},
+		"Range": {"Abide"},
+	}
+
+	out := testStructSearch{}
+	suite.Error(codec.JSONDecoder{}.DecodeValues(values, &out))
+
+	out = testStructSearch{}
+	suite.NoError(codec.JSONDecoder{Loose: true}.DecodeValues(values, &out))
+	suite.Equal("Dude", out.Text)
+	suite.Equal(testStructRange{}, out.Range)
+}
+
+func (suite *JSONSuite) TestDecodeValues_time() {
+	decode := func(values map[string][]string) (testStructTimeRange, error) {
+		out := testStructTimeRange{}
+		err := codec.JSONDecoder{}.DecodeValues(values, &out)
+		return out, err
+	}
+
+	out, err := decode(map[string][]string{
+		"Since":         {"2020-01-02T15:04:05Z"},
+		"UntilPtr":      {"2020-01-03T15:04:05.123-05:00"},
+		"Filter.Before": {`"2020-01-04T15:04:05Z"`},
+	})
+	suite.Require().NoError(err)
+	suite.Equal(time.Date(2020, time.January, 2, 15, 4, 5, 0, time.UTC), out.Since)
+	suite.Require().NotNil(out.UntilPtr)
+	suite.True(time.Date(2020, time.January, 3, 20, 4, 5, 123000000, time.UTC).Equal(*out.UntilPtr))
+	suite.Equal(time.Date(2020, time.January, 4, 15, 4, 5, 0, time.UTC), out.Filter.Before)
+
+	// An unescaped "+" in the query string arrives as a space.
+	out, err = decode(map[string][]string{"Since": {"2020-01-02T15:04:05 07:00"}})
+	suite.Require().NoError(err)
+	suite.True(time.Date(2020, time.January, 2, 8, 4, 5, 0, time.UTC).Equal(out.Since))
+
+	// Dates and times w/o a time zone are UTC.
+	out, err = decode(map[string][]string{"Since": {"2020-01-02"}, "UntilPtr": {"2020-01-03T10:00:00"}})
+	suite.Require().NoError(err)
+	suite.Equal(time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC), out.Since)
+	suite.Equal(time.Date(2020, time.January, 3, 10, 0, 0, 0, time.UTC), *out.UntilPtr)
+
+	// Empty values leave the time alone.
+	out, err = decode(map[string][]string{"Since": {""}, "UntilPtr": {""}})
+	suite.Require().NoError(err)
+	suite.True(out.Since.IsZero())
+	suite.Nil(out.UntilPtr)
+}
+
+func (suite *JSONSuite) TestDecodeValues_timeError() {
+	values := map[string][]string{"Since": {"2020"}, "Filter.Before": {"yesterday"}}
+
+	out := testStructTimeRange{}
+	err := codec.JSONDecoder{}.DecodeValues(values, &out)
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "RFC 3339")
+
+	out = testStructTimeRange{}
+	suite.NoError(codec.JSONDecoder{Loose: true}.DecodeValues(values, &out))
+	suite.True(out.Since.IsZero())
+	suite.True(out.Filter.Before.IsZero())
+}
+
+// Errors should never echo back the values of fields that are tagged as sensitive.
+func (suite *JSONSuite) TestDecodeValues_sensitive() {
+	decoder := codec.JSONDecoder{}
+
+	err := decoder.DecodeValues(map[string][]string{"PIN": {"12x34"}}, &testStructLogin{})
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "'PIN'='***'")
+	suite.NotContains(err.Error(), "12x34")
+
+	err = decoder.DecodeValues(map[string][]string{"User.Secret": {"hunter2"}}, &testStructLogin{})
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "'User.Secret'='***'")
+	suite.NotContains(err.Error(), "hunter2")
+
+	err = decoder.DecodeValues(map[string][]string{"User.Age": {"old"}}, &testStructLogin{})
+	suite.Require().Error(err)
+	suite.Contains(err.Error(), "'User.Age'='old'", "Non-sensitive values should still be echoed")
+}
@@ -0,0 +1,319 @@
+// Package awssqs provides an event broker that publishes events to an AWS SNS topic and consumes
+// them using SQS queues subscribed to that topic.
+//
+// Frodo doesn't depend on the AWS SDK directly. Instead, the broker talks to AWS through the small
+// Client interface, which you satisfy using a thin wrapper around the sns.Client and sqs.Client from
+// github.com/aws/aws-sdk-go-v2. This keeps the SDK (and its mountain of transitive dependencies) out
+// of projects that don't use AWS at all.
+//
+// Event keys such as "UserService.Created" are sent along with each message as a message attribute
+// named "Key" rather than being baked into topic/queue names. Each subscription gets its own SQS
+// queue that is subscribed to the topic with a filter policy that only matches that key. Since SNS/SQS
+// names can't contain periods, the queue names replace them with underscores:
+//
+//	SubscribeGroup("UserService.Created", "EmailService") -> "EmailService-UserService_Created"
+//	Subscribe("UserService.Created")                      -> "UserService_Created-{InstanceID}"
+//
+// Group subscriptions share a single queue, so every instance of the group competes for messages and
+// only one of them will handle each event. Non-group subscriptions (e.g. "GROUP *") get a queue that's
+// unique to this instance, so every instance receives every event; these queues are deleted when the
+// subscription is closed. If your handler returns an error, the message is not deleted, so SQS will
+// redeliver it once its visibility timeout expires.
+package awssqs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bridgekit-io/frodo/eventsource"
+	"github.com/bridgekit-io/frodo/fail"
+)
+
+// ErrNotConfigured is returned by the broker when you didn't give it an AWS client or topic.
+var ErrNotConfigured = fmt.Errorf("aws broker not configured: missing client or topic")
+
+// Client describes the handful of SNS/SQS operations that the broker needs. Implement this using the
+// sns.Client and sqs.Client from the official AWS SDK.
+type Client interface {
+	// Publish sends the payload to the SNS topic. The key must be included as a string message
+	// attribute named "Key" so that subscription filter policies can match on it.
+	Publish(ctx context.Context, topicARN string, key string, payload []byte) error
+	// CreateQueue creates the SQS queue (or looks up the existing one) with the given name and
+	// visibility timeout, returning its URL. The queue's policy must allow the topic to send to it.
+	CreateQueue(ctx context.Context, name string, visibilityTimeout time.Duration) (string, error)
+	// SubscribeQueue subscribes the queue to the topic w/ raw message delivery enabled and a filter
+	// policy that only matches messages whose "Key" attribute is the given key. It returns the ARN of
+	// the SNS subscription.
+	SubscribeQueue(ctx context.Context, topicARN string, queueURL string, key string) (string, error)
+	// Unsubscribe removes the SNS subscription created by SubscribeQueue.
+	Unsubscribe(ctx context.Context, subscriptionARN string) error
+	// ReceiveMessages long-polls the queue for up to 'waitTime', returning at most 'maxMessages' messages.
+	ReceiveMessages(ctx context.Context, queueURL string, maxMessages int, waitTime time.Duration) ([]Message, error)
+	// DeleteMessage acknowledges that a received message was handled, so SQS won't redeliver it.
+	DeleteMessage(ctx context.Context, queueURL string, receiptHandle string) error
+	// DeleteQueue permanently removes the queue.
+	DeleteQueue(ctx context.Context, queueURL string) error
+}
+
+// Message is a single SQS message received by Client.ReceiveMessages().
+type Message struct {
+	// Key is the value of the message's "Key" attribute (e.g. "UserService.Created").
+	Key string
+	// Payload is the raw body of the message.
+	Payload []byte
+	// ReceiptHandle is the SQS handle used to delete the message once it's been handled.
+	ReceiptHandle string
+	// Timestamp is when the message was originally sent.
+	Timestamp time.Time
+}
+
+// Broker creates a new event broker that publishes events to the given SNS topic and consumes them
+// using SQS queues subscribed to that topic.
+func Broker(client Client, topicARN string, options ...Option) eventsource.Broker {
+	b := broker{
+		client:            client,
+		topicARN:          topicARN,
+		instanceID:        randomID(),
+		visibilityTimeout: 30 * time.Second,
+		waitTime:          20 * time.Second,
+		maxMessages:       10,
+		errorHandler: func(err error) {
+			log.Printf("[aws broker error] %v\n", err)
+		},
+	}
+	for _, option := range options {
+		option(&b)
+	}
+	return &b
+}
+
+type broker struct {
+	client            Client
+	topicARN          string
+	queuePrefix       string
+	instanceID        string
+	visibilityTimeout time.Duration
+	waitTime          time.Duration
+	maxMessages       int
+	errorHandler      fail.ErrorHandler
+}
+
+func (b *broker) Publish(ctx context.Context, key string, payload []byte) error {
+	if b.client == nil || b.topicARN == "" {
+		return fmt.Errorf("broker publish error: %w", ErrNotConfigured)
+	}
+	if err := b.client.Publish(ctx, b.topicARN, key, payload); err != nil {
+		return fmt.Errorf("broker publish error: %w", err)
+	}
+	return nil
+}
+
+func (b *broker) Subscribe(ctx context.Context, key string, handlerFunc eventsource.EventHandlerFunc) (eventsource.Subscription, error) {
+	return b.consume(ctx, key, b.queueName(key, b.instanceID), true, handlerFunc)
+}
+
+func (b *broker) SubscribeGroup(ctx context.Context, key string, group string, handlerFunc eventsource.EventHandlerFunc) (eventsource.Subscription, error) {
+	return b.consume(ctx, key, b.queueName(group, key), false, handlerFunc)
+}
+
+// consume sets up the queue/subscription for the key and starts the goroutine that long-polls the queue. When
+// the subscription is 'ephemeral', the queue only exists for this instance, so we clean it up on Close().
+func (b *broker) consume(ctx context.Context, key string, queueName string, ephemeral bool, handlerFunc eventsource.EventHandlerFunc) (eventsource.Subscription, error) {
+	if b.client == nil || b.topicARN == "" {
+		return nil, fmt.Errorf("broker subscribe error: %w", ErrNotConfigured)
+	}
+
+	queueURL, err := b.client.CreateQueue(ctx, queueName, b.visibilityTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("broker queue error: %s: %w", queueName, err)
+	}
+	subscriptionARN, err := b.client.SubscribeQueue(ctx, b.topicARN, queueURL, key)
+	if err != nil {
+		return nil, fmt.Errorf("broker subscription error: %s: %w", queueName, err)
+	}
+
+	pollCtx, cancel := context.WithCancel(ctx)
+	sub := &subscription{
+		client:          b.client,
+		queueURL:        queueURL,
+		subscriptionARN: subscriptionARN,
+		ephemeral:       ephemeral,
+		cancel:          cancel,
+		done:            make(chan struct{}),
+	}
+	go b.poll(pollCtx, sub, handlerFunc)
+	return sub, nil
+}
+
+// poll continuously long-polls the subscription's queue until its context is canceled (either by
+// the context given to Subscribe/SubscribeGroup or by closing the subscription).
+func (b *broker) poll(ctx context.Context, sub *subscription, handlerFunc eventsource.EventHandlerFunc) {
+	defer close(sub.done)
+
+	for ctx.Err() == nil {
+		messages, err := b.client.ReceiveMessages(ctx, sub.queueURL, b.maxMessages, b.waitTime)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			b.errorHandler(fmt.Errorf("broker receive error: %s: %w", sub.queueURL, err))
+			b.backoff(ctx)
+			continue
+		}
+
+		for _, msg := range messages {
+			b.handleMessage(ctx, sub, msg, handlerFunc)
+		}
+	}
+}
+
+// handleMessage runs the handler for a single message. We only delete the message when the handler
+// succeeds. Otherwise, it becomes visible again once the visibility timeout passes, and SQS redelivers it.
+func (b *broker) handleMessage(ctx context.Context, sub *subscription, msg Message, handlerFunc eventsource.EventHandlerFunc) {
+	defer func() {
+		if recovery := recover(); recovery != nil {
+			b.errorHandler(fmt.Errorf("error handling event message '%s': %v", msg.Key, recovery))
+		}
+	}()
+
+	// Just like the other brokers, handlers start with a blank context rather than the one for the polling loop.
+	err := handlerFunc(context.Background(), &eventsource.EventMessage{
+		Timestamp: msg.Timestamp,
+		Key:       msg.Key,
+		Payload:   msg.Payload,
+	})
+	if err != nil {
+		b.errorHandler(fmt.Errorf("error handling event message '%s': %w", msg.Key, err))
+		return
+	}
+
+	if err = b.client.DeleteMessage(context.WithoutCancel(ctx), sub.queueURL, msg.ReceiptHandle); err != nil {
+		b.errorHandler(fmt.Errorf("error during ack for '%s': %w", msg.Key, err))
+	}
+}
+
+// backoff pauses the polling loop briefly after a failed receive, so we don't hammer AWS when it's having issues.
+func (b *broker) backoff(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+	}
+}
+
+// queueName builds a valid SQS queue name from the given parts. SQS only allows alphanumeric characters,
+// hyphens, and underscores, so periods (and anything else) become underscores. Names are limited to 80
+// characters, so really long names are truncated and suffixed w/ a hash to keep them unique.
+func (b *broker) queueName(parts ...string) string {
+	name := b.queuePrefix + strings.Join(parts, "-")
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+
+	const maxLength = 80
+	if len(name) <= maxLength {
+		return name
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	return fmt.Sprintf("%s-%08x", name[:maxLength-9], hash.Sum32())
+}
+
+type subscription struct {
+	client          Client
+	queueURL        string
+	subscriptionARN string
+	ephemeral       bool
+	cancel          context.CancelFunc
+	done            chan struct{}
+	closeOnce       sync.Once
+}
+
+// Close stops polling for messages, waiting for any in-progress handlers to finish. Group queues are
+// left alone since other instances are still consuming them, but per-instance queues are deleted.
+func (s *subscription) Close() (err error) {
+	s.closeOnce.Do(func() {
+		s.cancel()
+		<-s.done
+
+		if !s.ephemeral {
+			return
+		}
+		ctx := context.Background()
+		if unsubErr := s.client.Unsubscribe(ctx, s.subscriptionARN); unsubErr != nil {
+			err = fmt.Errorf("broker unsubscribe error: %w", unsubErr)
+			return
+		}
+		if deleteErr := s.client.DeleteQueue(ctx, s.queueURL); deleteErr != nil {
+			err = fmt.Errorf("broker delete queue error: %w", deleteErr)
+		}
+	})
+	return err
+}
+
+func randomID() string {
+	id := make([]byte, 4)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// Option customizes the behavior of the SNS/SQS broker.
+type Option func(b *broker)
+
+// WithQueuePrefix prepends the prefix to the names of all queues the broker creates (e.g. "prod-") so
+// that multiple environments can share an AWS account.
+func WithQueuePrefix(prefix string) Option {
+	return func(b *broker) {
+		b.queuePrefix = prefix
+	}
+}
+
+// WithInstanceID overrides the random id used to name the per-instance queues for non-group subscriptions.
+func WithInstanceID(instanceID string) Option {
+	return func(b *broker) {
+		b.instanceID = instanceID
+	}
+}
+
+// WithVisibilityTimeout sets how long a received message stays hidden from other consumers. If your handler
+// fails, this is how long until the message is redelivered. The default is 30 seconds.
+func WithVisibilityTimeout(timeout time.Duration) Option {
+	return func(b *broker) {
+		b.visibilityTimeout = timeout
+	}
+}
+
+// WithWaitTime sets how long each long-poll waits for messages to arrive. The default is 20 seconds, which
+// is the maximum that SQS supports.
+func WithWaitTime(waitTime time.Duration) Option {
+	return func(b *broker) {
+		b.waitTime = waitTime
+	}
+}
+
+// WithMaxMessages sets the maximum number of messages received in each long-poll. The default is 10, which
+// is the maximum that SQS supports.
+func WithMaxMessages(maxMessages int) Option {
+	return func(b *broker) {
+		b.maxMessages = maxMessages
+	}
+}
+
+// WithErrorHandler swaps the default error handler for this one. It is called for failures that occur
+// asynchronously while receiving/handling messages.
+func WithErrorHandler(handler fail.ErrorHandler) Option {
+	return func(b *broker) {
+		b.errorHandler = handler
+	}
+}
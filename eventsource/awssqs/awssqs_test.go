@@ -0,0 +1,396 @@
+//go:build unit
+
+package awssqs_test
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bridgekit-io/frodo/eventsource"
+	"github.com/bridgekit-io/frodo/eventsource/awssqs"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestAWSBroker(t *testing.T) {
+	suite.Run(t, new(AWSBrokerSuite))
+}
+
+type AWSBrokerSuite struct {
+	suite.Suite
+}
+
+func (suite *AWSBrokerSuite) newBroker(client *fakeClient, options ...awssqs.Option) eventsource.Broker {
+	options = append([]awssqs.Option{
+		awssqs.WithWaitTime(10 * time.Millisecond),
+		awssqs.WithErrorHandler(func(error) {}),
+	}, options...)
+	return awssqs.Broker(client, "arn:topic", options...)
+}
+
+func (suite *AWSBrokerSuite) TestNotConfigured() {
+	broker := awssqs.Broker(nil, "")
+	suite.ErrorIs(broker.Publish(context.Background(), "Foo.Bar", nil), awssqs.ErrNotConfigured)
+
+	_, err := broker.Subscribe(context.Background(), "Foo.Bar", nil)
+	suite.ErrorIs(err, awssqs.ErrNotConfigured)
+
+	_, err = broker.SubscribeGroup(context.Background(), "Foo.Bar", "Baz", nil)
+	suite.ErrorIs(err, awssqs.ErrNotConfigured)
+}
+
+func (suite *AWSBrokerSuite) TestPublish_error() {
+	client := newFakeClient()
+	client.publishErr = fmt.Errorf("no soup for you")
+	suite.Error(suite.newBroker(client).Publish(context.Background(), "Foo.Bar", []byte("Hello")))
+}
+
+func (suite *AWSBrokerSuite) TestQueueNames() {
+	client := newFakeClient()
+	broker := suite.newBroker(client, awssqs.WithInstanceID("1234"), awssqs.WithQueuePrefix("prod-"))
+
+	sub1, err := broker.SubscribeGroup(context.Background(), "UserService.Created", "Email.Service", suite.nopHandler)
+	suite.Require().NoError(err)
+	defer sub1.Close()
+
+	sub2, err := broker.Subscribe(context.Background(), "UserService.Created", suite.nopHandler)
+	suite.Require().NoError(err)
+	defer sub2.Close()
+
+	sub3, err := broker.SubscribeGroup(context.Background(), "UserService."+strings.Repeat("X", 100), "Email", suite.nopHandler)
+	suite.Require().NoError(err)
+	defer sub3.Close()
+
+	names := client.queueNames()
+	suite.Require().Len(names, 3)
+	suite.Regexp(`^prod-Email-UserService_X+-[0-9a-f]{8}$`, names[0])
+	suite.Len(names[0], 80)
+	suite.Equal("prod-Email_Service-UserService_Created", names[1])
+	suite.Equal("prod-UserService_Created-1234", names[2])
+}
+
+func (suite *AWSBrokerSuite) TestSubscribeGroup_competingConsumers() {
+	client := newFakeClient()
+	results := newResults()
+
+	// Two instances of the same service; only one of them should handle each message.
+	brokerA := suite.newBroker(client)
+	brokerB := suite.newBroker(client)
+	subA, err := brokerA.SubscribeGroup(context.Background(), "Foo.Bar", "Baz", results.handler("A"))
+	suite.Require().NoError(err)
+	defer subA.Close()
+	subB, err := brokerB.SubscribeGroup(context.Background(), "Foo.Bar", "Baz", results.handler("B"))
+	suite.Require().NoError(err)
+	defer subB.Close()
+
+	suite.Require().NoError(brokerA.Publish(context.Background(), "Foo.Bar", []byte("1")))
+	suite.Require().NoError(brokerA.Publish(context.Background(), "Foo.Bar", []byte("2")))
+	suite.Require().NoError(brokerA.Publish(context.Background(), "Foo.Nope", []byte("3")))
+
+	payloads := results.waitForPayloads(2)
+	suite.Equal([]string{"1", "2"}, payloads)
+	suite.Equal(1, client.queueCount(), "Group members should share a single queue")
+	suite.Eventually(func() bool { return client.messageCount() == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func (suite *AWSBrokerSuite) TestSubscribe_fanOut() {
+	client := newFakeClient()
+	results := newResults()
+
+	brokerA := suite.newBroker(client)
+	brokerB := suite.newBroker(client)
+	subA, err := brokerA.Subscribe(context.Background(), "Foo.Bar", results.handler("A"))
+	suite.Require().NoError(err)
+	subB, err := brokerB.Subscribe(context.Background(), "Foo.Bar", results.handler("B"))
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(brokerA.Publish(context.Background(), "Foo.Bar", []byte("1")))
+	suite.Equal([]string{"A:1", "B:1"}, results.waitForNames(2))
+	suite.Equal(2, client.queueCount(), "Each instance should get its own queue")
+
+	// Per-instance queues should be cleaned up once we're done with them.
+	suite.NoError(subA.Close())
+	suite.NoError(subB.Close())
+	suite.NoError(subB.Close(), "Closing twice should be harmless")
+	suite.Equal(0, client.queueCount())
+	suite.Equal(0, client.subscriptionCount())
+}
+
+func (suite *AWSBrokerSuite) TestSubscribe_redeliverOnFailure() {
+	client := newFakeClient()
+	results := newResults()
+
+	attempts := 0
+	broker := suite.newBroker(client, awssqs.WithVisibilityTimeout(20*time.Millisecond))
+	sub, err := broker.SubscribeGroup(context.Background(), "Foo.Bar", "Baz", func(ctx context.Context, evt *eventsource.EventMessage) error {
+		if attempts++; attempts == 1 {
+			return fmt.Errorf("try again")
+		}
+		return results.handler("A")(ctx, evt)
+	})
+	suite.Require().NoError(err)
+	defer sub.Close()
+
+	suite.Require().NoError(broker.Publish(context.Background(), "Foo.Bar", []byte("1")))
+	suite.Equal([]string{"A:1"}, results.waitForNames(1))
+	suite.Equal(2, attempts, "Failed message should be redelivered after the visibility timeout")
+}
+
+func (suite *AWSBrokerSuite) TestSubscribe_contextCanceled() {
+	client := newFakeClient()
+	results := newResults()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	broker := suite.newBroker(client, awssqs.WithWaitTime(time.Hour))
+	sub, err := broker.SubscribeGroup(ctx, "Foo.Bar", "Baz", results.handler("A"))
+	suite.Require().NoError(err)
+
+	// The long poll is an hour, so if we don't honor the cancellation, Close() will hang.
+	cancel()
+	closed := make(chan error)
+	go func() { closed <- sub.Close() }()
+
+	select {
+	case err = <-closed:
+		suite.NoError(err)
+	case <-time.After(time.Second):
+		suite.Fail("Canceling the context should stop the long-poll loop")
+	}
+}
+
+func (suite *AWSBrokerSuite) nopHandler(context.Context, *eventsource.EventMessage) error {
+	return nil
+}
+
+// results collects the messages handled by a bunch of subscribers.
+type results struct {
+	mutex sync.Mutex
+	names []string
+}
+
+func newResults() *results {
+	return &results{}
+}
+
+func (r *results) handler(name string) eventsource.EventHandlerFunc {
+	return func(ctx context.Context, evt *eventsource.EventMessage) error {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.names = append(r.names, name+":"+string(evt.Payload))
+		return nil
+	}
+}
+
+// waitForNames waits until we've handled 'count' messages, and returns "Name:Payload" for each, sorted.
+func (r *results) waitForNames(count int) []string {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		r.mutex.Lock()
+		if len(r.names) >= count {
+			names := append([]string{}, r.names...)
+			r.mutex.Unlock()
+			sort.Strings(names)
+			return names
+		}
+		r.mutex.Unlock()
+		time.Sleep(5 * time.Millisecond)
+	}
+	return nil
+}
+
+// waitForPayloads is just like waitForNames, but only includes the payload of each message.
+func (r *results) waitForPayloads(count int) []string {
+	names := r.waitForNames(count)
+	for i, name := range names {
+		_, names[i], _ = strings.Cut(name, ":")
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fakeClient is an in-memory stand-in for SNS/SQS that behaves just enough like the real thing to
+// exercise the broker: queues only receive messages whose key matches their subscription, and received
+// messages are hidden until they're deleted or their visibility timeout passes.
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		queues:        map[string]*fakeQueue{},
+		subscriptions: map[string]fakeSubscription{},
+	}
+}
+
+type fakeClient struct {
+	mutex         sync.Mutex
+	queues        map[string]*fakeQueue
+	subscriptions map[string]fakeSubscription
+	sequence      int
+	publishErr    error
+}
+
+type fakeQueue struct {
+	name              string
+	visibilityTimeout time.Duration
+	messages          []*fakeMessage
+}
+
+type fakeMessage struct {
+	awssqs.Message
+	visibleAt time.Time
+}
+
+type fakeSubscription struct {
+	queueURL string
+	key      string
+}
+
+func (c *fakeClient) Publish(_ context.Context, _ string, key string, payload []byte) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.publishErr != nil {
+		return c.publishErr
+	}
+	for _, sub := range c.subscriptions {
+		if sub.key != key {
+			continue
+		}
+		c.sequence++
+		queue := c.queues[sub.queueURL]
+		queue.messages = append(queue.messages, &fakeMessage{Message: awssqs.Message{
+			Key:           key,
+			Payload:       payload,
+			ReceiptHandle: fmt.Sprintf("receipt-%d", c.sequence),
+			Timestamp:     time.Now(),
+		}})
+	}
+	return nil
+}
+
+func (c *fakeClient) CreateQueue(_ context.Context, name string, visibilityTimeout time.Duration) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	queueURL := "https://sqs/" + name
+	if _, ok := c.queues[queueURL]; !ok {
+		c.queues[queueURL] = &fakeQueue{name: name, visibilityTimeout: visibilityTimeout}
+	}
+	return queueURL, nil
+}
+
+func (c *fakeClient) SubscribeQueue(_ context.Context, _ string, queueURL string, key string) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	subscriptionARN := "arn:" + queueURL + ":" + key
+	c.subscriptions[subscriptionARN] = fakeSubscription{queueURL: queueURL, key: key}
+	return subscriptionARN, nil
+}
+
+func (c *fakeClient) Unsubscribe(_ context.Context, subscriptionARN string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.subscriptions, subscriptionARN)
+	return nil
+}
+
+func (c *fakeClient) ReceiveMessages(ctx context.Context, queueURL string, maxMessages int, waitTime time.Duration) ([]awssqs.Message, error) {
+	deadline := time.Now().Add(waitTime)
+	for {
+		if messages := c.receive(queueURL, maxMessages); len(messages) > 0 {
+			return messages, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(2 * time.Millisecond):
+		}
+	}
+}
+
+func (c *fakeClient) receive(queueURL string, maxMessages int) []awssqs.Message {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	queue, ok := c.queues[queueURL]
+	if !ok {
+		return nil
+	}
+
+	var messages []awssqs.Message
+	now := time.Now()
+	for _, msg := range queue.messages {
+		if len(messages) >= maxMessages {
+			break
+		}
+		if msg.visibleAt.After(now) {
+			continue
+		}
+		msg.visibleAt = now.Add(queue.visibilityTimeout)
+		messages = append(messages, msg.Message)
+	}
+	return messages
+}
+
+func (c *fakeClient) DeleteMessage(_ context.Context, queueURL string, receiptHandle string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	queue := c.queues[queueURL]
+	for i, msg := range queue.messages {
+		if msg.ReceiptHandle == receiptHandle {
+			queue.messages = append(queue.messages[:i], queue.messages[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("receipt handle not found: %s", receiptHandle)
+}
+
+func (c *fakeClient) DeleteQueue(_ context.Context, queueURL string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.queues, queueURL)
+	return nil
+}
+
+func (c *fakeClient) queueNames() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var names []string
+	for _, queue := range c.queues {
+		names = append(names, queue.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c *fakeClient) queueCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.queues)
+}
+
+func (c *fakeClient) subscriptionCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.subscriptions)
+}
+
+func (c *fakeClient) messageCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	count := 0
+	for _, queue := range c.queues {
+		count += len(queue.messages)
+	}
+	return count
+}
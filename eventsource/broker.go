@@ -2,6 +2,7 @@ package eventsource
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"strings"
 	"time"
@@ -24,6 +25,31 @@ type Publisher interface {
 	Publish(ctx context.Context, key string, payload []byte) error
 }
 
+// BatchPublisher is an optional interface that a Publisher can implement when it can broadcast a bunch of
+// events more efficiently than calling Publish() over and over again (e.g. a network broker that can pipeline
+// a bunch of messages in a single round trip). Use the PublishBatch() helper rather than checking for this yourself.
+type BatchPublisher interface {
+	// PublishBatch broadcasts all of the given events to the broker. The Timestamp of each message is
+	// ignored; it's up to the broker to stamp messages as they're delivered. The same delivery caveats
+	// as Publish() apply to every message in the batch.
+	PublishBatch(ctx context.Context, messages []EventMessage) error
+}
+
+// PublishBatch broadcasts all of the messages using the publisher's PublishBatch() if it implements
+// BatchPublisher. Otherwise, it falls back to publishing each message one at a time, stopping at the
+// first message that fails.
+func PublishBatch(ctx context.Context, publisher Publisher, messages []EventMessage) error {
+	if batcher, ok := publisher.(BatchPublisher); ok {
+		return batcher.PublishBatch(ctx, messages)
+	}
+	for i, msg := range messages {
+		if err := publisher.Publish(ctx, msg.Key, msg.Payload); err != nil {
+			return fmt.Errorf("batch publish: message %d (%s): %w", i, msg.Key, err)
+		}
+	}
+	return nil
+}
+
 // Subscriber is a broker client/connection that lets you subscribe to asynchronous events
 // that occur elsewhere in the system.
 type Subscriber interface {
@@ -40,6 +66,51 @@ type Subscriber interface {
 	SubscribeGroup(ctx context.Context, key string, group string, handlerFunc EventHandlerFunc) (Subscription, error)
 }
 
+// WeightedGroupSubscriber is an optional interface that a Subscriber can implement when it can give some members
+// of a consumer group a bigger share of the group's events than others (e.g. via prefetch limits or weighted
+// partition assignment). This lets beefier instances take on more work. Use the SubscribeWeightedGroup() helper
+// rather than checking for this yourself.
+type WeightedGroupSubscriber interface {
+	// SubscribeWeightedGroup behaves just like SubscribeGroup(), but this listener should receive roughly 'weight'
+	// times as many of the group's events as a member with a weight of 1.
+	SubscribeWeightedGroup(ctx context.Context, key string, group string, weight int, handlerFunc EventHandlerFunc) (Subscription, error)
+}
+
+// SubscribeWeightedGroup creates a consumer group listener using the subscriber's SubscribeWeightedGroup() if it
+// implements WeightedGroupSubscriber. Otherwise, it falls back to a plain SubscribeGroup() and ignores the weight,
+// which is also what happens when the weight is 0.
+func SubscribeWeightedGroup(ctx context.Context, subscriber Subscriber, key string, group string, weight int, handlerFunc EventHandlerFunc) (Subscription, error) {
+	if weighted, ok := subscriber.(WeightedGroupSubscriber); ok && weight > 0 {
+		return weighted.SubscribeWeightedGroup(ctx, key, group, weight, handlerFunc)
+	}
+	return subscriber.SubscribeGroup(ctx, key, group, handlerFunc)
+}
+
+// ErrReplayNotSupported is what Replay() returns when the broker doesn't retain messages, so there's nothing
+// for it to re-deliver.
+var ErrReplayNotSupported = fmt.Errorf("broker does not support replay")
+
+// Replayable is an optional interface that a Subscriber can implement when it retains messages after delivering
+// them (e.g. a NATS JetStream stream or a Kafka topic). It lets you re-deliver old messages to a consumer group,
+// which is handy for recovering after a bug caused your handlers to mishandle a bunch of events. Use the Replay()
+// helper rather than checking for this yourself.
+type Replayable interface {
+	// Replay re-delivers every retained message whose key matches 'key' and that was published at or after 'since'
+	// to ONE member of the given consumer group, just like a newly published message. It returns the number
+	// of messages that it re-delivered. The group must already have at least one subscriber.
+	Replay(ctx context.Context, key string, group string, since time.Time) (int, error)
+}
+
+// Replay re-delivers the retained messages for the key to the consumer group using the subscriber's Replay() if it
+// implements Replayable. Otherwise, it fails with ErrReplayNotSupported. Keep in mind that your handlers will see
+// these messages a second time, so they should be idempotent.
+func Replay(ctx context.Context, subscriber Subscriber, key string, group string, since time.Time) (int, error) {
+	if replayable, ok := subscriber.(Replayable); ok {
+		return replayable.Replay(ctx, key, group, since)
+	}
+	return 0, fmt.Errorf("replay: %s: %w", key, ErrReplayNotSupported)
+}
+
 // Subscription is simply a registration pointer that can allow you to stop listening at any time.
 type Subscription interface {
 	// Closer contains 'Close()' which notifies the Broker/Subscriber that created this subscription that we
@@ -3,7 +3,10 @@
 package eventsource_test
 
 import (
+	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/bridgekit-io/frodo/eventsource"
 	"github.com/stretchr/testify/assert"
@@ -22,3 +25,95 @@ func TestNamespace(t *testing.T) {
 	assert.Equal(t, "🍺", eventsource.Namespace("🍺.Guzzled"))
 
 }
+
+type singlePublisher struct {
+	keys []string
+	fail string
+}
+
+func (p *singlePublisher) Publish(_ context.Context, key string, _ []byte) error {
+	if key == p.fail {
+		return fmt.Errorf("nope")
+	}
+	p.keys = append(p.keys, key)
+	return nil
+}
+
+func TestPublishBatch_fallback(t *testing.T) {
+	publisher := &singlePublisher{}
+	err := eventsource.PublishBatch(context.Background(), publisher, []eventsource.EventMessage{
+		{Key: "A"},
+		{Key: "B"},
+		{Key: "C"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "B", "C"}, publisher.keys)
+
+	publisher = &singlePublisher{fail: "B"}
+	err = eventsource.PublishBatch(context.Background(), publisher, []eventsource.EventMessage{
+		{Key: "A"},
+		{Key: "B"},
+		{Key: "C"},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"A"}, publisher.keys, "Should stop at the first failure")
+}
+
+type groupSubscriber struct {
+	subscribed []string
+}
+
+func (s *groupSubscriber) Subscribe(_ context.Context, key string, _ eventsource.EventHandlerFunc) (eventsource.Subscription, error) {
+	s.subscribed = append(s.subscribed, key)
+	return nil, nil
+}
+
+func (s *groupSubscriber) SubscribeGroup(_ context.Context, key string, group string, _ eventsource.EventHandlerFunc) (eventsource.Subscription, error) {
+	s.subscribed = append(s.subscribed, key+":"+group)
+	return nil, nil
+}
+
+type weightedSubscriber struct {
+	groupSubscriber
+}
+
+func (s *weightedSubscriber) SubscribeWeightedGroup(_ context.Context, key string, group string, weight int, _ eventsource.EventHandlerFunc) (eventsource.Subscription, error) {
+	s.subscribed = append(s.subscribed, fmt.Sprintf("%s:%s:%d", key, group, weight))
+	return nil, nil
+}
+
+func TestSubscribeWeightedGroup(t *testing.T) {
+	subscriber := &weightedSubscriber{}
+	_, _ = eventsource.SubscribeWeightedGroup(context.Background(), subscriber, "A", "Hug", 3, nil)
+	_, _ = eventsource.SubscribeWeightedGroup(context.Background(), subscriber, "B", "Hug", 0, nil)
+	assert.Equal(t, []string{"A:Hug:3", "B:Hug"}, subscriber.subscribed, "Zero weight should use a normal group")
+}
+
+func TestSubscribeWeightedGroup_fallback(t *testing.T) {
+	subscriber := &groupSubscriber{}
+	_, _ = eventsource.SubscribeWeightedGroup(context.Background(), subscriber, "A", "Hug", 3, nil)
+	assert.Equal(t, []string{"A:Hug"}, subscriber.subscribed, "Brokers w/o weight support should ignore it")
+}
+
+type replayableSubscriber struct {
+	groupSubscriber
+}
+
+func (s *replayableSubscriber) Replay(_ context.Context, key string, group string, _ time.Time) (int, error) {
+	s.subscribed = append(s.subscribed, "replay:"+key+":"+group)
+	return 2, nil
+}
+
+func TestReplay(t *testing.T) {
+	subscriber := &replayableSubscriber{}
+	count, err := eventsource.Replay(context.Background(), subscriber, "A", "Hug", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"replay:A:Hug"}, subscriber.subscribed)
+}
+
+func TestReplay_notSupported(t *testing.T) {
+	count, err := eventsource.Replay(context.Background(), &groupSubscriber{}, "A", "Hug", time.Now())
+	assert.ErrorIs(t, err, eventsource.ErrReplayNotSupported)
+	assert.Equal(t, 0, count)
+}
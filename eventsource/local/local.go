@@ -2,9 +2,11 @@ package local
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand/v2"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,6 +24,7 @@ func Broker(options ...BrokerOption) eventsource.Broker {
 		groups: map[string]*subscriptionGroup{},
 		mutex:  &sync.Mutex{},
 		now:    time.Now,
+		stats:  &brokerStats{delivered: map[string]int64{}, lastLogged: time.Now()},
 		errorHandler: func(err error) {
 			log.Printf("[WARN] Local broker publish error: %v", err)
 		},
@@ -33,10 +36,19 @@ func Broker(options ...BrokerOption) eventsource.Broker {
 }
 
 type broker struct {
-	mutex        *sync.Mutex
-	groups       map[string]*subscriptionGroup
+	mutex  *sync.Mutex
+	groups map[string]*subscriptionGroup
+	// sortedGroups contains the same groups as 'groups', but sorted by group key. This way, subscribers always
+	// receive messages in the same order, which keeps synchronous dispatch deterministic (nice for tests).
+	sortedGroups []*subscriptionGroup
 	now          func() time.Time
 	errorHandler fail.ErrorHandler
+	synchronous  bool
+	stats        *brokerStats
+	// retention is how long we hang onto published messages so you can Replay() them. It's zero (keep nothing)
+	// unless you enabled WithRetention(). The retained messages are in the order they were published.
+	retention time.Duration
+	retained  []eventsource.EventMessage
 }
 
 func (b *broker) Publish(ctx context.Context, key string, payload []byte) error {
@@ -44,10 +56,65 @@ func (b *broker) Publish(ctx context.Context, key string, payload []byte) error
 		return fmt.Errorf("local broker publish: %w", err)
 	}
 
-	keyTokens := b.tokenizeKey(key)
+	b.mutex.Lock()
+	deliveries := b.dispatch(nil, key, payload)
+	b.retain(key, payload)
+	b.mutex.Unlock()
+
+	b.stats.publish(1)
+	defer b.stats.logIfDue(b.now())
+	return b.deliver(deliveries)
+}
+
+// PublishBatch broadcasts all of the messages while only acquiring the lock on our subscriptions once.
+func (b *broker) PublishBatch(ctx context.Context, messages []eventsource.EventMessage) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("local broker publish: %w", err)
+	}
 
 	b.mutex.Lock()
-	defer b.mutex.Unlock()
+	var deliveries []delivery
+	for _, msg := range messages {
+		deliveries = b.dispatch(deliveries, msg.Key, msg.Payload)
+		b.retain(msg.Key, msg.Payload)
+	}
+	b.mutex.Unlock()
+
+	b.stats.publish(len(messages))
+	defer b.stats.logIfDue(b.now())
+	return b.deliver(deliveries)
+}
+
+// delivery is a single message that we need to hand off to a single subscriber.
+type delivery struct {
+	sub *subscription
+	msg eventsource.EventMessage
+}
+
+// deliver hands each message off to its subscriber. Normally, that happens in the background, but when the broker
+// is synchronous (see WithSynchronousDispatch), we run them one at a time and return all of their errors. We
+// must NOT be holding the mutex here; synchronous subscribers often publish events of their own.
+func (b *broker) deliver(deliveries []delivery) error {
+	if !b.synchronous {
+		for _, d := range deliveries {
+			go func() { _ = b.publishMessage(context.Background(), d.sub, d.msg) }()
+		}
+		return nil
+	}
+
+	var errs []error
+	for _, d := range deliveries {
+		if err := b.publishMessage(context.Background(), d.sub, d.msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dispatch figures out which subscriber in each subscription group that matches the key should receive the
+// message, appending those deliveries to the given slice. You must be holding the mutex when you call this.
+func (b *broker) dispatch(deliveries []delivery, key string, payload []byte) []delivery {
+	keyTokens := b.tokenizeKey(key)
 
 	// Yes, I realize this isn't the most efficient way to do this. It would be better to
 	// use something like a radix tree - similar to how HTTP routers typically figure out
@@ -58,7 +125,7 @@ func (b *broker) Publish(ctx context.Context, key string, payload []byte) error
 	// single instance monolith. It's not useful for much beyond playing around with the
 	// framework. You're probably going to swap in NATS or something like that, so that's
 	// how you make this better.
-	for _, group := range b.groups {
+	for _, group := range b.sortedGroups {
 		if !group.matches(keyTokens) {
 			continue
 		}
@@ -68,33 +135,45 @@ func (b *broker) Publish(ctx context.Context, key string, payload []byte) error
 			continue
 		}
 
-		// Do NOT use 'ctx' to pass along to the subscribers. We have no idea about the source/timeout status of
-		// the incoming context, and it's possible that the Publish() is being performed from a context that's just
-		// about to end (like an HTTP request). That's fine for the purposes of triggering the broadcast, but the
-		// subscribers on the other end should start with ah blank context. If you were using a distributed broker
-		// like NATS, Redis, etc. your handler would be starting with a different context than the publisher anyway.
-		// This just makes the local broker behave more like a distributed one and avoids weird bugs where the context
-		// is closed elsewhere while async subscribers are working.
-		go b.publishMessage(context.Background(), sub, eventsource.EventMessage{
-			Timestamp: b.now(),
-			Key:       key,
-			Payload:   payload,
+		deliveries = append(deliveries, delivery{
+			sub: sub,
+			msg: eventsource.EventMessage{
+				Timestamp: b.now(),
+				Key:       key,
+				Payload:   payload,
+			},
 		})
 	}
-	return nil
+	return deliveries
 }
 
-func (b *broker) publishMessage(ctx context.Context, sub *subscription, msg eventsource.EventMessage) {
+// publishMessage runs the subscriber's handler. Asynchronous brokers send failures to the error handler since
+// nobody is waiting around for the result. Synchronous ones just return the error to the publisher.
+//
+// Do NOT use the publisher's context to pass along to the subscribers. We have no idea about the source/timeout
+// status of the incoming context, and it's possible that the Publish() is being performed from a context that's
+// just about to end (like an HTTP request). That's fine for the purposes of triggering the broadcast, but the
+// subscribers on the other end should start with ah blank context. If you were using a distributed broker
+// like NATS, Redis, etc. your handler would be starting with a different context than the publisher anyway.
+// This just makes the local broker behave more like a distributed one and avoids weird bugs where the context
+// is closed elsewhere while async subscribers are working.
+func (b *broker) publishMessage(ctx context.Context, sub *subscription, msg eventsource.EventMessage) (err error) {
 	defer func() {
 		if recovery := recover(); recovery != nil {
-			err, _ := recovery.(error)
-			b.errorHandler(fmt.Errorf("local broker publish: %s: %w", sub.group.key, err))
+			recoveryErr, _ := recovery.(error)
+			err = fmt.Errorf("local broker publish: %s: %w", sub.group.key, recoveryErr)
+		}
+		b.stats.deliver(msg.Key, err)
+		if err != nil && !b.synchronous {
+			b.errorHandler(err)
+			err = nil
 		}
 	}()
 
-	if err := sub.handlerFunc(ctx, &msg); err != nil {
-		b.errorHandler(fmt.Errorf("local broker publish: %s: %w", sub.group.key, err))
+	if err = sub.handlerFunc(ctx, &msg); err != nil {
+		return fmt.Errorf("local broker publish: %s: %w", sub.group.key, err)
 	}
+	return nil
 }
 
 func (b *broker) Subscribe(ctx context.Context, key string, handlerFunc eventsource.EventHandlerFunc) (eventsource.Subscription, error) {
@@ -151,6 +230,14 @@ func (b *broker) loadGroup(key string, groupKey string) *subscriptionGroup {
 		subscriptions: &subscriptionRoundRobin{},
 	}
 	b.groups[lookupKey] = group
+
+	i := sort.Search(len(b.sortedGroups), func(i int) bool {
+		other := b.sortedGroups[i]
+		return other.groupKey > groupKey || (other.groupKey == groupKey && other.key > key)
+	})
+	b.sortedGroups = append(b.sortedGroups, nil)
+	copy(b.sortedGroups[i+1:], b.sortedGroups[i:])
+	b.sortedGroups[i] = group
 	return group
 }
 
@@ -266,6 +353,15 @@ func (robin *subscriptionRoundRobin) remove(sub *subscription) {
 // BrokerOption allows you to tweak the local broker's behavior in some way.
 type BrokerOption func(*broker)
 
+// WithSynchronousDispatch makes Publish() run every matching subscriber before it returns rather than firing them
+// off in the background. Any errors returned by the subscribers come back from Publish() rather than going to the
+// error handler. This is mainly useful in tests, so you don't have to sleep and hope your subscribers have finished.
+func WithSynchronousDispatch() BrokerOption {
+	return func(broker *broker) {
+		broker.synchronous = true
+	}
+}
+
 // WithErrorHandler swaps the default error handler for this one.
 func WithErrorHandler(handler fail.ErrorHandler) BrokerOption {
 	return func(broker *broker) {
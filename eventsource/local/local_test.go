@@ -3,8 +3,10 @@
 package local_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"sync"
 	"testing"
 	"time"
@@ -46,6 +48,31 @@ func (suite *LocalBrokerSuite) TestPublish_noSubscribers() {
 	suite.NoError(broker.Publish(context.Background(), "Baz", []byte("Seriously, go home.")))
 }
 
+func (suite *LocalBrokerSuite) TestPublishBatch() {
+	results := &testext.Sequence{}
+	broker := local.Broker()
+	suite.subscribe(broker, results, "Foo")
+	suite.subscribe(broker, results, "Foo.*")
+
+	results.ResetWithWorkers(3)
+	err := eventsource.PublishBatch(context.Background(), broker, []eventsource.EventMessage{
+		{Key: "Foo", Payload: []byte("A")},
+		{Key: "Foo.Bar", Payload: []byte("B")},
+		{Key: "Foo.Baz", Payload: []byte("C")},
+		{Key: "Bar", Payload: []byte("D")},
+	})
+	suite.Require().NoError(err)
+	suite.assertFired(results, []string{
+		"Foo:A",
+		"Foo.*:B",
+		"Foo.*:C",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	suite.Error(eventsource.PublishBatch(ctx, broker, []eventsource.EventMessage{{Key: "Foo"}}))
+}
+
 func (suite *LocalBrokerSuite) publish(broker eventsource.Broker, key string, value string) {
 	msg := "Publishing with a valid context, should always succeed"
 	suite.Require().NoError(broker.Publish(context.Background(), key, []byte(value)), msg)
@@ -387,6 +414,165 @@ func (suite *LocalBrokerSuite) TestPublish_subscriberErrors() {
 	})
 }
 
+func (suite *LocalBrokerSuite) TestPublish_synchronous() {
+	handledErrors := 0
+	broker := local.Broker(local.WithSynchronousDispatch(), local.WithErrorHandler(func(err error) {
+		handledErrors++
+	}))
+
+	var fired []string
+	_, _ = broker.Subscribe(context.Background(), "Foo", func(ctx context.Context, evt *eventsource.EventMessage) error {
+		fired = append(fired, "Foo:"+string(evt.Payload))
+		// Subscribers publishing their own events is how chains work, so make sure that doesn't deadlock.
+		return broker.Publish(ctx, "Bar", evt.Payload)
+	})
+	_, _ = broker.Subscribe(context.Background(), "Bar", func(ctx context.Context, evt *eventsource.EventMessage) error {
+		fired = append(fired, "Bar:"+string(evt.Payload))
+		if string(evt.Payload) == "error" {
+			return fmt.Errorf("bar failed")
+		}
+		return nil
+	})
+
+	// No sleeping/waiting. Everything should have run by the time Publish() returns.
+	suite.Require().NoError(broker.Publish(context.Background(), "Foo", []byte("A")))
+	suite.Equal([]string{"Foo:A", "Bar:A"}, fired)
+
+	err := broker.Publish(context.Background(), "Foo", []byte("error"))
+	suite.ErrorContains(err, "bar failed")
+	suite.Equal([]string{"Foo:A", "Bar:A", "Foo:error", "Bar:error"}, fired)
+	suite.Equal(0, handledErrors, "Synchronous errors should be returned rather than handled")
+}
+
+func (suite *LocalBrokerSuite) TestPublish_synchronousPanic() {
+	broker := local.Broker(local.WithSynchronousDispatch())
+	_, _ = broker.Subscribe(context.Background(), "Foo", func(ctx context.Context, evt *eventsource.EventMessage) error {
+		panic(fmt.Errorf("nobody calls me lebowski"))
+	})
+	suite.ErrorContains(broker.Publish(context.Background(), "Foo", []byte("A")), "nobody calls me lebowski")
+}
+
+func (suite *LocalBrokerSuite) TestStats() {
+	broker := local.Broker(local.WithSynchronousDispatch())
+	_, _ = broker.Subscribe(context.Background(), "Foo", func(ctx context.Context, evt *eventsource.EventMessage) error {
+		return nil
+	})
+	_, _ = broker.SubscribeGroup(context.Background(), "*", "Bowling", func(ctx context.Context, evt *eventsource.EventMessage) error {
+		if string(evt.Payload) == "error" {
+			return fmt.Errorf("over the line")
+		}
+		return nil
+	})
+
+	_ = broker.Publish(context.Background(), "Foo", []byte("A"))
+	_ = broker.Publish(context.Background(), "Foo", []byte("error"))
+	_ = eventsource.PublishBatch(context.Background(), broker, []eventsource.EventMessage{
+		{Key: "Bar", Payload: []byte("B")},
+		{Key: "Bar.Baz", Payload: []byte("C")}, // nobody is listening
+	})
+
+	stats, ok := local.ReadStats(broker)
+	suite.Require().True(ok)
+	suite.Equal(int64(4), stats.Published)
+	suite.Equal(map[string]int64{"Foo": 4, "Bar": 1}, stats.Delivered)
+	suite.Equal(int64(1), stats.Failed)
+
+	// Make sure that the snapshot doesn't change out from under you.
+	_ = broker.Publish(context.Background(), "Bar", []byte("D"))
+	suite.Equal(int64(4), stats.Published)
+	suite.Equal(int64(1), stats.Delivered["Bar"])
+
+	_, ok = local.ReadStats(struct{ eventsource.Broker }{broker})
+	suite.False(ok, "Only local brokers should have stats")
+}
+
+func (suite *LocalBrokerSuite) TestStats_logger() {
+	output := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(output, nil))
+	broker := local.Broker(local.WithSynchronousDispatch(), local.WithStatsLogger(logger, 20*time.Millisecond))
+	_, _ = broker.Subscribe(context.Background(), "Foo", func(ctx context.Context, evt *eventsource.EventMessage) error {
+		return nil
+	})
+
+	_ = broker.Publish(context.Background(), "Foo", []byte("A"))
+	suite.Empty(output.String(), "Should not log until the interval has passed")
+
+	time.Sleep(25 * time.Millisecond)
+	_ = broker.Publish(context.Background(), "Foo", []byte("B"))
+	suite.Contains(output.String(), `msg="Local broker stats" published=2 delivered.Foo=2 failed=0`)
+
+	output.Reset()
+	_ = broker.Publish(context.Background(), "Foo", []byte("C"))
+	suite.Empty(output.String(), "Should log at most once per interval")
+}
+
+func (suite *LocalBrokerSuite) TestReplay() {
+	broker := local.Broker(local.WithSynchronousDispatch(), local.WithRetention(time.Hour))
+
+	var fired []string
+	_, _ = broker.SubscribeGroup(context.Background(), "Foo.*", "Bowling", func(ctx context.Context, evt *eventsource.EventMessage) error {
+		fired = append(fired, evt.Key+":"+string(evt.Payload))
+		return nil
+	})
+	_, _ = broker.SubscribeGroup(context.Background(), "Foo.*", "Nihilists", func(ctx context.Context, evt *eventsource.EventMessage) error {
+		fired = append(fired, "Nihilists")
+		return nil
+	})
+
+	_ = broker.Publish(context.Background(), "Foo.Bar", []byte("A"))
+	time.Sleep(2 * time.Millisecond)
+	since := time.Now()
+	_ = broker.Publish(context.Background(), "Foo.Bar", []byte("B"))
+	_ = broker.Publish(context.Background(), "Foo.Baz", []byte("C"))
+	_ = broker.Publish(context.Background(), "Foo.Bar", []byte("D"))
+	fired = nil
+
+	count, err := eventsource.Replay(context.Background(), broker, "Foo.Bar", "Bowling", since)
+	suite.Require().NoError(err)
+	suite.Equal(2, count)
+	suite.Equal([]string{"Foo.Bar:B", "Foo.Bar:D"}, fired, "Should only re-deliver matching messages to that group")
+
+	fired = nil
+	count, err = eventsource.Replay(context.Background(), broker, "Foo.*", "Bowling", time.Time{})
+	suite.Require().NoError(err)
+	suite.Equal(4, count)
+	suite.Equal([]string{"Foo.Bar:A", "Foo.Bar:B", "Foo.Baz:C", "Foo.Bar:D"}, fired)
+
+	_, err = eventsource.Replay(context.Background(), broker, "Foo.Bar", "Dude", since)
+	suite.Error(err, "Should fail when nobody is in the group")
+}
+
+func (suite *LocalBrokerSuite) TestReplay_retention() {
+	broker := local.Broker(local.WithSynchronousDispatch(), local.WithRetention(20*time.Millisecond))
+
+	var fired []string
+	_, _ = broker.SubscribeGroup(context.Background(), "Foo", "Bowling", func(ctx context.Context, evt *eventsource.EventMessage) error {
+		fired = append(fired, string(evt.Payload))
+		return nil
+	})
+
+	_ = broker.Publish(context.Background(), "Foo", []byte("A"))
+	time.Sleep(25 * time.Millisecond)
+	_ = broker.Publish(context.Background(), "Foo", []byte("B"))
+	fired = nil
+
+	count, err := eventsource.Replay(context.Background(), broker, "Foo", "Bowling", time.Time{})
+	suite.Require().NoError(err)
+	suite.Equal(1, count)
+	suite.Equal([]string{"B"}, fired, "Messages older than the retention window should be discarded")
+}
+
+func (suite *LocalBrokerSuite) TestReplay_noRetention() {
+	broker := local.Broker(local.WithSynchronousDispatch())
+	_, _ = broker.SubscribeGroup(context.Background(), "Foo", "Bowling", func(ctx context.Context, evt *eventsource.EventMessage) error {
+		return nil
+	})
+	_ = broker.Publish(context.Background(), "Foo", []byte("A"))
+
+	_, err := eventsource.Replay(context.Background(), broker, "Foo", "Bowling", time.Time{})
+	suite.ErrorIs(err, eventsource.ErrReplayNotSupported)
+}
+
 func (suite *LocalBrokerSuite) TestUnsubscribe() {
 	results := &testext.Sequence{}
 	broker := local.Broker()
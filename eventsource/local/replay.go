@@ -0,0 +1,90 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bridgekit-io/frodo/eventsource"
+)
+
+// WithRetention makes the broker hang onto every message it publishes for the given amount of time, so that you can
+// Replay() them later. Since the local broker keeps everything in memory, you should keep this window small. Old
+// messages are discarded whenever you publish a new one, so there's no background goroutine doing cleanup.
+//
+//	broker := local.Broker(local.WithRetention(time.Hour))
+func WithRetention(maxAge time.Duration) BrokerOption {
+	return func(broker *broker) {
+		broker.retention = maxAge
+	}
+}
+
+// retain records the message for future replays, discarding any messages that have fallen out of the retention
+// window. You must be holding the mutex when you call this.
+func (b *broker) retain(key string, payload []byte) {
+	if b.retention <= 0 {
+		return
+	}
+
+	now := b.now()
+	cutoff := now.Add(-b.retention)
+	expired := 0
+	for expired < len(b.retained) && b.retained[expired].Timestamp.Before(cutoff) {
+		expired++
+	}
+	b.retained = append(b.retained[expired:], eventsource.EventMessage{
+		Timestamp: now,
+		Key:       key,
+		Payload:   payload,
+	})
+}
+
+// Replay re-delivers every retained message that matches the key and that was published at or after 'since' to
+// the subscribers in the given group. The key may contain "*" wildcards, just like a subscription. Deliveries
+// behave just like a normal Publish(), so synchronous brokers return the handlers' errors, too.
+func (b *broker) Replay(ctx context.Context, key string, group string, since time.Time) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, fmt.Errorf("local broker replay: %w", err)
+	}
+
+	b.mutex.Lock()
+	if b.retention <= 0 {
+		b.mutex.Unlock()
+		return 0, fmt.Errorf("local broker replay: %s: %w", key, eventsource.ErrReplayNotSupported)
+	}
+
+	keyTokens := b.tokenizeKey(key)
+	var deliveries []delivery
+	matchedGroup := false
+	for _, subscriptionGroup := range b.sortedGroups {
+		if subscriptionGroup.groupKey != group || len(subscriptionGroup.subscriptions.subscriptions) == 0 {
+			continue
+		}
+		matchedGroup = true
+
+		for _, msg := range b.retained {
+			if msg.Timestamp.Before(since) {
+				continue
+			}
+			msgTokens := b.tokenizeKey(msg.Key)
+			if !subscriptionGroup.matches(msgTokens) || !tokensMatch(keyTokens, msgTokens) {
+				continue
+			}
+			deliveries = append(deliveries, delivery{
+				sub: subscriptionGroup.subscriptions.next(),
+				msg: msg,
+			})
+		}
+	}
+	b.mutex.Unlock()
+
+	if !matchedGroup {
+		return 0, fmt.Errorf("local broker replay: %s: no subscribers in group '%s'", key, group)
+	}
+	return len(deliveries), b.deliver(deliveries)
+}
+
+// tokensMatch is the same wildcard matching that subscription groups use, but for an ad-hoc pattern.
+func tokensMatch(pattern []string, incomingKey []string) bool {
+	return (&subscriptionGroup{keyTokens: pattern}).matches(incomingKey)
+}
@@ -0,0 +1,136 @@
+package local
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bridgekit-io/frodo/eventsource"
+)
+
+// Stats is a snapshot of the local broker's counters since it was created. When an event isn't firing the way you
+// expect, these help you figure out whether the problem is on the publishing side (Published isn't going up), the
+// subscribing side (nothing Delivered for that key), or in the handler itself (Failed is going up).
+type Stats struct {
+	// Published is the total number of messages published to the broker, whether anybody was listening or not.
+	Published int64
+	// Delivered is the number of times that a subscriber's handler was invoked, broken down by message key. A
+	// message that 3 different subscription groups receive counts as 3 deliveries.
+	Delivered map[string]int64
+	// Failed is the number of deliveries whose handler returned an error or panicked.
+	Failed int64
+}
+
+// ReadStats returns the current counters for the given broker. The 'ok' value is false if the broker is not
+// a local broker, since other brokers (e.g. NATS) have their own monitoring tools.
+//
+//	broker := local.Broker()
+//	...
+//	stats, _ := local.ReadStats(broker)
+//	fmt.Printf("Published %d, Delivered %v, Failed %d\n", stats.Published, stats.Delivered, stats.Failed)
+func ReadStats(b eventsource.Broker) (Stats, bool) {
+	if localBroker, ok := b.(*broker); ok {
+		return localBroker.Stats(), true
+	}
+	return Stats{}, false
+}
+
+// Stats returns a snapshot of the broker's publish/delivery counters.
+func (b *broker) Stats() Stats {
+	return b.stats.snapshot()
+}
+
+// brokerStats tracks the counters for the broker's Stats(). It has its own mutex rather than sharing the broker's
+// since we update the counters while handlers run, and handlers are often publishing events of their own.
+type brokerStats struct {
+	mutex     sync.Mutex
+	published int64
+	delivered map[string]int64
+	failed    int64
+
+	// logger is where we periodically write the stats if you enabled WithStatsLogger(). It's nil otherwise.
+	logger      *slog.Logger
+	logInterval time.Duration
+	lastLogged  time.Time
+}
+
+func (stats *brokerStats) publish(count int) {
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+
+	stats.published += int64(count)
+}
+
+func (stats *brokerStats) deliver(key string, err error) {
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+
+	stats.delivered[key]++
+	if err != nil {
+		stats.failed++
+	}
+}
+
+func (stats *brokerStats) snapshot() Stats {
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+
+	delivered := make(map[string]int64, len(stats.delivered))
+	for key, count := range stats.delivered {
+		delivered[key] = count
+	}
+	return Stats{
+		Published: stats.published,
+		Delivered: delivered,
+		Failed:    stats.failed,
+	}
+}
+
+// logIfDue writes the current stats to the logger if it's been at least 'logInterval' since the last time we did.
+// Rather than running a background goroutine that outlives the broker, we piggyback on calls to Publish(), so
+// an idle broker doesn't log anything; there's nothing new to say anyway.
+func (stats *brokerStats) logIfDue(now time.Time) {
+	if stats.logger == nil {
+		return
+	}
+
+	stats.mutex.Lock()
+	if now.Sub(stats.lastLogged) < stats.logInterval {
+		stats.mutex.Unlock()
+		return
+	}
+	stats.lastLogged = now
+	stats.mutex.Unlock()
+
+	snapshot := stats.snapshot()
+	keys := make([]string, 0, len(snapshot.Delivered))
+	for key := range snapshot.Delivered {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	delivered := make([]any, 0, len(keys))
+	for _, key := range keys {
+		delivered = append(delivered, slog.Int64(key, snapshot.Delivered[key]))
+	}
+
+	stats.logger.LogAttrs(context.Background(), slog.LevelInfo, "Local broker stats",
+		slog.Int64("published", snapshot.Published),
+		slog.Group("delivered", delivered...),
+		slog.Int64("failed", snapshot.Failed),
+	)
+}
+
+// WithStatsLogger periodically writes the broker's Stats() to the given logger, at most once per interval. You'll
+// usually want to pass the same logger you give to services.WithLogger(). Since the local broker doesn't run any
+// background goroutines, it checks whether it's time to log whenever you publish a message.
+//
+//	broker := local.Broker(local.WithStatsLogger(logger, time.Minute))
+func WithStatsLogger(logger *slog.Logger, interval time.Duration) BrokerOption {
+	return func(broker *broker) {
+		broker.stats.logger = logger
+		broker.stats.logInterval = interval
+	}
+}
@@ -2,6 +2,7 @@ package nats
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -22,6 +23,7 @@ func Broker(options ...Option) eventsource.Broker {
 		uri:               nats.DefaultURL,
 		mutex:             &sync.Mutex{},
 		streams:           map[string]jetstream.Stream{},
+		groupHandlers:     map[string][]groupHandler{},
 		retentionMaxAge:   7 * 24 * time.Hour,
 		retentionMaxMsgs:  -1,
 		retentionMaxBytes: -1,
@@ -53,6 +55,9 @@ type client struct {
 
 	streams       map[string]jetstream.Stream
 	subscriptions []subscription
+	// groupHandlers are the handlers for every SubscribeGroup() on this connection, keyed by (normalized) group
+	// name, so that Replay() can hand old messages to a member of the group.
+	groupHandlers map[string][]groupHandler
 	username      string
 	password      string
 
@@ -75,6 +80,33 @@ func (c *client) Publish(ctx context.Context, key string, payload []byte) error
 	return nil
 }
 
+// PublishBatch uses JetStream's async publishing to pipeline all of the messages rather than waiting for
+// the server to acknowledge each one before sending the next.
+func (c *client) PublishBatch(ctx context.Context, messages []eventsource.EventMessage) error {
+	futures := make([]jetstream.PubAckFuture, 0, len(messages))
+	for _, msg := range messages {
+		if _, err := c.connectStream(ctx, msg.Key); err != nil {
+			return fmt.Errorf("broker publish error: %w", err)
+		}
+		future, err := c.js.PublishAsync(msg.Key, msg.Payload)
+		if err != nil {
+			return fmt.Errorf("broker publish error: %w", err)
+		}
+		futures = append(futures, future)
+	}
+
+	for _, future := range futures {
+		select {
+		case <-future.Ok():
+		case err := <-future.Err():
+			return fmt.Errorf("broker publish error: %s: %w", future.Msg().Subject, err)
+		case <-ctx.Done():
+			return fmt.Errorf("broker publish error: %w", ctx.Err())
+		}
+	}
+	return nil
+}
+
 func (c *client) Subscribe(ctx context.Context, key string, handlerFunc eventsource.EventHandlerFunc) (eventsource.Subscription, error) {
 	return c.consume(ctx, key, "", handlerFunc)
 }
@@ -112,6 +144,11 @@ func (c *client) consume(ctx context.Context, key string, group string, handlerF
 	if err != nil {
 		return nil, fmt.Errorf("broker consumer context error: %w", err)
 	}
+	if group != "" {
+		c.mutex.Lock()
+		c.groupHandlers[group] = append(c.groupHandlers[group], groupHandler{keyTokens: strings.Split(key, "."), handlerFunc: handlerFunc})
+		c.mutex.Unlock()
+	}
 	return subscription{consumer: consumer, consumerContext: consumerContext}, nil
 }
 
@@ -133,6 +170,99 @@ func (c *client) toJetStreamMessageHandler(handlerFunc eventsource.EventHandlerF
 	}
 }
 
+// Replay reads the stream's retained messages for the key starting at 'since' using a throwaway ordered consumer, and
+// runs the handler of this connection's subscriber in the group for each one. That means the replay only runs on the
+// instance where you called it rather than being spread around the group; that's fine for an occasional recovery
+// tool. How far back you can go depends on your retention settings (see WithMaxAge, WithMaxMsgs, and WithMaxBytes).
+func (c *client) Replay(ctx context.Context, key string, group string, since time.Time) (int, error) {
+	stream, err := c.connectStream(ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("broker replay error: %w", err)
+	}
+
+	group = strings.ReplaceAll(group, ".", "_")
+	c.mutex.Lock()
+	handlers := c.groupHandlers[group]
+	c.mutex.Unlock()
+	if len(handlers) == 0 {
+		return 0, fmt.Errorf("broker replay error: %s: no subscribers in group '%s'", key, group)
+	}
+
+	consumer, err := stream.OrderedConsumer(ctx, jetstream.OrderedConsumerConfig{
+		FilterSubjects: []string{key},
+		DeliverPolicy:  jetstream.DeliverByStartTimePolicy,
+		OptStartTime:   &since,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("broker replay error: %w", err)
+	}
+
+	count := 0
+	for {
+		if err = ctx.Err(); err != nil {
+			return count, fmt.Errorf("broker replay error: %w", err)
+		}
+
+		// When nothing comes back before the timeout, there are no messages left to replay.
+		msg, err := consumer.Next(jetstream.FetchMaxWait(time.Second))
+		if errors.Is(err, nats.ErrTimeout) {
+			return count, nil
+		}
+		if err != nil {
+			return count, fmt.Errorf("broker replay error: %w", err)
+		}
+
+		meta, err := msg.Metadata()
+		if err != nil {
+			return count, fmt.Errorf("broker replay error: %w", err)
+		}
+		if handler, ok := findGroupHandler(handlers, msg.Subject()); ok {
+			err = handler.handlerFunc(context.Background(), &eventsource.EventMessage{
+				Timestamp: meta.Timestamp,
+				Key:       msg.Subject(),
+				Payload:   msg.Data(),
+			})
+			if err != nil {
+				c.onError(fmt.Errorf("error replaying event message '%s': %w", msg.Subject(), err))
+			}
+			count++
+		}
+		if meta.NumPending == 0 {
+			return count, nil
+		}
+	}
+}
+
+// groupHandler is a single SubscribeGroup() handler, along w/ the key it subscribed to.
+type groupHandler struct {
+	keyTokens   []string
+	handlerFunc eventsource.EventHandlerFunc
+}
+
+// findGroupHandler returns the first handler whose subscription key matches the subject, honoring the "*" and ">"
+// wildcards the same way that NATS does.
+func findGroupHandler(handlers []groupHandler, subject string) (groupHandler, bool) {
+	subjectTokens := strings.Split(subject, ".")
+	for _, handler := range handlers {
+		if subjectMatches(handler.keyTokens, subjectTokens) {
+			return handler, true
+		}
+	}
+	return groupHandler{}, false
+}
+
+func subjectMatches(pattern []string, subject []string) bool {
+	for i, token := range pattern {
+		if token == ">" {
+			return len(subject) > i
+		}
+		if i >= len(subject) || (token != "*" && token != subject[i]) {
+			return false
+		}
+	}
+	return len(pattern) == len(subject)
+}
+
 // connectStream lazy creates the event stream where this key is supposed to go. Keys look like "FooService.BarMethod",
 // so this will create/update a stream named "FooService".
 func (c *client) connectStream(ctx context.Context, key string) (jetstream.Stream, error) {
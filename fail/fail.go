@@ -18,6 +18,9 @@ type StatusError struct {
 	Status int `json:"Status"`
 	// Message is the human-readable error message.
 	Message string `json:"Message"`
+	// Key identifies the message in your translation catalog (e.g. "errors.user.conflict"), so a localizer
+	// can swap in the caller's language. It's empty unless you supplied one using WithKey().
+	Key string `json:"-"`
 }
 
 // StatusCode returns the most relevant HTTP-style status code describing this type of error.
@@ -301,3 +304,19 @@ type errorWithHTTPStatusCode interface {
 // with them as you see fit. These are typically asynchronous things where you don't
 // have any handle over the control flow, but you don't want to lose these events.
 type ErrorHandler func(err error)
+
+// ErrorMapper translates errors that the framework doesn't understand (e.g. sql.ErrNoRows) into ones that it
+// does (e.g. fail.NotFound()), so you can normalize third-party errors in one place rather than wrapping them
+// everywhere you return them. Return the original error for anything that you don't want to translate.
+type ErrorMapper func(err error) error
+
+// Map runs the error through the mapper. Nil errors and nil mappers leave the error as-is.
+func (mapper ErrorMapper) Map(err error) error {
+	if mapper == nil || err == nil {
+		return err
+	}
+	if mapped := mapper(err); mapped != nil {
+		return mapped
+	}
+	return err
+}
@@ -3,8 +3,10 @@
 package fail_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/bridgekit-io/frodo/fail"
 	"github.com/stretchr/testify/suite"
@@ -338,6 +340,40 @@ func (suite *FailSuite) TestIsThrottled() {
 	suite.False(fail.IsThrottled(errWithStatusCode{statusCode: 401}))
 }
 
+func (suite *FailSuite) TestThrottledAfter() {
+	err := fail.ThrottledAfter(30*time.Second, "foo %s", "bar")
+	suite.assertError(err.StatusError, 429, "foo bar")
+	suite.Equal(429, fail.Status(err))
+	suite.True(fail.IsThrottled(err))
+	suite.Equal(30*time.Second, err.RetryAfter())
+}
+
+func (suite *FailSuite) TestUnavailableAfter() {
+	err := fail.UnavailableAfter(time.Minute, "foo %s", "bar")
+	suite.assertError(err.StatusError, 503, "foo bar")
+	suite.True(fail.IsUnavailable(err))
+	suite.Equal(time.Minute, err.RetryAfter())
+}
+
+func (suite *FailSuite) TestRetryAfter() {
+	delay, ok := fail.RetryAfter(fail.ThrottledAfter(5*time.Second, "foo"))
+	suite.True(ok)
+	suite.Equal(5*time.Second, delay)
+
+	delay, ok = fail.RetryAfter(fmt.Errorf("wrapped: %w", fail.ThrottledAfter(5*time.Second, "foo")))
+	suite.True(ok, "Should find the delay on wrapped errors")
+	suite.Equal(5*time.Second, delay)
+
+	delay, ok = fail.RetryAfter(fail.Throttled("foo"))
+	suite.False(ok)
+	suite.Equal(time.Duration(0), delay)
+
+	_, ok = fail.RetryAfter(nil)
+	suite.False(ok)
+	_, ok = fail.RetryAfter(errors.New("foo"))
+	suite.False(ok)
+}
+
 func (suite *FailSuite) TestNotImplemented() {
 	expectedStatus := 501
 	suite.assertError(fail.NotImplemented("foo"), expectedStatus, "foo")
@@ -395,6 +431,25 @@ func (suite *FailSuite) TestIsUnavailable() {
 	suite.False(fail.IsUnavailable(errWithStatusCode{statusCode: 401}))
 }
 
+func (suite *FailSuite) TestErrorMapper() {
+	errNoRows := fmt.Errorf("no rows")
+	mapper := fail.ErrorMapper(func(err error) error {
+		if errors.Is(err, errNoRows) {
+			return fail.NotFound("not found: %v", err)
+		}
+		return err
+	})
+
+	suite.Equal(404, fail.Status(mapper.Map(errNoRows)))
+	suite.Equal(404, fail.Status(mapper.Map(fmt.Errorf("wrapped: %w", errNoRows))))
+	suite.Equal(400, fail.Status(mapper.Map(fail.BadRequest("nope"))))
+	suite.Nil(mapper.Map(nil), "Nil errors should never be mapped")
+
+	// Nil mappers (or mappers that return nil) should leave the error alone.
+	suite.Equal(errNoRows, fail.ErrorMapper(nil).Map(errNoRows))
+	suite.Equal(errNoRows, fail.ErrorMapper(func(err error) error { return nil }).Map(errNoRows))
+}
+
 // assertError checks that both the status and message of the resulting 'err' are what we expect.
 func (suite *FailSuite) assertError(err fail.StatusError, expectedStatus int, expectedMessage string) {
 	suite.Require().Equal(expectedStatus, err.StatusCode())
@@ -0,0 +1,42 @@
+package fail
+
+import (
+	"errors"
+)
+
+// WithKey returns a copy of the error that includes the message key your localizer uses to look up the
+// translated version of the message. The original message is still what you get from Error(), so you
+// have a sensible fallback when there's no translation for the caller's language.
+//
+//	return nil, fail.AlreadyExists("always a conflict").WithKey("errors.conflict")
+func (r StatusError) WithKey(key string) StatusError {
+	r.Key = key
+	return r
+}
+
+// MessageKey returns the key that identifies this error's message in your translation catalog.
+func (r StatusError) MessageKey() string {
+	return r.Key
+}
+
+// WithKey returns a copy of the error that includes the message key your localizer uses to look up the
+// translated version of the message. It's just like StatusError.WithKey(), but you keep the retry delay.
+func (r RetryableError) WithKey(key string) RetryableError {
+	r.Key = key
+	return r
+}
+
+// MessageKey looks for a MessageKey() method on the error to figure out which entry in your translation
+// catalog describes it. This is an empty string when the error doesn't have a key.
+func MessageKey(err error) string {
+	var errMessageKey errorWithMessageKey
+	if errors.As(err, &errMessageKey) {
+		return errMessageKey.MessageKey()
+	}
+	return ""
+}
+
+type errorWithMessageKey interface {
+	error
+	MessageKey() string
+}
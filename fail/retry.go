@@ -0,0 +1,64 @@
+package fail
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// RetryableError is a StatusError that also tells the caller how long they should wait before trying
+// again. The API gateway sends this to the caller as the "Retry-After" header, and the Go client turns
+// that header back into a RetryableError, so you can use RetryAfter() on either side of the call.
+type RetryableError struct {
+	StatusError
+	// Delay is how long the caller should wait before trying again.
+	Delay time.Duration `json:"-"`
+}
+
+// RetryAfter returns how long the caller should wait before trying again.
+func (r RetryableError) RetryAfter() time.Duration {
+	return r.Delay
+}
+
+// ThrottledAfter is a 429-style error just like Throttled(), but it also tells the caller how long to
+// wait before trying again. The API gateway includes that as the "Retry-After" header in the response.
+//
+//	if !limiter.Allow() {
+//		return nil, fail.ThrottledAfter(30*time.Second, "slow down, dude")
+//	}
+func ThrottledAfter(retryAfter time.Duration, messageFormat string, args ...any) RetryableError {
+	return RetryableError{
+		StatusError: New(http.StatusTooManyRequests, messageFormat, args...),
+		Delay:       retryAfter,
+	}
+}
+
+// UnavailableAfter is a 503-style error just like Unavailable(), but it also tells the caller how long to
+// wait before trying again. The API gateway includes that as the "Retry-After" header in the response.
+func UnavailableAfter(retryAfter time.Duration, messageFormat string, args ...any) RetryableError {
+	return RetryableError{
+		StatusError: New(http.StatusServiceUnavailable, messageFormat, args...),
+		Delay:       retryAfter,
+	}
+}
+
+// RetryAfter looks for a RetryAfter() method on the error to figure out how long the caller should wait
+// before trying again. The 'ok' value is false when the error doesn't say anything about retrying.
+//
+//	err := client.Lookup(ctx, &LookupRequest{ID: "123"})
+//	if delay, ok := fail.RetryAfter(err); ok {
+//		time.Sleep(delay)
+//		...
+//	}
+func RetryAfter(err error) (time.Duration, bool) {
+	var errRetryAfter errorWithRetryAfter
+	if errors.As(err, &errRetryAfter) {
+		return errRetryAfter.RetryAfter(), true
+	}
+	return 0, false
+}
+
+type errorWithRetryAfter interface {
+	error
+	RetryAfter() time.Duration
+}
@@ -0,0 +1,76 @@
+//go:build unit
+
+package generate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/internal/testext"
+	gen "github.com/bridgekit-io/frodo/internal/testext/gen"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestFakeClientSuite(t *testing.T) {
+	suite.Run(t, new(FakeClientSuite))
+}
+
+type FakeClientSuite struct {
+	suite.Suite
+}
+
+// Ensures that the fake can stand in for the real client.
+func (suite *FakeClientSuite) TestInterface() {
+	var client testext.SampleService = gen.SampleServiceFakeClient()
+	suite.NotNil(client)
+}
+
+// Ensures that functions without a programmed response fail rather than returning empty data.
+func (suite *FakeClientSuite) TestNotImplemented() {
+	client := gen.SampleServiceFakeClient()
+	_, err := client.Defaults(context.Background(), &testext.SampleRequest{ID: "1"})
+	suite.Require().Error(err)
+	suite.Equal(501, fail.Status(err))
+}
+
+// Ensures that the client checks its arguments just like the real one.
+func (suite *FakeClientSuite) TestPreconditions() {
+	client := gen.SampleServiceFakeClient()
+	client.SetDefaults(&testext.SampleResponse{Text: "Hello"}, nil)
+
+	_, err := client.Defaults(nil, &testext.SampleRequest{})
+	suite.Error(err)
+	_, err = client.Defaults(context.Background(), nil)
+	suite.Error(err)
+}
+
+// Ensures that every call gets the canned response/error, and that stubs can base theirs on the request.
+func (suite *FakeClientSuite) TestSetAndStub() {
+	ctx := context.Background()
+	client := gen.SampleServiceFakeClient()
+
+	client.SetDefaults(&testext.SampleResponse{Text: "Hello"}, nil)
+	for i := 0; i < 2; i++ {
+		res, err := client.Defaults(ctx, &testext.SampleRequest{ID: "1"})
+		suite.Require().NoError(err)
+		suite.Equal("Hello", res.Text)
+	}
+
+	client.SetFail4XX(nil, errors.New("nope"))
+	_, err := client.Fail4XX(ctx, &testext.SampleRequest{})
+	suite.EqualError(err, "nope")
+
+	client.StubDefaults(func(_ context.Context, req *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return &testext.SampleResponse{ID: req.ID, Text: "Stubbed"}, nil
+	})
+	res, err := client.Defaults(ctx, &testext.SampleRequest{ID: "2"})
+	suite.Require().NoError(err)
+	suite.Equal("2", res.ID)
+	suite.Equal("Stubbed", res.Text)
+
+	client.StubDefaults(nil)
+	_, err = client.Defaults(ctx, &testext.SampleRequest{ID: "1"})
+	suite.Equal(501, fail.Status(err), "A nil stub should go back to not implemented")
+}
@@ -57,6 +57,26 @@ func (suite *GoClientSuite) TestDefaults() {
 	suite.Require().Equal("Defaults:Abide", res.Text)
 }
 
+// Ensures that the context-aware constructor gives you a working client once it resolves the address, and
+// that it respects the context while doing so.
+func (suite *GoClientSuite) TestClientContext() {
+	address, shutdown := suite.startServer()
+	defer shutdown()
+
+	ctx := context.Background()
+	client, err := gen.SampleServiceClientContext(ctx, address)
+	suite.Require().NoError(err)
+	res, err := client.Defaults(ctx, &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.Require().Equal("Defaults:Abide", res.Text)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	client, err = gen.SampleServiceClientContext(cancelled, address)
+	suite.Require().ErrorIs(err, context.Canceled)
+	suite.Require().Nil(client)
+}
+
 // Ensures that we can encode/decode non-flat structs w/ nothing but strings.
 func (suite *GoClientSuite) TestComplexValues() {
 	address, shutdown := suite.startServer()
@@ -435,6 +455,20 @@ func (suite *GoClientSuite) TestRoles() {
 	})
 }
 
+// Ensures that clients can discover the unresolved role templates for each function without calling the service.
+func (suite *GoClientSuite) TestRoleTemplates() {
+	roles := gen.SampleServiceRoles()
+	suite.Equal([]string{"admin.write", "user.{ID}.write", "user.{User.ID}.admin", "junk.{NotReal}.crap"}, roles["SecureWithRoles"])
+	suite.Equal([]string{"admin.write", "user.{FancyID}.write", "user.{User.FancyID}.admin", "junk.{NotReal}.crap"}, roles["SecureWithRolesAliased"])
+
+	defaults, ok := roles["Defaults"]
+	suite.True(ok, "Functions without a ROLES doc option should still be present")
+	suite.Empty(defaults)
+
+	_, ok = roles["NotARealFunction"]
+	suite.False(ok)
+}
+
 func (suite *GoClientSuite) TestRolesAliased() {
 	address, shutdown := suite.startServer()
 	defer shutdown()
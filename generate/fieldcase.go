@@ -0,0 +1,124 @@
+package generate
+
+import (
+	"log"
+	"strings"
+
+	"github.com/bridgekit-io/frodo/internal/naming"
+	"github.com/bridgekit-io/frodo/parser"
+)
+
+// FieldCase values are the supported ways to rename struct fields in the non-Go clients (the "--field-case" option).
+const (
+	// FieldCaseDefault leaves field names up to the template (e.g. the JS client uses the JSON names as-is).
+	FieldCaseDefault = ""
+	// FieldCaseCamel renames fields like "phoneNumber".
+	FieldCaseCamel = "camel"
+	// FieldCaseSnake renames fields like "phone_number".
+	FieldCaseSnake = "snake"
+	// FieldCasePascal renames fields like "PhoneNumber".
+	FieldCasePascal = "pascal"
+)
+
+// ValidFieldCase returns true if the value is one of the FieldCase constants that templates know how to apply.
+func ValidFieldCase(fieldCase string) bool {
+	switch fieldCase {
+	case FieldCaseDefault, FieldCaseCamel, FieldCaseSnake, FieldCasePascal:
+		return true
+	default:
+		return false
+	}
+}
+
+// fieldCaseFunctions are the template functions that let client templates rename fields to the casing the
+// developer asked for. The server still decodes JSON using the binding (JSON) name, so templates should only
+// use these names for the properties in the client code, never for the keys they send over the wire.
+type fieldCaseFunctions struct {
+	fieldCase string
+}
+
+// fieldName converts the field's binding name to the requested casing. Templates supply the casing they want to
+// use when the developer didn't ask for one (e.g. Dart prefers "camel" while JS uses the binding name as-is).
+func (funcs fieldCaseFunctions) fieldName(defaultCase string, bindingName string) string {
+	// Templates have always generated their default names w/o splitting on underscores, so we stick with that
+	// to avoid renaming properties in existing clients just because you upgraded frodo.
+	if funcs.fieldCase == FieldCaseDefault && defaultCase == FieldCaseCamel {
+		return naming.ToLowerCamel(bindingName)
+	}
+	if funcs.fieldCase == FieldCaseDefault {
+		return bindingName
+	}
+
+	switch funcs.fieldCase {
+	case FieldCaseCamel:
+		return naming.ToLowerCamel(joinWords(bindingName))
+	case FieldCasePascal:
+		return naming.ToUpperCamel(joinWords(bindingName))
+	case FieldCaseSnake:
+		return naming.ToSnake(bindingName)
+	default:
+		return bindingName
+	}
+}
+
+// enabled returns true when the developer explicitly asked for a field casing.
+func (funcs fieldCaseFunctions) enabled() bool {
+	return funcs.fieldCase != FieldCaseDefault
+}
+
+// warnCollisions logs a warning for each type where 2 or more fields end up w/ the same name once we apply the
+// casing (e.g. "ID" and "Id" are both "id" in snake case). The client can't tell those fields apart, so the
+// generated code will likely not compile or will silently drop one of the values.
+func (funcs fieldCaseFunctions) warnCollisions(data any) {
+	ctx, ok := data.(*parser.Context)
+	if !ok || !funcs.enabled() {
+		return
+	}
+
+	for _, t := range ctx.Types.NonBasicTypes() {
+		seen := map[string]string{}
+		for _, field := range t.NonOmittedFields() {
+			name := funcs.fieldName(FieldCaseDefault, field.Binding.Name)
+			if other, ok := seen[name]; ok {
+				log.Printf("Warning: fields '%s' and '%s' of type '%s' are both named '%s' w/ field case '%s'",
+					other, field.Binding.Name, t.Name, name, funcs.fieldCase)
+				continue
+			}
+			seen[name] = field.Binding.Name
+		}
+	}
+}
+
+// joinWords turns snake-cased names like "phone_number" into "phoneNumber", so camel/pascal casing works even
+// when your `json` tags use underscores.
+func joinWords(value string) string {
+	words := strings.Split(value, "_")
+	for i := 1; i < len(words); i++ {
+		words[i] = naming.ToUpperCamel(words[i])
+	}
+	return strings.Join(words, "")
+}
+
+// jsFieldType describes the nested type of a field so that the JS client can rename the fields of nested
+// objects, too. This is the type's name for objects, "[]Type" for slices, and "map:Type" for maps. It's
+// an empty string for everything else since those values don't have fields that need to be renamed.
+func jsFieldType(t *parser.TypeDeclaration) string {
+	switch {
+	case t == nil || t.Basic || t.Implements.MarshalJSON:
+		return ""
+	case t.SliceLike():
+		if elem := jsFieldType(t.Elem); elem != "" {
+			return "[]" + elem
+		}
+		return ""
+	case t.MapLike():
+		if elem := jsFieldType(t.Elem); elem != "" {
+			return "map:" + elem
+		}
+		return ""
+	case t.ObjectLike():
+		return naming.JoinPackageName(naming.NoPointer(t.Name))
+	default:
+		return ""
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -139,6 +140,10 @@ type FileTemplate struct {
 	FileSystem fs.FS
 	// Path is the location on the FileSystem where this template is located.
 	Path string
+	// FieldCase renames struct fields in the properties of non-Go clients (e.g. "camel" turns "PhoneNumber"
+	// into "phoneNumber"). See the FieldCase constants for the supported values. The client still sends and
+	// receives the JSON names that the server expects, so this only affects how your client code looks.
+	FieldCase string
 }
 
 // Eval runs the given value through the Go template resolved by looking up Path in the FileSystem. The 'data'
@@ -150,8 +155,20 @@ func (t FileTemplate) Eval(data any) ([]byte, error) {
 		return nil, fmt.Errorf("unable to read template: %w", err)
 	}
 
+	if !ValidFieldCase(t.FieldCase) {
+		return nil, fmt.Errorf("unsupported field case: '%s'", t.FieldCase)
+	}
+	fieldCase := fieldCaseFunctions{fieldCase: t.FieldCase}
+	fieldCase.warnCollisions(data)
+
 	templateText := string(templateData)
-	codeTemplate, err := template.New(t.Name).Funcs(templateFuncs).Parse(templateText)
+	codeTemplate, err := template.New(t.Name).
+		Funcs(templateFuncs).
+		Funcs(template.FuncMap{
+			"FieldName":        fieldCase.fieldName,
+			"FieldCaseEnabled": fieldCase.enabled,
+		}).
+		Parse(templateText)
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse template: %w", err)
@@ -194,8 +211,10 @@ var templateFuncs = template.FuncMap{
 
 	// Language/format-specific value conversions
 	"JSONType":       jsonFunctions{}.convertType,
+	"JSONSchema":     jsonSchemaFunctions{}.endpointSchema,
 	"JSPropertyType": jsFunctions{}.convertPropertyType,
 	"JSTypedefType":  jsFunctions{}.convertTypedefType,
+	"JSFieldType":    jsFieldType,
 	"JavaPackage":    javaFunctions{}.convertPackage,
 	"JavaType":       javaFunctions{}.convertType,
 	"DartType":       dartFunctions{}.convertType,
@@ -214,6 +233,9 @@ func (funcs jsFunctions) convertPropertyType(t *parser.TypeDeclaration) string {
 func (funcs jsFunctions) convertTypedefType(t *parser.TypeDeclaration) string {
 	switch t.Kind {
 	case reflect.String:
+		if t.Enum() {
+			return funcs.convertEnumType(t)
+		}
 		return "string"
 	case reflect.Bool:
 		return "boolean"
@@ -239,6 +261,15 @@ func (funcs jsFunctions) convertTypedefType(t *parser.TypeDeclaration) string {
 	}
 }
 
+// convertEnumType describes an enum as a union of its string literals (e.g. "active"|"paused").
+func (funcs jsFunctions) convertEnumType(t *parser.TypeDeclaration) string {
+	values := make([]string, len(t.EnumValues))
+	for i, value := range t.EnumValues {
+		values[i] = strconv.Quote(value)
+	}
+	return strings.Join(values, "|")
+}
+
 type jsonFunctions struct{}
 
 func (funcs jsonFunctions) convertType(t *parser.TypeDeclaration) string {
@@ -346,8 +377,9 @@ type openapiFunctions struct{}
 // path that OpenAPI/Swagger prefers: "/foo/{bar}/baz/{goo}"
 func (funcs openapiFunctions) convertPath(path string) string {
 	// The ":VAR" style is our old way of representing things. Now, we use "{VAR}" notation, so it lines up
-	// one to one with OpenAPI, and it's up to the HTTP gateway to convert "{}" to ":".
-	return path
+	// one to one with OpenAPI, and it's up to the HTTP gateway to convert "{}" to ":". OpenAPI doesn't have
+	// wildcards, though, so "{Path...}" just becomes "{Path}".
+	return strings.ReplaceAll(path, "...}", "}")
 	/*
 		segments := strings.Split(path, "/")
 		for i, segment := range segments {
@@ -3,10 +3,16 @@
 package generate_test
 
 import (
+	"encoding/json"
+	"go/format"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/bridgekit-io/frodo/generate"
+	"github.com/bridgekit-io/frodo/parser"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -81,6 +87,271 @@ func (suite *FileTemplateSuite) TestEval_success() {
 	suite.Require().Equal(expected, string(output))
 }
 
+// Ensures that the standard templates validate enum fields and list their allowed values.
+func (suite *FileTemplateSuite) TestEval_enums() {
+	ctx, err := parser.ParseFile("../parser/testdata/enums/service.go")
+	suite.Require().NoError(err)
+
+	eval := func(name string) string {
+		output, err := generate.NewStandardTemplate(name, "templates/"+name+".tmpl").Eval(ctx)
+		suite.Require().NoError(err)
+		if strings.HasSuffix(name, ".go") {
+			_, err = format.Source(output)
+			suite.Require().NoError(err, "Generated Go code should be valid")
+		}
+		return string(output)
+	}
+
+	server := eval("server.go")
+	suite.Contains(server, `services.ValidateEnum("Status", typedReq.Status, "paused", "active", "archived")`)
+	suite.Contains(server, `services.ValidateEnum("StatusPointer", *typedReq.StatusPointer, "paused", "active", "archived")`)
+	suite.Contains(server, `services.ValidateEnum("EmbeddedStatus", typedReq.EmbeddedStatus, "paused", "active", "archived")`)
+	suite.NotContains(server, `"Label"`)
+	suite.NotContains(server, `"Omitted"`)
+	suite.NotContains(server, `"EmbeddedPointerStatus"`)
+
+	client := eval("client.go")
+	suite.Contains(client, `"github.com/bridgekit-io/frodo/services"`)
+	suite.Contains(client, `services.ValidateEnum("Status", request.Status, "paused", "active", "archived")`)
+
+	js := eval("client.js")
+	suite.Contains(js, `validateEnum('Status', serviceRequest['Status'], ["paused", "active", "archived"]);`)
+	suite.Contains(js, `@typedef { "paused"|"active"|"archived" } Status`)
+
+	openapi := eval("openapi.yml")
+	suite.Regexp(`enum:\s+- "paused"\s+- "active"\s+- "archived"`, openapi)
+}
+
+// Ensures that event weights make it into the generated server, but only for routes that have one.
+func (suite *FileTemplateSuite) TestEval_eventWeights() {
+	ctx, err := parser.ParseFile("../parser/testdata/docoptions/service.go")
+	suite.Require().NoError(err)
+
+	output, err := generate.NewStandardTemplate("server.go", "templates/server.go.tmpl").Eval(ctx)
+	suite.Require().NoError(err)
+	_, err = format.Source(output)
+	suite.Require().NoError(err, "Generated Go code should be valid")
+
+	server := string(output)
+	suite.Regexp(`Group:\s+"League",\s+Weight:\s+3,`, server)
+	suite.Regexp(`Group:\s+"",\s+Weight:\s+2,`, server)
+	suite.Equal(2, strings.Count(server, "Weight:"))
+}
+
+// Ensures that the HTTP ALLOW/CACHE options make it into the generated server's routes.
+func (suite *FileTemplateSuite) TestEval_httpOptions() {
+	ctx, err := parser.ParseFile("../parser/testdata/httpoptions/service.go")
+	suite.Require().NoError(err)
+
+	output, err := generate.NewStandardTemplate("server.go", "templates/server.go.tmpl").Eval(ctx)
+	suite.Require().NoError(err)
+	_, err = format.Source(output)
+	suite.Require().NoError(err, "Generated Go code should be valid")
+
+	server := string(output)
+	suite.Regexp(`AllowMethods:\s+\[\]string\{"HEAD", "OPTIONS", "GET", \},\s+CacheControl:\s+"public, max-age=60",`, server)
+	suite.Regexp(`AllowMethods:\s+\[\]string\{"GET", "OPTIONS", \},\s+CacheControl:\s+"no-store",`, server)
+}
+
+func (suite *FileTemplateSuite) TestEval_clientContext() {
+	ctx, err := parser.ParseFile("../parser/testdata/docoptions/service.go")
+	suite.Require().NoError(err)
+
+	output, err := generate.NewStandardTemplate("client.go", "templates/client.go.tmpl").Eval(ctx)
+	suite.Require().NoError(err)
+	_, err = format.Source(output)
+	suite.Require().NoError(err, "Generated Go code should be valid")
+
+	client := string(output)
+	suite.Contains(client, "func LebowskiServiceClient(address string, options ...clients.ClientOption) docoptions.LebowskiService {")
+	suite.Contains(client, "func LebowskiServiceClientContext(ctx context.Context, address string, options ...clients.ClientOption) (docoptions.LebowskiService, error) {")
+	suite.Contains(client, `clients.NewClientContext(ctx, "LebowskiService", address, options...)`)
+}
+
+// Ensures that "ROLES ALL" functions tell the server that callers need every role.
+func (suite *FileTemplateSuite) TestEval_requireAllRoles() {
+	ctx, err := parser.ParseFile("../parser/testdata/docoptions/service.go")
+	suite.Require().NoError(err)
+
+	output, err := generate.NewStandardTemplate("server.go", "templates/server.go.tmpl").Eval(ctx)
+	suite.Require().NoError(err)
+	_, err = format.Source(output)
+	suite.Require().NoError(err, "Generated Go code should be valid")
+
+	server := string(output)
+	suite.Regexp(`"dude.\{id\}.write",\s+},\s+RequireAllRoles:\s+true,`, server)
+	suite.Equal(2, strings.Count(server, "RequireAllRoles:"), "Endpoint and route of Walter")
+}
+
+// Ensures that deprecated functions carry their sunset date into the generated server.
+func (suite *FileTemplateSuite) TestEval_deprecated() {
+	ctx, err := parser.ParseFile("../parser/testdata/docoptions/service.go")
+	suite.Require().NoError(err)
+
+	output, err := generate.NewStandardTemplate("server.go", "templates/server.go.tmpl").Eval(ctx)
+	suite.Require().NoError(err)
+	_, err = format.Source(output)
+	suite.Require().NoError(err, "Generated Go code should be valid")
+
+	server := string(output)
+	suite.Regexp(`Deprecated:\s+true,\s+Sunset:\s+"2025-06-01",`, server)
+	suite.Equal(6, strings.Count(server, "Deprecated:"), "Endpoint and route of Maude, Jackie, and Stranger")
+}
+
+// Ensures that API endpoints carry the JSON Schema of their request/response into the generated server.
+func (suite *FileTemplateSuite) TestEval_schema() {
+	ctx, err := parser.ParseFile("../parser/testdata/fieldtypes/service.go")
+	suite.Require().NoError(err)
+
+	output, err := generate.NewStandardTemplate("server.go", "templates/server.go.tmpl").Eval(ctx)
+	suite.Require().NoError(err)
+	_, err = format.Source(output)
+	suite.Require().NoError(err, "Generated Go code should be valid")
+
+	match := regexp.MustCompile(`Schema:\s+(".*"),`).FindStringSubmatch(string(output))
+	suite.Require().Len(match, 2)
+	schemaJSON, err := strconv.Unquote(match[1])
+	suite.Require().NoError(err)
+
+	type schemaDef struct {
+		Type                 string
+		Format               string
+		Ref                  string `json:"$ref"`
+		Items                *schemaDef
+		AdditionalProperties *schemaDef
+		Properties           map[string]schemaDef
+	}
+	schema := struct {
+		Schema     string `json:"$schema"`
+		Title      string
+		Properties map[string]schemaDef
+		Defs       map[string]schemaDef `json:"$defs"`
+	}{}
+	suite.Require().NoError(json.Unmarshal([]byte(schemaJSON), &schema))
+
+	suite.Equal("https://json-schema.org/draft/2020-12/schema", schema.Schema)
+	suite.Equal("HappyLittleService.PaintTree", schema.Title)
+	suite.Equal("#/$defs/Request", schema.Properties["request"].Ref)
+	suite.Equal("#/$defs/Response", schema.Properties["response"].Ref)
+
+	request := schema.Defs["Request"].Properties
+	suite.Equal("string", request["Basic"].Type)
+	suite.Equal("string", request["BasicPointer"].Type)
+	suite.Equal("date-time", request["Time"].Format)
+	suite.Equal("date-time", request["TimePointer"].Format)
+	suite.Equal("string", request["BasicSlice"].Items.Type)
+	suite.Equal("string", request["BasicMap"].AdditionalProperties.Type)
+	suite.Equal("#/$defs/ExportedStruct", request["ExportedStruct"].Ref)
+	suite.Equal("#/$defs/ExportedStruct", request["ExportedStructPointer"].Ref)
+	suite.Equal("string", schema.Defs["ExportedStruct"].Properties["Name"].Type)
+	suite.NotContains(request, "notExported")
+}
+
+// Ensures that the fake JS client can be swapped in for the real one and only knows about the service's functions.
+func (suite *FileTemplateSuite) TestEval_fakeJS() {
+	ctx, err := parser.ParseFile("../internal/testext/other_service.go")
+	suite.Require().NoError(err)
+
+	output, err := generate.NewStandardTemplate("client.fake.js", "templates/client.fake.js.tmpl").Eval(ctx)
+	suite.Require().NoError(err)
+
+	js := string(output)
+	suite.Contains(js, `class FakeOtherServiceClient {`)
+	suite.Contains(js, `export { FakeOtherServiceClient, FakeOtherServiceClient as OtherServiceClient, GatewayError };`)
+	suite.Contains(js, `return this._invoke('SpaceOut', serviceRequest, {authorization});`)
+	suite.NotContains(js, `fetch(`, "The fake should never talk to the server")
+}
+
+// Ensures that the non-Go clients rename fields to the requested casing, but still use the server's JSON names.
+func (suite *FileTemplateSuite) TestEval_fieldCase() {
+	ctx, err := parser.ParseFile("../parser/testdata/bindingopts/service.go")
+	suite.Require().NoError(err)
+
+	eval := func(name string, fieldCase string) string {
+		fileTemplate := generate.NewStandardTemplate(name, "templates/"+name+".tmpl")
+		fileTemplate.FieldCase = fieldCase
+		output, err := fileTemplate.Eval(ctx)
+		suite.Require().NoError(err)
+		return string(output)
+	}
+
+	js := eval("client.js", generate.FieldCaseDefault)
+	suite.Contains(js, `[record_id]`)
+	suite.Contains(js, `[Name]`)
+	suite.NotContains(js, `toServerFields`)
+	suite.NotContains(js, `const modelFields`)
+
+	js = eval("client.js", generate.FieldCaseCamel)
+	suite.Contains(js, `[recordId]`)
+	suite.Contains(js, `[name]`)
+	suite.Contains(js, `serviceRequest = toServerFields(serviceRequest, 'Request');`)
+	suite.Contains(js, `return fromServerFields(await handleResponseJSON(response), 'Response');`)
+	suite.Contains(js, `'record_id': ['recordId', ''],`)
+	suite.Contains(js, `'include': ['include', ''],`)
+	suite.NotContains(js, `'-'`)
+
+	js = eval("client.js", generate.FieldCaseSnake)
+	suite.Contains(js, `'record_id': ['record_id', ''],`)
+	suite.Contains(js, `'Name': ['name', ''],`)
+
+	js = eval("client.js", generate.FieldCasePascal)
+	suite.Contains(js, `'record_id': ['RecordId', ''],`)
+	suite.Contains(js, `'Name': ['Name', ''],`)
+
+	dart := eval("client.dart", generate.FieldCaseDefault)
+	suite.Contains(dart, `String? record_id;`)
+	suite.Contains(dart, `String? name;`)
+
+	dart = eval("client.dart", generate.FieldCaseCamel)
+	suite.Contains(dart, `String? recordId;`)
+	suite.Contains(dart, `'record_id': recordId,`)
+
+	dart = eval("client.dart", generate.FieldCaseSnake)
+	suite.Contains(dart, `String? record_id;`)
+	suite.Contains(dart, `String? name;`)
+	suite.Contains(dart, `'Name': name,`)
+
+	dart = eval("client.dart", generate.FieldCasePascal)
+	suite.Contains(dart, `String? RecordId;`)
+	suite.Contains(dart, `'record_id': RecordId,`)
+
+	_, err = generate.FileTemplate{Name: "client.js", FileSystem: generate.StandardTemplates, Path: "templates/client.js.tmpl", FieldCase: "kebab"}.Eval(ctx)
+	suite.Error(err)
+}
+
+// Ensures that the JS client knows how to rename the fields of nested objects, too.
+func (suite *FileTemplateSuite) TestEval_fieldCaseNested() {
+	ctx, err := parser.ParseFile("../parser/testdata/fieldtypes/service.go")
+	suite.Require().NoError(err)
+
+	fileTemplate := generate.NewStandardTemplate("client.js", "templates/client.js.tmpl")
+	fileTemplate.FieldCase = generate.FieldCaseCamel
+	output, err := fileTemplate.Eval(ctx)
+	suite.Require().NoError(err)
+
+	js := string(output)
+	suite.Contains(js, `'ExportedStruct': ['exportedStruct', 'ExportedStruct'],`)
+	suite.Contains(js, `'ExportedStructPointer': ['exportedStructPointer', 'ExportedStruct'],`)
+	suite.Contains(js, `'EmbeddedC': ['embeddedC', 'ExportedStruct'],`)
+	suite.Contains(js, `'Time': ['time', ''],`, "Types w/ custom JSON marshaling keep their fields as-is")
+	suite.Contains(js, `'BasicSlice': ['basicSlice', ''],`)
+}
+
+// Ensures that enum validation reports the client's name for the field, not the server's.
+func (suite *FileTemplateSuite) TestEval_fieldCaseEnums() {
+	ctx, err := parser.ParseFile("../parser/testdata/enums/service.go")
+	suite.Require().NoError(err)
+
+	fileTemplate := generate.NewStandardTemplate("client.js", "templates/client.js.tmpl")
+	fileTemplate.FieldCase = generate.FieldCaseSnake
+	output, err := fileTemplate.Eval(ctx)
+	suite.Require().NoError(err)
+
+	js := string(output)
+	suite.Contains(js, `validateEnum('status', serviceRequest['Status'], ["paused", "active", "archived"]);`)
+	suite.Contains(js, `'EmbeddedStatus': ['embedded_status', ''],`)
+}
+
 func TestFileTemplateSuite(t *testing.T) {
 	suite.Run(t, new(FileTemplateSuite))
 }
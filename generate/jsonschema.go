@@ -0,0 +1,120 @@
+package generate
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/bridgekit-io/frodo/internal/naming"
+	"github.com/bridgekit-io/frodo/parser"
+)
+
+type jsonSchemaFunctions struct{}
+
+// endpointSchema builds the JSON Schema document that describes a service function's request and response, so the
+// API gateway can serve it to tools that want to build forms and such (see apis.WithSchemas). It's a single object
+// schema whose "request" and "response" properties describe the two models. Every struct type that they use ends up
+// in "$defs", so recursive types work, too.
+func (funcs jsonSchemaFunctions) endpointSchema(function *parser.ServiceFunctionDeclaration) string {
+	defs := map[string]any{}
+	schema := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   function.Service.Name + "." + function.Name,
+		"type":    "object",
+		"properties": map[string]any{
+			"request":  funcs.typeSchema(function.Request, defs),
+			"response": funcs.typeSchema(function.Response, defs),
+		},
+		"$defs": defs,
+	}
+	if description := funcs.description(function.Documentation); description != "" {
+		schema["description"] = description
+	}
+
+	// Maps are encoded w/ sorted keys, so the output is the same every time you generate.
+	output, _ := json.Marshal(schema)
+	return string(output)
+}
+
+// typeSchema describes a single type. Structs are added to 'defs' (if they aren't there already), and we just
+// refer to them, so each struct is only described once no matter how many times it shows up.
+func (funcs jsonSchemaFunctions) typeSchema(t *parser.TypeDeclaration, defs map[string]any) map[string]any {
+	if t == nil {
+		return map[string]any{}
+	}
+
+	switch {
+	case naming.NoPointer(t.Name) == "time.Time":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Implements.MarshalJSON:
+		// We have no idea what your custom JSON looks like, so anything goes.
+		return map[string]any{}
+	case t.Enum():
+		return map[string]any{"type": "string", "enum": t.EnumValues}
+	}
+
+	switch t.Kind {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Array, reflect.Slice:
+		if t.Elem != nil && t.Elem.Kind == reflect.Uint8 {
+			// The standard JSON encoder writes []byte as a base64 string.
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": funcs.typeSchema(t.Elem, defs)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": funcs.typeSchema(t.Elem, defs)}
+	case reflect.Struct:
+		return funcs.structSchema(t, defs)
+	default:
+		return map[string]any{}
+	}
+}
+
+// structSchema adds the struct's definition to 'defs' and returns a reference to it.
+func (funcs jsonSchemaFunctions) structSchema(t *parser.TypeDeclaration, defs map[string]any) map[string]any {
+	name := naming.CleanTypeNameUpper(t.Name)
+	ref := map[string]any{"$ref": "#/$defs/" + name}
+	if _, ok := defs[name]; ok {
+		return ref
+	}
+
+	properties := map[string]any{}
+	definition := map[string]any{"type": "object", "properties": properties}
+	if description := funcs.description(t.Documentation); description != "" {
+		definition["description"] = description
+	}
+
+	// Claim the name before we describe the fields, so a struct that refers to itself doesn't recurse forever.
+	defs[name] = definition
+	for _, field := range t.NonOmittedFields() {
+		property := funcs.typeSchema(field.Type, defs)
+		if description := funcs.description(field.Documentation); description != "" {
+			property = withDescription(property, description)
+		}
+		properties[field.Binding.Name] = property
+	}
+	return ref
+}
+
+// description turns doc comment lines into a single description string.
+func (funcs jsonSchemaFunctions) description(docs parser.DocumentationLines) string {
+	return strings.TrimSpace(strings.Join(docs.Trim(), "\n"))
+}
+
+// withDescription adds the description to a copy of the property's schema, so we never modify a shared one.
+func withDescription(property map[string]any, description string) map[string]any {
+	described := map[string]any{"description": description}
+	for key, value := range property {
+		described[key] = value
+	}
+	return described
+}
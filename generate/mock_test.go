@@ -0,0 +1,125 @@
+//go:build unit
+
+package generate_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/internal/testext"
+	gen "github.com/bridgekit-io/frodo/internal/testext/gen"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMockSuite(t *testing.T) {
+	suite.Run(t, new(MockSuite))
+}
+
+type MockSuite struct {
+	suite.Suite
+}
+
+// recordingT captures assertion failures from the mock so we can verify them without failing this test.
+type recordingT struct {
+	errors []string
+}
+
+func (t *recordingT) Helper() {}
+
+func (t *recordingT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (suite *MockSuite) TestNotImplemented() {
+	mock := &gen.MockSampleService{}
+	_, err := mock.Defaults(context.Background(), &testext.SampleRequest{ID: "1"})
+	suite.Error(err)
+	suite.Equal(1, mock.Calls.Defaults.Times(), "Calls should be recorded even if there's no behavior")
+}
+
+func (suite *MockSuite) TestOn() {
+	ctx := context.Background()
+	mock := &gen.MockSampleService{
+		DefaultsFunc: func(ctx context.Context, req *testext.SampleRequest) (*testext.SampleResponse, error) {
+			return &testext.SampleResponse{Text: "Func"}, nil
+		},
+	}
+	mock.OnDefaults(func(req *testext.SampleRequest) bool { return req.ID == "1" }).
+		Return(&testext.SampleResponse{Text: "One"}, nil)
+	mock.OnDefaults(func(req *testext.SampleRequest) bool { return req.ID == "2" }).
+		Return(nil, fmt.Errorf("two"))
+	mock.OnDefaults(func(req *testext.SampleRequest) bool { return req.ID == "3" })
+	mock.OnDefaults(func(req *testext.SampleRequest) bool { return req.ID == "4" }).
+		Do(func(ctx context.Context, req *testext.SampleRequest) (*testext.SampleResponse, error) {
+			return &testext.SampleResponse{Text: "Do:" + req.Text}, nil
+		})
+
+	res, err := mock.Defaults(ctx, &testext.SampleRequest{ID: "1"})
+	suite.NoError(err)
+	suite.Equal("One", res.Text)
+
+	_, err = mock.Defaults(ctx, &testext.SampleRequest{ID: "2"})
+	suite.EqualError(err, "two")
+
+	res, err = mock.Defaults(ctx, &testext.SampleRequest{ID: "3"})
+	suite.NoError(err)
+	suite.Equal(&testext.SampleResponse{}, res, "Expectations w/o behaviors should respond w/ an empty response")
+
+	res, err = mock.Defaults(ctx, &testext.SampleRequest{ID: "4", Text: "Hello"})
+	suite.NoError(err)
+	suite.Equal("Do:Hello", res.Text)
+
+	res, err = mock.Defaults(ctx, &testext.SampleRequest{ID: "5"})
+	suite.NoError(err)
+	suite.Equal("Func", res.Text, "Should fall back to XxxFunc when no expectation matches")
+}
+
+func (suite *MockSuite) TestOn_firstMatchWins() {
+	mock := &gen.MockSampleService{}
+	mock.OnDefaults(nil).Return(&testext.SampleResponse{Text: "First"}, nil)
+	mock.OnDefaults(nil).Return(&testext.SampleResponse{Text: "Second"}, nil)
+
+	res, err := mock.Defaults(context.Background(), &testext.SampleRequest{})
+	suite.NoError(err)
+	suite.Equal("First", res.Text)
+}
+
+func (suite *MockSuite) TestOn_nameCollision() {
+	ctx := context.Background()
+	mock := &gen.MockSampleService{}
+	mock.OnFailAlwaysCall(nil).Return(&testext.FailAlwaysResponse{}, nil)
+	mock.OnOnFailAlways(nil).Return(nil, fmt.Errorf("nope"))
+
+	_, err := mock.FailAlways(ctx, &testext.FailAlwaysRequest{})
+	suite.NoError(err, "OnFailAlwaysCall should program FailAlways since the service already has an OnFailAlways")
+
+	_, err = mock.OnFailAlways(ctx, &testext.FailAlwaysErrorRequest{})
+	suite.EqualError(err, "nope")
+}
+
+func (suite *MockSuite) TestCalls() {
+	ctx := context.Background()
+	mock := &gen.MockSampleService{}
+	mock.OnDefaults(nil)
+	mock.OnSleep(nil)
+
+	_, _ = mock.Defaults(ctx, &testext.SampleRequest{ID: "1"})
+	_, _ = mock.Sleep(ctx, &testext.SampleRequest{ID: "2"})
+	_, _ = mock.Defaults(ctx, &testext.SampleRequest{ID: "3"})
+
+	suite.Equal([]testext.SampleRequest{{ID: "1"}, {ID: "3"}}, mock.Calls.Defaults.Requests())
+	suite.Equal([]testext.SampleRequest{{ID: "2"}}, mock.Calls.Sleep.Requests())
+	suite.Equal(1, mock.Calls.Defaults[0].Sequence)
+	suite.Equal(2, mock.Calls.Sleep[0].Sequence)
+	suite.Equal(3, mock.Calls.Defaults[1].Sequence)
+
+	t := &recordingT{}
+	suite.True(mock.AssertDefaultsCalled(t, 2))
+	suite.True(mock.AssertSleepCalled(t, 1))
+	suite.True(mock.AssertPanicCalled(t, 0))
+	suite.Empty(t.errors)
+
+	suite.False(mock.AssertDefaultsCalled(t, 1))
+	suite.Equal([]string{"SampleService.Defaults was called 2 time(s); expected 1"}, t.errors)
+}
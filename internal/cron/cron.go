@@ -0,0 +1,192 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed, standard 5-field cron expression ("minute hour day-of-month month day-of-week"). Use
+// Parse() to create one, and Next() to figure out when the job should run again.
+type Schedule struct {
+	minutes    uint64
+	hours      uint64
+	daysOfMon  uint64
+	months     uint64
+	daysOfWeek uint64
+	// anyDayOfMon and anyDayOfWeek track whether those fields were "*". Cron has a quirk where if you restrict
+	// both of the day fields, the job runs when EITHER of them match rather than when both of them match.
+	anyDayOfMon  bool
+	anyDayOfWeek bool
+}
+
+// macros are the common shorthand expressions that most cron implementations support.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field describes the valid range of values for one of the 5 cron fields.
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField    = field{name: "minute", min: 0, max: 59}
+	hourField      = field{name: "hour", min: 0, max: 23}
+	dayOfMonField  = field{name: "day of month", min: 1, max: 31}
+	monthField     = field{name: "month", min: 1, max: 12, names: map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}}
+	dayOfWeekField = field{name: "day of week", min: 0, max: 7, names: map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}}
+)
+
+// Parse reads a standard 5-field cron expression such as "0 2 * * *" (2am every day) or "*/15 9-17 * * MON-FRI"
+// (every 15 minutes during business hours). Each field supports "*", single values, ranges ("1-5"), lists ("1,3,5"),
+// and steps ("*/15" or "0-30/10"). Months and days of the week can also use their 3-letter names, and you can use
+// the macros "@yearly", "@monthly", "@weekly", "@daily", "@midnight", and "@hourly".
+func Parse(expression string) (Schedule, error) {
+	expression = strings.TrimSpace(expression)
+	if macro, ok := macros[strings.ToLower(expression)]; ok {
+		expression = macro
+	}
+
+	tokens := strings.Fields(expression)
+	if len(tokens) != 5 {
+		return Schedule{}, fmt.Errorf("invalid cron expression '%s': expected 5 fields, got %d", expression, len(tokens))
+	}
+
+	var err error
+	schedule := Schedule{
+		anyDayOfMon:  tokens[2] == "*",
+		anyDayOfWeek: tokens[4] == "*",
+	}
+	if schedule.minutes, err = parseField(tokens[0], minuteField); err != nil {
+		return Schedule{}, fmt.Errorf("invalid cron expression '%s': %w", expression, err)
+	}
+	if schedule.hours, err = parseField(tokens[1], hourField); err != nil {
+		return Schedule{}, fmt.Errorf("invalid cron expression '%s': %w", expression, err)
+	}
+	if schedule.daysOfMon, err = parseField(tokens[2], dayOfMonField); err != nil {
+		return Schedule{}, fmt.Errorf("invalid cron expression '%s': %w", expression, err)
+	}
+	if schedule.months, err = parseField(tokens[3], monthField); err != nil {
+		return Schedule{}, fmt.Errorf("invalid cron expression '%s': %w", expression, err)
+	}
+	if schedule.daysOfWeek, err = parseField(tokens[4], dayOfWeekField); err != nil {
+		return Schedule{}, fmt.Errorf("invalid cron expression '%s': %w", expression, err)
+	}
+
+	// Both 0 and 7 mean Sunday, so fold 7 into 0 to make matching simpler.
+	if schedule.daysOfWeek&(1<<7) != 0 {
+		schedule.daysOfWeek = schedule.daysOfWeek&^(1<<7) | 1
+	}
+	return schedule, nil
+}
+
+// Next returns the first time strictly after 't' that matches the schedule. Times are evaluated in the location
+// of 't', so "0 2 * * *" means 2am in whatever time zone you pass in. This returns the zero time if the schedule
+// can never be satisfied (e.g. "0 0 31 2 *" since February never has 31 days).
+func (s Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// Every valid schedule matches at least once within a few years (leap days being the worst case), so
+	// give up after that rather than spinning forever on impossible dates like February 31st.
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !has(s.months, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !has(s.hours, t.Hour()):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !has(s.minutes, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s Schedule) matchesDay(t time.Time) bool {
+	dayOfMon := has(s.daysOfMon, t.Day())
+	dayOfWeek := has(s.daysOfWeek, int(t.Weekday()))
+	if s.anyDayOfMon || s.anyDayOfWeek {
+		return dayOfMon && dayOfWeek
+	}
+	return dayOfMon || dayOfWeek
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
+
+// parseField converts a single field of the expression (e.g. "1-5" or "*/15") into a bitset
+// where bit N is set if the value N is allowed.
+func parseField(token string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(token, ",") {
+		rangeText, stepText, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepText); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid %s step: '%s'", f.name, part)
+			}
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangeText == "*":
+		case strings.Contains(rangeText, "-"):
+			lowText, highText, _ := strings.Cut(rangeText, "-")
+			var err error
+			if low, err = f.value(lowText); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(highText); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid %s range: '%s'", f.name, part)
+			}
+		default:
+			var err error
+			if low, err = f.value(rangeText); err != nil {
+				return 0, err
+			}
+			// Like most cron implementations, "5/10" means "starting at 5, every 10".
+			high = low
+			if hasStep {
+				high = f.max
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single number (or name like "MON") and makes sure that it's within the field's range.
+func (f field) value(text string) (int, error) {
+	if value, ok := f.names[strings.ToLower(text)]; ok {
+		return value, nil
+	}
+	value, err := strconv.Atoi(text)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("invalid %s: '%s'", f.name, text)
+	}
+	return value, nil
+}
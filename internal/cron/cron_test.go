@@ -0,0 +1,114 @@
+//go:build unit
+
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bridgekit-io/frodo/internal/cron"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCronSuite(t *testing.T) {
+	suite.Run(t, new(CronSuite))
+}
+
+type CronSuite struct {
+	suite.Suite
+}
+
+func (suite *CronSuite) date(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	suite.Require().NoError(err)
+	return t
+}
+
+func (suite *CronSuite) assertNext(expression string, from string, expected ...string) {
+	schedule, err := cron.Parse(expression)
+	suite.Require().NoError(err, expression)
+
+	t := suite.date(from)
+	for _, expectedValue := range expected {
+		t = schedule.Next(t)
+		suite.Equal(expectedValue, t.Format("2006-01-02 15:04"), expression)
+	}
+}
+
+func (suite *CronSuite) TestParse_invalid() {
+	invalid := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"* * * FOO *",
+		"@every 5m",
+	}
+	for _, expression := range invalid {
+		_, err := cron.Parse(expression)
+		suite.Error(err, expression)
+	}
+}
+
+func (suite *CronSuite) TestNext() {
+	suite.assertNext("* * * * *", "2024-01-01 10:30", "2024-01-01 10:31", "2024-01-01 10:32")
+	suite.assertNext("0 2 * * *", "2024-01-01 10:30", "2024-01-02 02:00", "2024-01-03 02:00")
+	suite.assertNext("0 2 * * *", "2024-01-01 01:59", "2024-01-01 02:00")
+	suite.assertNext("0 2 * * *", "2024-01-01 02:00", "2024-01-02 02:00")
+	suite.assertNext("*/15 * * * *", "2024-01-01 10:07", "2024-01-01 10:15", "2024-01-01 10:30", "2024-01-01 10:45", "2024-01-01 11:00")
+	suite.assertNext("5/20 * * * *", "2024-01-01 10:00", "2024-01-01 10:05", "2024-01-01 10:25", "2024-01-01 10:45", "2024-01-01 11:05")
+	suite.assertNext("0 9-17/4 * * *", "2024-01-01 10:00", "2024-01-01 13:00", "2024-01-01 17:00", "2024-01-02 09:00")
+	suite.assertNext("30 8 1,15 * *", "2024-01-10 00:00", "2024-01-15 08:30", "2024-02-01 08:30")
+	suite.assertNext("0 0 1 1 *", "2024-06-01 00:00", "2025-01-01 00:00")
+	suite.assertNext("0 0 29 2 *", "2024-03-01 00:00", "2028-02-29 00:00")
+	suite.assertNext("0 0 31 * *", "2024-04-01 00:00", "2024-05-31 00:00", "2024-07-31 00:00")
+}
+
+func (suite *CronSuite) TestNext_names() {
+	// 2024-01-01 was a Monday.
+	suite.assertNext("0 12 * * MON-FRI", "2024-01-05 13:00", "2024-01-08 12:00")
+	suite.assertNext("0 12 * JUN *", "2024-01-01 00:00", "2024-06-01 12:00")
+	suite.assertNext("0 12 * * sun", "2024-01-01 00:00", "2024-01-07 12:00")
+}
+
+func (suite *CronSuite) TestNext_sunday() {
+	suite.assertNext("0 0 * * 0", "2024-01-01 00:00", "2024-01-07 00:00", "2024-01-14 00:00")
+	suite.assertNext("0 0 * * 7", "2024-01-01 00:00", "2024-01-07 00:00", "2024-01-14 00:00")
+}
+
+// When both day fields are restricted, cron runs when EITHER matches.
+func (suite *CronSuite) TestNext_dayOfMonthOrWeek() {
+	suite.assertNext("0 0 15 * MON", "2024-01-01 00:00", "2024-01-08 00:00", "2024-01-15 00:00", "2024-01-22 00:00")
+}
+
+func (suite *CronSuite) TestNext_macros() {
+	suite.assertNext("@hourly", "2024-01-01 10:30", "2024-01-01 11:00")
+	suite.assertNext("@daily", "2024-01-01 10:30", "2024-01-02 00:00")
+	suite.assertNext("@midnight", "2024-01-01 10:30", "2024-01-02 00:00")
+	suite.assertNext("@weekly", "2024-01-01 10:30", "2024-01-07 00:00")
+	suite.assertNext("@monthly", "2024-01-01 10:30", "2024-02-01 00:00")
+	suite.assertNext("@yearly", "2024-01-01 10:30", "2025-01-01 00:00")
+	suite.assertNext("@annually", "2024-01-01 10:30", "2025-01-01 00:00")
+}
+
+func (suite *CronSuite) TestNext_impossible() {
+	schedule, err := cron.Parse("0 0 31 2 *")
+	suite.Require().NoError(err)
+	suite.True(schedule.Next(suite.date("2024-01-01 00:00")).IsZero())
+}
+
+func (suite *CronSuite) TestNext_location() {
+	schedule, err := cron.Parse("0 2 * * *")
+	suite.Require().NoError(err)
+
+	location := time.FixedZone("EST", -5*60*60)
+	next := schedule.Next(time.Date(2024, 1, 1, 10, 0, 0, 0, location))
+	suite.Equal(time.Date(2024, 1, 2, 2, 0, 0, 0, location), next)
+}
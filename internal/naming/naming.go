@@ -101,6 +101,38 @@ func ToUpperCamel(value string) string {
 	return strings.ToUpper(firstChar) + value[1:]
 }
 
+// ToSnake converts the string to snake-cased (e.g. "PhoneNumber" to "phone_number"). Runs of capital
+// letters are treated as a single word, so "HTTPServer" becomes "http_server" and "UserID" becomes "user_id".
+func ToSnake(value string) string {
+	runes := []rune(value)
+	builder := strings.Builder{}
+	for i, r := range runes {
+		if !unicode.IsUpper(r) {
+			builder.WriteRune(r)
+			continue
+		}
+		if i > 0 && startsWord(runes, i) {
+			builder.WriteRune('_')
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return builder.String()
+}
+
+// startsWord determines if the upper case rune at index 'i' is the first letter of a new word. That's the case
+// after a lower case letter/digit ("fooBar") or when it's the last capital in a run of them ("HTTPServer").
+func startsWord(runes []rune, i int) bool {
+	prev := runes[i-1]
+	switch {
+	case prev == '_':
+		return false
+	case unicode.IsLower(prev) || unicode.IsDigit(prev):
+		return true
+	default:
+		return unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+	}
+}
+
 // EmptyString is a predicate that returns true when the input value is "".
 func EmptyString(value string) bool {
 	return value == ""
@@ -192,11 +224,22 @@ func IsPathVariable(token string) bool {
 	return len(token) > 2 && strings.HasPrefix(token, "{") && strings.HasSuffix(token, "}")
 }
 
+// IsWildcardPathVariable returns true if the given path segment is a variable that captures the entire remainder
+// of the path rather than a single segment.
+//
+//	IsWildcardPathVariable("{Path}") -> false
+//	IsWildcardPathVariable("{Path...}") -> true
+//	IsWildcardPathVariable("{File.Path...}") -> true
+func IsWildcardPathVariable(token string) bool {
+	return IsPathVariable(token) && strings.HasSuffix(token, "...}") && len(token) > len("{...}")
+}
+
 // PathVariableName returns the raw variable name of the path variable if it actually is a variable (e.g. "{Foo.ID"}"
-// becomes "Foo.ID). If it's not a path variable, then this return "" (e.g "User" -> "").
+// becomes "Foo.ID). If it's not a path variable, then this return "" (e.g "User" -> ""). Wildcard variables don't
+// include the trailing "..." (e.g. "{Path...}" becomes "Path").
 func PathVariableName(pathSegment string) string {
 	if IsPathVariable(pathSegment) {
-		return pathSegment[1 : len(pathSegment)-1]
+		return strings.TrimSuffix(pathSegment[1:len(pathSegment)-1], "...")
 	}
 	return ""
 }
@@ -160,6 +160,22 @@ func (suite *NamingSuite) TestUpperCamel() {
 	r.Equal("5OOBAR", naming.ToUpperCamel("5OOBAR"))
 }
 
+func (suite *NamingSuite) TestSnake() {
+	r := suite.Require()
+	r.Equal("", naming.ToSnake(""))
+	r.Equal("foo", naming.ToSnake("foo"))
+	r.Equal("foo", naming.ToSnake("Foo"))
+	r.Equal("id", naming.ToSnake("ID"))
+	r.Equal("foo_bar", naming.ToSnake("fooBar"))
+	r.Equal("foo_bar", naming.ToSnake("FooBar"))
+	r.Equal("foo_bar", naming.ToSnake("foo_bar"))
+	r.Equal("foo_bar", naming.ToSnake("Foo_Bar"))
+	r.Equal("user_id", naming.ToSnake("UserID"))
+	r.Equal("http_client", naming.ToSnake("HTTPClient"))
+	r.Equal("address2", naming.ToSnake("Address2"))
+	r.Equal("address2_line", naming.ToSnake("Address2Line"))
+}
+
 func (suite *NamingSuite) TestIsPathVariable() {
 	r := suite.Require()
 	r.False(naming.IsPathVariable(""))
@@ -190,6 +206,21 @@ func (suite *NamingSuite) TestPathVariableName() {
 	r.Equal("foo.bar", naming.PathVariableName("{foo.bar}"))
 	r.Equal("Foo.Bar.Baz", naming.PathVariableName("{Foo.Bar.Baz}"))
 	r.Equal("Foo.{Bar}.Baz", naming.PathVariableName("{Foo.{Bar}.Baz}"))
+	r.Equal("Path", naming.PathVariableName("{Path...}"))
+	r.Equal("File.Path", naming.PathVariableName("{File.Path...}"))
+}
+
+func (suite *NamingSuite) TestIsWildcardPathVariable() {
+	r := suite.Require()
+	r.False(naming.IsWildcardPathVariable(""))
+	r.False(naming.IsWildcardPathVariable("foo..."))
+	r.False(naming.IsWildcardPathVariable("{foo}"))
+	r.False(naming.IsWildcardPathVariable("{foo.bar}"))
+	r.False(naming.IsWildcardPathVariable("{...}"))   // must have a variable name before the dots
+	r.False(naming.IsWildcardPathVariable("{foo...")) // no closing brace
+
+	r.True(naming.IsWildcardPathVariable("{foo...}"))
+	r.True(naming.IsWildcardPathVariable("{Foo.Bar...}"))
 }
 
 func (suite *NamingSuite) TestTokenizePath() {
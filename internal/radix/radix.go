@@ -110,7 +110,8 @@ func (e edges[T]) Sort() {
 }
 
 // Tree implements a radix tree. It's a map-like structure that lets you efficiently find exact instances of keys
-// as well as anything matching prefixes.
+// as well as anything matching prefixes. A Tree is NOT safe for concurrent use; guard it with your own lock if
+// multiple goroutines can modify it.
 type Tree[T any] struct {
 	root         *node[T]
 	size         int
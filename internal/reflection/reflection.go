@@ -92,6 +92,61 @@ func BindingName(field reflect.StructField) string {
 	}
 }
 
+// IsSensitive returns true if the field is tagged `sensitive:"true"`, meaning that its value should never
+// appear in logs or in error messages that echo back request data.
+func IsSensitive(field reflect.StructField) bool {
+	return field.Tag.Get("sensitive") == "true"
+}
+
+// TaggedFields finds every field in the struct (including nested/embedded structs) that has a value for the given
+// tag. The result maps each field's binding path (e.g. "Options.Locale") to its tag value (e.g. "Accept-Language").
+//
+//	type Foo struct {
+//	    Key     string `header:"Idempotency-Key"`
+//	    Options struct {
+//	        Locale string `header:"Accept-Language"`
+//	    }
+//	}
+//
+// TaggedFields(fooType, "header") gives you {"Key":"Idempotency-Key", "Options.Locale":"Accept-Language"}.
+func TaggedFields(structType reflect.Type, tag string) map[string]string {
+	fields := map[string]string{}
+	findTaggedFields(FlattenPointerType(structType), tag, "", fields, map[reflect.Type]bool{})
+	return fields
+}
+
+func findTaggedFields(structType reflect.Type, tag string, prefix string, fields map[string]string, visited map[reflect.Type]bool) {
+	// Don't chase our tail on recursive types like "type Node struct { Parent *Node }".
+	if !isStructType(structType) || visited[structType] {
+		return
+	}
+	visited[structType] = true
+	defer delete(visited, structType)
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		// Fields of embedded structs behave like they're fields on this struct, so they don't add to the path.
+		if field.Anonymous {
+			findTaggedFields(FlattenPointerType(field.Type), tag, prefix, fields, visited)
+			continue
+		}
+
+		path := BindingName(field)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		if value := field.Tag.Get(tag); value != "" && value != "-" {
+			fields[path] = value
+			continue
+		}
+		findTaggedFields(FlattenPointerType(field.Type), tag, path, fields, visited)
+	}
+}
+
 // FlattenPointerType looks at the reflective type and if it's a pointer it will flatten it to the
 // type it is a pointer for (e.g. "*string"->"string"). If it's already a non-pointer then we will
 // leave this type as-is.
@@ -110,7 +165,7 @@ func Assign(value any, out any) bool {
 	reflectOut := reflect.ValueOf(out)
 
 	valueIsPointer := reflectValue.Type().Kind() == reflect.Ptr
-	outIsPointerToPointer := reflectOut.Type().Kind() == reflect.Ptr
+	outIsPointerToPointer := reflectOut.Type().Kind() == reflect.Ptr && reflectOut.Type().Elem().Kind() == reflect.Ptr
 
 	switch {
 	case valueIsPointer && outIsPointerToPointer:
@@ -141,3 +141,38 @@ func (suite *ReflectionSuite) TestToBindingValue() {
 	r.False(reflection.ToBindingValue(dude, "Group.Org", &intValue))
 	r.False(reflection.ToBindingValue(dude, "Group.Org.ID", &intValue))
 }
+
+func (suite *ReflectionSuite) TestTaggedFields() {
+	type options struct {
+		Locale  string `header:"Accept-Language"`
+		Verbose bool
+	}
+	type Tracked struct {
+		TraceID string `header:"X-Trace-ID"`
+	}
+	type node struct {
+		Name   string `header:"X-Name"`
+		Parent *node
+	}
+	type request struct {
+		Tracked
+		ID       string
+		Key      string  `header:"Idempotency-Key"`
+		Opts     options `json:"Options"`
+		OptsPtr  *options
+		Ignored  string `header:"-"`
+		Node     node
+		internal string `header:"X-Internal"`
+	}
+
+	suite.Equal(map[string]string{
+		"TraceID":        "X-Trace-ID",
+		"Key":            "Idempotency-Key",
+		"Options.Locale": "Accept-Language",
+		"OptsPtr.Locale": "Accept-Language",
+		"Node.Name":      "X-Name",
+	}, reflection.TaggedFields(reflect.TypeOf(&request{}), "header"))
+
+	suite.Empty(reflection.TaggedFields(reflect.TypeOf(request{}), "nope"))
+	suite.Empty(reflection.TaggedFields(reflect.TypeOf("not a struct"), "header"))
+}
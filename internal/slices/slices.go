@@ -38,3 +38,26 @@ func Contains[T comparable](slice []T, value T) bool {
 	}
 	return false
 }
+
+// Clone returns a shallow copy of the slice, so you can modify the copy without affecting the original. A nil
+// slice stays nil.
+func Clone[T any](slice []T) []T {
+	if slice == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(slice)), slice...)
+}
+
+// Equal returns true if both slices have the same length and every element passes an == test w/ the element at
+// the same index in the other slice. Nil and empty slices are considered equal.
+func Equal[T comparable](a []T, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
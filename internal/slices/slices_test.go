@@ -68,3 +68,31 @@ func (suite *SlicesSuite) TestContains() {
 	r.False(slices.Contains([]int{1, 2, 3}, 50))
 	r.True(slices.Contains([]int{1, 2, 3}, 2))
 }
+
+func (suite *SlicesSuite) TestClone() {
+	r := suite.Require()
+
+	var nilSlice []string
+	r.Nil(slices.Clone(nilSlice))
+	r.Equal([]string{}, slices.Clone([]string{}))
+
+	// Changing the copy should not affect the original.
+	original := []string{"a", "b", "c"}
+	clone := slices.Clone(original)
+	r.Equal(original, clone)
+	clone[0] = "z"
+	r.Equal([]string{"a", "b", "c"}, original)
+}
+
+func (suite *SlicesSuite) TestEqual() {
+	r := suite.Require()
+
+	var nilSlice []string
+	r.True(slices.Equal(nilSlice, nilSlice))
+	r.True(slices.Equal(nilSlice, []string{}))
+	r.True(slices.Equal([]string{"a", "b"}, []string{"a", "b"}))
+
+	r.False(slices.Equal(nilSlice, []string{"a"}))
+	r.False(slices.Equal([]string{"a", "b"}, []string{"b", "a"}))
+	r.False(slices.Equal([]string{"a", "b"}, []string{"a", "b", "c"}))
+}
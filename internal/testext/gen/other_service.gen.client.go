@@ -1,6 +1,6 @@
 // Code generated by Frodo - DO NOT EDIT.
 //
-//	Timestamp: Fri, 15 Nov 2024 12:49:03 EST
+//	Timestamp: Fri, 16 Oct 2026 12:12:22 UTC
 //	Source:    other_service.go
 //	Generator: https://github.com/bridgekit-io/frodo
 package testext
@@ -23,6 +23,35 @@ func OtherServiceClient(address string, options ...clients.ClientOption) testext
 	return &otherServiceClient{Client: serviceClient}
 }
 
+// OtherServiceClientContext is just like OtherServiceClient, but it also performs any up-front connection setup
+// (e.g. resolving the remote host's address) using the given context. You get an error if that setup fails
+// or the context ends before it's done.
+func OtherServiceClientContext(ctx context.Context, address string, options ...clients.ClientOption) (testext.OtherService, error) {
+	serviceClient, err := clients.NewClientContext(ctx, "OtherService", address, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &otherServiceClient{Client: serviceClient}, nil
+}
+
+// OtherServiceRoles returns the role templates (from the ROLES doc option) that a caller must have in order
+// to invoke each of the service's functions, keyed by function name. The templates have NOT been resolved, so you will
+// see values like "group.{ID}.write" rather than "group.123.write". This lets UIs hide actions that the current user
+// won't be allowed to perform anyway.
+func OtherServiceRoles() map[string][]string {
+	return map[string][]string{
+		"ChainFail":      {},
+		"ChainFailAfter": {},
+		"ChainFour":      {},
+		"ChainOne":       {},
+		"ChainThree":     {},
+		"ChainTwo":       {},
+		"ListenWell":     {},
+		"RPCExample":     {},
+		"SpaceOut":       {},
+	}
+}
+
 // otherServiceClient manages all interaction w/ a remote OtherService instance by letting you invoke functions
 // on this instance as if you were doing it locally (hence... RPC client). Use the OtherServiceClient constructor
 // function to actually get an instance of this client.
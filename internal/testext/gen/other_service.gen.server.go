@@ -1,6 +1,6 @@
 // Code generated by Frodo - DO NOT EDIT.
 //
-//	Timestamp: Fri, 15 Nov 2024 12:49:03 EST
+//	Timestamp: Fri, 16 Oct 2026 11:21:42 UTC
 //	Source:    other_service.go
 //	Generator: https://github.com/bridgekit-io/frodo
 package testext
@@ -47,7 +47,8 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 					}
 					return handler.ChainFail(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"OtherRequest\":{\"description\":\"OtherRequest is a basic payload that partially matches the schema of SampleResponse so\\nwhen we invoke service methods through the event gateway, we can make sure that we\\ncan get the Text value while ignoring everything else from the original payload.\",\"properties\":{\"Text\":{\"description\":\"Text is the result of the previous call's invocation.\",\"type\":\"string\"},\"UniqueThing\":{\"description\":\"UniqueThing is just a field that doesn't exist in any other testing response. This ensures\\nthat we can use events to decode the values like 'Text' which are present while ignoring those\\nthat are not... quietly.\",\"type\":\"boolean\"}},\"type\":\"object\"},\"OtherResponse\":{\"properties\":{\"Text\":{\"type\":\"string\"},\"UniqueThing\":{\"type\":\"boolean\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"ChainFail fires after ChainOne, but should always return an error. This will prevent ChainFailAfter\\nfrom ever actually running.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/OtherRequest\"},\"response\":{\"$ref\":\"#/$defs/OtherResponse\"}},\"title\":\"OtherService.ChainFail\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -56,6 +57,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "OtherService",
+						Name:        "ChainFail",
+						Roles:       []string{},
 					},
 
 					{
@@ -65,6 +69,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "OtherService",
+						Name:        "ChainFail",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -80,7 +87,8 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 					}
 					return handler.ChainFailAfter(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"OtherRequest\":{\"description\":\"OtherRequest is a basic payload that partially matches the schema of SampleResponse so\\nwhen we invoke service methods through the event gateway, we can make sure that we\\ncan get the Text value while ignoring everything else from the original payload.\",\"properties\":{\"Text\":{\"description\":\"Text is the result of the previous call's invocation.\",\"type\":\"string\"},\"UniqueThing\":{\"description\":\"UniqueThing is just a field that doesn't exist in any other testing response. This ensures\\nthat we can use events to decode the values like 'Text' which are present while ignoring those\\nthat are not... quietly.\",\"type\":\"boolean\"}},\"type\":\"object\"},\"OtherResponse\":{\"properties\":{\"Text\":{\"type\":\"string\"},\"UniqueThing\":{\"type\":\"boolean\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"ChainFailAfter is dependent on a successful call to ChainFail... which always fails. So this NEVER runs.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/OtherRequest\"},\"response\":{\"$ref\":\"#/$defs/OtherResponse\"}},\"title\":\"OtherService.ChainFailAfter\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -89,6 +97,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "OtherService",
+						Name:        "ChainFailAfter",
+						Roles:       []string{},
 					},
 
 					{
@@ -98,6 +109,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "OtherService",
+						Name:        "ChainFailAfter",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -113,7 +127,8 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 					}
 					return handler.ChainFour(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"OtherRequest\":{\"description\":\"OtherRequest is a basic payload that partially matches the schema of SampleResponse so\\nwhen we invoke service methods through the event gateway, we can make sure that we\\ncan get the Text value while ignoring everything else from the original payload.\",\"properties\":{\"Text\":{\"description\":\"Text is the result of the previous call's invocation.\",\"type\":\"string\"},\"UniqueThing\":{\"description\":\"UniqueThing is just a field that doesn't exist in any other testing response. This ensures\\nthat we can use events to decode the values like 'Text' which are present while ignoring those\\nthat are not... quietly.\",\"type\":\"boolean\"}},\"type\":\"object\"},\"OtherResponse\":{\"properties\":{\"Text\":{\"type\":\"string\"},\"UniqueThing\":{\"type\":\"boolean\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"ChainFour is used to test that methods invoked via the event gateway can trigger even more events.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/OtherRequest\"},\"response\":{\"$ref\":\"#/$defs/OtherResponse\"}},\"title\":\"OtherService.ChainFour\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -122,6 +137,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "OtherService",
+						Name:        "ChainFour",
+						Roles:       []string{},
 					},
 
 					{
@@ -131,6 +149,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "OtherService",
+						Name:        "ChainFour",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -146,7 +167,8 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 					}
 					return handler.ChainOne(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"OtherRequest\":{\"description\":\"OtherRequest is a basic payload that partially matches the schema of SampleResponse so\\nwhen we invoke service methods through the event gateway, we can make sure that we\\ncan get the Text value while ignoring everything else from the original payload.\",\"properties\":{\"Text\":{\"description\":\"Text is the result of the previous call's invocation.\",\"type\":\"string\"},\"UniqueThing\":{\"description\":\"UniqueThing is just a field that doesn't exist in any other testing response. This ensures\\nthat we can use events to decode the values like 'Text' which are present while ignoring those\\nthat are not... quietly.\",\"type\":\"boolean\"}},\"type\":\"object\"},\"OtherResponse\":{\"properties\":{\"Text\":{\"type\":\"string\"},\"UniqueThing\":{\"type\":\"boolean\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"ChainOne allows us to test the cascading of events to create more complex flows. When this\\nfinishes it will trigger ChainTwo which will, in turn, trigger ChainThree and ChainFour.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/OtherRequest\"},\"response\":{\"$ref\":\"#/$defs/OtherResponse\"}},\"title\":\"OtherService.ChainOne\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -155,6 +177,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "OtherService",
+						Name:        "ChainOne",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -170,7 +195,8 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 					}
 					return handler.ChainThree(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"OtherRequest\":{\"description\":\"OtherRequest is a basic payload that partially matches the schema of SampleResponse so\\nwhen we invoke service methods through the event gateway, we can make sure that we\\ncan get the Text value while ignoring everything else from the original payload.\",\"properties\":{\"Text\":{\"description\":\"Text is the result of the previous call's invocation.\",\"type\":\"string\"},\"UniqueThing\":{\"description\":\"UniqueThing is just a field that doesn't exist in any other testing response. This ensures\\nthat we can use events to decode the values like 'Text' which are present while ignoring those\\nthat are not... quietly.\",\"type\":\"boolean\"}},\"type\":\"object\"},\"OtherResponse\":{\"properties\":{\"Text\":{\"type\":\"string\"},\"UniqueThing\":{\"type\":\"boolean\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"ChainThree is used to test that methods invoked via the event gateway can trigger even more events.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/OtherRequest\"},\"response\":{\"$ref\":\"#/$defs/OtherResponse\"}},\"title\":\"OtherService.ChainThree\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -179,6 +205,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "OtherService",
+						Name:        "ChainThree",
+						Roles:       []string{},
 					},
 
 					{
@@ -188,6 +217,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "OtherService",
+						Name:        "ChainThree",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -203,7 +235,8 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 					}
 					return handler.ChainTwo(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"OtherRequest\":{\"description\":\"OtherRequest is a basic payload that partially matches the schema of SampleResponse so\\nwhen we invoke service methods through the event gateway, we can make sure that we\\ncan get the Text value while ignoring everything else from the original payload.\",\"properties\":{\"Text\":{\"description\":\"Text is the result of the previous call's invocation.\",\"type\":\"string\"},\"UniqueThing\":{\"description\":\"UniqueThing is just a field that doesn't exist in any other testing response. This ensures\\nthat we can use events to decode the values like 'Text' which are present while ignoring those\\nthat are not... quietly.\",\"type\":\"boolean\"}},\"type\":\"object\"},\"OtherResponse\":{\"properties\":{\"Text\":{\"type\":\"string\"},\"UniqueThing\":{\"type\":\"boolean\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"ChainTwo is used to test that methods invoked via the event gateway can trigger even more events.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/OtherRequest\"},\"response\":{\"$ref\":\"#/$defs/OtherResponse\"}},\"title\":\"OtherService.ChainTwo\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -212,6 +245,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "OtherService",
+						Name:        "ChainTwo",
+						Roles:       []string{},
 					},
 
 					{
@@ -221,6 +257,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "OtherService",
+						Name:        "ChainTwo",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -236,7 +275,8 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 					}
 					return handler.ListenWell(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"OtherRequest\":{\"description\":\"OtherRequest is a basic payload that partially matches the schema of SampleResponse so\\nwhen we invoke service methods through the event gateway, we can make sure that we\\ncan get the Text value while ignoring everything else from the original payload.\",\"properties\":{\"Text\":{\"description\":\"Text is the result of the previous call's invocation.\",\"type\":\"string\"},\"UniqueThing\":{\"description\":\"UniqueThing is just a field that doesn't exist in any other testing response. This ensures\\nthat we can use events to decode the values like 'Text' which are present while ignoring those\\nthat are not... quietly.\",\"type\":\"boolean\"}},\"type\":\"object\"},\"OtherResponse\":{\"properties\":{\"Text\":{\"type\":\"string\"},\"UniqueThing\":{\"type\":\"boolean\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"ListenWell can listen for successful responses across multiple services.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/OtherRequest\"},\"response\":{\"$ref\":\"#/$defs/OtherResponse\"}},\"title\":\"OtherService.ListenWell\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -245,6 +285,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "OtherService",
+						Name:        "ListenWell",
+						Roles:       []string{},
 					},
 
 					{
@@ -254,6 +297,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "OtherService",
+						Name:        "ListenWell",
+						Roles:       []string{},
 					},
 
 					{
@@ -263,6 +309,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "OtherService",
+						Name:        "ListenWell",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -278,7 +327,8 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 					}
 					return handler.RPCExample(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"OtherRequest\":{\"description\":\"OtherRequest is a basic payload that partially matches the schema of SampleResponse so\\nwhen we invoke service methods through the event gateway, we can make sure that we\\ncan get the Text value while ignoring everything else from the original payload.\",\"properties\":{\"Text\":{\"description\":\"Text is the result of the previous call's invocation.\",\"type\":\"string\"},\"UniqueThing\":{\"description\":\"UniqueThing is just a field that doesn't exist in any other testing response. This ensures\\nthat we can use events to decode the values like 'Text' which are present while ignoring those\\nthat are not... quietly.\",\"type\":\"boolean\"}},\"type\":\"object\"},\"OtherResponse\":{\"properties\":{\"Text\":{\"type\":\"string\"},\"UniqueThing\":{\"type\":\"boolean\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"RPCExample invokes the TriggerUpperCase() function on the SampleService to get work done.\\nThis will make sure that we can do cross-service communication.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/OtherRequest\"},\"response\":{\"$ref\":\"#/$defs/OtherResponse\"}},\"title\":\"OtherService.RPCExample\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -287,6 +337,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "OtherService",
+						Name:        "RPCExample",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -302,7 +355,8 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 					}
 					return handler.SpaceOut(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"OtherRequest\":{\"description\":\"OtherRequest is a basic payload that partially matches the schema of SampleResponse so\\nwhen we invoke service methods through the event gateway, we can make sure that we\\ncan get the Text value while ignoring everything else from the original payload.\",\"properties\":{\"Text\":{\"description\":\"Text is the result of the previous call's invocation.\",\"type\":\"string\"},\"UniqueThing\":{\"description\":\"UniqueThing is just a field that doesn't exist in any other testing response. This ensures\\nthat we can use events to decode the values like 'Text' which are present while ignoring those\\nthat are not... quietly.\",\"type\":\"boolean\"}},\"type\":\"object\"},\"OtherResponse\":{\"properties\":{\"Text\":{\"type\":\"string\"},\"UniqueThing\":{\"type\":\"boolean\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"SpaceOut takes your input text and puts spaces in between all the letters.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/OtherRequest\"},\"response\":{\"$ref\":\"#/$defs/OtherResponse\"}},\"title\":\"OtherService.SpaceOut\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -311,6 +365,9 @@ func OtherServiceServer(handler testext.OtherService, middleware ...services.Mid
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "OtherService",
+						Name:        "SpaceOut",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -0,0 +1,1129 @@
+// Code generated by Frodo - DO NOT EDIT.
+//
+//	Timestamp: Fri, 16 Oct 2026 11:13:08 UTC
+//	Source:    sample_service.go
+//	Generator: https://github.com/bridgekit-io/frodo
+package testext
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/internal/testext"
+)
+
+// SampleServiceFakeClient creates a client that conforms to the SampleService interface, but never leaves the
+// process. Every function responds with whatever you programmed for it using the "SetXxx" and "StubXxx"
+// functions, so you can run your app against canned data when the real service isn't available:
+//
+//	client := testext.SampleServiceFakeClient()
+//	client.SetAuthorization(&testext.SampleResponse{ ... }, nil)
+//
+// Functions that you haven't programmed fail with a 501 "not implemented" error.
+func SampleServiceFakeClient() *FakeSampleServiceClient {
+	return &FakeSampleServiceClient{}
+}
+
+// FakeSampleServiceClient is an in-memory stand-in for the SampleService client. It's meant for local development,
+// not tests (use the generated mock for those), so it doesn't record calls or match requests; it just responds.
+// You can program it at any point, even while other goroutines are using it.
+type FakeSampleServiceClient struct {
+	mutex sync.RWMutex
+	stubs struct {
+		Authorization          func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		Chain1                 func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		Chain1GroupFooBar      func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		Chain1GroupStar        func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		Chain2                 func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		Chain2OnError          func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error)
+		Chain2OnSuccess        func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		ComplexValues          func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error)
+		ComplexValuesPath      func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error)
+		CustomRoute            func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		CustomRouteBody        func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		CustomRouteQuery       func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		Defaults               func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		Download               func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error)
+		DownloadResumable      func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error)
+		Fail4XX                func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		Fail5XX                func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		FailAlways             func(context.Context, *testext.FailAlwaysRequest) (*testext.FailAlwaysResponse, error)
+		ListenerA              func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		ListenerB              func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		OmitMe                 func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		OnFailAlways           func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error)
+		Panic                  func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		Redirect               func(context.Context, *testext.SampleRedirectRequest) (*testext.SampleRedirectResponse, error)
+		SecureWithRoles        func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error)
+		SecureWithRolesAliased func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error)
+		Sleep                  func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		TriggerFailure         func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		TriggerLowerCase       func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+		TriggerUpperCase       func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+	}
+}
+
+// Authorization regurgitates the "Authorization" metadata/header.
+func (client *FakeSampleServiceClient) Authorization(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Authorization
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Authorization has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetAuthorization makes every call to Authorization respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetAuthorization(response *testext.SampleResponse, err error) {
+	client.StubAuthorization(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubAuthorization makes every call to Authorization respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubAuthorization(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Authorization = stub
+}
+
+// Chain1 kicks off the Chain1/Chain2/Chain3 event chain, but we expect that it's going to stop after
+func (client *FakeSampleServiceClient) Chain1(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Chain1
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Chain1 has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetChain1 makes every call to Chain1 respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetChain1(response *testext.SampleResponse, err error) {
+	client.StubChain1(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubChain1 makes every call to Chain1 respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubChain1(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Chain1 = stub
+}
+
+// Chain1GroupFooBar listens for calls to Chain1, but rather than being part of a consumer group that only lets
+// one instance of the service run it, it should define its own shared group name.
+func (client *FakeSampleServiceClient) Chain1GroupFooBar(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Chain1GroupFooBar
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Chain1GroupFooBar has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetChain1GroupFooBar makes every call to Chain1GroupFooBar respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetChain1GroupFooBar(response *testext.SampleResponse, err error) {
+	client.StubChain1GroupFooBar(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubChain1GroupFooBar makes every call to Chain1GroupFooBar respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubChain1GroupFooBar(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Chain1GroupFooBar = stub
+}
+
+// Chain1GroupStar listens for calls to Chain1, but rather than being part of a consumer group that only lets
+// one instance of the service run it, it should define its own group that lets EVERY instance of this service
+// react to this event.
+func (client *FakeSampleServiceClient) Chain1GroupStar(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Chain1GroupStar
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Chain1GroupStar has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetChain1GroupStar makes every call to Chain1GroupStar respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetChain1GroupStar(response *testext.SampleResponse, err error) {
+	client.StubChain1GroupStar(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubChain1GroupStar makes every call to Chain1GroupStar respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubChain1GroupStar(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Chain1GroupStar = stub
+}
+
+// Chain2 ALWAYS FAILS, SO CHAIN3 NEVER FIRES!!!
+func (client *FakeSampleServiceClient) Chain2(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Chain2
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Chain2 has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetChain2 makes every call to Chain2 respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetChain2(response *testext.SampleResponse, err error) {
+	client.StubChain2(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubChain2 makes every call to Chain2 respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubChain2(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Chain2 = stub
+}
+
+// Chain2OnError listens for errors that occur on calls to Chain2
+func (client *FakeSampleServiceClient) Chain2OnError(ctx context.Context, request *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Chain2OnError
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Chain2OnError has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetChain2OnError makes every call to Chain2OnError respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetChain2OnError(response *testext.FailAlwaysErrorResponse, err error) {
+	client.StubChain2OnError(func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error) {
+		return response, err
+	})
+}
+
+// StubChain2OnError makes every call to Chain2OnError respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubChain2OnError(stub func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Chain2OnError = stub
+}
+
+// Chain2OnSuccess never fires. It listens for the success of Chain2, but since that always fails, this
+// should never be triggered, so tests should never have this in its output.
+func (client *FakeSampleServiceClient) Chain2OnSuccess(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Chain2OnSuccess
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Chain2OnSuccess has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetChain2OnSuccess makes every call to Chain2OnSuccess respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetChain2OnSuccess(response *testext.SampleResponse, err error) {
+	client.StubChain2OnSuccess(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubChain2OnSuccess makes every call to Chain2OnSuccess respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubChain2OnSuccess(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Chain2OnSuccess = stub
+}
+
+// ComplexValues flexes our ability to encode/decode non-flat structs.
+func (client *FakeSampleServiceClient) ComplexValues(ctx context.Context, request *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.ComplexValues
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.ComplexValues has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetComplexValues makes every call to ComplexValues respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetComplexValues(response *testext.SampleComplexResponse, err error) {
+	client.StubComplexValues(func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error) {
+		return response, err
+	})
+}
+
+// StubComplexValues makes every call to ComplexValues respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubComplexValues(stub func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.ComplexValues = stub
+}
+
+// ComplexValuesPath flexes our ability to encode/decode non-flat structs while
+// specifying them via path and query string.
+func (client *FakeSampleServiceClient) ComplexValuesPath(ctx context.Context, request *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.ComplexValuesPath
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.ComplexValuesPath has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetComplexValuesPath makes every call to ComplexValuesPath respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetComplexValuesPath(response *testext.SampleComplexResponse, err error) {
+	client.StubComplexValuesPath(func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error) {
+		return response, err
+	})
+}
+
+// StubComplexValuesPath makes every call to ComplexValuesPath respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubComplexValuesPath(stub func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.ComplexValuesPath = stub
+}
+
+// CustomRoute performs a service operation where you override default behavior
+// by providing routing-related Doc Options.
+func (client *FakeSampleServiceClient) CustomRoute(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.CustomRoute
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.CustomRoute has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetCustomRoute makes every call to CustomRoute respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetCustomRoute(response *testext.SampleResponse, err error) {
+	client.StubCustomRoute(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubCustomRoute makes every call to CustomRoute respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubCustomRoute(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.CustomRoute = stub
+}
+
+// CustomRouteBody performs a service operation where you override default behavior
+// by providing routing-related Doc Options, but rely on body encoding rather than path.
+func (client *FakeSampleServiceClient) CustomRouteBody(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.CustomRouteBody
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.CustomRouteBody has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetCustomRouteBody makes every call to CustomRouteBody respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetCustomRouteBody(response *testext.SampleResponse, err error) {
+	client.StubCustomRouteBody(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubCustomRouteBody makes every call to CustomRouteBody respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubCustomRouteBody(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.CustomRouteBody = stub
+}
+
+// CustomRouteQuery performs a service operation where you override default behavior
+// by providing routing-related Doc Options. The input data relies on the path
+func (client *FakeSampleServiceClient) CustomRouteQuery(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.CustomRouteQuery
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.CustomRouteQuery has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetCustomRouteQuery makes every call to CustomRouteQuery respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetCustomRouteQuery(response *testext.SampleResponse, err error) {
+	client.StubCustomRouteQuery(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubCustomRouteQuery makes every call to CustomRouteQuery respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubCustomRouteQuery(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.CustomRouteQuery = stub
+}
+
+// Defaults simply utilizes all of the framework's default behaviors.
+func (client *FakeSampleServiceClient) Defaults(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Defaults
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Defaults has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetDefaults makes every call to Defaults respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetDefaults(response *testext.SampleResponse, err error) {
+	client.StubDefaults(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubDefaults makes every call to Defaults respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubDefaults(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Defaults = stub
+}
+
+// Download results in a raw stream of data rather than relying on auto-encoding
+// the response value.
+func (client *FakeSampleServiceClient) Download(ctx context.Context, request *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Download
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Download has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetDownload makes every call to Download respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetDownload(response *testext.SampleDownloadResponse, err error) {
+	client.StubDownload(func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error) {
+		return response, err
+	})
+}
+
+// StubDownload makes every call to Download respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubDownload(stub func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Download = stub
+}
+
+// DownloadResumable results in a raw stream of data rather than relying on auto-encoding
+// the response value. The stream includes Content-Range info as though you could resume
+// your stream/download progress later.
+func (client *FakeSampleServiceClient) DownloadResumable(ctx context.Context, request *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.DownloadResumable
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.DownloadResumable has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetDownloadResumable makes every call to DownloadResumable respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetDownloadResumable(response *testext.SampleDownloadResponse, err error) {
+	client.StubDownloadResumable(func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error) {
+		return response, err
+	})
+}
+
+// StubDownloadResumable makes every call to DownloadResumable respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubDownloadResumable(stub func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.DownloadResumable = stub
+}
+
+// Fail4XX always returns a non-nil 400-series error.
+func (client *FakeSampleServiceClient) Fail4XX(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Fail4XX
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Fail4XX has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetFail4XX makes every call to Fail4XX respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetFail4XX(response *testext.SampleResponse, err error) {
+	client.StubFail4XX(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubFail4XX makes every call to Fail4XX respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubFail4XX(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Fail4XX = stub
+}
+
+// Fail5XX always returns a non-nil 500-series error.
+func (client *FakeSampleServiceClient) Fail5XX(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Fail5XX
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Fail5XX has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetFail5XX makes every call to Fail5XX respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetFail5XX(response *testext.SampleResponse, err error) {
+	client.StubFail5XX(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubFail5XX makes every call to Fail5XX respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubFail5XX(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Fail5XX = stub
+}
+
+// FailAlways will return an error no matter what. It's only goal in life is to trigger OnFailAlways.
+func (client *FakeSampleServiceClient) FailAlways(ctx context.Context, request *testext.FailAlwaysRequest) (*testext.FailAlwaysResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.FailAlways
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.FailAlways has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetFailAlways makes every call to FailAlways respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetFailAlways(response *testext.FailAlwaysResponse, err error) {
+	client.StubFailAlways(func(context.Context, *testext.FailAlwaysRequest) (*testext.FailAlwaysResponse, error) {
+		return response, err
+	})
+}
+
+// StubFailAlways makes every call to FailAlways respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubFailAlways(stub func(context.Context, *testext.FailAlwaysRequest) (*testext.FailAlwaysResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.FailAlways = stub
+}
+
+// ListenerA fires on only one of the triggers.
+func (client *FakeSampleServiceClient) ListenerA(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.ListenerA
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.ListenerA has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetListenerA makes every call to ListenerA respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetListenerA(response *testext.SampleResponse, err error) {
+	client.StubListenerA(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubListenerA makes every call to ListenerA respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubListenerA(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.ListenerA = stub
+}
+
+// ListenerB fires on multiple triggers... including another event-based endpoint. We also
+// listen for the TriggerFailure event which should never fire properly. The "payment.succeeded"
+// event is published by some system outside of Frodo, so its payload is just raw request JSON.
+func (client *FakeSampleServiceClient) ListenerB(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.ListenerB
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.ListenerB has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetListenerB makes every call to ListenerB respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetListenerB(response *testext.SampleResponse, err error) {
+	client.StubListenerB(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubListenerB makes every call to ListenerB respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubListenerB(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.ListenerB = stub
+}
+
+// OmitMe exists in the service, but should be excluded from the public API.
+func (client *FakeSampleServiceClient) OmitMe(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.OmitMe
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.OmitMe has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetOmitMe makes every call to OmitMe respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetOmitMe(response *testext.SampleResponse, err error) {
+	client.StubOmitMe(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubOmitMe makes every call to OmitMe respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubOmitMe(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.OmitMe = stub
+}
+
+// OnFailAlways should trigger after FailAlways inevitably shits the bed.
+func (client *FakeSampleServiceClient) OnFailAlways(ctx context.Context, request *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.OnFailAlways
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.OnFailAlways has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetOnFailAlways makes every call to OnFailAlways respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetOnFailAlways(response *testext.FailAlwaysErrorResponse, err error) {
+	client.StubOnFailAlways(func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error) {
+		return response, err
+	})
+}
+
+// StubOnFailAlways makes every call to OnFailAlways respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubOnFailAlways(stub func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.OnFailAlways = stub
+}
+
+// Panic um... panics. It never succeeds. It always behaves like me when I'm on a high place looking down.
+func (client *FakeSampleServiceClient) Panic(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Panic
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Panic has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetPanic makes every call to Panic respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetPanic(response *testext.SampleResponse, err error) {
+	client.StubPanic(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubPanic makes every call to Panic respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubPanic(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Panic = stub
+}
+
+// Redirect results in a 307-style redirect to the Download endpoint.
+func (client *FakeSampleServiceClient) Redirect(ctx context.Context, request *testext.SampleRedirectRequest) (*testext.SampleRedirectResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Redirect
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Redirect has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetRedirect makes every call to Redirect respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetRedirect(response *testext.SampleRedirectResponse, err error) {
+	client.StubRedirect(func(context.Context, *testext.SampleRedirectRequest) (*testext.SampleRedirectResponse, error) {
+		return response, err
+	})
+}
+
+// StubRedirect makes every call to Redirect respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubRedirect(stub func(context.Context, *testext.SampleRedirectRequest) (*testext.SampleRedirectResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Redirect = stub
+}
+
+// SecureWithRoles lets us test role based security by looking at the 'roles' doc option.
+func (client *FakeSampleServiceClient) SecureWithRoles(ctx context.Context, request *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.SecureWithRoles
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.SecureWithRoles has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetSecureWithRoles makes every call to SecureWithRoles respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetSecureWithRoles(response *testext.SampleSecurityResponse, err error) {
+	client.StubSecureWithRoles(func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error) {
+		return response, err
+	})
+}
+
+// StubSecureWithRoles makes every call to SecureWithRoles respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubSecureWithRoles(stub func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.SecureWithRoles = stub
+}
+
+// SecureWithRolesAliased lets us test role based security by looking at the 'roles' doc option. Specifically,
+// we make sure we can resolve role segments with string alias types, not just strings.
+func (client *FakeSampleServiceClient) SecureWithRolesAliased(ctx context.Context, request *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.SecureWithRolesAliased
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.SecureWithRolesAliased has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetSecureWithRolesAliased makes every call to SecureWithRolesAliased respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetSecureWithRolesAliased(response *testext.SampleSecurityResponse, err error) {
+	client.StubSecureWithRolesAliased(func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error) {
+		return response, err
+	})
+}
+
+// StubSecureWithRolesAliased makes every call to SecureWithRolesAliased respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubSecureWithRolesAliased(stub func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.SecureWithRolesAliased = stub
+}
+
+// Sleep successfully responds, but it will sleep for 5 seconds before doing so. Use this
+// for test cases where you want to try out timeouts.
+func (client *FakeSampleServiceClient) Sleep(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.Sleep
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.Sleep has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetSleep makes every call to Sleep respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetSleep(response *testext.SampleResponse, err error) {
+	client.StubSleep(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubSleep makes every call to Sleep respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubSleep(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.Sleep = stub
+}
+
+func (client *FakeSampleServiceClient) TriggerFailure(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.TriggerFailure
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.TriggerFailure has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetTriggerFailure makes every call to TriggerFailure respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetTriggerFailure(response *testext.SampleResponse, err error) {
+	client.StubTriggerFailure(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubTriggerFailure makes every call to TriggerFailure respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubTriggerFailure(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.TriggerFailure = stub
+}
+
+func (client *FakeSampleServiceClient) TriggerLowerCase(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.TriggerLowerCase
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.TriggerLowerCase has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetTriggerLowerCase makes every call to TriggerLowerCase respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetTriggerLowerCase(response *testext.SampleResponse, err error) {
+	client.StubTriggerLowerCase(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubTriggerLowerCase makes every call to TriggerLowerCase respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubTriggerLowerCase(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.TriggerLowerCase = stub
+}
+
+// TriggerUpperCase ensures that events still fire as "SampleService.TriggerUpperCase" even though
+// we are going to set a different HTTP path.
+func (client *FakeSampleServiceClient) TriggerUpperCase(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if ctx == nil {
+		return nil, fail.Unexpected("precondition failed: nil context")
+	}
+	if request == nil {
+		return nil, fail.Unexpected("precondition failed: nil request")
+	}
+
+	client.mutex.RLock()
+	stub := client.stubs.TriggerUpperCase
+	client.mutex.RUnlock()
+
+	if stub == nil {
+		return nil, fail.NotImplemented("SampleService.TriggerUpperCase has no fake response")
+	}
+	return stub(ctx, request)
+}
+
+// SetTriggerUpperCase makes every call to TriggerUpperCase respond with these values. Every call gets the same
+// response pointer, so treat it as read-only.
+func (client *FakeSampleServiceClient) SetTriggerUpperCase(response *testext.SampleResponse, err error) {
+	client.StubTriggerUpperCase(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// StubTriggerUpperCase makes every call to TriggerUpperCase respond by invoking your function, so you can base the
+// response on the request. A nil function goes back to failing with "not implemented".
+func (client *FakeSampleServiceClient) StubTriggerUpperCase(stub func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.stubs.TriggerUpperCase = stub
+}
@@ -1,6 +1,6 @@
 // Code generated by Frodo - DO NOT EDIT.
 //
-//	Timestamp: Fri, 15 Nov 2024 12:49:04 EST
+//	Timestamp: Fri, 16 Oct 2026 12:12:20 UTC
 //	Source:    sample_service.go
 //	Generator: https://github.com/bridgekit-io/frodo
 package testext
@@ -25,6 +25,56 @@ func SampleServiceClient(address string, options ...clients.ClientOption) testex
 	return &sampleServiceClient{Client: serviceClient}
 }
 
+// SampleServiceClientContext is just like SampleServiceClient, but it also performs any up-front connection setup
+// (e.g. resolving the remote host's address) using the given context. You get an error if that setup fails
+// or the context ends before it's done.
+func SampleServiceClientContext(ctx context.Context, address string, options ...clients.ClientOption) (testext.SampleService, error) {
+	serviceClient, err := clients.NewClientContext(ctx, "SampleService", address, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &sampleServiceClient{Client: serviceClient}, nil
+}
+
+// SampleServiceRoles returns the role templates (from the ROLES doc option) that a caller must have in order
+// to invoke each of the service's functions, keyed by function name. The templates have NOT been resolved, so you will
+// see values like "group.{ID}.write" rather than "group.123.write". This lets UIs hide actions that the current user
+// won't be allowed to perform anyway.
+func SampleServiceRoles() map[string][]string {
+	return map[string][]string{
+		"Authorization":          {},
+		"Chain1":                 {},
+		"Chain1GroupFooBar":      {},
+		"Chain1GroupStar":        {},
+		"Chain2":                 {},
+		"Chain2OnError":          {},
+		"Chain2OnSuccess":        {},
+		"ComplexValues":          {},
+		"ComplexValuesPath":      {},
+		"CustomRoute":            {},
+		"CustomRouteBody":        {},
+		"CustomRouteQuery":       {},
+		"Defaults":               {},
+		"Download":               {},
+		"DownloadResumable":      {},
+		"Fail4XX":                {},
+		"Fail5XX":                {},
+		"FailAlways":             {},
+		"ListenerA":              {},
+		"ListenerB":              {},
+		"OmitMe":                 {},
+		"OnFailAlways":           {},
+		"Panic":                  {},
+		"Redirect":               {},
+		"SecureWithRoles":        {"admin.write", "user.{ID}.write", "user.{User.ID}.admin", "junk.{NotReal}.crap"},
+		"SecureWithRolesAliased": {"admin.write", "user.{FancyID}.write", "user.{User.FancyID}.admin", "junk.{NotReal}.crap"},
+		"Sleep":                  {},
+		"TriggerFailure":         {},
+		"TriggerLowerCase":       {},
+		"TriggerUpperCase":       {},
+	}
+}
+
 // sampleServiceClient manages all interaction w/ a remote SampleService instance by letting you invoke functions
 // on this instance as if you were doing it locally (hence... RPC client). Use the SampleServiceClient constructor
 // function to actually get an instance of this client.
@@ -332,7 +382,8 @@ func (client *sampleServiceClient) ListenerA(ctx context.Context, request *teste
 }
 
 // ListenerB fires on multiple triggers... including another event-based endpoint. We also
-// listen for the TriggerFailure event which should never fire properly.
+// listen for the TriggerFailure event which should never fire properly. The "payment.succeeded"
+// event is published by some system outside of Frodo, so its payload is just raw request JSON.
 func (client *sampleServiceClient) ListenerB(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
 
 	// Not exposed, so don't bother with a round trip to the server just to get a "not found" error anyway.
@@ -1,6 +1,6 @@
 // Code generated by Frodo - DO NOT EDIT.
 //
-//	Timestamp: Fri, 15 Nov 2024 12:49:05 EST
+//	Timestamp: Fri, 16 Oct 2026 12:02:54 UTC
 //	Source:    sample_service.go
 //	Generator: https://github.com/bridgekit-io/frodo
 package testext
@@ -8,6 +8,7 @@ package testext
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/bridgekit-io/frodo/internal/testext"
@@ -17,6 +18,12 @@ import (
 // dynamic functions named "XxxFunc" to provide the custom behavior; so if your service has a function
 // called 'CreateUser', you supply a function for 'CreateUserFunc'.
 //
+// You can also program behaviors that only fire for specific requests using the "OnXxx" functions
+// (e.g. 'OnCreateUser'). Expectations are checked in the order you defined them, and the first one
+// whose matcher accepts the request wins. If none of them match, we fall back to the "XxxFunc" behavior.
+// When your service already has a function named "OnXxx" (e.g. an event handler), the helper for Xxx is
+// named "OnXxxCall" instead so that the two don't collide.
+//
 // You do not need to supply behaviors for every single service function; just the ones you plan to
 // test. If you do invoke a function without a programmed behavior, it will just return an error
 // with a message indicating that it wasn't implemented.
@@ -84,28 +91,141 @@ type MockSampleService struct {
 		TriggerLowerCase       callsSampleServiceTriggerLowerCase
 		TriggerUpperCase       callsSampleServiceTriggerUpperCase
 	}
+
+	mutex        sync.Mutex
+	sequence     int
+	expectations struct {
+		Authorization          []*expectSampleServiceAuthorization
+		Chain1                 []*expectSampleServiceChain1
+		Chain1GroupFooBar      []*expectSampleServiceChain1GroupFooBar
+		Chain1GroupStar        []*expectSampleServiceChain1GroupStar
+		Chain2                 []*expectSampleServiceChain2
+		Chain2OnError          []*expectSampleServiceChain2OnError
+		Chain2OnSuccess        []*expectSampleServiceChain2OnSuccess
+		ComplexValues          []*expectSampleServiceComplexValues
+		ComplexValuesPath      []*expectSampleServiceComplexValuesPath
+		CustomRoute            []*expectSampleServiceCustomRoute
+		CustomRouteBody        []*expectSampleServiceCustomRouteBody
+		CustomRouteQuery       []*expectSampleServiceCustomRouteQuery
+		Defaults               []*expectSampleServiceDefaults
+		Download               []*expectSampleServiceDownload
+		DownloadResumable      []*expectSampleServiceDownloadResumable
+		Fail4XX                []*expectSampleServiceFail4XX
+		Fail5XX                []*expectSampleServiceFail5XX
+		FailAlways             []*expectSampleServiceFailAlways
+		ListenerA              []*expectSampleServiceListenerA
+		ListenerB              []*expectSampleServiceListenerB
+		OmitMe                 []*expectSampleServiceOmitMe
+		OnFailAlways           []*expectSampleServiceOnFailAlways
+		Panic                  []*expectSampleServicePanic
+		Redirect               []*expectSampleServiceRedirect
+		SecureWithRoles        []*expectSampleServiceSecureWithRoles
+		SecureWithRolesAliased []*expectSampleServiceSecureWithRolesAliased
+		Sleep                  []*expectSampleServiceSleep
+		TriggerFailure         []*expectSampleServiceTriggerFailure
+		TriggerLowerCase       []*expectSampleServiceTriggerLowerCase
+		TriggerUpperCase       []*expectSampleServiceTriggerUpperCase
+	}
 }
 
 /* ---- SampleService.Authorization Mock Support For  ---- */
 
 func (mock *MockSampleService) Authorization(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.Authorization = mock.Calls.Authorization.invoked(*request)
-	if mock.AuthorizationFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Authorization = mock.Calls.Authorization.invoked(mock.sequence, *request)
+	expectation := mock.matchAuthorization(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.AuthorizationFunc != nil:
+		return mock.AuthorizationFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Authorization not implemented")
 	}
-	response, err := mock.AuthorizationFunc(ctx, request)
-	return response, err
+}
+
+// OnAuthorization programs a behavior that only fires when Authorization is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnAuthorization(matcher func(*testext.SampleRequest) bool) *expectSampleServiceAuthorization {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceAuthorization{matcher: matcher}
+	mock.expectations.Authorization = append(mock.expectations.Authorization, expectation)
+	return expectation
+}
+
+// AssertAuthorizationCalled reports a test failure if Authorization was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertAuthorizationCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Authorization.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Authorization was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchAuthorization(request *testext.SampleRequest) *expectSampleServiceAuthorization {
+	for _, expectation := range mock.expectations.Authorization {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceAuthorization is a behavior for Authorization that only fires for requests accepted by its matcher.
+type expectSampleServiceAuthorization struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceAuthorization) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceAuthorization) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceAuthorization) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceAuthorization struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Authorization was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceAuthorization []callSampleServiceAuthorization
 
-func (calls callsSampleServiceAuthorization) invoked(request testext.SampleRequest) callsSampleServiceAuthorization {
-	return append(calls, callSampleServiceAuthorization{Time: time.Now(), Request: request})
+func (calls callsSampleServiceAuthorization) invoked(sequence int, request testext.SampleRequest) callsSampleServiceAuthorization {
+	return append(calls, callSampleServiceAuthorization{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Authorization was invoked with any request arguments.
@@ -113,6 +233,15 @@ func (calls callsSampleServiceAuthorization) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Authorization, in the order they were called.
+func (calls callsSampleServiceAuthorization) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Authorization was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -138,23 +267,101 @@ func (calls callsSampleServiceAuthorization) TimesMatching(pred func(testext.Sam
 /* ---- SampleService.Chain1 Mock Support For  ---- */
 
 func (mock *MockSampleService) Chain1(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.Chain1 = mock.Calls.Chain1.invoked(*request)
-	if mock.Chain1Func == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Chain1 = mock.Calls.Chain1.invoked(mock.sequence, *request)
+	expectation := mock.matchChain1(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.Chain1Func != nil:
+		return mock.Chain1Func(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Chain1 not implemented")
 	}
-	response, err := mock.Chain1Func(ctx, request)
-	return response, err
+}
+
+// OnChain1 programs a behavior that only fires when Chain1 is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnChain1(matcher func(*testext.SampleRequest) bool) *expectSampleServiceChain1 {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceChain1{matcher: matcher}
+	mock.expectations.Chain1 = append(mock.expectations.Chain1, expectation)
+	return expectation
+}
+
+// AssertChain1Called reports a test failure if Chain1 was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertChain1Called(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Chain1.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Chain1 was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchChain1(request *testext.SampleRequest) *expectSampleServiceChain1 {
+	for _, expectation := range mock.expectations.Chain1 {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceChain1 is a behavior for Chain1 that only fires for requests accepted by its matcher.
+type expectSampleServiceChain1 struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceChain1) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceChain1) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceChain1) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceChain1 struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Chain1 was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceChain1 []callSampleServiceChain1
 
-func (calls callsSampleServiceChain1) invoked(request testext.SampleRequest) callsSampleServiceChain1 {
-	return append(calls, callSampleServiceChain1{Time: time.Now(), Request: request})
+func (calls callsSampleServiceChain1) invoked(sequence int, request testext.SampleRequest) callsSampleServiceChain1 {
+	return append(calls, callSampleServiceChain1{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Chain1 was invoked with any request arguments.
@@ -162,6 +369,15 @@ func (calls callsSampleServiceChain1) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Chain1, in the order they were called.
+func (calls callsSampleServiceChain1) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Chain1 was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -187,23 +403,101 @@ func (calls callsSampleServiceChain1) TimesMatching(pred func(testext.SampleRequ
 /* ---- SampleService.Chain1GroupFooBar Mock Support For  ---- */
 
 func (mock *MockSampleService) Chain1GroupFooBar(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.Chain1GroupFooBar = mock.Calls.Chain1GroupFooBar.invoked(*request)
-	if mock.Chain1GroupFooBarFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Chain1GroupFooBar = mock.Calls.Chain1GroupFooBar.invoked(mock.sequence, *request)
+	expectation := mock.matchChain1GroupFooBar(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.Chain1GroupFooBarFunc != nil:
+		return mock.Chain1GroupFooBarFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Chain1GroupFooBar not implemented")
 	}
-	response, err := mock.Chain1GroupFooBarFunc(ctx, request)
-	return response, err
+}
+
+// OnChain1GroupFooBar programs a behavior that only fires when Chain1GroupFooBar is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnChain1GroupFooBar(matcher func(*testext.SampleRequest) bool) *expectSampleServiceChain1GroupFooBar {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceChain1GroupFooBar{matcher: matcher}
+	mock.expectations.Chain1GroupFooBar = append(mock.expectations.Chain1GroupFooBar, expectation)
+	return expectation
+}
+
+// AssertChain1GroupFooBarCalled reports a test failure if Chain1GroupFooBar was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertChain1GroupFooBarCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Chain1GroupFooBar.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Chain1GroupFooBar was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchChain1GroupFooBar(request *testext.SampleRequest) *expectSampleServiceChain1GroupFooBar {
+	for _, expectation := range mock.expectations.Chain1GroupFooBar {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceChain1GroupFooBar is a behavior for Chain1GroupFooBar that only fires for requests accepted by its matcher.
+type expectSampleServiceChain1GroupFooBar struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceChain1GroupFooBar) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceChain1GroupFooBar) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceChain1GroupFooBar) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceChain1GroupFooBar struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Chain1GroupFooBar was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceChain1GroupFooBar []callSampleServiceChain1GroupFooBar
 
-func (calls callsSampleServiceChain1GroupFooBar) invoked(request testext.SampleRequest) callsSampleServiceChain1GroupFooBar {
-	return append(calls, callSampleServiceChain1GroupFooBar{Time: time.Now(), Request: request})
+func (calls callsSampleServiceChain1GroupFooBar) invoked(sequence int, request testext.SampleRequest) callsSampleServiceChain1GroupFooBar {
+	return append(calls, callSampleServiceChain1GroupFooBar{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Chain1GroupFooBar was invoked with any request arguments.
@@ -211,6 +505,15 @@ func (calls callsSampleServiceChain1GroupFooBar) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Chain1GroupFooBar, in the order they were called.
+func (calls callsSampleServiceChain1GroupFooBar) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Chain1GroupFooBar was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -236,23 +539,101 @@ func (calls callsSampleServiceChain1GroupFooBar) TimesMatching(pred func(testext
 /* ---- SampleService.Chain1GroupStar Mock Support For  ---- */
 
 func (mock *MockSampleService) Chain1GroupStar(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.Chain1GroupStar = mock.Calls.Chain1GroupStar.invoked(*request)
-	if mock.Chain1GroupStarFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Chain1GroupStar = mock.Calls.Chain1GroupStar.invoked(mock.sequence, *request)
+	expectation := mock.matchChain1GroupStar(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.Chain1GroupStarFunc != nil:
+		return mock.Chain1GroupStarFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Chain1GroupStar not implemented")
 	}
-	response, err := mock.Chain1GroupStarFunc(ctx, request)
-	return response, err
+}
+
+// OnChain1GroupStar programs a behavior that only fires when Chain1GroupStar is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnChain1GroupStar(matcher func(*testext.SampleRequest) bool) *expectSampleServiceChain1GroupStar {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceChain1GroupStar{matcher: matcher}
+	mock.expectations.Chain1GroupStar = append(mock.expectations.Chain1GroupStar, expectation)
+	return expectation
+}
+
+// AssertChain1GroupStarCalled reports a test failure if Chain1GroupStar was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertChain1GroupStarCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Chain1GroupStar.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Chain1GroupStar was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchChain1GroupStar(request *testext.SampleRequest) *expectSampleServiceChain1GroupStar {
+	for _, expectation := range mock.expectations.Chain1GroupStar {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceChain1GroupStar is a behavior for Chain1GroupStar that only fires for requests accepted by its matcher.
+type expectSampleServiceChain1GroupStar struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceChain1GroupStar) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceChain1GroupStar) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceChain1GroupStar) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceChain1GroupStar struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Chain1GroupStar was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceChain1GroupStar []callSampleServiceChain1GroupStar
 
-func (calls callsSampleServiceChain1GroupStar) invoked(request testext.SampleRequest) callsSampleServiceChain1GroupStar {
-	return append(calls, callSampleServiceChain1GroupStar{Time: time.Now(), Request: request})
+func (calls callsSampleServiceChain1GroupStar) invoked(sequence int, request testext.SampleRequest) callsSampleServiceChain1GroupStar {
+	return append(calls, callSampleServiceChain1GroupStar{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Chain1GroupStar was invoked with any request arguments.
@@ -260,6 +641,15 @@ func (calls callsSampleServiceChain1GroupStar) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Chain1GroupStar, in the order they were called.
+func (calls callsSampleServiceChain1GroupStar) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Chain1GroupStar was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -285,23 +675,101 @@ func (calls callsSampleServiceChain1GroupStar) TimesMatching(pred func(testext.S
 /* ---- SampleService.Chain2 Mock Support For  ---- */
 
 func (mock *MockSampleService) Chain2(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.Chain2 = mock.Calls.Chain2.invoked(*request)
-	if mock.Chain2Func == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Chain2 = mock.Calls.Chain2.invoked(mock.sequence, *request)
+	expectation := mock.matchChain2(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.Chain2Func != nil:
+		return mock.Chain2Func(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Chain2 not implemented")
 	}
-	response, err := mock.Chain2Func(ctx, request)
-	return response, err
+}
+
+// OnChain2 programs a behavior that only fires when Chain2 is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnChain2(matcher func(*testext.SampleRequest) bool) *expectSampleServiceChain2 {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceChain2{matcher: matcher}
+	mock.expectations.Chain2 = append(mock.expectations.Chain2, expectation)
+	return expectation
+}
+
+// AssertChain2Called reports a test failure if Chain2 was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertChain2Called(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Chain2.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Chain2 was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchChain2(request *testext.SampleRequest) *expectSampleServiceChain2 {
+	for _, expectation := range mock.expectations.Chain2 {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceChain2 is a behavior for Chain2 that only fires for requests accepted by its matcher.
+type expectSampleServiceChain2 struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceChain2) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceChain2) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceChain2) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceChain2 struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Chain2 was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceChain2 []callSampleServiceChain2
 
-func (calls callsSampleServiceChain2) invoked(request testext.SampleRequest) callsSampleServiceChain2 {
-	return append(calls, callSampleServiceChain2{Time: time.Now(), Request: request})
+func (calls callsSampleServiceChain2) invoked(sequence int, request testext.SampleRequest) callsSampleServiceChain2 {
+	return append(calls, callSampleServiceChain2{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Chain2 was invoked with any request arguments.
@@ -309,6 +777,15 @@ func (calls callsSampleServiceChain2) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Chain2, in the order they were called.
+func (calls callsSampleServiceChain2) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Chain2 was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -334,23 +811,101 @@ func (calls callsSampleServiceChain2) TimesMatching(pred func(testext.SampleRequ
 /* ---- SampleService.Chain2OnError Mock Support For  ---- */
 
 func (mock *MockSampleService) Chain2OnError(ctx context.Context, request *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error) {
-	mock.Calls.Chain2OnError = mock.Calls.Chain2OnError.invoked(*request)
-	if mock.Chain2OnErrorFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Chain2OnError = mock.Calls.Chain2OnError.invoked(mock.sequence, *request)
+	expectation := mock.matchChain2OnError(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.Chain2OnErrorFunc != nil:
+		return mock.Chain2OnErrorFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Chain2OnError not implemented")
 	}
-	response, err := mock.Chain2OnErrorFunc(ctx, request)
-	return response, err
+}
+
+// OnChain2OnError programs a behavior that only fires when Chain2OnError is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnChain2OnError(matcher func(*testext.FailAlwaysErrorRequest) bool) *expectSampleServiceChain2OnError {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceChain2OnError{matcher: matcher}
+	mock.expectations.Chain2OnError = append(mock.expectations.Chain2OnError, expectation)
+	return expectation
+}
+
+// AssertChain2OnErrorCalled reports a test failure if Chain2OnError was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertChain2OnErrorCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Chain2OnError.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Chain2OnError was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchChain2OnError(request *testext.FailAlwaysErrorRequest) *expectSampleServiceChain2OnError {
+	for _, expectation := range mock.expectations.Chain2OnError {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceChain2OnError is a behavior for Chain2OnError that only fires for requests accepted by its matcher.
+type expectSampleServiceChain2OnError struct {
+	matcher  func(*testext.FailAlwaysErrorRequest) bool
+	behavior func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceChain2OnError) Return(response *testext.FailAlwaysErrorResponse, err error) {
+	expectation.Do(func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceChain2OnError) Do(behavior func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceChain2OnError) invoke(ctx context.Context, request *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.FailAlwaysErrorResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceChain2OnError struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Chain2OnError was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.FailAlwaysErrorRequest
 }
 
 type callsSampleServiceChain2OnError []callSampleServiceChain2OnError
 
-func (calls callsSampleServiceChain2OnError) invoked(request testext.FailAlwaysErrorRequest) callsSampleServiceChain2OnError {
-	return append(calls, callSampleServiceChain2OnError{Time: time.Now(), Request: request})
+func (calls callsSampleServiceChain2OnError) invoked(sequence int, request testext.FailAlwaysErrorRequest) callsSampleServiceChain2OnError {
+	return append(calls, callSampleServiceChain2OnError{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Chain2OnError was invoked with any request arguments.
@@ -358,6 +913,15 @@ func (calls callsSampleServiceChain2OnError) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Chain2OnError, in the order they were called.
+func (calls callsSampleServiceChain2OnError) Requests() []testext.FailAlwaysErrorRequest {
+	requests := make([]testext.FailAlwaysErrorRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Chain2OnError was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -383,23 +947,101 @@ func (calls callsSampleServiceChain2OnError) TimesMatching(pred func(testext.Fai
 /* ---- SampleService.Chain2OnSuccess Mock Support For  ---- */
 
 func (mock *MockSampleService) Chain2OnSuccess(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.Chain2OnSuccess = mock.Calls.Chain2OnSuccess.invoked(*request)
-	if mock.Chain2OnSuccessFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Chain2OnSuccess = mock.Calls.Chain2OnSuccess.invoked(mock.sequence, *request)
+	expectation := mock.matchChain2OnSuccess(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.Chain2OnSuccessFunc != nil:
+		return mock.Chain2OnSuccessFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Chain2OnSuccess not implemented")
 	}
-	response, err := mock.Chain2OnSuccessFunc(ctx, request)
-	return response, err
+}
+
+// OnChain2OnSuccess programs a behavior that only fires when Chain2OnSuccess is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnChain2OnSuccess(matcher func(*testext.SampleRequest) bool) *expectSampleServiceChain2OnSuccess {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceChain2OnSuccess{matcher: matcher}
+	mock.expectations.Chain2OnSuccess = append(mock.expectations.Chain2OnSuccess, expectation)
+	return expectation
+}
+
+// AssertChain2OnSuccessCalled reports a test failure if Chain2OnSuccess was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertChain2OnSuccessCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Chain2OnSuccess.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Chain2OnSuccess was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchChain2OnSuccess(request *testext.SampleRequest) *expectSampleServiceChain2OnSuccess {
+	for _, expectation := range mock.expectations.Chain2OnSuccess {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceChain2OnSuccess is a behavior for Chain2OnSuccess that only fires for requests accepted by its matcher.
+type expectSampleServiceChain2OnSuccess struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceChain2OnSuccess) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceChain2OnSuccess) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceChain2OnSuccess) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceChain2OnSuccess struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Chain2OnSuccess was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceChain2OnSuccess []callSampleServiceChain2OnSuccess
 
-func (calls callsSampleServiceChain2OnSuccess) invoked(request testext.SampleRequest) callsSampleServiceChain2OnSuccess {
-	return append(calls, callSampleServiceChain2OnSuccess{Time: time.Now(), Request: request})
+func (calls callsSampleServiceChain2OnSuccess) invoked(sequence int, request testext.SampleRequest) callsSampleServiceChain2OnSuccess {
+	return append(calls, callSampleServiceChain2OnSuccess{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Chain2OnSuccess was invoked with any request arguments.
@@ -407,6 +1049,15 @@ func (calls callsSampleServiceChain2OnSuccess) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Chain2OnSuccess, in the order they were called.
+func (calls callsSampleServiceChain2OnSuccess) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Chain2OnSuccess was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -432,23 +1083,101 @@ func (calls callsSampleServiceChain2OnSuccess) TimesMatching(pred func(testext.S
 /* ---- SampleService.ComplexValues Mock Support For  ---- */
 
 func (mock *MockSampleService) ComplexValues(ctx context.Context, request *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error) {
-	mock.Calls.ComplexValues = mock.Calls.ComplexValues.invoked(*request)
-	if mock.ComplexValuesFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.ComplexValues = mock.Calls.ComplexValues.invoked(mock.sequence, *request)
+	expectation := mock.matchComplexValues(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.ComplexValuesFunc != nil:
+		return mock.ComplexValuesFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.ComplexValues not implemented")
 	}
-	response, err := mock.ComplexValuesFunc(ctx, request)
-	return response, err
+}
+
+// OnComplexValues programs a behavior that only fires when ComplexValues is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnComplexValues(matcher func(*testext.SampleComplexRequest) bool) *expectSampleServiceComplexValues {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceComplexValues{matcher: matcher}
+	mock.expectations.ComplexValues = append(mock.expectations.ComplexValues, expectation)
+	return expectation
+}
+
+// AssertComplexValuesCalled reports a test failure if ComplexValues was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertComplexValuesCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.ComplexValues.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.ComplexValues was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchComplexValues(request *testext.SampleComplexRequest) *expectSampleServiceComplexValues {
+	for _, expectation := range mock.expectations.ComplexValues {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceComplexValues is a behavior for ComplexValues that only fires for requests accepted by its matcher.
+type expectSampleServiceComplexValues struct {
+	matcher  func(*testext.SampleComplexRequest) bool
+	behavior func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceComplexValues) Return(response *testext.SampleComplexResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceComplexValues) Do(behavior func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceComplexValues) invoke(ctx context.Context, request *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleComplexResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceComplexValues struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that ComplexValues was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleComplexRequest
 }
 
 type callsSampleServiceComplexValues []callSampleServiceComplexValues
 
-func (calls callsSampleServiceComplexValues) invoked(request testext.SampleComplexRequest) callsSampleServiceComplexValues {
-	return append(calls, callSampleServiceComplexValues{Time: time.Now(), Request: request})
+func (calls callsSampleServiceComplexValues) invoked(sequence int, request testext.SampleComplexRequest) callsSampleServiceComplexValues {
+	return append(calls, callSampleServiceComplexValues{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that ComplexValues was invoked with any request arguments.
@@ -456,6 +1185,15 @@ func (calls callsSampleServiceComplexValues) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of ComplexValues, in the order they were called.
+func (calls callsSampleServiceComplexValues) Requests() []testext.SampleComplexRequest {
+	requests := make([]testext.SampleComplexRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that ComplexValues was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -481,23 +1219,101 @@ func (calls callsSampleServiceComplexValues) TimesMatching(pred func(testext.Sam
 /* ---- SampleService.ComplexValuesPath Mock Support For  ---- */
 
 func (mock *MockSampleService) ComplexValuesPath(ctx context.Context, request *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error) {
-	mock.Calls.ComplexValuesPath = mock.Calls.ComplexValuesPath.invoked(*request)
-	if mock.ComplexValuesPathFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.ComplexValuesPath = mock.Calls.ComplexValuesPath.invoked(mock.sequence, *request)
+	expectation := mock.matchComplexValuesPath(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.ComplexValuesPathFunc != nil:
+		return mock.ComplexValuesPathFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.ComplexValuesPath not implemented")
 	}
-	response, err := mock.ComplexValuesPathFunc(ctx, request)
-	return response, err
+}
+
+// OnComplexValuesPath programs a behavior that only fires when ComplexValuesPath is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnComplexValuesPath(matcher func(*testext.SampleComplexRequest) bool) *expectSampleServiceComplexValuesPath {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceComplexValuesPath{matcher: matcher}
+	mock.expectations.ComplexValuesPath = append(mock.expectations.ComplexValuesPath, expectation)
+	return expectation
+}
+
+// AssertComplexValuesPathCalled reports a test failure if ComplexValuesPath was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertComplexValuesPathCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.ComplexValuesPath.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.ComplexValuesPath was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchComplexValuesPath(request *testext.SampleComplexRequest) *expectSampleServiceComplexValuesPath {
+	for _, expectation := range mock.expectations.ComplexValuesPath {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceComplexValuesPath is a behavior for ComplexValuesPath that only fires for requests accepted by its matcher.
+type expectSampleServiceComplexValuesPath struct {
+	matcher  func(*testext.SampleComplexRequest) bool
+	behavior func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceComplexValuesPath) Return(response *testext.SampleComplexResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceComplexValuesPath) Do(behavior func(context.Context, *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceComplexValuesPath) invoke(ctx context.Context, request *testext.SampleComplexRequest) (*testext.SampleComplexResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleComplexResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceComplexValuesPath struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that ComplexValuesPath was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleComplexRequest
 }
 
 type callsSampleServiceComplexValuesPath []callSampleServiceComplexValuesPath
 
-func (calls callsSampleServiceComplexValuesPath) invoked(request testext.SampleComplexRequest) callsSampleServiceComplexValuesPath {
-	return append(calls, callSampleServiceComplexValuesPath{Time: time.Now(), Request: request})
+func (calls callsSampleServiceComplexValuesPath) invoked(sequence int, request testext.SampleComplexRequest) callsSampleServiceComplexValuesPath {
+	return append(calls, callSampleServiceComplexValuesPath{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that ComplexValuesPath was invoked with any request arguments.
@@ -505,6 +1321,15 @@ func (calls callsSampleServiceComplexValuesPath) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of ComplexValuesPath, in the order they were called.
+func (calls callsSampleServiceComplexValuesPath) Requests() []testext.SampleComplexRequest {
+	requests := make([]testext.SampleComplexRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that ComplexValuesPath was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -530,23 +1355,101 @@ func (calls callsSampleServiceComplexValuesPath) TimesMatching(pred func(testext
 /* ---- SampleService.CustomRoute Mock Support For  ---- */
 
 func (mock *MockSampleService) CustomRoute(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.CustomRoute = mock.Calls.CustomRoute.invoked(*request)
-	if mock.CustomRouteFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.CustomRoute = mock.Calls.CustomRoute.invoked(mock.sequence, *request)
+	expectation := mock.matchCustomRoute(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.CustomRouteFunc != nil:
+		return mock.CustomRouteFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.CustomRoute not implemented")
 	}
-	response, err := mock.CustomRouteFunc(ctx, request)
-	return response, err
+}
+
+// OnCustomRoute programs a behavior that only fires when CustomRoute is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnCustomRoute(matcher func(*testext.SampleRequest) bool) *expectSampleServiceCustomRoute {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceCustomRoute{matcher: matcher}
+	mock.expectations.CustomRoute = append(mock.expectations.CustomRoute, expectation)
+	return expectation
+}
+
+// AssertCustomRouteCalled reports a test failure if CustomRoute was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertCustomRouteCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.CustomRoute.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.CustomRoute was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchCustomRoute(request *testext.SampleRequest) *expectSampleServiceCustomRoute {
+	for _, expectation := range mock.expectations.CustomRoute {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceCustomRoute is a behavior for CustomRoute that only fires for requests accepted by its matcher.
+type expectSampleServiceCustomRoute struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceCustomRoute) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceCustomRoute) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceCustomRoute) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceCustomRoute struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that CustomRoute was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceCustomRoute []callSampleServiceCustomRoute
 
-func (calls callsSampleServiceCustomRoute) invoked(request testext.SampleRequest) callsSampleServiceCustomRoute {
-	return append(calls, callSampleServiceCustomRoute{Time: time.Now(), Request: request})
+func (calls callsSampleServiceCustomRoute) invoked(sequence int, request testext.SampleRequest) callsSampleServiceCustomRoute {
+	return append(calls, callSampleServiceCustomRoute{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that CustomRoute was invoked with any request arguments.
@@ -554,6 +1457,15 @@ func (calls callsSampleServiceCustomRoute) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of CustomRoute, in the order they were called.
+func (calls callsSampleServiceCustomRoute) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that CustomRoute was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -579,23 +1491,101 @@ func (calls callsSampleServiceCustomRoute) TimesMatching(pred func(testext.Sampl
 /* ---- SampleService.CustomRouteBody Mock Support For  ---- */
 
 func (mock *MockSampleService) CustomRouteBody(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.CustomRouteBody = mock.Calls.CustomRouteBody.invoked(*request)
-	if mock.CustomRouteBodyFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.CustomRouteBody = mock.Calls.CustomRouteBody.invoked(mock.sequence, *request)
+	expectation := mock.matchCustomRouteBody(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.CustomRouteBodyFunc != nil:
+		return mock.CustomRouteBodyFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.CustomRouteBody not implemented")
 	}
-	response, err := mock.CustomRouteBodyFunc(ctx, request)
-	return response, err
 }
 
-type callSampleServiceCustomRouteBody struct {
-	Time    time.Time
-	Request testext.SampleRequest
+// OnCustomRouteBody programs a behavior that only fires when CustomRouteBody is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnCustomRouteBody(matcher func(*testext.SampleRequest) bool) *expectSampleServiceCustomRouteBody {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceCustomRouteBody{matcher: matcher}
+	mock.expectations.CustomRouteBody = append(mock.expectations.CustomRouteBody, expectation)
+	return expectation
+}
+
+// AssertCustomRouteBodyCalled reports a test failure if CustomRouteBody was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertCustomRouteBodyCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.CustomRouteBody.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.CustomRouteBody was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchCustomRouteBody(request *testext.SampleRequest) *expectSampleServiceCustomRouteBody {
+	for _, expectation := range mock.expectations.CustomRouteBody {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceCustomRouteBody is a behavior for CustomRouteBody that only fires for requests accepted by its matcher.
+type expectSampleServiceCustomRouteBody struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceCustomRouteBody) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceCustomRouteBody) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceCustomRouteBody) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
+}
+
+type callSampleServiceCustomRouteBody struct {
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that CustomRouteBody was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
+	Request testext.SampleRequest
 }
 
 type callsSampleServiceCustomRouteBody []callSampleServiceCustomRouteBody
 
-func (calls callsSampleServiceCustomRouteBody) invoked(request testext.SampleRequest) callsSampleServiceCustomRouteBody {
-	return append(calls, callSampleServiceCustomRouteBody{Time: time.Now(), Request: request})
+func (calls callsSampleServiceCustomRouteBody) invoked(sequence int, request testext.SampleRequest) callsSampleServiceCustomRouteBody {
+	return append(calls, callSampleServiceCustomRouteBody{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that CustomRouteBody was invoked with any request arguments.
@@ -603,6 +1593,15 @@ func (calls callsSampleServiceCustomRouteBody) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of CustomRouteBody, in the order they were called.
+func (calls callsSampleServiceCustomRouteBody) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that CustomRouteBody was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -628,23 +1627,101 @@ func (calls callsSampleServiceCustomRouteBody) TimesMatching(pred func(testext.S
 /* ---- SampleService.CustomRouteQuery Mock Support For  ---- */
 
 func (mock *MockSampleService) CustomRouteQuery(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.CustomRouteQuery = mock.Calls.CustomRouteQuery.invoked(*request)
-	if mock.CustomRouteQueryFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.CustomRouteQuery = mock.Calls.CustomRouteQuery.invoked(mock.sequence, *request)
+	expectation := mock.matchCustomRouteQuery(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.CustomRouteQueryFunc != nil:
+		return mock.CustomRouteQueryFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.CustomRouteQuery not implemented")
 	}
-	response, err := mock.CustomRouteQueryFunc(ctx, request)
-	return response, err
+}
+
+// OnCustomRouteQuery programs a behavior that only fires when CustomRouteQuery is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnCustomRouteQuery(matcher func(*testext.SampleRequest) bool) *expectSampleServiceCustomRouteQuery {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceCustomRouteQuery{matcher: matcher}
+	mock.expectations.CustomRouteQuery = append(mock.expectations.CustomRouteQuery, expectation)
+	return expectation
+}
+
+// AssertCustomRouteQueryCalled reports a test failure if CustomRouteQuery was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertCustomRouteQueryCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.CustomRouteQuery.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.CustomRouteQuery was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchCustomRouteQuery(request *testext.SampleRequest) *expectSampleServiceCustomRouteQuery {
+	for _, expectation := range mock.expectations.CustomRouteQuery {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceCustomRouteQuery is a behavior for CustomRouteQuery that only fires for requests accepted by its matcher.
+type expectSampleServiceCustomRouteQuery struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceCustomRouteQuery) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceCustomRouteQuery) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceCustomRouteQuery) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceCustomRouteQuery struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that CustomRouteQuery was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceCustomRouteQuery []callSampleServiceCustomRouteQuery
 
-func (calls callsSampleServiceCustomRouteQuery) invoked(request testext.SampleRequest) callsSampleServiceCustomRouteQuery {
-	return append(calls, callSampleServiceCustomRouteQuery{Time: time.Now(), Request: request})
+func (calls callsSampleServiceCustomRouteQuery) invoked(sequence int, request testext.SampleRequest) callsSampleServiceCustomRouteQuery {
+	return append(calls, callSampleServiceCustomRouteQuery{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that CustomRouteQuery was invoked with any request arguments.
@@ -652,6 +1729,15 @@ func (calls callsSampleServiceCustomRouteQuery) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of CustomRouteQuery, in the order they were called.
+func (calls callsSampleServiceCustomRouteQuery) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that CustomRouteQuery was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -677,23 +1763,101 @@ func (calls callsSampleServiceCustomRouteQuery) TimesMatching(pred func(testext.
 /* ---- SampleService.Defaults Mock Support For  ---- */
 
 func (mock *MockSampleService) Defaults(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.Defaults = mock.Calls.Defaults.invoked(*request)
-	if mock.DefaultsFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Defaults = mock.Calls.Defaults.invoked(mock.sequence, *request)
+	expectation := mock.matchDefaults(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.DefaultsFunc != nil:
+		return mock.DefaultsFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Defaults not implemented")
 	}
-	response, err := mock.DefaultsFunc(ctx, request)
-	return response, err
+}
+
+// OnDefaults programs a behavior that only fires when Defaults is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnDefaults(matcher func(*testext.SampleRequest) bool) *expectSampleServiceDefaults {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceDefaults{matcher: matcher}
+	mock.expectations.Defaults = append(mock.expectations.Defaults, expectation)
+	return expectation
+}
+
+// AssertDefaultsCalled reports a test failure if Defaults was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertDefaultsCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Defaults.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Defaults was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchDefaults(request *testext.SampleRequest) *expectSampleServiceDefaults {
+	for _, expectation := range mock.expectations.Defaults {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceDefaults is a behavior for Defaults that only fires for requests accepted by its matcher.
+type expectSampleServiceDefaults struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceDefaults) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceDefaults) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceDefaults) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceDefaults struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Defaults was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceDefaults []callSampleServiceDefaults
 
-func (calls callsSampleServiceDefaults) invoked(request testext.SampleRequest) callsSampleServiceDefaults {
-	return append(calls, callSampleServiceDefaults{Time: time.Now(), Request: request})
+func (calls callsSampleServiceDefaults) invoked(sequence int, request testext.SampleRequest) callsSampleServiceDefaults {
+	return append(calls, callSampleServiceDefaults{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Defaults was invoked with any request arguments.
@@ -701,6 +1865,15 @@ func (calls callsSampleServiceDefaults) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Defaults, in the order they were called.
+func (calls callsSampleServiceDefaults) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Defaults was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -726,23 +1899,101 @@ func (calls callsSampleServiceDefaults) TimesMatching(pred func(testext.SampleRe
 /* ---- SampleService.Download Mock Support For  ---- */
 
 func (mock *MockSampleService) Download(ctx context.Context, request *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error) {
-	mock.Calls.Download = mock.Calls.Download.invoked(*request)
-	if mock.DownloadFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Download = mock.Calls.Download.invoked(mock.sequence, *request)
+	expectation := mock.matchDownload(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.DownloadFunc != nil:
+		return mock.DownloadFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Download not implemented")
 	}
-	response, err := mock.DownloadFunc(ctx, request)
-	return response, err
+}
+
+// OnDownload programs a behavior that only fires when Download is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnDownload(matcher func(*testext.SampleDownloadRequest) bool) *expectSampleServiceDownload {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceDownload{matcher: matcher}
+	mock.expectations.Download = append(mock.expectations.Download, expectation)
+	return expectation
+}
+
+// AssertDownloadCalled reports a test failure if Download was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertDownloadCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Download.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Download was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchDownload(request *testext.SampleDownloadRequest) *expectSampleServiceDownload {
+	for _, expectation := range mock.expectations.Download {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceDownload is a behavior for Download that only fires for requests accepted by its matcher.
+type expectSampleServiceDownload struct {
+	matcher  func(*testext.SampleDownloadRequest) bool
+	behavior func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceDownload) Return(response *testext.SampleDownloadResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceDownload) Do(behavior func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceDownload) invoke(ctx context.Context, request *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleDownloadResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceDownload struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Download was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleDownloadRequest
 }
 
 type callsSampleServiceDownload []callSampleServiceDownload
 
-func (calls callsSampleServiceDownload) invoked(request testext.SampleDownloadRequest) callsSampleServiceDownload {
-	return append(calls, callSampleServiceDownload{Time: time.Now(), Request: request})
+func (calls callsSampleServiceDownload) invoked(sequence int, request testext.SampleDownloadRequest) callsSampleServiceDownload {
+	return append(calls, callSampleServiceDownload{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Download was invoked with any request arguments.
@@ -750,6 +2001,15 @@ func (calls callsSampleServiceDownload) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Download, in the order they were called.
+func (calls callsSampleServiceDownload) Requests() []testext.SampleDownloadRequest {
+	requests := make([]testext.SampleDownloadRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Download was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -775,23 +2035,101 @@ func (calls callsSampleServiceDownload) TimesMatching(pred func(testext.SampleDo
 /* ---- SampleService.DownloadResumable Mock Support For  ---- */
 
 func (mock *MockSampleService) DownloadResumable(ctx context.Context, request *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error) {
-	mock.Calls.DownloadResumable = mock.Calls.DownloadResumable.invoked(*request)
-	if mock.DownloadResumableFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.DownloadResumable = mock.Calls.DownloadResumable.invoked(mock.sequence, *request)
+	expectation := mock.matchDownloadResumable(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.DownloadResumableFunc != nil:
+		return mock.DownloadResumableFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.DownloadResumable not implemented")
 	}
-	response, err := mock.DownloadResumableFunc(ctx, request)
-	return response, err
+}
+
+// OnDownloadResumable programs a behavior that only fires when DownloadResumable is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnDownloadResumable(matcher func(*testext.SampleDownloadRequest) bool) *expectSampleServiceDownloadResumable {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceDownloadResumable{matcher: matcher}
+	mock.expectations.DownloadResumable = append(mock.expectations.DownloadResumable, expectation)
+	return expectation
+}
+
+// AssertDownloadResumableCalled reports a test failure if DownloadResumable was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertDownloadResumableCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.DownloadResumable.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.DownloadResumable was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchDownloadResumable(request *testext.SampleDownloadRequest) *expectSampleServiceDownloadResumable {
+	for _, expectation := range mock.expectations.DownloadResumable {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceDownloadResumable is a behavior for DownloadResumable that only fires for requests accepted by its matcher.
+type expectSampleServiceDownloadResumable struct {
+	matcher  func(*testext.SampleDownloadRequest) bool
+	behavior func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceDownloadResumable) Return(response *testext.SampleDownloadResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceDownloadResumable) Do(behavior func(context.Context, *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceDownloadResumable) invoke(ctx context.Context, request *testext.SampleDownloadRequest) (*testext.SampleDownloadResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleDownloadResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceDownloadResumable struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that DownloadResumable was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleDownloadRequest
 }
 
 type callsSampleServiceDownloadResumable []callSampleServiceDownloadResumable
 
-func (calls callsSampleServiceDownloadResumable) invoked(request testext.SampleDownloadRequest) callsSampleServiceDownloadResumable {
-	return append(calls, callSampleServiceDownloadResumable{Time: time.Now(), Request: request})
+func (calls callsSampleServiceDownloadResumable) invoked(sequence int, request testext.SampleDownloadRequest) callsSampleServiceDownloadResumable {
+	return append(calls, callSampleServiceDownloadResumable{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that DownloadResumable was invoked with any request arguments.
@@ -799,6 +2137,15 @@ func (calls callsSampleServiceDownloadResumable) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of DownloadResumable, in the order they were called.
+func (calls callsSampleServiceDownloadResumable) Requests() []testext.SampleDownloadRequest {
+	requests := make([]testext.SampleDownloadRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that DownloadResumable was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -824,23 +2171,101 @@ func (calls callsSampleServiceDownloadResumable) TimesMatching(pred func(testext
 /* ---- SampleService.Fail4XX Mock Support For  ---- */
 
 func (mock *MockSampleService) Fail4XX(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.Fail4XX = mock.Calls.Fail4XX.invoked(*request)
-	if mock.Fail4XXFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Fail4XX = mock.Calls.Fail4XX.invoked(mock.sequence, *request)
+	expectation := mock.matchFail4XX(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.Fail4XXFunc != nil:
+		return mock.Fail4XXFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Fail4XX not implemented")
 	}
-	response, err := mock.Fail4XXFunc(ctx, request)
-	return response, err
+}
+
+// OnFail4XX programs a behavior that only fires when Fail4XX is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnFail4XX(matcher func(*testext.SampleRequest) bool) *expectSampleServiceFail4XX {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceFail4XX{matcher: matcher}
+	mock.expectations.Fail4XX = append(mock.expectations.Fail4XX, expectation)
+	return expectation
+}
+
+// AssertFail4XXCalled reports a test failure if Fail4XX was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertFail4XXCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Fail4XX.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Fail4XX was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchFail4XX(request *testext.SampleRequest) *expectSampleServiceFail4XX {
+	for _, expectation := range mock.expectations.Fail4XX {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceFail4XX is a behavior for Fail4XX that only fires for requests accepted by its matcher.
+type expectSampleServiceFail4XX struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceFail4XX) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceFail4XX) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceFail4XX) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceFail4XX struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Fail4XX was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceFail4XX []callSampleServiceFail4XX
 
-func (calls callsSampleServiceFail4XX) invoked(request testext.SampleRequest) callsSampleServiceFail4XX {
-	return append(calls, callSampleServiceFail4XX{Time: time.Now(), Request: request})
+func (calls callsSampleServiceFail4XX) invoked(sequence int, request testext.SampleRequest) callsSampleServiceFail4XX {
+	return append(calls, callSampleServiceFail4XX{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Fail4XX was invoked with any request arguments.
@@ -848,6 +2273,15 @@ func (calls callsSampleServiceFail4XX) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Fail4XX, in the order they were called.
+func (calls callsSampleServiceFail4XX) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Fail4XX was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -873,23 +2307,101 @@ func (calls callsSampleServiceFail4XX) TimesMatching(pred func(testext.SampleReq
 /* ---- SampleService.Fail5XX Mock Support For  ---- */
 
 func (mock *MockSampleService) Fail5XX(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.Fail5XX = mock.Calls.Fail5XX.invoked(*request)
-	if mock.Fail5XXFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Fail5XX = mock.Calls.Fail5XX.invoked(mock.sequence, *request)
+	expectation := mock.matchFail5XX(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.Fail5XXFunc != nil:
+		return mock.Fail5XXFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Fail5XX not implemented")
 	}
-	response, err := mock.Fail5XXFunc(ctx, request)
-	return response, err
+}
+
+// OnFail5XX programs a behavior that only fires when Fail5XX is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnFail5XX(matcher func(*testext.SampleRequest) bool) *expectSampleServiceFail5XX {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceFail5XX{matcher: matcher}
+	mock.expectations.Fail5XX = append(mock.expectations.Fail5XX, expectation)
+	return expectation
+}
+
+// AssertFail5XXCalled reports a test failure if Fail5XX was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertFail5XXCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Fail5XX.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Fail5XX was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchFail5XX(request *testext.SampleRequest) *expectSampleServiceFail5XX {
+	for _, expectation := range mock.expectations.Fail5XX {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceFail5XX is a behavior for Fail5XX that only fires for requests accepted by its matcher.
+type expectSampleServiceFail5XX struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceFail5XX) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceFail5XX) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceFail5XX) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceFail5XX struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Fail5XX was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceFail5XX []callSampleServiceFail5XX
 
-func (calls callsSampleServiceFail5XX) invoked(request testext.SampleRequest) callsSampleServiceFail5XX {
-	return append(calls, callSampleServiceFail5XX{Time: time.Now(), Request: request})
+func (calls callsSampleServiceFail5XX) invoked(sequence int, request testext.SampleRequest) callsSampleServiceFail5XX {
+	return append(calls, callSampleServiceFail5XX{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Fail5XX was invoked with any request arguments.
@@ -897,6 +2409,15 @@ func (calls callsSampleServiceFail5XX) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Fail5XX, in the order they were called.
+func (calls callsSampleServiceFail5XX) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Fail5XX was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -922,23 +2443,101 @@ func (calls callsSampleServiceFail5XX) TimesMatching(pred func(testext.SampleReq
 /* ---- SampleService.FailAlways Mock Support For  ---- */
 
 func (mock *MockSampleService) FailAlways(ctx context.Context, request *testext.FailAlwaysRequest) (*testext.FailAlwaysResponse, error) {
-	mock.Calls.FailAlways = mock.Calls.FailAlways.invoked(*request)
-	if mock.FailAlwaysFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.FailAlways = mock.Calls.FailAlways.invoked(mock.sequence, *request)
+	expectation := mock.matchFailAlways(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.FailAlwaysFunc != nil:
+		return mock.FailAlwaysFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.FailAlways not implemented")
 	}
-	response, err := mock.FailAlwaysFunc(ctx, request)
-	return response, err
+}
+
+// OnFailAlwaysCall programs a behavior that only fires when FailAlways is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnFailAlwaysCall(matcher func(*testext.FailAlwaysRequest) bool) *expectSampleServiceFailAlways {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceFailAlways{matcher: matcher}
+	mock.expectations.FailAlways = append(mock.expectations.FailAlways, expectation)
+	return expectation
+}
+
+// AssertFailAlwaysCalled reports a test failure if FailAlways was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertFailAlwaysCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.FailAlways.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.FailAlways was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchFailAlways(request *testext.FailAlwaysRequest) *expectSampleServiceFailAlways {
+	for _, expectation := range mock.expectations.FailAlways {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceFailAlways is a behavior for FailAlways that only fires for requests accepted by its matcher.
+type expectSampleServiceFailAlways struct {
+	matcher  func(*testext.FailAlwaysRequest) bool
+	behavior func(context.Context, *testext.FailAlwaysRequest) (*testext.FailAlwaysResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceFailAlways) Return(response *testext.FailAlwaysResponse, err error) {
+	expectation.Do(func(context.Context, *testext.FailAlwaysRequest) (*testext.FailAlwaysResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceFailAlways) Do(behavior func(context.Context, *testext.FailAlwaysRequest) (*testext.FailAlwaysResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceFailAlways) invoke(ctx context.Context, request *testext.FailAlwaysRequest) (*testext.FailAlwaysResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.FailAlwaysResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceFailAlways struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that FailAlways was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.FailAlwaysRequest
 }
 
 type callsSampleServiceFailAlways []callSampleServiceFailAlways
 
-func (calls callsSampleServiceFailAlways) invoked(request testext.FailAlwaysRequest) callsSampleServiceFailAlways {
-	return append(calls, callSampleServiceFailAlways{Time: time.Now(), Request: request})
+func (calls callsSampleServiceFailAlways) invoked(sequence int, request testext.FailAlwaysRequest) callsSampleServiceFailAlways {
+	return append(calls, callSampleServiceFailAlways{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that FailAlways was invoked with any request arguments.
@@ -946,6 +2545,15 @@ func (calls callsSampleServiceFailAlways) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of FailAlways, in the order they were called.
+func (calls callsSampleServiceFailAlways) Requests() []testext.FailAlwaysRequest {
+	requests := make([]testext.FailAlwaysRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that FailAlways was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -971,23 +2579,101 @@ func (calls callsSampleServiceFailAlways) TimesMatching(pred func(testext.FailAl
 /* ---- SampleService.ListenerA Mock Support For  ---- */
 
 func (mock *MockSampleService) ListenerA(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.ListenerA = mock.Calls.ListenerA.invoked(*request)
-	if mock.ListenerAFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.ListenerA = mock.Calls.ListenerA.invoked(mock.sequence, *request)
+	expectation := mock.matchListenerA(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.ListenerAFunc != nil:
+		return mock.ListenerAFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.ListenerA not implemented")
 	}
-	response, err := mock.ListenerAFunc(ctx, request)
-	return response, err
+}
+
+// OnListenerA programs a behavior that only fires when ListenerA is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnListenerA(matcher func(*testext.SampleRequest) bool) *expectSampleServiceListenerA {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceListenerA{matcher: matcher}
+	mock.expectations.ListenerA = append(mock.expectations.ListenerA, expectation)
+	return expectation
+}
+
+// AssertListenerACalled reports a test failure if ListenerA was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertListenerACalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.ListenerA.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.ListenerA was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchListenerA(request *testext.SampleRequest) *expectSampleServiceListenerA {
+	for _, expectation := range mock.expectations.ListenerA {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceListenerA is a behavior for ListenerA that only fires for requests accepted by its matcher.
+type expectSampleServiceListenerA struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceListenerA) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceListenerA) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceListenerA) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceListenerA struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that ListenerA was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceListenerA []callSampleServiceListenerA
 
-func (calls callsSampleServiceListenerA) invoked(request testext.SampleRequest) callsSampleServiceListenerA {
-	return append(calls, callSampleServiceListenerA{Time: time.Now(), Request: request})
+func (calls callsSampleServiceListenerA) invoked(sequence int, request testext.SampleRequest) callsSampleServiceListenerA {
+	return append(calls, callSampleServiceListenerA{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that ListenerA was invoked with any request arguments.
@@ -995,6 +2681,15 @@ func (calls callsSampleServiceListenerA) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of ListenerA, in the order they were called.
+func (calls callsSampleServiceListenerA) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that ListenerA was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -1020,23 +2715,101 @@ func (calls callsSampleServiceListenerA) TimesMatching(pred func(testext.SampleR
 /* ---- SampleService.ListenerB Mock Support For  ---- */
 
 func (mock *MockSampleService) ListenerB(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.ListenerB = mock.Calls.ListenerB.invoked(*request)
-	if mock.ListenerBFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.ListenerB = mock.Calls.ListenerB.invoked(mock.sequence, *request)
+	expectation := mock.matchListenerB(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.ListenerBFunc != nil:
+		return mock.ListenerBFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.ListenerB not implemented")
 	}
-	response, err := mock.ListenerBFunc(ctx, request)
-	return response, err
+}
+
+// OnListenerB programs a behavior that only fires when ListenerB is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnListenerB(matcher func(*testext.SampleRequest) bool) *expectSampleServiceListenerB {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceListenerB{matcher: matcher}
+	mock.expectations.ListenerB = append(mock.expectations.ListenerB, expectation)
+	return expectation
+}
+
+// AssertListenerBCalled reports a test failure if ListenerB was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertListenerBCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.ListenerB.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.ListenerB was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchListenerB(request *testext.SampleRequest) *expectSampleServiceListenerB {
+	for _, expectation := range mock.expectations.ListenerB {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceListenerB is a behavior for ListenerB that only fires for requests accepted by its matcher.
+type expectSampleServiceListenerB struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceListenerB) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceListenerB) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceListenerB) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceListenerB struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that ListenerB was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceListenerB []callSampleServiceListenerB
 
-func (calls callsSampleServiceListenerB) invoked(request testext.SampleRequest) callsSampleServiceListenerB {
-	return append(calls, callSampleServiceListenerB{Time: time.Now(), Request: request})
+func (calls callsSampleServiceListenerB) invoked(sequence int, request testext.SampleRequest) callsSampleServiceListenerB {
+	return append(calls, callSampleServiceListenerB{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that ListenerB was invoked with any request arguments.
@@ -1044,6 +2817,15 @@ func (calls callsSampleServiceListenerB) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of ListenerB, in the order they were called.
+func (calls callsSampleServiceListenerB) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that ListenerB was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -1069,23 +2851,101 @@ func (calls callsSampleServiceListenerB) TimesMatching(pred func(testext.SampleR
 /* ---- SampleService.OmitMe Mock Support For  ---- */
 
 func (mock *MockSampleService) OmitMe(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.OmitMe = mock.Calls.OmitMe.invoked(*request)
-	if mock.OmitMeFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.OmitMe = mock.Calls.OmitMe.invoked(mock.sequence, *request)
+	expectation := mock.matchOmitMe(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.OmitMeFunc != nil:
+		return mock.OmitMeFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.OmitMe not implemented")
 	}
-	response, err := mock.OmitMeFunc(ctx, request)
-	return response, err
+}
+
+// OnOmitMe programs a behavior that only fires when OmitMe is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnOmitMe(matcher func(*testext.SampleRequest) bool) *expectSampleServiceOmitMe {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceOmitMe{matcher: matcher}
+	mock.expectations.OmitMe = append(mock.expectations.OmitMe, expectation)
+	return expectation
+}
+
+// AssertOmitMeCalled reports a test failure if OmitMe was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertOmitMeCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.OmitMe.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.OmitMe was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchOmitMe(request *testext.SampleRequest) *expectSampleServiceOmitMe {
+	for _, expectation := range mock.expectations.OmitMe {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceOmitMe is a behavior for OmitMe that only fires for requests accepted by its matcher.
+type expectSampleServiceOmitMe struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceOmitMe) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceOmitMe) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceOmitMe) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceOmitMe struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that OmitMe was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceOmitMe []callSampleServiceOmitMe
 
-func (calls callsSampleServiceOmitMe) invoked(request testext.SampleRequest) callsSampleServiceOmitMe {
-	return append(calls, callSampleServiceOmitMe{Time: time.Now(), Request: request})
+func (calls callsSampleServiceOmitMe) invoked(sequence int, request testext.SampleRequest) callsSampleServiceOmitMe {
+	return append(calls, callSampleServiceOmitMe{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that OmitMe was invoked with any request arguments.
@@ -1093,6 +2953,15 @@ func (calls callsSampleServiceOmitMe) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of OmitMe, in the order they were called.
+func (calls callsSampleServiceOmitMe) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that OmitMe was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -1118,23 +2987,101 @@ func (calls callsSampleServiceOmitMe) TimesMatching(pred func(testext.SampleRequ
 /* ---- SampleService.OnFailAlways Mock Support For  ---- */
 
 func (mock *MockSampleService) OnFailAlways(ctx context.Context, request *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error) {
-	mock.Calls.OnFailAlways = mock.Calls.OnFailAlways.invoked(*request)
-	if mock.OnFailAlwaysFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.OnFailAlways = mock.Calls.OnFailAlways.invoked(mock.sequence, *request)
+	expectation := mock.matchOnFailAlways(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.OnFailAlwaysFunc != nil:
+		return mock.OnFailAlwaysFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.OnFailAlways not implemented")
 	}
-	response, err := mock.OnFailAlwaysFunc(ctx, request)
-	return response, err
+}
+
+// OnOnFailAlways programs a behavior that only fires when OnFailAlways is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnOnFailAlways(matcher func(*testext.FailAlwaysErrorRequest) bool) *expectSampleServiceOnFailAlways {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceOnFailAlways{matcher: matcher}
+	mock.expectations.OnFailAlways = append(mock.expectations.OnFailAlways, expectation)
+	return expectation
+}
+
+// AssertOnFailAlwaysCalled reports a test failure if OnFailAlways was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertOnFailAlwaysCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.OnFailAlways.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.OnFailAlways was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchOnFailAlways(request *testext.FailAlwaysErrorRequest) *expectSampleServiceOnFailAlways {
+	for _, expectation := range mock.expectations.OnFailAlways {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceOnFailAlways is a behavior for OnFailAlways that only fires for requests accepted by its matcher.
+type expectSampleServiceOnFailAlways struct {
+	matcher  func(*testext.FailAlwaysErrorRequest) bool
+	behavior func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceOnFailAlways) Return(response *testext.FailAlwaysErrorResponse, err error) {
+	expectation.Do(func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceOnFailAlways) Do(behavior func(context.Context, *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceOnFailAlways) invoke(ctx context.Context, request *testext.FailAlwaysErrorRequest) (*testext.FailAlwaysErrorResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.FailAlwaysErrorResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceOnFailAlways struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that OnFailAlways was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.FailAlwaysErrorRequest
 }
 
 type callsSampleServiceOnFailAlways []callSampleServiceOnFailAlways
 
-func (calls callsSampleServiceOnFailAlways) invoked(request testext.FailAlwaysErrorRequest) callsSampleServiceOnFailAlways {
-	return append(calls, callSampleServiceOnFailAlways{Time: time.Now(), Request: request})
+func (calls callsSampleServiceOnFailAlways) invoked(sequence int, request testext.FailAlwaysErrorRequest) callsSampleServiceOnFailAlways {
+	return append(calls, callSampleServiceOnFailAlways{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that OnFailAlways was invoked with any request arguments.
@@ -1142,6 +3089,15 @@ func (calls callsSampleServiceOnFailAlways) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of OnFailAlways, in the order they were called.
+func (calls callsSampleServiceOnFailAlways) Requests() []testext.FailAlwaysErrorRequest {
+	requests := make([]testext.FailAlwaysErrorRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that OnFailAlways was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -1167,23 +3123,101 @@ func (calls callsSampleServiceOnFailAlways) TimesMatching(pred func(testext.Fail
 /* ---- SampleService.Panic Mock Support For  ---- */
 
 func (mock *MockSampleService) Panic(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.Panic = mock.Calls.Panic.invoked(*request)
-	if mock.PanicFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Panic = mock.Calls.Panic.invoked(mock.sequence, *request)
+	expectation := mock.matchPanic(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.PanicFunc != nil:
+		return mock.PanicFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Panic not implemented")
 	}
-	response, err := mock.PanicFunc(ctx, request)
-	return response, err
+}
+
+// OnPanic programs a behavior that only fires when Panic is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnPanic(matcher func(*testext.SampleRequest) bool) *expectSampleServicePanic {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServicePanic{matcher: matcher}
+	mock.expectations.Panic = append(mock.expectations.Panic, expectation)
+	return expectation
+}
+
+// AssertPanicCalled reports a test failure if Panic was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertPanicCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Panic.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Panic was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchPanic(request *testext.SampleRequest) *expectSampleServicePanic {
+	for _, expectation := range mock.expectations.Panic {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServicePanic is a behavior for Panic that only fires for requests accepted by its matcher.
+type expectSampleServicePanic struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServicePanic) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServicePanic) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServicePanic) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServicePanic struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Panic was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServicePanic []callSampleServicePanic
 
-func (calls callsSampleServicePanic) invoked(request testext.SampleRequest) callsSampleServicePanic {
-	return append(calls, callSampleServicePanic{Time: time.Now(), Request: request})
+func (calls callsSampleServicePanic) invoked(sequence int, request testext.SampleRequest) callsSampleServicePanic {
+	return append(calls, callSampleServicePanic{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Panic was invoked with any request arguments.
@@ -1191,6 +3225,15 @@ func (calls callsSampleServicePanic) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Panic, in the order they were called.
+func (calls callsSampleServicePanic) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Panic was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -1216,23 +3259,101 @@ func (calls callsSampleServicePanic) TimesMatching(pred func(testext.SampleReque
 /* ---- SampleService.Redirect Mock Support For  ---- */
 
 func (mock *MockSampleService) Redirect(ctx context.Context, request *testext.SampleRedirectRequest) (*testext.SampleRedirectResponse, error) {
-	mock.Calls.Redirect = mock.Calls.Redirect.invoked(*request)
-	if mock.RedirectFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Redirect = mock.Calls.Redirect.invoked(mock.sequence, *request)
+	expectation := mock.matchRedirect(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.RedirectFunc != nil:
+		return mock.RedirectFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Redirect not implemented")
 	}
-	response, err := mock.RedirectFunc(ctx, request)
-	return response, err
+}
+
+// OnRedirect programs a behavior that only fires when Redirect is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnRedirect(matcher func(*testext.SampleRedirectRequest) bool) *expectSampleServiceRedirect {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceRedirect{matcher: matcher}
+	mock.expectations.Redirect = append(mock.expectations.Redirect, expectation)
+	return expectation
+}
+
+// AssertRedirectCalled reports a test failure if Redirect was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertRedirectCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Redirect.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Redirect was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchRedirect(request *testext.SampleRedirectRequest) *expectSampleServiceRedirect {
+	for _, expectation := range mock.expectations.Redirect {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceRedirect is a behavior for Redirect that only fires for requests accepted by its matcher.
+type expectSampleServiceRedirect struct {
+	matcher  func(*testext.SampleRedirectRequest) bool
+	behavior func(context.Context, *testext.SampleRedirectRequest) (*testext.SampleRedirectResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceRedirect) Return(response *testext.SampleRedirectResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRedirectRequest) (*testext.SampleRedirectResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceRedirect) Do(behavior func(context.Context, *testext.SampleRedirectRequest) (*testext.SampleRedirectResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceRedirect) invoke(ctx context.Context, request *testext.SampleRedirectRequest) (*testext.SampleRedirectResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleRedirectResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceRedirect struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Redirect was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRedirectRequest
 }
 
 type callsSampleServiceRedirect []callSampleServiceRedirect
 
-func (calls callsSampleServiceRedirect) invoked(request testext.SampleRedirectRequest) callsSampleServiceRedirect {
-	return append(calls, callSampleServiceRedirect{Time: time.Now(), Request: request})
+func (calls callsSampleServiceRedirect) invoked(sequence int, request testext.SampleRedirectRequest) callsSampleServiceRedirect {
+	return append(calls, callSampleServiceRedirect{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Redirect was invoked with any request arguments.
@@ -1240,6 +3361,15 @@ func (calls callsSampleServiceRedirect) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Redirect, in the order they were called.
+func (calls callsSampleServiceRedirect) Requests() []testext.SampleRedirectRequest {
+	requests := make([]testext.SampleRedirectRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Redirect was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -1265,23 +3395,101 @@ func (calls callsSampleServiceRedirect) TimesMatching(pred func(testext.SampleRe
 /* ---- SampleService.SecureWithRoles Mock Support For  ---- */
 
 func (mock *MockSampleService) SecureWithRoles(ctx context.Context, request *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error) {
-	mock.Calls.SecureWithRoles = mock.Calls.SecureWithRoles.invoked(*request)
-	if mock.SecureWithRolesFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.SecureWithRoles = mock.Calls.SecureWithRoles.invoked(mock.sequence, *request)
+	expectation := mock.matchSecureWithRoles(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.SecureWithRolesFunc != nil:
+		return mock.SecureWithRolesFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.SecureWithRoles not implemented")
 	}
-	response, err := mock.SecureWithRolesFunc(ctx, request)
-	return response, err
+}
+
+// OnSecureWithRoles programs a behavior that only fires when SecureWithRoles is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnSecureWithRoles(matcher func(*testext.SampleSecurityRequest) bool) *expectSampleServiceSecureWithRoles {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceSecureWithRoles{matcher: matcher}
+	mock.expectations.SecureWithRoles = append(mock.expectations.SecureWithRoles, expectation)
+	return expectation
+}
+
+// AssertSecureWithRolesCalled reports a test failure if SecureWithRoles was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertSecureWithRolesCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.SecureWithRoles.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.SecureWithRoles was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchSecureWithRoles(request *testext.SampleSecurityRequest) *expectSampleServiceSecureWithRoles {
+	for _, expectation := range mock.expectations.SecureWithRoles {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceSecureWithRoles is a behavior for SecureWithRoles that only fires for requests accepted by its matcher.
+type expectSampleServiceSecureWithRoles struct {
+	matcher  func(*testext.SampleSecurityRequest) bool
+	behavior func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceSecureWithRoles) Return(response *testext.SampleSecurityResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceSecureWithRoles) Do(behavior func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceSecureWithRoles) invoke(ctx context.Context, request *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleSecurityResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceSecureWithRoles struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that SecureWithRoles was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleSecurityRequest
 }
 
 type callsSampleServiceSecureWithRoles []callSampleServiceSecureWithRoles
 
-func (calls callsSampleServiceSecureWithRoles) invoked(request testext.SampleSecurityRequest) callsSampleServiceSecureWithRoles {
-	return append(calls, callSampleServiceSecureWithRoles{Time: time.Now(), Request: request})
+func (calls callsSampleServiceSecureWithRoles) invoked(sequence int, request testext.SampleSecurityRequest) callsSampleServiceSecureWithRoles {
+	return append(calls, callSampleServiceSecureWithRoles{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that SecureWithRoles was invoked with any request arguments.
@@ -1289,6 +3497,15 @@ func (calls callsSampleServiceSecureWithRoles) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of SecureWithRoles, in the order they were called.
+func (calls callsSampleServiceSecureWithRoles) Requests() []testext.SampleSecurityRequest {
+	requests := make([]testext.SampleSecurityRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that SecureWithRoles was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -1314,23 +3531,101 @@ func (calls callsSampleServiceSecureWithRoles) TimesMatching(pred func(testext.S
 /* ---- SampleService.SecureWithRolesAliased Mock Support For  ---- */
 
 func (mock *MockSampleService) SecureWithRolesAliased(ctx context.Context, request *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error) {
-	mock.Calls.SecureWithRolesAliased = mock.Calls.SecureWithRolesAliased.invoked(*request)
-	if mock.SecureWithRolesAliasedFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.SecureWithRolesAliased = mock.Calls.SecureWithRolesAliased.invoked(mock.sequence, *request)
+	expectation := mock.matchSecureWithRolesAliased(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.SecureWithRolesAliasedFunc != nil:
+		return mock.SecureWithRolesAliasedFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.SecureWithRolesAliased not implemented")
 	}
-	response, err := mock.SecureWithRolesAliasedFunc(ctx, request)
-	return response, err
+}
+
+// OnSecureWithRolesAliased programs a behavior that only fires when SecureWithRolesAliased is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnSecureWithRolesAliased(matcher func(*testext.SampleSecurityRequest) bool) *expectSampleServiceSecureWithRolesAliased {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceSecureWithRolesAliased{matcher: matcher}
+	mock.expectations.SecureWithRolesAliased = append(mock.expectations.SecureWithRolesAliased, expectation)
+	return expectation
+}
+
+// AssertSecureWithRolesAliasedCalled reports a test failure if SecureWithRolesAliased was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertSecureWithRolesAliasedCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.SecureWithRolesAliased.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.SecureWithRolesAliased was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchSecureWithRolesAliased(request *testext.SampleSecurityRequest) *expectSampleServiceSecureWithRolesAliased {
+	for _, expectation := range mock.expectations.SecureWithRolesAliased {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceSecureWithRolesAliased is a behavior for SecureWithRolesAliased that only fires for requests accepted by its matcher.
+type expectSampleServiceSecureWithRolesAliased struct {
+	matcher  func(*testext.SampleSecurityRequest) bool
+	behavior func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceSecureWithRolesAliased) Return(response *testext.SampleSecurityResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceSecureWithRolesAliased) Do(behavior func(context.Context, *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceSecureWithRolesAliased) invoke(ctx context.Context, request *testext.SampleSecurityRequest) (*testext.SampleSecurityResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleSecurityResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceSecureWithRolesAliased struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that SecureWithRolesAliased was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleSecurityRequest
 }
 
 type callsSampleServiceSecureWithRolesAliased []callSampleServiceSecureWithRolesAliased
 
-func (calls callsSampleServiceSecureWithRolesAliased) invoked(request testext.SampleSecurityRequest) callsSampleServiceSecureWithRolesAliased {
-	return append(calls, callSampleServiceSecureWithRolesAliased{Time: time.Now(), Request: request})
+func (calls callsSampleServiceSecureWithRolesAliased) invoked(sequence int, request testext.SampleSecurityRequest) callsSampleServiceSecureWithRolesAliased {
+	return append(calls, callSampleServiceSecureWithRolesAliased{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that SecureWithRolesAliased was invoked with any request arguments.
@@ -1338,6 +3633,15 @@ func (calls callsSampleServiceSecureWithRolesAliased) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of SecureWithRolesAliased, in the order they were called.
+func (calls callsSampleServiceSecureWithRolesAliased) Requests() []testext.SampleSecurityRequest {
+	requests := make([]testext.SampleSecurityRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that SecureWithRolesAliased was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -1363,23 +3667,101 @@ func (calls callsSampleServiceSecureWithRolesAliased) TimesMatching(pred func(te
 /* ---- SampleService.Sleep Mock Support For  ---- */
 
 func (mock *MockSampleService) Sleep(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.Sleep = mock.Calls.Sleep.invoked(*request)
-	if mock.SleepFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.Sleep = mock.Calls.Sleep.invoked(mock.sequence, *request)
+	expectation := mock.matchSleep(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.SleepFunc != nil:
+		return mock.SleepFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.Sleep not implemented")
 	}
-	response, err := mock.SleepFunc(ctx, request)
-	return response, err
+}
+
+// OnSleep programs a behavior that only fires when Sleep is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnSleep(matcher func(*testext.SampleRequest) bool) *expectSampleServiceSleep {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceSleep{matcher: matcher}
+	mock.expectations.Sleep = append(mock.expectations.Sleep, expectation)
+	return expectation
+}
+
+// AssertSleepCalled reports a test failure if Sleep was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertSleepCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.Sleep.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.Sleep was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchSleep(request *testext.SampleRequest) *expectSampleServiceSleep {
+	for _, expectation := range mock.expectations.Sleep {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceSleep is a behavior for Sleep that only fires for requests accepted by its matcher.
+type expectSampleServiceSleep struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceSleep) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceSleep) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceSleep) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceSleep struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that Sleep was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceSleep []callSampleServiceSleep
 
-func (calls callsSampleServiceSleep) invoked(request testext.SampleRequest) callsSampleServiceSleep {
-	return append(calls, callSampleServiceSleep{Time: time.Now(), Request: request})
+func (calls callsSampleServiceSleep) invoked(sequence int, request testext.SampleRequest) callsSampleServiceSleep {
+	return append(calls, callSampleServiceSleep{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that Sleep was invoked with any request arguments.
@@ -1387,6 +3769,15 @@ func (calls callsSampleServiceSleep) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of Sleep, in the order they were called.
+func (calls callsSampleServiceSleep) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that Sleep was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -1412,23 +3803,101 @@ func (calls callsSampleServiceSleep) TimesMatching(pred func(testext.SampleReque
 /* ---- SampleService.TriggerFailure Mock Support For  ---- */
 
 func (mock *MockSampleService) TriggerFailure(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.TriggerFailure = mock.Calls.TriggerFailure.invoked(*request)
-	if mock.TriggerFailureFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.TriggerFailure = mock.Calls.TriggerFailure.invoked(mock.sequence, *request)
+	expectation := mock.matchTriggerFailure(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.TriggerFailureFunc != nil:
+		return mock.TriggerFailureFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.TriggerFailure not implemented")
 	}
-	response, err := mock.TriggerFailureFunc(ctx, request)
-	return response, err
+}
+
+// OnTriggerFailure programs a behavior that only fires when TriggerFailure is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnTriggerFailure(matcher func(*testext.SampleRequest) bool) *expectSampleServiceTriggerFailure {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceTriggerFailure{matcher: matcher}
+	mock.expectations.TriggerFailure = append(mock.expectations.TriggerFailure, expectation)
+	return expectation
+}
+
+// AssertTriggerFailureCalled reports a test failure if TriggerFailure was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertTriggerFailureCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.TriggerFailure.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.TriggerFailure was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchTriggerFailure(request *testext.SampleRequest) *expectSampleServiceTriggerFailure {
+	for _, expectation := range mock.expectations.TriggerFailure {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceTriggerFailure is a behavior for TriggerFailure that only fires for requests accepted by its matcher.
+type expectSampleServiceTriggerFailure struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceTriggerFailure) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceTriggerFailure) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceTriggerFailure) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceTriggerFailure struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that TriggerFailure was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceTriggerFailure []callSampleServiceTriggerFailure
 
-func (calls callsSampleServiceTriggerFailure) invoked(request testext.SampleRequest) callsSampleServiceTriggerFailure {
-	return append(calls, callSampleServiceTriggerFailure{Time: time.Now(), Request: request})
+func (calls callsSampleServiceTriggerFailure) invoked(sequence int, request testext.SampleRequest) callsSampleServiceTriggerFailure {
+	return append(calls, callSampleServiceTriggerFailure{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that TriggerFailure was invoked with any request arguments.
@@ -1436,6 +3905,15 @@ func (calls callsSampleServiceTriggerFailure) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of TriggerFailure, in the order they were called.
+func (calls callsSampleServiceTriggerFailure) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that TriggerFailure was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -1461,23 +3939,101 @@ func (calls callsSampleServiceTriggerFailure) TimesMatching(pred func(testext.Sa
 /* ---- SampleService.TriggerLowerCase Mock Support For  ---- */
 
 func (mock *MockSampleService) TriggerLowerCase(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.TriggerLowerCase = mock.Calls.TriggerLowerCase.invoked(*request)
-	if mock.TriggerLowerCaseFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.TriggerLowerCase = mock.Calls.TriggerLowerCase.invoked(mock.sequence, *request)
+	expectation := mock.matchTriggerLowerCase(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.TriggerLowerCaseFunc != nil:
+		return mock.TriggerLowerCaseFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.TriggerLowerCase not implemented")
 	}
-	response, err := mock.TriggerLowerCaseFunc(ctx, request)
-	return response, err
+}
+
+// OnTriggerLowerCase programs a behavior that only fires when TriggerLowerCase is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnTriggerLowerCase(matcher func(*testext.SampleRequest) bool) *expectSampleServiceTriggerLowerCase {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceTriggerLowerCase{matcher: matcher}
+	mock.expectations.TriggerLowerCase = append(mock.expectations.TriggerLowerCase, expectation)
+	return expectation
+}
+
+// AssertTriggerLowerCaseCalled reports a test failure if TriggerLowerCase was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertTriggerLowerCaseCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.TriggerLowerCase.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.TriggerLowerCase was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchTriggerLowerCase(request *testext.SampleRequest) *expectSampleServiceTriggerLowerCase {
+	for _, expectation := range mock.expectations.TriggerLowerCase {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceTriggerLowerCase is a behavior for TriggerLowerCase that only fires for requests accepted by its matcher.
+type expectSampleServiceTriggerLowerCase struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceTriggerLowerCase) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceTriggerLowerCase) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceTriggerLowerCase) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceTriggerLowerCase struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that TriggerLowerCase was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceTriggerLowerCase []callSampleServiceTriggerLowerCase
 
-func (calls callsSampleServiceTriggerLowerCase) invoked(request testext.SampleRequest) callsSampleServiceTriggerLowerCase {
-	return append(calls, callSampleServiceTriggerLowerCase{Time: time.Now(), Request: request})
+func (calls callsSampleServiceTriggerLowerCase) invoked(sequence int, request testext.SampleRequest) callsSampleServiceTriggerLowerCase {
+	return append(calls, callSampleServiceTriggerLowerCase{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that TriggerLowerCase was invoked with any request arguments.
@@ -1485,6 +4041,15 @@ func (calls callsSampleServiceTriggerLowerCase) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of TriggerLowerCase, in the order they were called.
+func (calls callsSampleServiceTriggerLowerCase) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that TriggerLowerCase was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
@@ -1510,23 +4075,101 @@ func (calls callsSampleServiceTriggerLowerCase) TimesMatching(pred func(testext.
 /* ---- SampleService.TriggerUpperCase Mock Support For  ---- */
 
 func (mock *MockSampleService) TriggerUpperCase(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
-	mock.Calls.TriggerUpperCase = mock.Calls.TriggerUpperCase.invoked(*request)
-	if mock.TriggerUpperCaseFunc == nil {
+	mock.mutex.Lock()
+	mock.sequence++
+	mock.Calls.TriggerUpperCase = mock.Calls.TriggerUpperCase.invoked(mock.sequence, *request)
+	expectation := mock.matchTriggerUpperCase(request)
+	mock.mutex.Unlock()
+
+	switch {
+	case expectation != nil:
+		return expectation.invoke(ctx, request)
+	case mock.TriggerUpperCaseFunc != nil:
+		return mock.TriggerUpperCaseFunc(ctx, request)
+	default:
 		return nil, fmt.Errorf("SampleService.TriggerUpperCase not implemented")
 	}
-	response, err := mock.TriggerUpperCaseFunc(ctx, request)
-	return response, err
+}
+
+// OnTriggerUpperCase programs a behavior that only fires when TriggerUpperCase is invoked with a request that your
+// matcher accepts. A nil matcher accepts every request. Use Return() or Do() on the result to decide how
+// the mock responds; if you do neither, it responds with an empty response and no error.
+func (mock *MockSampleService) OnTriggerUpperCase(matcher func(*testext.SampleRequest) bool) *expectSampleServiceTriggerUpperCase {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+
+	expectation := &expectSampleServiceTriggerUpperCase{matcher: matcher}
+	mock.expectations.TriggerUpperCase = append(mock.expectations.TriggerUpperCase, expectation)
+	return expectation
+}
+
+// AssertTriggerUpperCaseCalled reports a test failure if TriggerUpperCase was not invoked exactly 'times' times. It
+// works with *testing.T, *testing.B, or anything else with Helper() and Errorf() methods.
+func (mock *MockSampleService) AssertTriggerUpperCaseCalled(t interface {
+	Helper()
+	Errorf(string, ...any)
+}, times int) bool {
+	t.Helper()
+
+	mock.mutex.Lock()
+	actual := mock.Calls.TriggerUpperCase.Times()
+	mock.mutex.Unlock()
+
+	if actual != times {
+		t.Errorf("SampleService.TriggerUpperCase was called %d time(s); expected %d", actual, times)
+		return false
+	}
+	return true
+}
+
+func (mock *MockSampleService) matchTriggerUpperCase(request *testext.SampleRequest) *expectSampleServiceTriggerUpperCase {
+	for _, expectation := range mock.expectations.TriggerUpperCase {
+		if expectation.matcher == nil || expectation.matcher(request) {
+			return expectation
+		}
+	}
+	return nil
+}
+
+// expectSampleServiceTriggerUpperCase is a behavior for TriggerUpperCase that only fires for requests accepted by its matcher.
+type expectSampleServiceTriggerUpperCase struct {
+	matcher  func(*testext.SampleRequest) bool
+	behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)
+}
+
+// Return tells the mock to respond with these values when the expectation matches.
+func (expectation *expectSampleServiceTriggerUpperCase) Return(response *testext.SampleResponse, err error) {
+	expectation.Do(func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error) {
+		return response, err
+	})
+}
+
+// Do tells the mock to respond by invoking your function when the expectation matches.
+func (expectation *expectSampleServiceTriggerUpperCase) Do(behavior func(context.Context, *testext.SampleRequest) (*testext.SampleResponse, error)) {
+	expectation.behavior = behavior
+}
+
+func (expectation *expectSampleServiceTriggerUpperCase) invoke(ctx context.Context, request *testext.SampleRequest) (*testext.SampleResponse, error) {
+	if expectation.behavior == nil {
+		return &testext.SampleResponse{}, nil
+	}
+	return expectation.behavior(ctx, request)
 }
 
 type callSampleServiceTriggerUpperCase struct {
-	Time    time.Time
+	// Time is when the mock function was invoked.
+	Time time.Time
+	// Sequence indicates the order of this call relative to calls to ALL functions on this mock,
+	// so you can verify that TriggerUpperCase was called before/after some other function.
+	Sequence int
+	// Request is a copy of the request that was passed to the function.
 	Request testext.SampleRequest
 }
 
 type callsSampleServiceTriggerUpperCase []callSampleServiceTriggerUpperCase
 
-func (calls callsSampleServiceTriggerUpperCase) invoked(request testext.SampleRequest) callsSampleServiceTriggerUpperCase {
-	return append(calls, callSampleServiceTriggerUpperCase{Time: time.Now(), Request: request})
+func (calls callsSampleServiceTriggerUpperCase) invoked(sequence int, request testext.SampleRequest) callsSampleServiceTriggerUpperCase {
+	return append(calls, callSampleServiceTriggerUpperCase{Time: time.Now(), Sequence: sequence, Request: request})
 }
 
 // Times return the total number of times that TriggerUpperCase was invoked with any request arguments.
@@ -1534,6 +4177,15 @@ func (calls callsSampleServiceTriggerUpperCase) Times() int {
 	return len(calls)
 }
 
+// Requests returns the captured request from every invocation of TriggerUpperCase, in the order they were called.
+func (calls callsSampleServiceTriggerUpperCase) Requests() []testext.SampleRequest {
+	requests := make([]testext.SampleRequest, len(calls))
+	for i, call := range calls {
+		requests[i] = call.Request
+	}
+	return requests
+}
+
 // TimesFor return the total number of times that TriggerUpperCase was invoked with the specific input. Equality
 // is determined using == on this 'request' param and the de-referenced one used in the invocation, so
 // we'll only county times for those with structural equality.
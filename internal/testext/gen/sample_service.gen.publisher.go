@@ -0,0 +1,511 @@
+// Code generated by Frodo - DO NOT EDIT.
+//
+//	Timestamp: Fri, 16 Oct 2026 11:58:06 UTC
+//	Source:    sample_service.go
+//	Generator: https://github.com/bridgekit-io/frodo
+package testext
+
+import (
+	"context"
+
+	"github.com/bridgekit-io/frodo/eventsource"
+	"github.com/bridgekit-io/frodo/internal/testext"
+	"github.com/bridgekit-io/frodo/services/gateways/events"
+)
+
+// NewSampleServicePublisher creates a publisher that broadcasts SampleService events directly to the broker
+// without going through the API gateway (or even running SampleService at all). Use the same broker that
+// your event gateways use, so the subscribers actually receive the events.
+//
+//	publisher := testext.NewSampleServicePublisher(broker)
+//	err := publisher.PublishXxx(ctx, &testext.XxxResponse{ /* ... */ })
+func NewSampleServicePublisher(broker eventsource.Publisher, options ...events.PublisherOption) *SampleServicePublisher {
+	return &SampleServicePublisher{publisher: events.NewPublisher(broker, options...)}
+}
+
+// SampleServicePublisher fires the same events that the event gateway publishes when one of SampleService's
+// functions completes successfully. Any "ON SampleService.Xxx" subscribers will fire just like the function
+// was invoked and returned the value you publish.
+type SampleServicePublisher struct {
+	publisher events.Publisher
+}
+
+// PublishAuthorization triggers the subscribers of "SampleService.Authorization" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishAuthorization(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Authorization", response)
+}
+
+// PublishAuthorizationBatch triggers the subscribers of "SampleService.Authorization" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishAuthorizationBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Authorization", values...)
+}
+
+// PublishChain1 triggers the subscribers of "SampleService.Chain1" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishChain1(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Chain1", response)
+}
+
+// PublishChain1Batch triggers the subscribers of "SampleService.Chain1" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishChain1Batch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Chain1", values...)
+}
+
+// PublishChain1GroupFooBar triggers the subscribers of "SampleService.Chain1GroupFooBar" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishChain1GroupFooBar(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Chain1GroupFooBar", response)
+}
+
+// PublishChain1GroupFooBarBatch triggers the subscribers of "SampleService.Chain1GroupFooBar" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishChain1GroupFooBarBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Chain1GroupFooBar", values...)
+}
+
+// PublishChain1GroupStar triggers the subscribers of "SampleService.Chain1GroupStar" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishChain1GroupStar(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Chain1GroupStar", response)
+}
+
+// PublishChain1GroupStarBatch triggers the subscribers of "SampleService.Chain1GroupStar" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishChain1GroupStarBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Chain1GroupStar", values...)
+}
+
+// PublishChain2 triggers the subscribers of "SampleService.Chain2" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishChain2(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Chain2", response)
+}
+
+// PublishChain2Batch triggers the subscribers of "SampleService.Chain2" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishChain2Batch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Chain2", values...)
+}
+
+// PublishChain2OnError triggers the subscribers of "SampleService.Chain2OnError" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishChain2OnError(ctx context.Context, response *testext.FailAlwaysErrorResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Chain2OnError", response)
+}
+
+// PublishChain2OnErrorBatch triggers the subscribers of "SampleService.Chain2OnError" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishChain2OnErrorBatch(ctx context.Context, responses ...*testext.FailAlwaysErrorResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Chain2OnError", values...)
+}
+
+// PublishChain2OnSuccess triggers the subscribers of "SampleService.Chain2OnSuccess" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishChain2OnSuccess(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Chain2OnSuccess", response)
+}
+
+// PublishChain2OnSuccessBatch triggers the subscribers of "SampleService.Chain2OnSuccess" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishChain2OnSuccessBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Chain2OnSuccess", values...)
+}
+
+// PublishComplexValues triggers the subscribers of "SampleService.ComplexValues" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishComplexValues(ctx context.Context, response *testext.SampleComplexResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "ComplexValues", response)
+}
+
+// PublishComplexValuesBatch triggers the subscribers of "SampleService.ComplexValues" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishComplexValuesBatch(ctx context.Context, responses ...*testext.SampleComplexResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "ComplexValues", values...)
+}
+
+// PublishComplexValuesPath triggers the subscribers of "SampleService.ComplexValuesPath" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishComplexValuesPath(ctx context.Context, response *testext.SampleComplexResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "ComplexValuesPath", response)
+}
+
+// PublishComplexValuesPathBatch triggers the subscribers of "SampleService.ComplexValuesPath" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishComplexValuesPathBatch(ctx context.Context, responses ...*testext.SampleComplexResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "ComplexValuesPath", values...)
+}
+
+// PublishCustomRoute triggers the subscribers of "SampleService.CustomRoute" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishCustomRoute(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "CustomRoute", response)
+}
+
+// PublishCustomRouteBatch triggers the subscribers of "SampleService.CustomRoute" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishCustomRouteBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "CustomRoute", values...)
+}
+
+// PublishCustomRouteBody triggers the subscribers of "SampleService.CustomRouteBody" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishCustomRouteBody(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "CustomRouteBody", response)
+}
+
+// PublishCustomRouteBodyBatch triggers the subscribers of "SampleService.CustomRouteBody" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishCustomRouteBodyBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "CustomRouteBody", values...)
+}
+
+// PublishCustomRouteQuery triggers the subscribers of "SampleService.CustomRouteQuery" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishCustomRouteQuery(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "CustomRouteQuery", response)
+}
+
+// PublishCustomRouteQueryBatch triggers the subscribers of "SampleService.CustomRouteQuery" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishCustomRouteQueryBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "CustomRouteQuery", values...)
+}
+
+// PublishDefaults triggers the subscribers of "SampleService.Defaults" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishDefaults(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Defaults", response)
+}
+
+// PublishDefaultsBatch triggers the subscribers of "SampleService.Defaults" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishDefaultsBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Defaults", values...)
+}
+
+// PublishDownload triggers the subscribers of "SampleService.Download" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishDownload(ctx context.Context, response *testext.SampleDownloadResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Download", response)
+}
+
+// PublishDownloadBatch triggers the subscribers of "SampleService.Download" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishDownloadBatch(ctx context.Context, responses ...*testext.SampleDownloadResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Download", values...)
+}
+
+// PublishDownloadResumable triggers the subscribers of "SampleService.DownloadResumable" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishDownloadResumable(ctx context.Context, response *testext.SampleDownloadResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "DownloadResumable", response)
+}
+
+// PublishDownloadResumableBatch triggers the subscribers of "SampleService.DownloadResumable" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishDownloadResumableBatch(ctx context.Context, responses ...*testext.SampleDownloadResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "DownloadResumable", values...)
+}
+
+// PublishFail4XX triggers the subscribers of "SampleService.Fail4XX" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishFail4XX(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Fail4XX", response)
+}
+
+// PublishFail4XXBatch triggers the subscribers of "SampleService.Fail4XX" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishFail4XXBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Fail4XX", values...)
+}
+
+// PublishFail5XX triggers the subscribers of "SampleService.Fail5XX" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishFail5XX(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Fail5XX", response)
+}
+
+// PublishFail5XXBatch triggers the subscribers of "SampleService.Fail5XX" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishFail5XXBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Fail5XX", values...)
+}
+
+// PublishFailAlways triggers the subscribers of "SampleService.FailAlways" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishFailAlways(ctx context.Context, response *testext.FailAlwaysResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "FailAlways", response)
+}
+
+// PublishFailAlwaysBatch triggers the subscribers of "SampleService.FailAlways" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishFailAlwaysBatch(ctx context.Context, responses ...*testext.FailAlwaysResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "FailAlways", values...)
+}
+
+// PublishListenerA triggers the subscribers of "SampleService.ListenerA" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishListenerA(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "ListenerA", response)
+}
+
+// PublishListenerABatch triggers the subscribers of "SampleService.ListenerA" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishListenerABatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "ListenerA", values...)
+}
+
+// PublishListenerB triggers the subscribers of "SampleService.ListenerB" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishListenerB(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "ListenerB", response)
+}
+
+// PublishListenerBBatch triggers the subscribers of "SampleService.ListenerB" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishListenerBBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "ListenerB", values...)
+}
+
+// PublishOmitMe triggers the subscribers of "SampleService.OmitMe" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishOmitMe(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "OmitMe", response)
+}
+
+// PublishOmitMeBatch triggers the subscribers of "SampleService.OmitMe" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishOmitMeBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "OmitMe", values...)
+}
+
+// PublishOnFailAlways triggers the subscribers of "SampleService.OnFailAlways" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishOnFailAlways(ctx context.Context, response *testext.FailAlwaysErrorResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "OnFailAlways", response)
+}
+
+// PublishOnFailAlwaysBatch triggers the subscribers of "SampleService.OnFailAlways" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishOnFailAlwaysBatch(ctx context.Context, responses ...*testext.FailAlwaysErrorResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "OnFailAlways", values...)
+}
+
+// PublishPanic triggers the subscribers of "SampleService.Panic" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishPanic(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Panic", response)
+}
+
+// PublishPanicBatch triggers the subscribers of "SampleService.Panic" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishPanicBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Panic", values...)
+}
+
+// PublishRedirect triggers the subscribers of "SampleService.Redirect" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishRedirect(ctx context.Context, response *testext.SampleRedirectResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Redirect", response)
+}
+
+// PublishRedirectBatch triggers the subscribers of "SampleService.Redirect" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishRedirectBatch(ctx context.Context, responses ...*testext.SampleRedirectResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Redirect", values...)
+}
+
+// PublishSecureWithRoles triggers the subscribers of "SampleService.SecureWithRoles" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishSecureWithRoles(ctx context.Context, response *testext.SampleSecurityResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "SecureWithRoles", response)
+}
+
+// PublishSecureWithRolesBatch triggers the subscribers of "SampleService.SecureWithRoles" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishSecureWithRolesBatch(ctx context.Context, responses ...*testext.SampleSecurityResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "SecureWithRoles", values...)
+}
+
+// PublishSecureWithRolesAliased triggers the subscribers of "SampleService.SecureWithRolesAliased" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishSecureWithRolesAliased(ctx context.Context, response *testext.SampleSecurityResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "SecureWithRolesAliased", response)
+}
+
+// PublishSecureWithRolesAliasedBatch triggers the subscribers of "SampleService.SecureWithRolesAliased" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishSecureWithRolesAliasedBatch(ctx context.Context, responses ...*testext.SampleSecurityResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "SecureWithRolesAliased", values...)
+}
+
+// PublishSleep triggers the subscribers of "SampleService.Sleep" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishSleep(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "Sleep", response)
+}
+
+// PublishSleepBatch triggers the subscribers of "SampleService.Sleep" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishSleepBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "Sleep", values...)
+}
+
+// PublishTriggerFailure triggers the subscribers of "SampleService.TriggerFailure" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishTriggerFailure(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "TriggerFailure", response)
+}
+
+// PublishTriggerFailureBatch triggers the subscribers of "SampleService.TriggerFailure" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishTriggerFailureBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "TriggerFailure", values...)
+}
+
+// PublishTriggerLowerCase triggers the subscribers of "SampleService.TriggerLowerCase" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishTriggerLowerCase(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "TriggerLowerCase", response)
+}
+
+// PublishTriggerLowerCaseBatch triggers the subscribers of "SampleService.TriggerLowerCase" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishTriggerLowerCaseBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "TriggerLowerCase", values...)
+}
+
+// PublishTriggerUpperCase triggers the subscribers of "SampleService.TriggerUpperCase" as though the function returned
+// this response. Metadata on the context (authorization, trace id, values) follows the event to the subscribers.
+func (p *SampleServicePublisher) PublishTriggerUpperCase(ctx context.Context, response *testext.SampleResponse) error {
+	return p.publisher.Publish(ctx, "SampleService", "TriggerUpperCase", response)
+}
+
+// PublishTriggerUpperCaseBatch triggers the subscribers of "SampleService.TriggerUpperCase" once for each of the responses.
+// Brokers that support batching (see eventsource.BatchPublisher) send them all at once.
+func (p *SampleServicePublisher) PublishTriggerUpperCaseBatch(ctx context.Context, responses ...*testext.SampleResponse) error {
+	values := make([]any, len(responses))
+	for i, response := range responses {
+		values[i] = response
+	}
+	return p.publisher.PublishBatch(ctx, "SampleService", "TriggerUpperCase", values...)
+}
@@ -1,6 +1,6 @@
 // Code generated by Frodo - DO NOT EDIT.
 //
-//	Timestamp: Fri, 15 Nov 2024 12:49:04 EST
+//	Timestamp: Fri, 16 Oct 2026 11:21:39 UTC
 //	Source:    sample_service.go
 //	Generator: https://github.com/bridgekit-io/frodo
 package testext
@@ -47,7 +47,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Authorization(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Authorization regurgitates the \\\"Authorization\\\" metadata/header.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.Authorization\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -56,6 +57,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Authorization",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -71,7 +75,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Chain1(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Chain1 kicks off the Chain1/Chain2/Chain3 event chain, but we expect that it's going to stop after\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.Chain1\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -80,6 +85,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Chain1",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -95,7 +103,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Chain1GroupFooBar(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Chain1GroupFooBar listens for calls to Chain1, but rather than being part of a consumer group that only lets\\none instance of the service run it, it should define its own shared group name.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.Chain1GroupFooBar\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -104,6 +113,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Chain1GroupFooBar",
+						Roles:       []string{},
 					},
 
 					{
@@ -113,6 +125,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "FooBar",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "Chain1GroupFooBar",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -128,7 +143,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Chain1GroupStar(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Chain1GroupStar listens for calls to Chain1, but rather than being part of a consumer group that only lets\\none instance of the service run it, it should define its own group that lets EVERY instance of this service\\nreact to this event.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.Chain1GroupStar\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -137,6 +153,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Chain1GroupStar",
+						Roles:       []string{},
 					},
 
 					{
@@ -146,6 +165,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "*",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "Chain1GroupStar",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -161,7 +183,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Chain2(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Chain2 ALWAYS FAILS, SO CHAIN3 NEVER FIRES!!!\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.Chain2\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -170,6 +193,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Chain2",
+						Roles:       []string{},
 					},
 
 					{
@@ -179,6 +205,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "Chain2",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -203,6 +232,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "Chain2OnError",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -227,6 +259,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "Chain2OnSuccess",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -242,7 +277,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.ComplexValues(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleComplexRequest\":{\"properties\":{\"InFlag\":{\"type\":\"boolean\"},\"InFloat\":{\"type\":\"number\"},\"InTime\":{\"format\":\"date-time\",\"type\":\"string\"},\"InTimePtr\":{\"format\":\"date-time\",\"type\":\"string\"},\"InUser\":{\"$ref\":\"#/$defs/SampleUser\"}},\"type\":\"object\"},\"SampleComplexResponse\":{\"properties\":{\"OutFlag\":{\"type\":\"boolean\"},\"OutFloat\":{\"type\":\"number\"},\"OutTime\":{\"format\":\"date-time\",\"type\":\"string\"},\"OutTimePtr\":{\"format\":\"date-time\",\"type\":\"string\"},\"OutUser\":{\"$ref\":\"#/$defs/SampleUser\"}},\"type\":\"object\"},\"SampleUser\":{\"description\":\"SampleUser contains an array of different fields that we support sending to/from clients\\nin all of our supported languages.\",\"properties\":{\"Age\":{\"description\":\"Age is a numeric value that we should support.\",\"type\":\"integer\"},\"Attention\":{\"description\":\"Attention is a duration to ensure that we use epoch nanos as the format, NOT the string.\",\"type\":\"integer\"},\"AttentionString\":{\"description\":\"AttentionString is a custom duration alias that overrides MarshalJSON/UnmarshalJSON to use strings for transport.\"},\"Digits\":{\"description\":\"PhoneNumber exercises the notion that clients should refer to this field as Digits, not PhoneNumber.\",\"type\":\"string\"},\"FancyID\":{\"description\":\"FancyID makes sure that we can use aliases properly rather than just the raw primitive types.\",\"type\":\"string\"},\"ID\":{\"description\":\"ID is a string value that will likely have no whitespace.\",\"type\":\"string\"},\"MarshalToObject\":{\"description\":\"MarshalToString makes sure that we can use custom marshaling of struct values.\\nThis is NOT globally supported in all client languages - just Go for now.\"},\"MarshalToString\":{\"description\":\"MarshalToString makes sure that we can use strings as an alternate JSON format for structs.\"},\"Name\":{\"description\":\"Name is a string value that will likely have spaces.\",\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"ComplexValues flexes our ability to encode/decode non-flat structs.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleComplexRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleComplexResponse\"}},\"title\":\"SampleService.ComplexValues\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -251,6 +287,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "ComplexValues",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -266,7 +305,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.ComplexValuesPath(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleComplexRequest\":{\"properties\":{\"InFlag\":{\"type\":\"boolean\"},\"InFloat\":{\"type\":\"number\"},\"InTime\":{\"format\":\"date-time\",\"type\":\"string\"},\"InTimePtr\":{\"format\":\"date-time\",\"type\":\"string\"},\"InUser\":{\"$ref\":\"#/$defs/SampleUser\"}},\"type\":\"object\"},\"SampleComplexResponse\":{\"properties\":{\"OutFlag\":{\"type\":\"boolean\"},\"OutFloat\":{\"type\":\"number\"},\"OutTime\":{\"format\":\"date-time\",\"type\":\"string\"},\"OutTimePtr\":{\"format\":\"date-time\",\"type\":\"string\"},\"OutUser\":{\"$ref\":\"#/$defs/SampleUser\"}},\"type\":\"object\"},\"SampleUser\":{\"description\":\"SampleUser contains an array of different fields that we support sending to/from clients\\nin all of our supported languages.\",\"properties\":{\"Age\":{\"description\":\"Age is a numeric value that we should support.\",\"type\":\"integer\"},\"Attention\":{\"description\":\"Attention is a duration to ensure that we use epoch nanos as the format, NOT the string.\",\"type\":\"integer\"},\"AttentionString\":{\"description\":\"AttentionString is a custom duration alias that overrides MarshalJSON/UnmarshalJSON to use strings for transport.\"},\"Digits\":{\"description\":\"PhoneNumber exercises the notion that clients should refer to this field as Digits, not PhoneNumber.\",\"type\":\"string\"},\"FancyID\":{\"description\":\"FancyID makes sure that we can use aliases properly rather than just the raw primitive types.\",\"type\":\"string\"},\"ID\":{\"description\":\"ID is a string value that will likely have no whitespace.\",\"type\":\"string\"},\"MarshalToObject\":{\"description\":\"MarshalToString makes sure that we can use custom marshaling of struct values.\\nThis is NOT globally supported in all client languages - just Go for now.\"},\"MarshalToString\":{\"description\":\"MarshalToString makes sure that we can use strings as an alternate JSON format for structs.\"},\"Name\":{\"description\":\"Name is a string value that will likely have spaces.\",\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"ComplexValuesPath flexes our ability to encode/decode non-flat structs while\\nspecifying them via path and query string.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleComplexRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleComplexResponse\"}},\"title\":\"SampleService.ComplexValuesPath\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -277,8 +317,11 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 
 							"InUser.Name",
 						},
-						Group:  "",
-						Status: 200,
+						Group:       "",
+						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "ComplexValuesPath",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -294,7 +337,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.CustomRoute(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"CustomRoute performs a service operation where you override default behavior\\nby providing routing-related Doc Options.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.CustomRoute\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -305,8 +349,11 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 
 							"Text",
 						},
-						Group:  "",
-						Status: 202,
+						Group:       "",
+						Status:      202,
+						ServiceName: "SampleService",
+						Name:        "CustomRoute",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -322,7 +369,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.CustomRouteBody(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"CustomRouteBody performs a service operation where you override default behavior\\nby providing routing-related Doc Options, but rely on body encoding rather than path.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.CustomRouteBody\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -331,8 +379,11 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams: []string{
 							"ID",
 						},
-						Group:  "",
-						Status: 201,
+						Group:       "",
+						Status:      201,
+						ServiceName: "SampleService",
+						Name:        "CustomRouteBody",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -348,7 +399,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.CustomRouteQuery(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"CustomRouteQuery performs a service operation where you override default behavior\\nby providing routing-related Doc Options. The input data relies on the path\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.CustomRouteQuery\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -357,8 +409,11 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams: []string{
 							"ID",
 						},
-						Group:  "",
-						Status: 202,
+						Group:       "",
+						Status:      202,
+						ServiceName: "SampleService",
+						Name:        "CustomRouteQuery",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -374,7 +429,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Defaults(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Defaults simply utilizes all of the framework's default behaviors.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.Defaults\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -383,6 +439,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Defaults",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -398,7 +457,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Download(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleDownloadRequest\":{\"properties\":{\"Format\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleDownloadResponse\":{\"properties\":{},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Download results in a raw stream of data rather than relying on auto-encoding\\nthe response value.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleDownloadRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleDownloadResponse\"}},\"title\":\"SampleService.Download\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -407,6 +467,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Download",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -422,7 +485,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.DownloadResumable(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleDownloadRequest\":{\"properties\":{\"Format\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleDownloadResponse\":{\"properties\":{},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"DownloadResumable results in a raw stream of data rather than relying on auto-encoding\\nthe response value. The stream includes Content-Range info as though you could resume\\nyour stream/download progress later.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleDownloadRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleDownloadResponse\"}},\"title\":\"SampleService.DownloadResumable\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -431,6 +495,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "DownloadResumable",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -446,7 +513,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Fail4XX(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Fail4XX always returns a non-nil 400-series error.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.Fail4XX\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -455,6 +523,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Fail4XX",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -470,7 +541,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Fail5XX(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Fail5XX always returns a non-nil 500-series error.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.Fail5XX\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -479,6 +551,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Fail5XX",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -494,7 +569,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.FailAlways(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"FailAlwaysRequest\":{\"properties\":{\"RequestValue\":{\"type\":\"string\"}},\"type\":\"object\"},\"FailAlwaysResponse\":{\"properties\":{\"ResponseValue\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"FailAlways will return an error no matter what. It's only goal in life is to trigger OnFailAlways.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/FailAlwaysRequest\"},\"response\":{\"$ref\":\"#/$defs/FailAlwaysResponse\"}},\"title\":\"SampleService.FailAlways\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -503,6 +579,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "FailAlways",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -518,7 +597,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.ListenerA(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"ListenerA fires on only one of the triggers.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.ListenerA\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -527,6 +607,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "ListenerA",
+						Roles:       []string{},
 					},
 
 					{
@@ -536,6 +619,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "ListenerA",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -560,6 +646,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "ListenerB",
+						Roles:       []string{},
 					},
 
 					{
@@ -569,6 +658,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "ListenerB",
+						Roles:       []string{},
 					},
 
 					{
@@ -578,6 +670,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "ListenerB",
+						Roles:       []string{},
 					},
 
 					{
@@ -587,6 +682,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "ListenerB",
+						Roles:       []string{},
 					},
 
 					{
@@ -596,6 +694,21 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "ListenerB",
+						Roles:       []string{},
+					},
+
+					{
+						GatewayType: "EVENTS",
+						Method:      "ON",
+						Path:        "payment.succeeded",
+						PathParams:  []string{},
+						Group:       "",
+						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "ListenerB",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -626,7 +739,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.OnFailAlways(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"EventError\":{\"description\":\"EventError captures the various ways you can bind the error message and its status codes\",\"properties\":{\"Code\":{\"type\":\"integer\"},\"Error\":{\"type\":\"string\"},\"HTTPStatusCode\":{\"type\":\"integer\"},\"Message\":{\"type\":\"string\"},\"Status\":{\"type\":\"integer\"},\"StatusCode\":{\"type\":\"integer\"}},\"type\":\"object\"},\"FailAlwaysErrorRequest\":{\"properties\":{\"Error\":{\"$ref\":\"#/$defs/EventError\"},\"RequestValue\":{\"type\":\"string\"},\"ResponseValue\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"FailAlwaysErrorResponse\":{\"properties\":{},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"OnFailAlways should trigger after FailAlways inevitably shits the bed.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/FailAlwaysErrorRequest\"},\"response\":{\"$ref\":\"#/$defs/FailAlwaysErrorResponse\"}},\"title\":\"SampleService.OnFailAlways\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -635,6 +749,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "OnFailAlways",
+						Roles:       []string{},
 					},
 
 					{
@@ -644,6 +761,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      0,
+						ServiceName: "SampleService",
+						Name:        "OnFailAlways",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -659,7 +779,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Panic(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Panic um... panics. It never succeeds. It always behaves like me when I'm on a high place looking down.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.Panic\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -668,6 +789,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Panic",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -683,7 +807,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Redirect(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRedirectRequest\":{\"properties\":{},\"type\":\"object\"},\"SampleRedirectResponse\":{\"properties\":{\"URI\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Redirect results in a 307-style redirect to the Download endpoint.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRedirectRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleRedirectResponse\"}},\"title\":\"SampleService.Redirect\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -692,6 +817,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Redirect",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -713,6 +841,7 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					"user.{User.ID}.admin",
 					"junk.{NotReal}.crap",
 				},
+				Schema: "{\"$defs\":{\"SampleSecurityRequest\":{\"properties\":{\"FancyID\":{\"type\":\"string\"},\"ID\":{\"type\":\"string\"},\"User\":{\"$ref\":\"#/$defs/SampleUser\"}},\"type\":\"object\"},\"SampleSecurityResponse\":{\"properties\":{\"Roles\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"}},\"type\":\"object\"},\"SampleUser\":{\"description\":\"SampleUser contains an array of different fields that we support sending to/from clients\\nin all of our supported languages.\",\"properties\":{\"Age\":{\"description\":\"Age is a numeric value that we should support.\",\"type\":\"integer\"},\"Attention\":{\"description\":\"Attention is a duration to ensure that we use epoch nanos as the format, NOT the string.\",\"type\":\"integer\"},\"AttentionString\":{\"description\":\"AttentionString is a custom duration alias that overrides MarshalJSON/UnmarshalJSON to use strings for transport.\"},\"Digits\":{\"description\":\"PhoneNumber exercises the notion that clients should refer to this field as Digits, not PhoneNumber.\",\"type\":\"string\"},\"FancyID\":{\"description\":\"FancyID makes sure that we can use aliases properly rather than just the raw primitive types.\",\"type\":\"string\"},\"ID\":{\"description\":\"ID is a string value that will likely have no whitespace.\",\"type\":\"string\"},\"MarshalToObject\":{\"description\":\"MarshalToString makes sure that we can use custom marshaling of struct values.\\nThis is NOT globally supported in all client languages - just Go for now.\"},\"MarshalToString\":{\"description\":\"MarshalToString makes sure that we can use strings as an alternate JSON format for structs.\"},\"Name\":{\"description\":\"Name is a string value that will likely have spaces.\",\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"SecureWithRoles lets us test role based security by looking at the 'roles' doc option.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleSecurityRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleSecurityResponse\"}},\"title\":\"SampleService.SecureWithRoles\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -721,6 +850,14 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "SecureWithRoles",
+						Roles: []string{
+							"admin.write",
+							"user.{ID}.write",
+							"user.{User.ID}.admin",
+							"junk.{NotReal}.crap",
+						},
 					},
 				},
 			},
@@ -742,6 +879,7 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					"user.{User.FancyID}.admin",
 					"junk.{NotReal}.crap",
 				},
+				Schema: "{\"$defs\":{\"SampleSecurityRequest\":{\"properties\":{\"FancyID\":{\"type\":\"string\"},\"ID\":{\"type\":\"string\"},\"User\":{\"$ref\":\"#/$defs/SampleUser\"}},\"type\":\"object\"},\"SampleSecurityResponse\":{\"properties\":{\"Roles\":{\"items\":{\"type\":\"string\"},\"type\":\"array\"}},\"type\":\"object\"},\"SampleUser\":{\"description\":\"SampleUser contains an array of different fields that we support sending to/from clients\\nin all of our supported languages.\",\"properties\":{\"Age\":{\"description\":\"Age is a numeric value that we should support.\",\"type\":\"integer\"},\"Attention\":{\"description\":\"Attention is a duration to ensure that we use epoch nanos as the format, NOT the string.\",\"type\":\"integer\"},\"AttentionString\":{\"description\":\"AttentionString is a custom duration alias that overrides MarshalJSON/UnmarshalJSON to use strings for transport.\"},\"Digits\":{\"description\":\"PhoneNumber exercises the notion that clients should refer to this field as Digits, not PhoneNumber.\",\"type\":\"string\"},\"FancyID\":{\"description\":\"FancyID makes sure that we can use aliases properly rather than just the raw primitive types.\",\"type\":\"string\"},\"ID\":{\"description\":\"ID is a string value that will likely have no whitespace.\",\"type\":\"string\"},\"MarshalToObject\":{\"description\":\"MarshalToString makes sure that we can use custom marshaling of struct values.\\nThis is NOT globally supported in all client languages - just Go for now.\"},\"MarshalToString\":{\"description\":\"MarshalToString makes sure that we can use strings as an alternate JSON format for structs.\"},\"Name\":{\"description\":\"Name is a string value that will likely have spaces.\",\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"SecureWithRolesAliased lets us test role based security by looking at the 'roles' doc option. Specifically,\\nwe make sure we can resolve role segments with string alias types, not just strings.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleSecurityRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleSecurityResponse\"}},\"title\":\"SampleService.SecureWithRolesAliased\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -750,6 +888,14 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "SecureWithRolesAliased",
+						Roles: []string{
+							"admin.write",
+							"user.{FancyID}.write",
+							"user.{User.FancyID}.admin",
+							"junk.{NotReal}.crap",
+						},
 					},
 				},
 			},
@@ -765,7 +911,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.Sleep(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"Sleep successfully responds, but it will sleep for 5 seconds before doing so. Use this\\nfor test cases where you want to try out timeouts.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.Sleep\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -774,6 +921,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "Sleep",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -789,7 +939,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.TriggerFailure(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.TriggerFailure\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -798,6 +949,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "TriggerFailure",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -813,7 +967,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.TriggerLowerCase(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.TriggerLowerCase\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -822,6 +977,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "TriggerLowerCase",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -837,7 +995,8 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 					}
 					return handler.TriggerUpperCase(ctx, typedReq)
 				}),
-				Roles: []string{},
+				Roles:  []string{},
+				Schema: "{\"$defs\":{\"SampleRequest\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"},\"SampleResponse\":{\"properties\":{\"ID\":{\"type\":\"string\"},\"Text\":{\"type\":\"string\"}},\"type\":\"object\"}},\"$schema\":\"https://json-schema.org/draft/2020-12/schema\",\"description\":\"TriggerUpperCase ensures that events still fire as \\\"SampleService.TriggerUpperCase\\\" even though\\nwe are going to set a different HTTP path.\",\"properties\":{\"request\":{\"$ref\":\"#/$defs/SampleRequest\"},\"response\":{\"$ref\":\"#/$defs/SampleResponse\"}},\"title\":\"SampleService.TriggerUpperCase\",\"type\":\"object\"}",
 				Routes: []services.EndpointRoute{
 					{
 						GatewayType: "API",
@@ -846,6 +1005,9 @@ func SampleServiceServer(handler testext.SampleService, middleware ...services.M
 						PathParams:  []string{},
 						Group:       "",
 						Status:      200,
+						ServiceName: "SampleService",
+						Name:        "TriggerUpperCase",
+						Roles:       []string{},
 					},
 				},
 			},
@@ -14,7 +14,9 @@ import (
 //go:generate ../../out/frodo client  $GOFILE --force
 //go:generate ../../out/frodo client  $GOFILE --force --language=js
 //go:generate ../../out/frodo client  $GOFILE --force --language=dart
+//go:generate ../../out/frodo client  $GOFILE --force --fake
 //go:generate ../../out/frodo mock    $GOFILE --force
+//go:generate ../../out/frodo publisher $GOFILE --force
 //go:generate ../../out/frodo docs    $GOFILE --force
 
 // SampleService is a mix of different options, parameter setups, and responses so that we can
@@ -111,7 +113,8 @@ type SampleService interface {
 	ListenerA(context.Context, *SampleRequest) (*SampleResponse, error)
 
 	// ListenerB fires on multiple triggers... including another event-based endpoint. We also
-	// listen for the TriggerFailure event which should never fire properly.
+	// listen for the TriggerFailure event which should never fire properly. The "payment.succeeded"
+	// event is published by some system outside of Frodo, so its payload is just raw request JSON.
 	//
 	// HTTP OMIT
 	// ON SampleService.TriggerUpperCase
@@ -119,6 +122,7 @@ type SampleService interface {
 	// ON SampleService.TriggerFailure
 	// ON SampleService.ListenerA
 	// ON OtherService.SpaceOut
+	// ON payment.succeeded
 	ListenerB(context.Context, *SampleRequest) (*SampleResponse, error)
 
 	// FailAlways will return an error no matter what. It's only goal in life is to trigger OnFailAlways.
@@ -268,6 +272,9 @@ func (MarshalToString) escape(value string) string {
 // to the request builder code the correct structure it should submit. I include this
 // so that we can have a test codifying that this behavior is not supported. If you want
 // different fields, use `json:""` tags.
+//
+// It also implements MarshalValue/UnmarshalValue so that path/query values look like
+// "home@x.com,work@x.com" rather than a URL-encoded blob of JSON.
 type MarshalToObject struct {
 	// Home is supposed to be a home email address.
 	Home string
@@ -289,6 +296,15 @@ func (m MarshalToObject) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf(`{"H":"%s", "W":"%s"}`, m.Home, m.Work)), nil
 }
 
+func (m MarshalToObject) MarshalValue() string {
+	return m.Home + "," + m.Work
+}
+
+func (m *MarshalToObject) UnmarshalValue(value string) error {
+	m.Home, m.Work, _ = strings.Cut(value, ",")
+	return nil
+}
+
 // CustomDuration is a standard Duration alias that uses duration strings for JSON
 // transport as opposed to epoch nanos.
 type CustomDuration time.Duration
@@ -160,8 +160,14 @@ func (s SampleServiceHandler) ListenerA(_ context.Context, req *SampleRequest) (
 	return &SampleResponse{Text: "ListenerA:" + req.Text}, nil
 }
 
-func (s SampleServiceHandler) ListenerB(_ context.Context, req *SampleRequest) (*SampleResponse, error) {
+func (s SampleServiceHandler) ListenerB(ctx context.Context, req *SampleRequest) (*SampleResponse, error) {
 	s.Sequence.Append("ListenerB:" + req.Text)
+
+	// Lets us verify that metadata values from the original caller make it all the way to event handlers.
+	tenant := ""
+	if metadata.Value(ctx, "Tenant", &tenant) {
+		s.Sequence.Append("ListenerB.Tenant:" + tenant)
+	}
 	return &SampleResponse{Text: "ListenerB:" + req.Text}, nil
 }
 
@@ -0,0 +1,33 @@
+package metadata
+
+import (
+	"context"
+)
+
+type contextKeyCallDepth struct{}
+
+// CallDepth returns how many events deep the current call is. A call that came straight from an API request
+// (or some other source outside of Frodo) has a depth of 0. When it publishes an event, the handlers of that
+// event run at depth 1, the handlers of their events run at depth 2, and so on. The event gateway uses this
+// to stop runaway chains of events (see events.WithMaxCallDepth).
+//
+// The depth follows you to other services just like the rest of your metadata, so it keeps counting even
+// when the chain of events passes through a call made using a generated client.
+func CallDepth(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+	if depth, ok := ctx.Value(contextKeyCallDepth{}).(int); ok {
+		return depth
+	}
+	return 0
+}
+
+// WithCallDepth stores how many events deep the current call is. Typically, you should NOT call this
+// directly. The event gateway increments it every time it publishes an event.
+func WithCallDepth(ctx context.Context, depth int) context.Context {
+	if ctx == nil {
+		return nil
+	}
+	return context.WithValue(ctx, contextKeyCallDepth{}, depth)
+}
@@ -0,0 +1,45 @@
+//go:build unit
+
+package metadata_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCallDepthSuite(t *testing.T) {
+	suite.Run(t, new(CallDepthSuite))
+}
+
+type CallDepthSuite struct {
+	suite.Suite
+}
+
+func (suite *CallDepthSuite) TestDefaults() {
+	suite.Equal(0, metadata.CallDepth(nil))
+	suite.Equal(0, metadata.CallDepth(context.Background()))
+	suite.Nil(metadata.WithCallDepth(nil, 2))
+}
+
+func (suite *CallDepthSuite) TestEncodeDecode() {
+	suite.Equal(metadata.EncodedBytes(""), metadata.Encode(metadata.WithCallDepth(context.Background(), 0)), "Depth 0 is nothing worth encoding")
+
+	ctx := metadata.WithCallDepth(context.Background(), 3)
+	suite.Equal(3, metadata.CallDepth(metadata.Decode(context.Background(), metadata.Encode(ctx))))
+
+	ctx = metadata.WithCallDepth(context.Background(), 5)
+	suite.Equal(0, metadata.CallDepth(metadata.Decode(ctx, "")), "Decode should replace whatever depth was there before")
+}
+
+func (suite *CallDepthSuite) TestMerge() {
+	encoded := metadata.Encode(metadata.WithCallDepth(context.Background(), 3))
+
+	ctx := metadata.Merge(context.Background(), encoded, metadata.DefaultMergePolicy())
+	suite.Equal(3, metadata.CallDepth(ctx))
+
+	ctx = metadata.Merge(metadata.WithCallDepth(context.Background(), 5), encoded, metadata.DefaultMergePolicy())
+	suite.Equal(5, metadata.CallDepth(ctx), "Merge should keep the deeper of the two")
+}
@@ -0,0 +1,37 @@
+package metadata
+
+import (
+	"context"
+)
+
+// CallerHeader is the HTTP header that service clients use to tell the gateway which service is calling it.
+const CallerHeader = "X-RPC-Caller"
+
+type contextKeyCaller struct{}
+
+// Caller returns the name of the service that invoked the current call (e.g. "BillingService"). When one
+// service calls another using a generated client, the client tells the callee who it is. When an event
+// triggered the call, this is the service that published the event. It's "" when the call didn't come
+// from another service (e.g. a request from your frontend or an event from outside of Frodo).
+//
+// Like request headers, this only describes the current call; it does NOT follow you to other services.
+// The caller supplies this value itself, so only trust it for authorization decisions when you know that
+// nobody else can reach the service (e.g. it's only exposed to your other services using mTLS).
+func Caller(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if caller, ok := ctx.Value(contextKeyCaller{}).(string); ok {
+		return caller
+	}
+	return ""
+}
+
+// WithCaller stores the name of the service that invoked the current call. You typically should not
+// call this on your own as the framework will do that for you as part of our gateways' standard processing.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	if ctx == nil {
+		return nil
+	}
+	return context.WithValue(ctx, contextKeyCaller{}, caller)
+}
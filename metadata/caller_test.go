@@ -0,0 +1,38 @@
+//go:build unit
+
+package metadata_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCallerSuite(t *testing.T) {
+	suite.Run(t, new(CallerSuite))
+}
+
+type CallerSuite struct {
+	suite.Suite
+}
+
+func (suite *CallerSuite) TestDefaults() {
+	suite.Equal("", metadata.Caller(nil))
+	suite.Equal("", metadata.Caller(context.Background()))
+	suite.Nil(metadata.WithCaller(nil, "BillingService"))
+}
+
+func (suite *CallerSuite) TestWithCaller() {
+	ctx := metadata.WithCaller(context.Background(), "BillingService")
+	suite.Equal("BillingService", metadata.Caller(ctx))
+
+	ctx = metadata.WithCaller(ctx, "")
+	suite.Equal("", metadata.Caller(ctx), "Should be able to clear the caller for a new call")
+}
+
+func (suite *CallerSuite) TestCaller_notEncoded() {
+	ctx := metadata.WithCaller(context.Background(), "BillingService")
+	suite.Equal("", metadata.Caller(metadata.Decode(context.Background(), metadata.Encode(ctx))), "Caller should not propagate w/ other metadata")
+}
@@ -0,0 +1,84 @@
+package metadata
+
+import (
+	"context"
+	"crypto/x509"
+)
+
+type contextKeyClientCert struct{}
+
+// ClientCert extracts the identity of the peer that connected to the gateway using mutual TLS. This is
+// only populated when the gateway verified the client's certificate against your trusted CAs (see
+// apis.WithClientCertAuth()). If the caller didn't present a verified certificate, you get a zero value
+// whose Verified field is false.
+//
+// THIS VALUE DOES NOT FOLLOW YOU if your request makes RPC-style calls or triggers other event
+// gateways to fire. It only describes the peer that made the most recent/current request.
+func ClientCert(ctx context.Context) ClientCertificate {
+	if ctx == nil {
+		return ClientCertificate{}
+	}
+	if cert, ok := ctx.Value(contextKeyClientCert{}).(ClientCertificate); ok {
+		return cert
+	}
+	return ClientCertificate{}
+}
+
+// WithClientCert stores the verified client certificate identity on the request context. Typically,
+// you will not need to call this yourself as the API gateway will take care of this for you.
+func WithClientCert(ctx context.Context, cert ClientCertificate) context.Context {
+	if ctx == nil {
+		return nil
+	}
+	return context.WithValue(ctx, contextKeyClientCert{}, cert)
+}
+
+// ClientCertificate is the subset of a verified x509 client certificate that you'll likely want in order to
+// make authorization decisions based on the peer's identity rather than a bearer token.
+type ClientCertificate struct {
+	// Verified is true when the gateway actually verified the peer's certificate chain.
+	Verified bool
+	// CommonName is the subject's CN (e.g. "order-service").
+	CommonName string
+	// Organization contains the subject's O values.
+	Organization []string
+	// OrganizationalUnit contains the subject's OU values.
+	OrganizationalUnit []string
+	// DNSNames are the DNS subject alternative names (e.g. "orders.internal.example.com").
+	DNSNames []string
+	// EmailAddresses are the email subject alternative names.
+	EmailAddresses []string
+	// IPAddresses are the IP subject alternative names in string form.
+	IPAddresses []string
+	// URIs are the URI subject alternative names (e.g. SPIFFE ids like "spiffe://example.com/orders").
+	URIs []string
+	// SerialNumber is the certificate's serial number in base 10.
+	SerialNumber string
+}
+
+// NewClientCertificate captures the identity information from a certificate that has already been
+// verified by the TLS layer.
+func NewClientCertificate(cert *x509.Certificate) ClientCertificate {
+	if cert == nil {
+		return ClientCertificate{}
+	}
+
+	identity := ClientCertificate{
+		Verified:           true,
+		CommonName:         cert.Subject.CommonName,
+		Organization:       cert.Subject.Organization,
+		OrganizationalUnit: cert.Subject.OrganizationalUnit,
+		DNSNames:           cert.DNSNames,
+		EmailAddresses:     cert.EmailAddresses,
+	}
+	for _, ip := range cert.IPAddresses {
+		identity.IPAddresses = append(identity.IPAddresses, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		identity.URIs = append(identity.URIs, uri.String())
+	}
+	if cert.SerialNumber != nil {
+		identity.SerialNumber = cert.SerialNumber.String()
+	}
+	return identity
+}
@@ -0,0 +1,73 @@
+//go:build unit
+
+package metadata_test
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestClientCertSuite(t *testing.T) {
+	suite.Run(t, new(ClientCertSuite))
+}
+
+type ClientCertSuite struct {
+	suite.Suite
+}
+
+func (suite *ClientCertSuite) TestDefaults() {
+	suite.Equal(metadata.ClientCertificate{}, metadata.ClientCert(nil))
+	suite.Equal(metadata.ClientCertificate{}, metadata.ClientCert(context.Background()))
+	suite.Nil(metadata.WithClientCert(nil, metadata.ClientCertificate{}))
+	suite.False(metadata.ClientCert(context.Background()).Verified)
+}
+
+func (suite *ClientCertSuite) TestWithClientCert() {
+	ctx := context.Background()
+
+	cert := metadata.ClientCertificate{Verified: true, CommonName: "dude"}
+	ctx = metadata.WithClientCert(ctx, cert)
+	suite.Equal(cert, metadata.ClientCert(ctx))
+
+	cert = metadata.ClientCertificate{Verified: true, CommonName: "walter"}
+	ctx = metadata.WithClientCert(ctx, cert)
+	suite.Equal(cert, metadata.ClientCert(ctx))
+}
+
+func (suite *ClientCertSuite) TestNewClientCertificate() {
+	suite.Equal(metadata.ClientCertificate{}, metadata.NewClientCertificate(nil))
+
+	spiffe, _ := url.Parse("spiffe://example.com/orders")
+	identity := metadata.NewClientCertificate(&x509.Certificate{
+		SerialNumber: big.NewInt(1234),
+		Subject: pkix.Name{
+			CommonName:         "order-service",
+			Organization:       []string{"Bowling League"},
+			OrganizationalUnit: []string{"Lanes"},
+		},
+		DNSNames:       []string{"orders.internal"},
+		EmailAddresses: []string{"dude@example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:           []*url.URL{spiffe},
+	})
+
+	suite.Equal(metadata.ClientCertificate{
+		Verified:           true,
+		CommonName:         "order-service",
+		Organization:       []string{"Bowling League"},
+		OrganizationalUnit: []string{"Lanes"},
+		DNSNames:           []string{"orders.internal"},
+		EmailAddresses:     []string{"dude@example.com"},
+		IPAddresses:        []string{"10.0.0.1"},
+		URIs:               []string{"spiffe://example.com/orders"},
+		SerialNumber:       "1234",
+	}, identity)
+}
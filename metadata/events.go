@@ -0,0 +1,51 @@
+package metadata
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type contextKeyEventSuppression struct{}
+
+// SuppressEvent tells the event gateway NOT to publish the "Service.Method" event for the current call, even
+// though it succeeded. Use this when your handler decides at runtime that nothing interesting happened (e.g. an
+// update that didn't actually change anything), so subscribers shouldn't react to it.
+//
+//	func (svc UserServiceHandler) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*UpdateUserResponse, error) {
+//		if !changed {
+//			metadata.SuppressEvent(ctx)
+//		}
+//		...
+//	}
+//
+// This only affects the call that you're currently handling; functions that you invoke from here still publish
+// their own events. It's a no-op when you're not running an event gateway.
+func SuppressEvent(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+	if suppressed, ok := ctx.Value(contextKeyEventSuppression{}).(*atomic.Bool); ok {
+		suppressed.Store(true)
+	}
+}
+
+// EventSuppressed returns true if the handler called SuppressEvent() for the current call.
+func EventSuppressed(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	if suppressed, ok := ctx.Value(contextKeyEventSuppression{}).(*atomic.Bool); ok {
+		return suppressed.Load()
+	}
+	return false
+}
+
+// WithEventSuppression gives the context a fresh place for SuppressEvent() to record the handler's decision, so
+// that the caller can check EventSuppressed() once the handler returns. Typically, you should NOT call this
+// directly. The event gateway does this for every call before it invokes your handler.
+func WithEventSuppression(ctx context.Context) context.Context {
+	if ctx == nil {
+		return nil
+	}
+	return context.WithValue(ctx, contextKeyEventSuppression{}, &atomic.Bool{})
+}
@@ -0,0 +1,50 @@
+//go:build unit
+
+package metadata_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestEventsSuite(t *testing.T) {
+	suite.Run(t, new(EventsSuite))
+}
+
+type EventsSuite struct {
+	suite.Suite
+}
+
+func (suite *EventsSuite) TestDefaults() {
+	suite.False(metadata.EventSuppressed(nil))
+	suite.False(metadata.EventSuppressed(context.Background()))
+	suite.Nil(metadata.WithEventSuppression(nil))
+
+	// Nothing to record the decision on, so these should quietly do nothing.
+	metadata.SuppressEvent(nil)
+	metadata.SuppressEvent(context.Background())
+}
+
+func (suite *EventsSuite) TestSuppressEvent() {
+	ctx := metadata.WithEventSuppression(context.Background())
+	suite.False(metadata.EventSuppressed(ctx))
+
+	// The handler might have added its own values to the context before deciding, but we should still see it.
+	metadata.SuppressEvent(context.WithValue(ctx, "foo", "bar"))
+	suite.True(metadata.EventSuppressed(ctx))
+
+	metadata.SuppressEvent(ctx)
+	suite.True(metadata.EventSuppressed(ctx), "Suppressing twice should be harmless")
+}
+
+func (suite *EventsSuite) TestWithEventSuppression_fresh() {
+	ctx := metadata.WithEventSuppression(context.Background())
+	metadata.SuppressEvent(ctx)
+
+	nested := metadata.WithEventSuppression(ctx)
+	suite.False(metadata.EventSuppressed(nested), "Nested calls should get their own decision")
+	suite.True(metadata.EventSuppressed(ctx))
+}
@@ -0,0 +1,111 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Precedence indicates which source of a piece of metadata should win when a request supplies it in
+// more than one place. The "standard" source is the gateway's native way of supplying the value (e.g. the
+// HTTP Authorization or X-Request-ID header) or whatever is already on the context. The "propagated" source
+// is the value that was carried over from the calling service in the X-RPC-Metadata header/payload.
+type Precedence int
+
+const (
+	// PreferStandard uses the standard/native value when it's present and only falls back to the
+	// propagated value when the standard one is missing.
+	PreferStandard Precedence = iota
+	// PreferPropagated uses the value carried over from the calling service when it's present and only
+	// falls back to the standard/native value when the propagated one is missing.
+	PreferPropagated
+)
+
+// String returns a human-readable name for the precedence rule.
+func (p Precedence) String() string {
+	switch p {
+	case PreferPropagated:
+		return "PreferPropagated"
+	default:
+		return "PreferStandard"
+	}
+}
+
+// Choose resolves a conflict between the standard and propagated version of a single value. A blank value
+// is treated as "not supplied", so the other one always wins regardless of the precedence.
+func (p Precedence) Choose(standard string, propagated string) string {
+	switch {
+	case standard == "":
+		return propagated
+	case propagated == "":
+		return standard
+	case p == PreferPropagated:
+		return propagated
+	default:
+		return standard
+	}
+}
+
+// MergePolicy defines the precedence rules gateways use when the same metadata arrives from both the
+// standard source of a request and the propagated X-RPC-Metadata. Each piece of metadata has its own rule
+// because the sensible default is not the same for all of them:
+//
+//   - Authorization prefers the standard header so that Service A can call Service B using a different
+//     set of credentials than the ones it was called with.
+//   - TraceID prefers the propagated value so that every hop in a call chain shares the same id even
+//     if some proxy stamped a new X-Request-ID on an intermediate request.
+//   - Values prefer the propagated entries when the same key is already on the context.
+//
+// The call depth (see CallDepth) isn't up for debate; merging always keeps the deeper of the two.
+type MergePolicy struct {
+	// Authorization is the rule for choosing between the Authorization header and the propagated credentials.
+	Authorization Precedence
+	// TraceID is the rule for choosing between the X-Request-ID header and the propagated trace id.
+	TraceID Precedence
+	// Values is the rule for resolving keys that exist both on the context and in the propagated values.
+	Values Precedence
+}
+
+// DefaultMergePolicy returns the precedence rules that gateways use unless you tell them otherwise.
+func DefaultMergePolicy() MergePolicy {
+	return MergePolicy{
+		Authorization: PreferStandard,
+		TraceID:       PreferPropagated,
+		Values:        PreferPropagated,
+	}
+}
+
+// Merge behaves like Decode(), except that it doesn't blindly clobber metadata that is already on the context.
+// Anything already on the context is treated as the "standard" source, and the encoded metadata is treated as
+// the "propagated" source; conflicts are resolved using the given policy.
+func Merge(ctx context.Context, encodedMetadata EncodedBytes, policy MergePolicy) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	meta := transport{Values: values{}}
+	_ = json.Unmarshal([]byte(encodedMetadata), &meta)
+
+	ctx = WithAuthorization(ctx, policy.Authorization.Choose(Authorization(ctx), meta.Authorization))
+	ctx = WithTraceID(ctx, policy.TraceID.Choose(TraceID(ctx), meta.TraceID))
+	ctx = WithCallDepth(ctx, max(CallDepth(ctx), meta.CallDepth))
+	ctx = context.WithValue(ctx, contextKeyValues{}, mergeValues(ctx, meta.Values, policy.Values))
+	return ctx
+}
+
+// mergeValues creates a brand new values map containing the entries already on the context as well as the
+// propagated ones. When a key exists in both, the precedence determines which entry survives.
+func mergeValues(ctx context.Context, propagated values, precedence Precedence) values {
+	standard, _ := ctx.Value(contextKeyValues{}).(values)
+
+	merged := values{}
+	for key, entry := range standard {
+		merged[key] = entry
+	}
+	for key, entry := range propagated {
+		if _, exists := merged[key]; exists && precedence == PreferStandard {
+			continue
+		}
+		merged[key] = entry
+	}
+	return merged
+}
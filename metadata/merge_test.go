@@ -0,0 +1,128 @@
+//go:build unit
+
+package metadata_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMergeSuite(t *testing.T) {
+	suite.Run(t, new(MergeSuite))
+}
+
+type MergeSuite struct {
+	suite.Suite
+}
+
+func (suite *MergeSuite) TestPrecedence_Choose() {
+	suite.Equal("", metadata.PreferStandard.Choose("", ""))
+	suite.Equal("A", metadata.PreferStandard.Choose("A", ""))
+	suite.Equal("B", metadata.PreferStandard.Choose("", "B"))
+	suite.Equal("A", metadata.PreferStandard.Choose("A", "B"))
+
+	suite.Equal("", metadata.PreferPropagated.Choose("", ""))
+	suite.Equal("A", metadata.PreferPropagated.Choose("A", ""))
+	suite.Equal("B", metadata.PreferPropagated.Choose("", "B"))
+	suite.Equal("B", metadata.PreferPropagated.Choose("A", "B"))
+}
+
+func (suite *MergeSuite) TestDefaultMergePolicy() {
+	policy := metadata.DefaultMergePolicy()
+	suite.Equal(metadata.PreferStandard, policy.Authorization)
+	suite.Equal(metadata.PreferPropagated, policy.TraceID)
+	suite.Equal(metadata.PreferPropagated, policy.Values)
+}
+
+func (suite *MergeSuite) TestMerge_nilContext() {
+	ctx := metadata.Merge(nil, `{"Authorization":"Abide","TraceID":"12345"}`, metadata.DefaultMergePolicy())
+	suite.Require().NotNil(ctx)
+	suite.Equal("Abide", metadata.Authorization(ctx))
+	suite.Equal("12345", metadata.TraceID(ctx))
+}
+
+func (suite *MergeSuite) TestMerge_emptyContext() {
+	// With nothing on the context, the policy doesn't matter. You just get the propagated values.
+	ctx := metadata.Merge(context.Background(), `{"Authorization":"Abide","TraceID":"12345"}`, metadata.MergePolicy{
+		Authorization: metadata.PreferStandard,
+		TraceID:       metadata.PreferStandard,
+		Values:        metadata.PreferStandard,
+	})
+	suite.Equal("Abide", metadata.Authorization(ctx))
+	suite.Equal("12345", metadata.TraceID(ctx))
+}
+
+func (suite *MergeSuite) TestMerge_preferStandard() {
+	ctx := context.Background()
+	ctx = metadata.WithAuthorization(ctx, "Walter")
+	ctx = metadata.WithTraceID(ctx, "ABC")
+	ctx = metadata.WithValue(ctx, "Name", "Dude")
+	ctx = metadata.WithValue(ctx, "Drink", "White Russian")
+
+	encoded := metadata.Encode(metadata.WithValue(
+		metadata.WithTraceID(metadata.WithAuthorization(context.Background(), "Donny"), "XYZ"),
+		"Name", "Lebowski"),
+	)
+	ctx = metadata.Merge(ctx, encoded, metadata.MergePolicy{
+		Authorization: metadata.PreferStandard,
+		TraceID:       metadata.PreferStandard,
+		Values:        metadata.PreferStandard,
+	})
+
+	suite.Equal("Walter", metadata.Authorization(ctx))
+	suite.Equal("ABC", metadata.TraceID(ctx))
+
+	name := ""
+	suite.True(metadata.Value(ctx, "Name", &name))
+	suite.Equal("Dude", name)
+
+	drink := ""
+	suite.True(metadata.Value(ctx, "Drink", &drink))
+	suite.Equal("White Russian", drink)
+}
+
+func (suite *MergeSuite) TestMerge_preferPropagated() {
+	ctx := context.Background()
+	ctx = metadata.WithAuthorization(ctx, "Walter")
+	ctx = metadata.WithTraceID(ctx, "ABC")
+	ctx = metadata.WithValue(ctx, "Name", "Dude")
+	ctx = metadata.WithValue(ctx, "Drink", "White Russian")
+
+	encoded := metadata.Encode(metadata.WithValue(
+		metadata.WithTraceID(metadata.WithAuthorization(context.Background(), "Donny"), "XYZ"),
+		"Name", "Lebowski"),
+	)
+	ctx = metadata.Merge(ctx, encoded, metadata.MergePolicy{
+		Authorization: metadata.PreferPropagated,
+		TraceID:       metadata.PreferPropagated,
+		Values:        metadata.PreferPropagated,
+	})
+
+	suite.Equal("Donny", metadata.Authorization(ctx))
+	suite.Equal("XYZ", metadata.TraceID(ctx))
+
+	name := ""
+	suite.True(metadata.Value(ctx, "Name", &name))
+	suite.Equal("Lebowski", name)
+
+	drink := ""
+	suite.True(metadata.Value(ctx, "Drink", &drink))
+	suite.Equal("White Russian", drink, "Values that aren't duplicated should survive regardless of policy")
+}
+
+func (suite *MergeSuite) TestMerge_missingPropagated() {
+	ctx := context.Background()
+	ctx = metadata.WithAuthorization(ctx, "Walter")
+	ctx = metadata.WithTraceID(ctx, "ABC")
+
+	ctx = metadata.Merge(ctx, ``, metadata.MergePolicy{
+		Authorization: metadata.PreferPropagated,
+		TraceID:       metadata.PreferPropagated,
+		Values:        metadata.PreferPropagated,
+	})
+	suite.Equal("Walter", metadata.Authorization(ctx))
+	suite.Equal("ABC", metadata.TraceID(ctx))
+}
@@ -11,6 +11,7 @@ type transport struct {
 	Authorization string `json:",omitempty"`
 	TraceID       string `json:",omitempty"`
 	Values        values `json:",omitempty"`
+	CallDepth     int    `json:",omitempty"`
 }
 
 type EncodedBytes string
@@ -22,6 +23,7 @@ func Encode(ctx context.Context) EncodedBytes {
 	meta := transport{
 		Authorization: Authorization(ctx),
 		TraceID:       TraceID(ctx),
+		CallDepth:     CallDepth(ctx),
 	}
 
 	if metaValues, ok := ctx.Value(contextKeyValues{}).(values); ok {
@@ -53,6 +55,7 @@ func Decode(ctx context.Context, encodedMetadata EncodedBytes) context.Context {
 
 	ctx = WithAuthorization(ctx, meta.Authorization)
 	ctx = WithTraceID(ctx, meta.TraceID)
+	ctx = WithCallDepth(ctx, meta.CallDepth)
 	ctx = context.WithValue(ctx, contextKeyValues{}, meta.Values)
 	return ctx
 }
@@ -98,6 +98,41 @@ func (suite *MetadataSuite) TestEncodeDecode_ignoreTransients() {
 	suite.Equal("", metadata.Route(decoded).Name)
 }
 
+// Values are decoded lazily, so a service in the middle of a call chain (e.g. one that triggers an event) might
+// never look at some values before passing them along. Make sure they survive multiple hops untouched.
+func (suite *MetadataSuite) TestEncodeDecode_multipleHops() {
+	type tenant struct {
+		ID   string
+		Name string
+	}
+
+	ctx := context.Background()
+	ctx = metadata.WithValue(ctx, "Tenant", tenant{ID: "42", Name: "Lebowski"})
+	ctx = metadata.WithValue(ctx, "Count", 3)
+	ctx = metadata.WithValue(ctx, "Read", "Yes")
+
+	// Hop 1 only reads one of the values before passing them all along.
+	hop1 := metadata.Decode(context.Background(), metadata.Encode(ctx))
+	read := ""
+	suite.Require().True(metadata.Value(hop1, "Read", &read))
+	suite.Equal("Yes", read)
+
+	hop2 := metadata.Decode(context.Background(), metadata.Encode(hop1))
+	hop3 := metadata.Decode(context.Background(), metadata.Encode(hop2))
+
+	tenantValue := tenant{}
+	suite.Require().True(metadata.Value(hop3, "Tenant", &tenantValue))
+	suite.Equal(tenant{ID: "42", Name: "Lebowski"}, tenantValue)
+
+	count := 0
+	suite.Require().True(metadata.Value(hop3, "Count", &count))
+	suite.Equal(3, count)
+
+	read = ""
+	suite.Require().True(metadata.Value(hop3, "Read", &read))
+	suite.Equal("Yes", read)
+}
+
 // Given that values are handled in a slightly more odd fashion, make sure that Encode/Decode
 // works just fine with contexts that only specify Authorization and TraceID.
 func (suite *MetadataSuite) TestEncodeDecode_noValues() {
@@ -49,3 +49,57 @@ func WithRequestHeaders(ctx context.Context, headers map[string][]string) contex
 	}
 	return context.WithValue(ctx, contextKeyRequestHeaders{}, canonicalHeaders)
 }
+
+type contextKeyRequestInfo struct{}
+
+// RequestInfo describes the transport-level details of the original gateway request. Like request headers,
+// these only describe the most recent/current request; they do NOT follow you to other services.
+type RequestInfo struct {
+	// Method is the verb used to invoke the gateway (e.g. "GET" or "POST" for API requests).
+	Method string
+	// RemoteAddr is the IP address of the client that made the request. If the gateway is configured to trust
+	// the proxy that forwarded the request, this is the client address reported by the proxy instead.
+	RemoteAddr string
+	// TLS is true when the request came in over an encrypted connection.
+	TLS bool
+	// TLSVersion is the negotiated TLS version (e.g. tls.VersionTLS13) or 0 when the request was not encrypted.
+	TLSVersion uint16
+}
+
+// RequestMethod returns the verb used to invoke the original gateway request (e.g. "GET" or "POST").
+func RequestMethod(ctx context.Context) string {
+	return requestInfo(ctx).Method
+}
+
+// RemoteAddr returns the IP address of the client that made the original gateway request. This is handy for
+// audit logs and things like that. Behind a load balancer, this is the proxy's address unless you've configured
+// the gateway to trust it, in which case we use the X-Forwarded-For/X-Real-IP address it supplies.
+func RemoteAddr(ctx context.Context) string {
+	return requestInfo(ctx).RemoteAddr
+}
+
+// TLSState indicates whether the original gateway request came in over TLS and, if so, which TLS version
+// was negotiated (e.g. tls.VersionTLS13).
+func TLSState(ctx context.Context) (bool, uint16) {
+	info := requestInfo(ctx)
+	return info.TLS, info.TLSVersion
+}
+
+// WithRequestInfo stores transport-level details of the original gateway request. You typically should not
+// call this on your own as the framework will do that for you as part of our gateways' standard processing.
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	if ctx == nil {
+		return nil
+	}
+	return context.WithValue(ctx, contextKeyRequestInfo{}, info)
+}
+
+func requestInfo(ctx context.Context) RequestInfo {
+	if ctx == nil {
+		return RequestInfo{}
+	}
+	if info, ok := ctx.Value(contextKeyRequestInfo{}).(RequestInfo); ok {
+		return info
+	}
+	return RequestInfo{}
+}
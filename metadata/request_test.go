@@ -4,6 +4,7 @@ package metadata_test
 
 import (
 	"context"
+	"crypto/tls"
 	"testing"
 
 	"github.com/bridgekit-io/frodo/metadata"
@@ -54,3 +55,28 @@ func (suite *RequestSuite) TestWithRequestHeaders() {
 	// If there are multiple values, return a comma-space delimited string of them
 	suite.Equal("application/json, text/html;q=0.9, */*", metadata.RequestHeader(ctx, "Accept"))
 }
+
+func (suite *RequestSuite) TestRequestInfo_defaults() {
+	suite.Equal("", metadata.RequestMethod(nil))
+	suite.Equal("", metadata.RemoteAddr(nil))
+	suite.Nil(metadata.WithRequestInfo(nil, metadata.RequestInfo{}))
+
+	tlsEnabled, tlsVersion := metadata.TLSState(context.Background())
+	suite.False(tlsEnabled)
+	suite.Equal(uint16(0), tlsVersion)
+}
+
+func (suite *RequestSuite) TestWithRequestInfo() {
+	ctx := metadata.WithRequestInfo(context.Background(), metadata.RequestInfo{
+		Method:     "PATCH",
+		RemoteAddr: "10.0.0.1",
+		TLS:        true,
+		TLSVersion: tls.VersionTLS13,
+	})
+	suite.Equal("PATCH", metadata.RequestMethod(ctx))
+	suite.Equal("10.0.0.1", metadata.RemoteAddr(ctx))
+
+	tlsEnabled, tlsVersion := metadata.TLSState(ctx)
+	suite.True(tlsEnabled)
+	suite.Equal(uint16(tls.VersionTLS13), tlsVersion)
+}
@@ -2,6 +2,7 @@ package metadata
 
 import (
 	"context"
+	"slices"
 )
 
 type contextKeyRoute struct{}
@@ -59,6 +60,14 @@ type EndpointRoute struct {
 	// Friendly reminder that these are the roles you want the security layer to look for - it's
 	// not necessarily what the caller actually has!
 	Roles []string
+	// RequireAllRoles is true when the caller must have ALL of the Roles (the "ROLES ALL" doc option) rather than
+	// just one of them, which is the default.
+	RequireAllRoles bool
+	// Deprecated indicates that the operation is being retired (see the "DEPRECATED" doc option).
+	Deprecated bool
+	// Sunset is the date (formatted "2006-01-02") when the deprecated operation is expected to stop working.
+	// It's empty if the operation isn't deprecated or there's no firm date for its retirement.
+	Sunset string
 }
 
 // QualifiedName returns the fully-qualified name/identifier of this service operation. It
@@ -73,3 +82,26 @@ func (e EndpointRoute) QualifiedName() string {
 		return e.ServiceName + "." + e.Name
 	}
 }
+
+// AllowsRoles returns true when the caller's roles satisfy this route's Roles. Normally, any single one of the
+// route's roles will do, but with RequireAllRoles, the caller needs every one of them. Routes without any roles
+// allow everyone.
+//
+//	if !metadata.Route(ctx).AllowsRoles(user.Roles) {
+//		return nil, fail.PermissionDenied("you don't have rights to access this resource")
+//	}
+func (e EndpointRoute) AllowsRoles(callerRoles []string) bool {
+	if len(e.Roles) == 0 {
+		return true
+	}
+	for _, role := range e.Roles {
+		has := slices.Contains(callerRoles, role)
+		switch {
+		case has && !e.RequireAllRoles:
+			return true
+		case !has && e.RequireAllRoles:
+			return false
+		}
+	}
+	return e.RequireAllRoles
+}
@@ -70,3 +70,22 @@ func (suite *RouteSuite) TestRoute_QualifiedName() {
 	route.Name = "Beer🍺"
 	suite.Equal("Tasty.Beer🍺", route.QualifiedName())
 }
+
+func (suite *RouteSuite) TestRoute_AllowsRoles() {
+	route := metadata.EndpointRoute{}
+	suite.True(route.AllowsRoles(nil), "No roles should allow everyone")
+	suite.True(route.AllowsRoles([]string{"admin.write"}), "No roles should allow everyone")
+
+	route.Roles = []string{"admin.write", "group.123.write"}
+	suite.True(route.AllowsRoles([]string{"admin.write"}))
+	suite.True(route.AllowsRoles([]string{"foo", "group.123.write"}))
+	suite.True(route.AllowsRoles([]string{"admin.write", "group.123.write"}))
+	suite.False(route.AllowsRoles([]string{"group.456.write"}))
+	suite.False(route.AllowsRoles(nil))
+
+	route.RequireAllRoles = true
+	suite.False(route.AllowsRoles([]string{"admin.write"}), "Should need every role")
+	suite.False(route.AllowsRoles([]string{"foo", "group.123.write"}), "Should need every role")
+	suite.True(route.AllowsRoles([]string{"group.123.write", "foo", "admin.write"}))
+	suite.False(route.AllowsRoles(nil))
+}
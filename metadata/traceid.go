@@ -3,10 +3,13 @@ package metadata
 import (
 	"context"
 	"math/rand"
-	"time"
+	"sync/atomic"
 )
 
-var traceIDRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+// TraceIDHeader is the HTTP header that gateways read the caller's trace id from and that clients use to
+// send it, unless you configure them to use some other header (e.g. the W3C "traceparent" header).
+const TraceIDHeader = "X-Request-ID"
+
 var traceIDRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
 var traceIDLen = 24
 
@@ -34,12 +37,36 @@ func WithTraceID(ctx context.Context, id string) context.Context {
 	return context.WithValue(ctx, contextKeyTraceID{}, id)
 }
 
+// traceIDGenerator is the custom generator supplied via WithTraceIDGenerator(), if any.
+var traceIDGenerator atomic.Pointer[func() string]
+
+// WithTraceIDGenerator changes how NewTraceID() creates trace ids for the entire process, so the API gateway,
+// service clients, and scheduled events all create ids in the same format. This lets you generate something
+// like a W3C "traceparent" value for interop w/ your OpenTelemetry tooling. Call this once when your program
+// starts, before you start any gateways. Supplying nil restores the default generator.
+//
+//	metadata.WithTraceIDGenerator(func() string {
+//		return newTraceParent() // e.g. "00-{trace-id}-{parent-id}-01"
+//	})
+func WithTraceIDGenerator(generator func() string) {
+	if generator == nil {
+		traceIDGenerator.Store(nil)
+		return
+	}
+	traceIDGenerator.Store(&generator)
+}
+
 // NewTraceID generates a pseudo-random request id for your context/request if one wasn't
-// already provided by the client/caller.
+// already provided by the client/caller. It's safe to call from multiple goroutines. If you
+// supplied your own generator using WithTraceIDGenerator(), we use that instead.
 func NewTraceID() string {
+	if generator := traceIDGenerator.Load(); generator != nil {
+		return (*generator)()
+	}
+
 	id := make([]rune, traceIDLen)
 	for i := range id {
-		id[i] = traceIDRunes[traceIDRand.Intn(len(traceIDRunes))]
+		id[i] = traceIDRunes[rand.Intn(len(traceIDRunes))]
 	}
 	return string(id)
 }
@@ -61,3 +61,13 @@ func (suite *TraceIDSuite) TestNewTraceID() {
 	ctx = metadata.WithTraceID(ctx, id1)
 	suite.Equal(id1, metadata.TraceID(ctx))
 }
+
+func (suite *TraceIDSuite) TestWithTraceIDGenerator() {
+	defer metadata.WithTraceIDGenerator(nil)
+
+	metadata.WithTraceIDGenerator(func() string { return "00-abide-01" })
+	suite.Equal("00-abide-01", metadata.NewTraceID())
+
+	metadata.WithTraceIDGenerator(nil)
+	suite.Len(metadata.NewTraceID(), 24, "Clearing the generator should restore the default ids")
+}
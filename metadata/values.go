@@ -45,6 +45,16 @@ type valuesEntry struct {
 func (v *valuesEntry) MarshalJSON() ([]byte, error) {
 	buf := &bytes.Buffer{}
 	buf.WriteString(`{"value":`)
+
+	// This entry came from another service, and nobody has looked it up via Value() yet, so we
+	// still only have the raw JSON. Pass that along as-is. Otherwise, we'd encode the nil Value
+	// and the next hop (e.g. an event handler triggered by this call) would receive a "null".
+	if v.Value == nil && v.JSON != "" {
+		buf.WriteString(v.JSON)
+		buf.WriteString(`}`)
+		return buf.Bytes(), nil
+	}
+
 	err := json.NewEncoder(buf).Encode(v.Value)
 	if err != nil {
 		return nil, err
@@ -139,3 +149,29 @@ func WithValue(ctx context.Context, key string, value any) context.Context {
 	entries[key] = &valuesEntry{Value: value}
 	return ctx
 }
+
+// WithTypedValue is a type-safe version of WithValue(). It's handy for structured values like a tenant that
+// need to follow your calls from service to service (and into event handlers) without you manually encoding
+// them into a string and back again:
+//
+//	ctx = metadata.WithTypedValue(ctx, "tenant", Tenant{ID: "123", Region: "us-east-1", Plan: "pro"})
+//
+// Values travel in the X-RPC-Metadata header as JSON, so the type must survive a trip through encoding/json.
+// If your type needs a special wire format, implement json.Marshaler and json.Unmarshaler on it.
+func WithTypedValue[T any](ctx context.Context, key string, value T) context.Context {
+	return WithValue(ctx, key, value)
+}
+
+// TypedValue is a type-safe version of Value(). Rather than filling in an 'out' pointer, it returns the value
+// stored under the given key. The boolean is false if there's no value for that key or it's not a T, in which
+// case you get T's zero value:
+//
+//	tenant, ok := metadata.TypedValue[Tenant](ctx, "tenant")
+func TypedValue[T any](ctx context.Context, key string) (T, bool) {
+	var out T
+	if !Value(ctx, key, &out) {
+		var zero T
+		return zero, false
+	}
+	return out, true
+}
@@ -116,6 +116,59 @@ func (suite *ValuesSuite) TestTypes() {
 // in any sub-contexts. This is how we make it so that you can set meta values in your
 // service handlers, and they'll be available in RPC/Event calls to other services
 // even though they might be fired using outer contexts.
+type tenant struct {
+	ID     string
+	Region string
+	Plan   string
+}
+
+func (suite *ValuesSuite) TestTypedValue() {
+	ctx := context.Background()
+	ctx = metadata.WithTypedValue(ctx, "tenant", tenant{ID: "123", Region: "us-east-1", Plan: "pro"})
+	ctx = metadata.WithTypedValue(ctx, "tenantPointer", &tenant{ID: "456"})
+	ctx = metadata.WithTypedValue(ctx, "count", 42)
+
+	value, ok := metadata.TypedValue[tenant](ctx, "tenant")
+	suite.True(ok)
+	suite.Equal(tenant{ID: "123", Region: "us-east-1", Plan: "pro"}, value)
+
+	pointer, ok := metadata.TypedValue[*tenant](ctx, "tenantPointer")
+	suite.True(ok)
+	suite.Equal(&tenant{ID: "456"}, pointer)
+
+	count, ok := metadata.TypedValue[int](ctx, "count")
+	suite.True(ok)
+	suite.Equal(42, count)
+
+	value, ok = metadata.TypedValue[tenant](ctx, "nope")
+	suite.False(ok)
+	suite.Equal(tenant{}, value)
+
+	value, ok = metadata.TypedValue[tenant](ctx, "count")
+	suite.False(ok, "Wrong type should not be ok")
+	suite.Equal(tenant{}, value)
+}
+
+// Typed values should survive the trip to another service (and then on to the next hop, like an event handler).
+func (suite *ValuesSuite) TestTypedValue_roundTrip() {
+	ctx := metadata.WithTypedValue(context.Background(), "tenant", tenant{ID: "123", Region: "us-east-1", Plan: "pro"})
+
+	remoteCtx := metadata.Decode(context.Background(), metadata.Encode(ctx))
+	eventCtx := metadata.Decode(context.Background(), metadata.Encode(remoteCtx))
+
+	value, ok := metadata.TypedValue[tenant](remoteCtx, "tenant")
+	suite.True(ok)
+	suite.Equal(tenant{ID: "123", Region: "us-east-1", Plan: "pro"}, value)
+
+	value, ok = metadata.TypedValue[tenant](remoteCtx, "tenant")
+	suite.True(ok, "Should still work once we've decoded the value")
+	suite.Equal(tenant{ID: "123", Region: "us-east-1", Plan: "pro"}, value)
+
+	value, ok = metadata.TypedValue[tenant](eventCtx, "tenant")
+	suite.True(ok)
+	suite.Equal(tenant{ID: "123", Region: "us-east-1", Plan: "pro"}, value)
+}
+
 func (suite *ValuesSuite) TestMutable() {
 	base := metadata.WithValue(context.Background(), "Foo", "A")
 
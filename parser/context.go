@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 	"unicode"
@@ -134,6 +135,15 @@ type ServiceFunctionDeclaration struct {
 	// Roles defines the role-based security identifiers that a user/principal must have in order to access
 	// this endpoint. These can be exact values like "admin.write" or parameterized like "group.{Group.ID}.write".
 	Roles []string
+	// RequireAllRoles is true when the "ROLES ALL" option says that a caller needs every one of the Roles rather
+	// than just one of them.
+	RequireAllRoles bool
+	// Deprecated indicates that this operation is being retired (see the "DEPRECATED" doc option), so callers
+	// should stop using it.
+	Deprecated bool
+	// Sunset is the date (formatted "2006-01-02") when a Deprecated operation is expected to stop working. It's
+	// empty when the operation isn't deprecated or you didn't tell us when it's going away.
+	Sunset string
 	// Documentation are all of the comments documenting this operation.
 	Documentation DocumentationLines
 	// Service represents the interface/service that this function belongs to.
@@ -395,6 +405,12 @@ type GatewayServiceOptions struct {
 	Service *ServiceDeclaration
 	// PathPrefix is the optional version/domain prefix for all endpoints in the API (e.g. "v2/").
 	PathPrefix string
+	// AllowMethods are the HTTP methods from the service's "HTTP ALLOW" option that every endpoint should
+	// support by default (e.g. HEAD and OPTIONS). Endpoints can override this with their own "HTTP ALLOW".
+	AllowMethods []string
+	// CacheControl is the default Cache-Control header from the service's "HTTP CACHE" option for successful
+	// responses. Endpoints can override this with their own "HTTP CACHE".
+	CacheControl string
 }
 
 // GatewayRoutes manages the collection of all possible routes that you can register to
@@ -471,8 +487,17 @@ type GatewayRoute struct {
 	Status int
 	// Group provides additional routing/grouping info that means different things to different gateways.
 	Group string
+	// Weight is the relative share of a consumer group's events that this subscriber should receive when the
+	// broker supports it (e.g. "ON FooService.Bar GROUP Hug WEIGHT 3"). Zero means that you didn't specify one.
+	Weight int
 	// RouteType describes how the gateway or client should handle implementation of this endpoint (e.g. REST request vs websocket).
 	RouteType RouteType
+	// AllowMethods are the extra HTTP behaviors that the API gateway should provide for this route (e.g. "HEAD"
+	// and "OPTIONS") based on the "HTTP ALLOW" option of the function or its service.
+	AllowMethods []string
+	// CacheControl is the Cache-Control header the API gateway includes on successful responses based on the
+	// "HTTP CACHE" option of the function or its service. It's empty when neither one specified it.
+	CacheControl string
 }
 
 // QualifiedPath returns the route's path with the service's PathPrefix prepended to it. This includes a leading "/"
@@ -495,7 +520,8 @@ func (route *GatewayRoute) QualifiedPath() string {
 }
 
 // ParsePathParams extracts a slice of the path parameter names from the Path of this route. For instance the
-// path "/user/{UserID}/transaction/{TransactionID}" will parse to []string{"UserID", "TransactionID"}.
+// path "/user/{UserID}/transaction/{TransactionID}" will parse to []string{"UserID", "TransactionID"}. Wildcard
+// params don't include the trailing "...", so "/files/{Path...}" parses to []string{"Path"}.
 func (route *GatewayRoute) ParsePathParams() []string {
 	var params []string
 	segments := strings.Split(strings.TrimSpace(route.Path), "/")
@@ -505,7 +531,7 @@ func (route *GatewayRoute) ParsePathParams() []string {
 		}
 
 		param := strings.TrimPrefix(strings.TrimSuffix(segment, "}"), "{")
-		params = append(params, param)
+		params = append(params, strings.TrimSuffix(param, "..."))
 	}
 	return params
 }
@@ -636,6 +662,10 @@ type TypeDeclaration struct {
 	Fields FieldDeclarations
 	// Documentation are all of the comments documenting this operation.
 	Documentation DocumentationLines
+	// EnumValues contains the allowed values for enum-style string types (e.g. "type Status string") that opt in
+	// by declaring a "Values() []Status" method. We populate this using all of the constants of this type declared
+	// in the same package as the type.
+	EnumValues []string
 	// Implements contains some quick checks for whether this type implements the various
 	// single function interfaces used to handle raw data responses.
 	Implements struct {
@@ -697,6 +727,29 @@ func (t TypeDeclaration) PrimitiveLike() bool {
 		t.Kind == reflect.Float64
 }
 
+// Enum returns true for string types that have a fixed set of allowed values (see EnumValues).
+func (t TypeDeclaration) Enum() bool {
+	return len(t.EnumValues) > 0
+}
+
+// EnumFields returns the subset of non-omitted fields whose type is an enum. We only include fields declared
+// directly on this struct (or promoted from an embedded struct value) so that code generated to check them
+// never has to dereference a nil, embedded struct pointer.
+func (t TypeDeclaration) EnumFields() FieldDeclarations {
+	structType, ok := underlyingStruct(t.Type)
+	if !ok {
+		return nil
+	}
+
+	var results FieldDeclarations
+	for _, f := range t.NonOmittedFields() {
+		if f.Type.Enum() && directFieldAccess(structType, f.Name) {
+			results = append(results, f)
+		}
+	}
+	return results
+}
+
 // ObjectLike returns true for types that represent some sort complex object (i.e. struct/interface).
 func (t TypeDeclaration) ObjectLike() bool {
 	return t.Kind == reflect.Struct || t.Kind == reflect.Interface
@@ -750,17 +803,32 @@ func (reg TypeRegistry) WithoutInvalid() TypeRegistry {
 }
 
 // NonBasicTypes returns a slice containing only types not declared as "Basic". This way you only
-// iterate complex types that you defined or imported.
+// iterate complex types that you defined or imported. The types are sorted by name, so generating
+// an artifact from the same service definition always produces the same output.
 func (reg TypeRegistry) NonBasicTypes() []*TypeDeclaration {
-	var results []*TypeDeclaration
-	for _, t := range reg {
+	var keys []string
+	for key, t := range reg {
 		if t.Basic {
 			continue
 		}
 		if t.Kind == reflect.Interface {
 			continue
 		}
-		results = append(results, t)
+		keys = append(keys, key)
+	}
+
+	// The keys are unique, so they break the tie when two types from different packages share a name.
+	sort.Slice(keys, func(i, j int) bool {
+		nameI, nameJ := reg[keys[i]].Name, reg[keys[j]].Name
+		if nameI != nameJ {
+			return nameI < nameJ
+		}
+		return keys[i] < keys[j]
+	})
+
+	results := make([]*TypeDeclaration, len(keys))
+	for i, key := range keys {
+		results[i] = reg[key]
 	}
 	return results
 }
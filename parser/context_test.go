@@ -4,6 +4,7 @@ package parser_test
 
 import (
 	"go/ast"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -586,6 +587,25 @@ func (suite *ContextSuite) TestContext_timestampString() {
 	suite.Equal("Fri, 24 Sep 2021 14:44:42 EDT", ctx.TimestampString())
 }
 
+func (suite *ContextSuite) TestTypeRegistry_NonBasicTypes() {
+	reg := parser.NewTypeRegistry()
+	reg["b.zebra"] = &parser.TypeDeclaration{Name: "Zebra", Kind: reflect.Struct}
+	reg["b.apple"] = &parser.TypeDeclaration{Name: "Apple", Kind: reflect.Struct}
+	reg["a.apple"] = &parser.TypeDeclaration{Name: "Apple", Kind: reflect.Struct}
+	reg["mango"] = &parser.TypeDeclaration{Name: "Mango", Kind: reflect.Slice}
+	reg["fruit"] = &parser.TypeDeclaration{Name: "Fruit", Kind: reflect.Interface}
+
+	// Run it a bunch of times since map iteration order is random; we want the same result every time.
+	for i := 0; i < 20; i++ {
+		types := reg.NonBasicTypes()
+		suite.Require().Len(types, 4)
+		suite.Same(reg["a.apple"], types[0])
+		suite.Same(reg["b.apple"], types[1])
+		suite.Same(reg["mango"], types[2])
+		suite.Same(reg["b.zebra"], types[3])
+	}
+}
+
 func TestContextSuite(t *testing.T) {
 	suite.Run(t, new(ContextSuite))
 }
@@ -3,6 +3,7 @@ package parser
 import (
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/doc"
 	"go/parser"
 	"go/token"
@@ -12,11 +13,14 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/internal/cron"
 	"github.com/bridgekit-io/frodo/internal/implements"
 	"github.com/bridgekit-io/frodo/internal/naming"
 	"github.com/bridgekit-io/frodo/internal/slices"
@@ -55,6 +59,12 @@ var ErrTypeNotTwoParams = fmt.Errorf("must have two params")
 // ErrTypeNotTwoReturns is the error for when your function signature doesn't return two values.
 var ErrTypeNotTwoReturns = fmt.Errorf("must have two return values")
 
+// ErrPathParamNotFound is the error for when a route's path has a "{param}" that doesn't match any request field.
+var ErrPathParamNotFound = fmt.Errorf("path parameter does not match a field on the request struct")
+
+// ErrPathWildcardNotLast is the error for when a route's path has a "{param...}" wildcard that isn't the last segment.
+var ErrPathWildcardNotLast = fmt.Errorf("wildcard path parameter must be the last segment of the path")
+
 // InvalidType is the type instance used by the AST parser to indicate types that the parser couldn't resolve.
 var InvalidType = types.Typ[0]
 
@@ -213,6 +223,13 @@ func registerTypeEntry(ctx *Context, registry TypeRegistry, entry *TypeDeclarati
 		entry.Implements.ContentRangeSetter = implements.Method(tt, "SetContentRange", []string{"int", "int", "int"}, nil)
 		entry.Implements.ContentFileNameSetter = implements.Method(tt, "SetContentFileName", []string{"string"}, nil)
 
+		// String types like "type Status string" behave like enums when you declare constants for them and
+		// opt in by giving the type a "Values() []Status" method. Plenty of string types have a few well-known
+		// constants (e.g. default values) without wanting to reject everything else, so we don't assume.
+		if entry.Kind == reflect.String && implements.Method(tt, "Values", nil, []string{"[]" + tt.String()}) {
+			entry.EnumValues = parseEnumValues(tt)
+		}
+
 	case *types.Array:
 		entry.Basic = entry.Type == t
 		entry.Kind = reflect.Array
@@ -245,6 +262,47 @@ func registerTypeEntry(ctx *Context, registry TypeRegistry, entry *TypeDeclarati
 	return err
 }
 
+// parseEnumValues finds all of the constants of the given named type that are declared in the same package as the
+// type itself. The values are in the order that they were declared, so documentation matches your source code.
+//
+//	type Status string
+//
+//	const (
+//		StatusActive Status = "active"
+//		StatusPaused Status = "paused"
+//	)
+//
+//	func (Status) Values() []Status {
+//		return []Status{StatusActive, StatusPaused}
+//	}
+func parseEnumValues(t *types.Named) []string {
+	if t.Obj().Pkg() == nil {
+		return nil
+	}
+
+	scope := t.Obj().Pkg().Scope()
+	var constants []*types.Const
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), t) || c.Val().Kind() != constant.String {
+			continue
+		}
+		constants = append(constants, c)
+	}
+	sort.SliceStable(constants, func(i, j int) bool {
+		return constants[i].Pos() < constants[j].Pos()
+	})
+
+	var values []string
+	for _, c := range constants {
+		value := constant.StringVal(c.Val())
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func parseStructFields(ctx *Context, registry TypeRegistry, model *TypeDeclaration, structType *types.Struct) {
 	for _, structField := range flattenedStructFields(structType) {
 		fieldDecl := parseStructField(ctx, registry, model, structField)
@@ -596,9 +654,37 @@ func ParseServiceFunction(ctx *Context, service *ServiceDeclaration, funcType *t
 	}
 
 	ApplyFunctionDocumentation(ctx, function)
+	if err := validatePathParams(function); err != nil {
+		return nil, err
+	}
 	return function, nil
 }
 
+// validatePathParams makes sure that every "{param}" in the function's API route path refers to a field that
+// actually exists on the request struct. At runtime, a path parameter that doesn't match anything is silently
+// ignored, so renaming a field would quietly break the route. We'd much rather you find out when you generate.
+func validatePathParams(function *ServiceFunctionDeclaration) error {
+	route := function.Routes.API()
+	if route == nil {
+		return nil
+	}
+	for _, param := range route.ParsePathParams() {
+		if function.Request.Fields.ByBindingName(param) == nil {
+			return fmt.Errorf("%s.%s(): %s {%s}: %w", function.Service.Name, function.Name, route.Path, param, ErrPathParamNotFound)
+		}
+	}
+
+	// The router only lets a "{param...}" wildcard capture the remainder of the path, so it can't be followed by
+	// anything else. We'd rather tell you now than have the gateway panic when it registers the route.
+	segments := strings.Split(strings.Trim(route.Path, "/"), "/")
+	for i, segment := range segments {
+		if naming.IsWildcardPathVariable(segment) && i < len(segments)-1 {
+			return fmt.Errorf("%s.%s(): %s %s: %w", function.Service.Name, function.Name, route.Path, segment, ErrPathWildcardNotLast)
+		}
+	}
+	return nil
+}
+
 func flattenedStructFields(structType *types.Struct) []*types.Var {
 	var fields []*types.Var
 	for i := 0; i < structType.NumFields(); i++ {
@@ -725,6 +811,24 @@ func underlyingPointer(t types.Type) (*types.Pointer, bool) {
 	}
 }
 
+// directFieldAccess returns true when "value.FieldName" can never panic; the field is either declared directly
+// on the struct or promoted from embedded struct values (not pointers).
+func directFieldAccess(structType *types.Struct, fieldName string) bool {
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		if field.Name() == fieldName {
+			return true
+		}
+		if !field.Embedded() || pointerType(field.Type()) {
+			continue
+		}
+		if embeddedStruct, ok := underlyingStruct(field.Type()); ok && directFieldAccess(embeddedStruct, fieldName) {
+			return true
+		}
+	}
+	return false
+}
+
 func underlyingStruct(t types.Type) (*types.Struct, bool) {
 	switch typed := t.(type) {
 	case *types.Struct:
@@ -764,6 +868,10 @@ func ApplyServiceDocumentation(ctx *Context, service *ServiceDeclaration) *Servi
 			service.Gateway.PathPrefix = normalizePath(line[7:])
 		case strings.HasPrefix(line, "VERSION "):
 			service.Version = strings.TrimSpace(line[8:])
+		case strings.HasPrefix(line, "HTTP ALLOW "):
+			service.Gateway.AllowMethods = parseOptionALLOW(line)
+		case strings.HasPrefix(line, "HTTP CACHE "):
+			service.Gateway.CacheControl = strings.TrimSpace(line[11:])
 		default:
 			service.Documentation = append(service.Documentation, line)
 		}
@@ -789,6 +897,11 @@ func ApplyFunctionDocumentation(ctx *Context, function *ServiceFunctionDeclarati
 		Status:      200,
 		RouteType:   RouteTypeDefault,
 	}
+	// Service-wide HTTP behaviors apply to every endpoint unless the function has its own version of the option.
+	if function.Service != nil && function.Service.Gateway != nil {
+		apiRoute.AllowMethods = function.Service.Gateway.AllowMethods
+		apiRoute.CacheControl = function.Service.Gateway.CacheControl
+	}
 	function.Routes = append(function.Routes, &apiRoute)
 
 	// Notice that "OPTIONS /" is not one of the cases. That's by design. When the gateway
@@ -825,6 +938,10 @@ func ApplyFunctionDocumentation(ctx *Context, function *ServiceFunctionDeclarati
 			// like that, the "GET " option will still fire, but it will just update the
 			// apiRoute variable harmlessly since it's no longer in the routes list.
 			function.Routes = slices.Remove(function.Routes, &apiRoute)
+		case strings.HasPrefix(line, "HTTP ALLOW "):
+			apiRoute.AllowMethods = parseOptionALLOW(line)
+		case strings.HasPrefix(line, "HTTP CACHE "):
+			apiRoute.CacheControl = strings.TrimSpace(line[11:])
 		case strings.HasPrefix(line, "HTTP "):
 			apiRoute.Status = parseHTTPStatus(line[5:])
 
@@ -847,13 +964,19 @@ func ApplyFunctionDocumentation(ctx *Context, function *ServiceFunctionDeclarati
 			if route := parseOptionON(ctx, function, line); route != nil {
 				function.Routes = append(function.Routes, route)
 			}
+		case strings.HasPrefix(line, "SCHEDULE "):
+			if route := parseOptionSCHEDULE(ctx, function, line); route != nil {
+				function.Routes = append(function.Routes, route)
+			}
 
 		//
 		// General purpose options (like for security/metadata)
 		//
 		case strings.HasPrefix(line, "ROLES "):
-			roles := strings.Split(strings.TrimSpace(line[6:]), ",")
-			function.Roles = slices.Map(roles, strings.TrimSpace)
+			function.Roles, function.RequireAllRoles = parseOptionROLES(line)
+		case line == "DEPRECATED" || strings.HasPrefix(line, "DEPRECATED "):
+			function.Deprecated = true
+			function.Sunset = parseOptionDEPRECATED(line)
 
 		default:
 			function.Documentation = append(function.Documentation, line)
@@ -862,17 +985,119 @@ func ApplyFunctionDocumentation(ctx *Context, function *ServiceFunctionDeclarati
 	function.Documentation = function.Documentation.Trim()
 }
 
+// parseOptionALLOW returns the methods from an "HTTP ALLOW HEAD, OPTIONS" option. Only HEAD and OPTIONS change
+// how the gateway behaves, but you can list your other methods, too, so that they show up in the "Allow" header
+// of OPTIONS responses. Methods we don't recognize are ignored.
+func parseOptionALLOW(line string) []string {
+	var methods []string
+	for _, method := range strings.FieldsFunc(line[11:], func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		method = strings.ToUpper(method)
+		switch {
+		case !isHTTPMethod(method):
+			log.Println("Warning: invalid HTTP ALLOW doc option format: '" + line + "': unknown method '" + method + "'")
+		case !slices.Contains(methods, method):
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+func isHTTPMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseOptionDEPRECATED returns the sunset date from a "DEPRECATED 2025-06-01" option, or "" if you didn't include
+// one. A date that we can't parse is ignored, but the function is still deprecated; it just has no sunset.
+func parseOptionDEPRECATED(line string) string {
+	sunset := strings.TrimSpace(strings.TrimPrefix(line, "DEPRECATED"))
+	if sunset == "" {
+		return ""
+	}
+	if _, err := time.Parse(time.DateOnly, sunset); err != nil {
+		log.Println("Warning: invalid DEPRECATED doc option format: '" + line + "': sunset must be a date like 2025-06-01")
+		return ""
+	}
+	return sunset
+}
+
+// parseOptionROLES parses the "ROLES" doc option, returning the list of role patterns and whether the caller needs
+// all of them rather than just one. The list can start with an "ALL" or "ANY" qualifier, with "ANY" being the default:
+//
+//	ROLES admin.write, group.{ID}.write
+//	ROLES ALL billing.read, tenant.{TenantID}.access
+//
+// The qualifier is just the first word when a space follows it, so a role that's literally named "ALL" or "ANY"
+// gets swallowed as the qualifier whenever there's a space after it (e.g. "ROLES ALL , admin"). When you have a
+// role with one of those names, put an explicit qualifier in front of it to be safe: "ROLES ANY ALL, admin".
+func parseOptionROLES(line string) ([]string, bool) {
+	value := strings.TrimSpace(strings.TrimPrefix(line, "ROLES "))
+
+	requireAll := false
+	if qualifier, rest, ok := strings.Cut(value, " "); ok {
+		switch qualifier {
+		case "ALL":
+			requireAll = true
+			value = rest
+		case "ANY":
+			value = rest
+		}
+	}
+
+	roles := strings.Split(strings.TrimSpace(value), ",")
+	return slices.Map(roles, strings.TrimSpace), requireAll
+}
+
 func parseOptionON(_ *Context, function *ServiceFunctionDeclaration, line string) *GatewayRoute {
 	tokens := strings.Fields(strings.TrimSpace(line))
-	switch {
-	case len(tokens) == 2 && tokens[0] == "ON":
-		return &GatewayRoute{Function: function, GatewayType: "EVENTS", Method: "ON", Path: tokens[1], Group: ""}
-	case len(tokens) == 4 && tokens[0] == "ON" && tokens[2] == "GROUP":
-		return &GatewayRoute{Function: function, GatewayType: "EVENTS", Method: "ON", Path: tokens[1], Group: tokens[3]}
-	default:
+	if len(tokens) < 2 || tokens[0] != "ON" {
 		log.Println("Warning: invalid ON doc option format: '" + line + "'")
 		return nil
 	}
+
+	// Everything after the key is an optional "GROUP Xxx" and/or "WEIGHT N" pair.
+	route := &GatewayRoute{Function: function, GatewayType: "EVENTS", Method: "ON", Path: tokens[1]}
+	for i := 2; i < len(tokens); i += 2 {
+		if i+1 >= len(tokens) {
+			log.Println("Warning: invalid ON doc option format: '" + line + "'")
+			return nil
+		}
+
+		switch tokens[i] {
+		case "GROUP":
+			route.Group = tokens[i+1]
+		case "WEIGHT":
+			weight, err := strconv.Atoi(tokens[i+1])
+			if err != nil || weight <= 0 {
+				log.Println("Warning: invalid ON doc option format: '" + line + "': WEIGHT must be a positive integer")
+				return nil
+			}
+			route.Weight = weight
+		default:
+			log.Println("Warning: invalid ON doc option format: '" + line + "'")
+			return nil
+		}
+	}
+
+	// "GROUP *" means that every instance gets every event, so there's no group to share the load with.
+	if route.Group == "*" && route.Weight > 0 {
+		log.Println("Warning: invalid ON doc option format: '" + line + "': WEIGHT doesn't apply to GROUP *")
+		return nil
+	}
+	return route
+}
+
+func parseOptionSCHEDULE(_ *Context, function *ServiceFunctionDeclaration, line string) *GatewayRoute {
+	expression := strings.TrimSpace(line[9:])
+	if _, err := cron.Parse(expression); err != nil {
+		log.Println("Warning: invalid SCHEDULE doc option format: '" + line + "': " + err.Error())
+		return nil
+	}
+	return &GatewayRoute{Function: function, GatewayType: "EVENTS", Method: "SCHEDULE", Path: expression}
 }
 
 // ApplyTypeDocumentation takes the documentation comment block above your struct/alias type
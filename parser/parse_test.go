@@ -55,7 +55,7 @@ func (suite *ParserSuite) TestDocOptions() {
 		Name:         "LebowskiService",
 		Version:      "999.12",
 		PathPrefix:   "/big",
-		NumFunctions: 11,
+		NumFunctions: 14,
 	})
 
 	suite.assertFunction(service, "Dude", expectedFunction{
@@ -72,6 +72,8 @@ func (suite *ParserSuite) TestDocOptions() {
 		Routes: parser.GatewayRoutes{
 			&parser.GatewayRoute{GatewayType: "API", Method: "POST", Path: "/LebowskiService.Walter", Status: 200},
 		},
+		Roles:           []string{"admin.write", "dude.{id}.write"},
+		RequireAllRoles: true,
 	})
 
 	suite.assertFunction(service, "Donny", expectedFunction{
@@ -79,6 +81,7 @@ func (suite *ParserSuite) TestDocOptions() {
 		Routes: parser.GatewayRoutes{
 			&parser.GatewayRoute{GatewayType: "API", Method: "POST", Path: "/LebowskiService.Donny", Status: 204},
 		},
+		Roles: []string{"admin.write", "dude.{id}.write"},
 	})
 
 	suite.assertFunction(service, "Maude", expectedFunction{
@@ -86,6 +89,7 @@ func (suite *ParserSuite) TestDocOptions() {
 		Routes: parser.GatewayRoutes{
 			&parser.GatewayRoute{GatewayType: "API", Method: "POST", Path: "/dude/{id}/child", Status: 201},
 		},
+		Deprecated: true,
 	})
 
 	suite.assertFunction(service, "Jackie", expectedFunction{
@@ -93,6 +97,8 @@ func (suite *ParserSuite) TestDocOptions() {
 		Routes: parser.GatewayRoutes{
 			&parser.GatewayRoute{GatewayType: "API", Method: "PUT", Path: "/dude/jail", Status: 200},
 		},
+		Deprecated: true,
+		Sunset:     "2025-06-01",
 	})
 
 	suite.assertFunction(service, "Stranger", expectedFunction{
@@ -104,6 +110,8 @@ func (suite *ParserSuite) TestDocOptions() {
 		Routes: parser.GatewayRoutes{
 			&parser.GatewayRoute{GatewayType: "API", Method: "PATCH", Path: "/dude/{id}", Status: 200},
 		},
+		// The sunset isn't a valid date, but we still know that you want to retire it.
+		Deprecated: true,
 	})
 
 	suite.assertFunction(service, "RemoveToe", expectedFunction{
@@ -113,6 +121,18 @@ func (suite *ParserSuite) TestDocOptions() {
 		Routes: parser.GatewayRoutes{
 			&parser.GatewayRoute{GatewayType: "API", Method: "DELETE", Path: "/nihilist/{id}/toe", Status: 200},
 		},
+		Roles: []string{"ALL"}, // no roles after it, so it must be the name of a role
+	})
+
+	suite.assertFunction(service, "Nihilist", expectedFunction{
+		Documentation: parser.DocumentationLines{
+			"Nihilists believe in nothing, but they still believe in the ALL role.",
+		},
+		Routes: parser.GatewayRoutes{
+			&parser.GatewayRoute{GatewayType: "API", Method: "POST", Path: "/LebowskiService.Nihilist", Status: 200},
+		},
+		Roles:           []string{"ALL", "admin.write"}, // the leading ANY is the qualifier, so ALL is a role
+		RequireAllRoles: false,
 	})
 
 	suite.assertFunction(service, "Rug", expectedFunction{
@@ -153,6 +173,30 @@ func (suite *ParserSuite) TestDocOptions() {
 			&parser.GatewayRoute{GatewayType: "EVENTS", Method: "ON", Path: "LebowskiService.Walter"},
 		},
 	})
+
+	suite.assertFunction(service, "Sleep", expectedFunction{
+		Documentation: parser.DocumentationLines{
+			"Sleep runs every night, but it's still available through the API.",
+		},
+		Routes: parser.GatewayRoutes{
+			// The invalid cron expression is ignored.
+			&parser.GatewayRoute{GatewayType: "API", Method: "POST", Path: "/LebowskiService.Sleep", Status: 200},
+			&parser.GatewayRoute{GatewayType: "EVENTS", Method: "SCHEDULE", Path: "0 2 * * *"},
+			&parser.GatewayRoute{GatewayType: "EVENTS", Method: "SCHEDULE", Path: "@hourly"},
+		},
+	})
+
+	suite.assertFunction(service, "Strike", expectedFunction{
+		Documentation: parser.DocumentationLines{
+			"Strike takes a bigger share of the league's events.",
+		},
+		Routes: parser.GatewayRoutes{
+			// Weights only apply to real groups, and they have to be positive numbers.
+			&parser.GatewayRoute{GatewayType: "EVENTS", Method: "ON", Path: "LebowskiService.Dude", Group: "League", Weight: 3},
+			&parser.GatewayRoute{GatewayType: "EVENTS", Method: "ON", Path: "LebowskiService.Walter", Weight: 2},
+			&parser.GatewayRoute{GatewayType: "EVENTS", Method: "ON", Path: "LebowskiService.Donny", Group: "*"},
+		},
+	})
 }
 
 func (suite *ParserSuite) TestBindingOptions() {
@@ -244,6 +288,65 @@ func (suite *ParserSuite) TestFieldTypes() {
 	suite.Require().Nil(fields.ByName("notExported"))
 }
 
+// Ensures that we treat string types w/ constants as enums, and we can find all of the fields
+// that generated code should validate.
+func (suite *ParserSuite) TestEnums() {
+	ctx, err := parser.ParseFile("testdata/enums/service.go")
+	suite.Require().NoError(err)
+
+	status, _ := ctx.Types.LookupByName("Status")
+	suite.Require().NotNil(status, "Type registry should have 'Status' type")
+	suite.True(status.Enum())
+	suite.Equal([]string{"paused", "active", "archived"}, status.EnumValues, "Values should be unique and in declaration order")
+
+	label, _ := ctx.Types.LookupByName("Label")
+	suite.Require().NotNil(label, "Type registry should have 'Label' type")
+	suite.False(label.Enum(), "String types w/o constants are not enums")
+	suite.Empty(label.EnumValues)
+
+	priority, _ := ctx.Types.LookupByName("Priority")
+	suite.Require().NotNil(priority, "Type registry should have 'Priority' type")
+	suite.False(priority.Enum(), "String types w/ constants but no Values() method are not enums")
+	suite.Empty(priority.EnumValues)
+
+	request, _ := ctx.Types.LookupByName("PauseRequest")
+	suite.Require().NotNil(request, "Type registry should have 'PauseRequest' type")
+
+	var fieldNames []string
+	for _, field := range request.EnumFields() {
+		fieldNames = append(fieldNames, field.Name)
+	}
+	suite.ElementsMatch([]string{"EmbeddedStatus", "Status", "StatusPointer"}, fieldNames,
+		"Should only include non-omitted enum fields that are not promoted through embedded pointers")
+
+	response, _ := ctx.Types.LookupByName("PauseResponse")
+	suite.Require().NotNil(response, "Type registry should have 'PauseResponse' type")
+	suite.Len(response.EnumFields(), 1)
+}
+
+// Ensures that service-wide HTTP options apply to every endpoint unless the function has its own.
+func (suite *ParserSuite) TestHTTPOptions() {
+	ctx, err := parser.ParseFile("testdata/httpoptions/service.go")
+	suite.Require().NoError(err)
+
+	service := ctx.Service
+	suite.Equal([]string{"HEAD", "OPTIONS", "GET"}, service.Gateway.AllowMethods, "Should normalize, de-dupe, and skip bogus methods")
+	suite.Equal("public, max-age=60", service.Gateway.CacheControl)
+	suite.Equal(parser.DocumentationLines{"ArchiveService serves old bowling league records."}, service.Documentation)
+
+	route := service.FunctionByName("Scores").Routes.API()
+	suite.Equal([]string{"HEAD", "OPTIONS", "GET"}, route.AllowMethods)
+	suite.Equal("public, max-age=60", route.CacheControl)
+
+	route = service.FunctionByName("Standings").Routes.API()
+	suite.Equal([]string{"GET", "OPTIONS"}, route.AllowMethods, "Function options should win")
+	suite.Equal("no-store", route.CacheControl, "Function options should win")
+
+	route = service.FunctionByName("Record").Routes.API()
+	suite.Equal(201, route.Status, "Should still parse the status")
+	suite.Equal("public, max-age=60", route.CacheControl)
+}
+
 // Ensures that you can only have one service defined in the same file.
 func (suite *ParserSuite) TestMultiService() {
 	_, err := parser.ParseFile("testdata/multiservice/service.go")
@@ -324,6 +427,26 @@ func (suite *ParserSuite) TestErrorResponseNotStruct() {
 	suite.Require().Contains(err.Error(), "error", "Error should mention the need for an error return value")
 }
 
+// Ensure that we fail generation when a route's path refers to a request field that doesn't exist rather than
+// letting the gateway silently ignore the path parameter at runtime.
+func (suite *ParserSuite) TestErrorPathParamNotFound() {
+	_, err := parser.ParseFile("testdata/errors/pathparam/service.go")
+	suite.Require().Error(err, "Should fail when a path parameter doesn't match a request field")
+	suite.Require().ErrorIs(err, parser.ErrPathParamNotFound)
+	suite.Require().Contains(err.Error(), "FooService.Goodbye()", "Error should mention the offending function")
+	suite.Require().Contains(err.Error(), "{UserID}", "Error should mention the offending parameter")
+}
+
+// Ensure that we fail generation when a "{param...}" wildcard isn't the last segment of the path, since the
+// router would panic trying to register the route.
+func (suite *ParserSuite) TestErrorPathWildcardNotLast() {
+	_, err := parser.ParseFile("testdata/errors/pathwildcard/service.go")
+	suite.Require().Error(err, "Should fail when a wildcard path parameter isn't the last segment")
+	suite.Require().ErrorIs(err, parser.ErrPathWildcardNotLast)
+	suite.Require().Contains(err.Error(), "FooService.Metadata()", "Error should mention the offending function")
+	suite.Require().Contains(err.Error(), "{Path...}", "Error should mention the offending parameter")
+}
+
 /*
  * ----------- Assertion Helpers ----------------------
  */
@@ -354,6 +477,10 @@ func (suite *ParserSuite) assertFunction(service *parser.ServiceDeclaration, fun
 	suite.Require().Equal(functionName, f.Name, "%s: Incorrect name", name)
 	suite.Require().Equal(service, f.Service, "%s: Incorrect service back-pointer", name)
 	suite.Require().Equal(expected.Documentation.String(), f.Documentation.String(), "%s: Incorrect documentation", name)
+	suite.Equal(expected.Deprecated, f.Deprecated, "%s: Incorrect deprecation", name)
+	suite.Equal(expected.Sunset, f.Sunset, "%s: Incorrect sunset", name)
+	suite.Equal(expected.Roles, f.Roles, "%s: Incorrect roles", name)
+	suite.Equal(expected.RequireAllRoles, f.RequireAllRoles, "%s: Incorrect roles qualifier", name)
 
 	apiRoute := f.Routes.API()
 	switch expectedRoute := expected.Routes.API(); expectedRoute {
@@ -375,6 +502,8 @@ func (suite *ParserSuite) assertFunction(service *parser.ServiceDeclaration, fun
 		suite.Equal(f, events[i].Function, "%s: Event Route: Incorrect function back-pointer", name)
 		suite.Equal(expectedEvent.Path, events[i].Path, "%s: Event Route: Incorrect path", name)
 		suite.Equal(expectedEvent.Method, events[i].Method, "%s: Event Route: Incorrect method", name)
+		suite.Equal(expectedEvent.Group, events[i].Group, "%s: Event Route: Incorrect group", name)
+		suite.Equal(expectedEvent.Weight, events[i].Weight, "%s: Event Route: Incorrect weight", name)
 	}
 
 	// Only check the model types if specified. Blank means this test doesn't care about the request/response models.
@@ -451,10 +580,14 @@ type expectedService struct {
 }
 
 type expectedFunction struct {
-	RequestType   string
-	ResponseType  string
-	Documentation parser.DocumentationLines
-	Routes        parser.GatewayRoutes
+	RequestType     string
+	ResponseType    string
+	Documentation   parser.DocumentationLines
+	Routes          parser.GatewayRoutes
+	Deprecated      bool
+	Sunset          string
+	Roles           []string
+	RequireAllRoles bool
 }
 
 type expectedModel struct {
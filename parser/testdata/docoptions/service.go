@@ -18,6 +18,9 @@ import "context"
  * - All supported HTTP methods are accounted for
  * - Option key can have leading spaces, but not other leading characters
  * - Option order doesn't matter (can do route then status or status then route)
+ * - DEPRECATED works with or without a sunset date, and a bad date still deprecates the function
+ * - ROLES can start with an ALL/ANY qualifier, but only when it's followed by a role
+ * - A role literally named ALL/ANY needs an explicit qualifier in front of it
  */
 
 // LebowskiService occupies various administration buildings.
@@ -30,25 +33,34 @@ type LebowskiService interface {
 	// GET /dude/{id}/
 	// HTTP 202
 	Dude(context.Context, *Request) (*Response, error)
+	// ROLES   ALL  admin.write, dude.{id}.write
 	Walter(context.Context, *Request) (*Response, error)
 	//
 	// HTTP 204
+	// ROLES ANY admin.write,dude.{id}.write
 	//
 	//
 	Donny(context.Context, *Request) (*Response, error)
 	// HTTP 201
 	// POST /dude/{id}/child
+	// DEPRECATED
 	Maude(context.Context, *Request) (*Response, error)
 	// PUT       /dude/jail
+	// DEPRECATED   2025-06-01
 	Jackie(context.Context, *Request) (*Response, error)
 	// Sometimes you eat the bar.
 	//
 	// PATCH dude/{id}
+	// DEPRECATED next week
 	// Sometimes the bar eats you.
 	Stranger(context.Context, *Request) (*Response, error)
 	// RemoveToe attempts to extort $1 million.
 	// DELETE /nihilist/{id}/toe
+	// ROLES ALL
 	RemoveToe(context.Context, *Request) (*Response, error)
+	// Nihilists believe in nothing, but they still believe in the ALL role.
+	// ROLES ANY ALL, admin.write
+	Nihilist(context.Context, *Request) (*Response, error)
 	//     HEAD /ties/room/together
 	// * HTTP 202
 	Rug(context.Context, *Request) (*Response, error)
@@ -67,7 +79,25 @@ type LebowskiService interface {
 	// ON LebowskiService.Dude
 	// ON LebowskiService.Walter
 	BowlingEnd(context.Context, *Request) (*Response, error)
+
+	// Sleep runs every night, but it's still available through the API.
+	// SCHEDULE 0 2 * * *
+	// SCHEDULE   @hourly
+	// SCHEDULE 61 * * * *
+	Sleep(context.Context, *Request) (*Response, error)
+
+	// Strike takes a bigger share of the league's events.
+	// HTTP OMIT
+	// ON LebowskiService.Dude GROUP League WEIGHT 3
+	// ON LebowskiService.Walter WEIGHT 2
+	// ON LebowskiService.Donny GROUP *
+	// ON LebowskiService.Maude GROUP * WEIGHT 2
+	// ON LebowskiService.Jesus GROUP League WEIGHT zero
+	// ON LebowskiService.Bunny GROUP
+	Strike(context.Context, *Request) (*Response, error)
 }
 
-type Request struct{}
+type Request struct {
+	ID string
+}
 type Response struct{}
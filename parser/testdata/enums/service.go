@@ -0,0 +1,62 @@
+package enums
+
+import (
+	"context"
+)
+
+type EnumService interface {
+	Pause(context.Context, *PauseRequest) (*PauseResponse, error)
+}
+
+type PauseRequest struct {
+	Embedded
+	*EmbeddedPointer
+
+	Status        Status
+	StatusPointer *Status
+	Label         Label
+	Priority      Priority
+	Omitted       Status `json:"-"`
+}
+
+type PauseResponse struct {
+	Status Status
+}
+
+type Embedded struct {
+	EmbeddedStatus Status
+}
+
+type EmbeddedPointer struct {
+	EmbeddedPointerStatus Status
+}
+
+// Status is an enum, so it should only allow the constants below.
+type Status string
+
+const (
+	StatusPaused   Status = "paused"
+	StatusActive   Status = "active"
+	StatusArchived Status = "archived"
+
+	// StatusDefault is a duplicate value, so it should only show up once.
+	StatusDefault = StatusActive
+)
+
+// Values opts Status into enum validation.
+func (Status) Values() []Status {
+	return []Status{StatusPaused, StatusActive, StatusArchived}
+}
+
+// Priority has some well-known constants, but no Values() method, so it still allows anything.
+type Priority string
+
+const (
+	PriorityDefault Priority = "normal"
+)
+
+// Label is just a string type. There are no constants of this type, so it allows anything.
+type Label string
+
+// NotALabel is a string constant, but it's not of type Label.
+const NotALabel = "nope"
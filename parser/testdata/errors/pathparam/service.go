@@ -0,0 +1,28 @@
+package pathparam
+
+import (
+	"context"
+)
+
+type FooService interface {
+	// Hello has path params that all match fields on the request.
+	//
+	// GET /hello/{ID}/{user.name}
+	Hello(context.Context, *Request) (*Response, error)
+
+	// Goodbye refers to a field that was renamed from UserID to ID.
+	//
+	// GET /goodbye/{UserID}
+	Goodbye(context.Context, *Request) (*Response, error)
+}
+
+type Request struct {
+	ID   string
+	User User `json:"user"`
+}
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type Response struct{}
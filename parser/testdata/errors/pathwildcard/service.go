@@ -0,0 +1,23 @@
+package pathwildcard
+
+import (
+	"context"
+)
+
+type FooService interface {
+	// Download captures the entire remainder of the path in a single field.
+	//
+	// GET /files/{Path...}
+	Download(context.Context, *Request) (*Response, error)
+
+	// Metadata tries to put more path segments after the wildcard.
+	//
+	// GET /metadata/{Path...}/info
+	Metadata(context.Context, *Request) (*Response, error)
+}
+
+type Request struct {
+	Path string
+}
+
+type Response struct{}
@@ -0,0 +1,27 @@
+package httpoptions
+
+import "context"
+
+// ArchiveService serves old bowling league records.
+//
+// HTTP ALLOW head,  OPTIONS, GET, HEAD, BOGUS
+// HTTP CACHE public, max-age=60
+type ArchiveService interface {
+	// GET /scores/{id}
+	Scores(context.Context, *Request) (*Response, error)
+	// GET /standings
+	// HTTP ALLOW GET, OPTIONS
+	// HTTP CACHE no-store
+	Standings(context.Context, *Request) (*Response, error)
+	// POST /scores
+	// HTTP 201
+	Record(context.Context, *Request) (*Response, error)
+}
+
+type Request struct {
+	ID string
+}
+
+type Response struct {
+	Score int
+}
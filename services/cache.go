@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bridgekit-io/frodo/metadata"
+)
+
+// CacheStore is where CacheMiddleware() keeps the responses it has cached. The values are the actual response
+// structs your handlers return, so implementations that live outside the process (e.g. Redis) need to encode them
+// on their own. Use NewMemoryCache() for a simple, in-process store.
+type CacheStore interface {
+	// Get returns the cached response for the key, or false when there isn't one (or it has expired).
+	Get(ctx context.Context, key string) (any, bool)
+	// Set stores the response for the key, so that Get() returns it for roughly the next 'ttl'.
+	Set(ctx context.Context, key string, value any, ttl time.Duration)
+}
+
+// CacheKeyFunc decides the cache key for the current call. Return false when this call should not be
+// cached at all (e.g. the request asks for data that changes too often).
+type CacheKeyFunc func(ctx context.Context, req any) (string, bool)
+
+// CacheMiddleware returns cached responses rather than invoking your handler when it has already seen a request
+// with the same key in the last 'ttl'. This works well for read-heavy endpoints whose data rarely changes:
+//
+//	cache := services.NewMemoryCache()
+//	keyFunc := func(ctx context.Context, req any) (string, bool) {
+//		if req, ok := req.(*GetUserRequest); ok {
+//			return req.ID, true
+//		}
+//		return "", false
+//	}
+//	userService := usergen.UserServiceServer(handler, services.CacheMiddleware(cache, keyFunc, time.Minute))
+//
+// The middleware prefixes your key with the "Service.Function" name of the call, so you don't need to worry about
+// 2 different functions colliding when you use the same key func for all of them. Only successful, non-nil
+// responses are cached; errors always come from your handler.
+//
+// When you return a cached response, nothing actually happened, so the middleware suppresses the "Service.Function"
+// event just like calling metadata.SuppressEvent() in your handler would. The server always runs your middleware
+// (see WithMiddleware) inside of the gateways' own middleware, so the event gateway sees that decision before it
+// decides whether to publish. Cache misses publish their events as usual.
+//
+// Cached responses are shared between every call that hits the same key, so treat them as read-only. A nil
+// store or key func turns the middleware into a no-op.
+func CacheMiddleware(store CacheStore, keyFunc CacheKeyFunc, ttl time.Duration) MiddlewareFunc {
+	return func(ctx context.Context, req any, next HandlerFunc) (any, error) {
+		if store == nil || keyFunc == nil {
+			return next(ctx, req)
+		}
+		key, ok := keyFunc(ctx, req)
+		if !ok {
+			return next(ctx, req)
+		}
+
+		key = metadata.Route(ctx).QualifiedName() + ":" + key
+		if res, ok := store.Get(ctx, key); ok {
+			metadata.SuppressEvent(ctx)
+			return res, nil
+		}
+
+		res, err := next(ctx, req)
+		if err == nil && res != nil {
+			store.Set(ctx, key, res, ttl)
+		}
+		return res, err
+	}
+}
+
+// NewMemoryCache creates a CacheStore that keeps responses in memory. Expired entries are removed as new
+// ones come in, but there's no other limit on its size, so keep your TTLs short when the keys vary a lot.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: map[string]memoryCacheEntry{}}
+}
+
+// MemoryCache is a CacheStore that keeps responses in memory. Use NewMemoryCache() to create one.
+type MemoryCache struct {
+	mutex     sync.Mutex
+	entries   map[string]memoryCacheEntry
+	nextPrune time.Time
+}
+
+type memoryCacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+// Get returns the cached response for the key, or false when there isn't one (or it has expired).
+func (cache *MemoryCache) Get(_ context.Context, key string) (any, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	entry, ok := cache.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set stores the response for the key, so that Get() returns it for the next 'ttl'.
+func (cache *MemoryCache) Set(_ context.Context, key string, value any, ttl time.Duration) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	now := time.Now()
+	cache.entries[key] = memoryCacheEntry{value: value, expires: now.Add(ttl)}
+
+	// Sweeping the whole map on every Set() would get expensive, so we only bother
+	// doing it at most once per TTL.
+	if now.Before(cache.nextPrune) {
+		return
+	}
+	for k, entry := range cache.entries {
+		if now.After(entry.expires) {
+			delete(cache.entries, k)
+		}
+	}
+	cache.nextPrune = now.Add(ttl)
+}
+
+// Delete removes the cached response for the key. Remember that CacheMiddleware() prefixes your keys with the
+// "Service.Function" name (e.g. "UserService.GetUser:123").
+func (cache *MemoryCache) Delete(_ context.Context, key string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	delete(cache.entries, key)
+}
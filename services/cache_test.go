@@ -0,0 +1,171 @@
+//go:build unit
+
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bridgekit-io/frodo/internal/testext"
+	gen "github.com/bridgekit-io/frodo/internal/testext/gen"
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/bridgekit-io/frodo/services/servicetest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheSuite))
+}
+
+type CacheSuite struct {
+	suite.Suite
+}
+
+type cacheRequest struct {
+	ID string
+}
+
+type cacheResponse struct {
+	Calls int
+}
+
+func (suite *CacheSuite) context(name string) context.Context {
+	ctx := metadata.WithRoute(context.Background(), metadata.EndpointRoute{ServiceName: "UserService", Name: name})
+	return metadata.WithEventSuppression(ctx)
+}
+
+func (suite *CacheSuite) keyFunc(_ context.Context, req any) (string, bool) {
+	if req.(*cacheRequest).ID == "" {
+		return "", false
+	}
+	return req.(*cacheRequest).ID, true
+}
+
+// handler counts how many times it was actually invoked, so we can tell cached responses from fresh ones.
+func (suite *CacheSuite) handler(calls *int) services.HandlerFunc {
+	return func(ctx context.Context, req any) (any, error) {
+		*calls++
+		if req.(*cacheRequest).ID == "error" {
+			return nil, errors.New("nope")
+		}
+		return &cacheResponse{Calls: *calls}, nil
+	}
+}
+
+func (suite *CacheSuite) TestCacheMiddleware_hit() {
+	calls := 0
+	cache := services.NewMemoryCache()
+	handler := services.MiddlewareFuncs{services.CacheMiddleware(cache, suite.keyFunc, time.Minute)}.Then(suite.handler(&calls))
+
+	ctx := suite.context("GetUser")
+	res, err := handler(ctx, &cacheRequest{ID: "123"})
+	suite.Require().NoError(err)
+	suite.Equal(1, res.(*cacheResponse).Calls)
+	suite.False(metadata.EventSuppressed(ctx), "Cache misses should publish events as usual")
+
+	ctx = suite.context("GetUser")
+	res, err = handler(ctx, &cacheRequest{ID: "123"})
+	suite.Require().NoError(err)
+	suite.Equal(1, res.(*cacheResponse).Calls, "Should have returned the cached response")
+	suite.Equal(1, calls, "Should not have invoked the handler on a hit")
+	suite.True(metadata.EventSuppressed(ctx), "Cache hits should suppress the event")
+
+	res, err = handler(suite.context("GetUser"), &cacheRequest{ID: "456"})
+	suite.Require().NoError(err)
+	suite.Equal(2, res.(*cacheResponse).Calls, "Different keys should not share responses")
+}
+
+func (suite *CacheSuite) TestCacheMiddleware_routePrefix() {
+	calls := 0
+	cache := services.NewMemoryCache()
+	handler := services.MiddlewareFuncs{services.CacheMiddleware(cache, suite.keyFunc, time.Minute)}.Then(suite.handler(&calls))
+
+	_, _ = handler(suite.context("GetUser"), &cacheRequest{ID: "123"})
+	_, _ = handler(suite.context("GetProfile"), &cacheRequest{ID: "123"})
+	suite.Equal(2, calls, "The same key for different functions should not collide")
+
+	_, ok := cache.Get(context.Background(), "UserService.GetUser:123")
+	suite.True(ok)
+	_, ok = cache.Get(context.Background(), "UserService.GetProfile:123")
+	suite.True(ok)
+}
+
+func (suite *CacheSuite) TestCacheMiddleware_skipped() {
+	calls := 0
+	cache := services.NewMemoryCache()
+	handler := services.MiddlewareFuncs{services.CacheMiddleware(cache, suite.keyFunc, time.Minute)}.Then(suite.handler(&calls))
+
+	_, _ = handler(suite.context("GetUser"), &cacheRequest{ID: ""})
+	_, _ = handler(suite.context("GetUser"), &cacheRequest{ID: ""})
+	suite.Equal(2, calls, "Should not cache when the key func says no")
+
+	_, err := handler(suite.context("GetUser"), &cacheRequest{ID: "error"})
+	suite.Error(err)
+	_, err = handler(suite.context("GetUser"), &cacheRequest{ID: "error"})
+	suite.Error(err)
+	suite.Equal(4, calls, "Should not cache errors")
+}
+
+func (suite *CacheSuite) TestCacheMiddleware_nil() {
+	calls := 0
+	handler := services.MiddlewareFuncs{services.CacheMiddleware(nil, suite.keyFunc, time.Minute)}.Then(suite.handler(&calls))
+	_, _ = handler(suite.context("GetUser"), &cacheRequest{ID: "123"})
+	_, _ = handler(suite.context("GetUser"), &cacheRequest{ID: "123"})
+	suite.Equal(2, calls)
+
+	calls = 0
+	handler = services.MiddlewareFuncs{services.CacheMiddleware(services.NewMemoryCache(), nil, time.Minute)}.Then(suite.handler(&calls))
+	_, _ = handler(suite.context("GetUser"), &cacheRequest{ID: "123"})
+	_, _ = handler(suite.context("GetUser"), &cacheRequest{ID: "123"})
+	suite.Equal(2, calls)
+}
+
+func (suite *CacheSuite) TestCacheMiddleware_skipsEvents() {
+	keyFunc := func(_ context.Context, req any) (string, bool) {
+		if req, ok := req.(*testext.SampleRequest); ok {
+			return req.Text, true
+		}
+		return "", false
+	}
+
+	sequence := &testext.Sequence{}
+	harness := servicetest.NewHarness(suite.T(),
+		services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: sequence})),
+		services.WithMiddleware(services.CacheMiddleware(services.NewMemoryCache(), keyFunc, time.Minute)),
+	)
+
+	_, err := harness.Invoke(context.Background(), "SampleService", "TriggerLowerCase", &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.Equal([]string{"SampleService.TriggerLowerCase", "SampleService.ListenerB"}, harness.Invoked())
+
+	harness.Reset()
+	res, err := harness.Invoke(context.Background(), "SampleService", "TriggerLowerCase", &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.Equal("abide", res.(*testext.SampleResponse).Text)
+	suite.Empty(harness.Invoked(), "A cache hit should neither run the handler nor trigger ListenerB")
+}
+
+func (suite *CacheSuite) TestMemoryCache() {
+	ctx := context.Background()
+	cache := services.NewMemoryCache()
+
+	_, ok := cache.Get(ctx, "foo")
+	suite.False(ok)
+
+	cache.Set(ctx, "foo", "bar", time.Minute)
+	value, ok := cache.Get(ctx, "foo")
+	suite.True(ok)
+	suite.Equal("bar", value)
+
+	cache.Set(ctx, "expired", "baz", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	_, ok = cache.Get(ctx, "expired")
+	suite.False(ok, "Expired entries should not come back")
+
+	cache.Delete(ctx, "foo")
+	_, ok = cache.Get(ctx, "foo")
+	suite.False(ok)
+}
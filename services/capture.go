@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/bridgekit-io/frodo/metadata"
+)
+
+// Capture is a snapshot of a single service call that CaptureMiddleware() hands to your sink. The Request
+// and Response values have already been passed through Redact(), so fields tagged `sensitive:"true"`
+// will not leak into whatever storage your sink writes to.
+type Capture struct {
+	// TraceID is the trace id of the call, so you can correlate this with your other logs.
+	TraceID string
+	// Route describes the service operation that was invoked (and the gateway that invoked it).
+	Route metadata.EndpointRoute
+	// Start is the time that the call began.
+	Start time.Time
+	// Duration is how long the call took from this middleware's point of view.
+	Duration time.Duration
+	// Request is the redacted, decoded request value that was handed to your handler.
+	Request any
+	// Response is the redacted response value. This is nil when the call failed.
+	Response any
+	// Error is the error returned by the call, if any.
+	Error error
+}
+
+// CaptureSink receives the calls sampled by CaptureMiddleware(). It is invoked synchronously after the
+// handler completes, so if you're writing to something slow, consider buffering/shipping it elsewhere.
+type CaptureSink func(ctx context.Context, capture Capture)
+
+// CaptureSampler decides whether the current call should be recorded by CaptureMiddleware().
+type CaptureSampler func(ctx context.Context) bool
+
+// SampleRate returns a CaptureSampler that records roughly the given fraction of calls (e.g. 0.01 for 1%).
+// Any rate <= 0 never samples and any rate >= 1 samples every call.
+func SampleRate(rate float64) CaptureSampler {
+	return func(ctx context.Context) bool {
+		switch {
+		case rate <= 0:
+			return false
+		case rate >= 1:
+			return true
+		default:
+			return rand.Float64() < rate
+		}
+	}
+}
+
+// CaptureMiddleware records the request and response (or error) of a sampled subset of calls and hands
+// them to your sink. It's meant to help debug production issues without having to log every payload. Since
+// it operates on the decoded Go values, it works the same for every gateway and never needs to re-read
+// an HTTP body:
+//
+//	sampler := services.SampleRate(0.01)
+//	sink := func(ctx context.Context, capture services.Capture) {
+//		services.Logger(ctx).Debug("captured call", "request", capture.Request, "error", capture.Error)
+//	}
+//	userService := usergen.UserServiceServer(handler, services.CaptureMiddleware(sink, sampler))
+//
+// A nil sampler records every call, and a nil sink turns the middleware into a no-op.
+func CaptureMiddleware(sink CaptureSink, sampler CaptureSampler) MiddlewareFunc {
+	return func(ctx context.Context, req any, next HandlerFunc) (any, error) {
+		if sink == nil || (sampler != nil && !sampler(ctx)) {
+			return next(ctx, req)
+		}
+
+		start := time.Now()
+		res, err := next(ctx, req)
+
+		capture := Capture{
+			TraceID:  metadata.TraceID(ctx),
+			Route:    metadata.Route(ctx),
+			Start:    start,
+			Duration: time.Since(start),
+			Request:  Redact(req),
+			Error:    err,
+		}
+		if err == nil {
+			capture.Response = Redact(res)
+		}
+		sink(ctx, capture)
+		return res, err
+	}
+}
@@ -0,0 +1,115 @@
+//go:build unit
+
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCaptureSuite(t *testing.T) {
+	suite.Run(t, new(CaptureSuite))
+}
+
+type CaptureSuite struct {
+	suite.Suite
+}
+
+type captureRequest struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+type captureResponse struct {
+	Greeting string
+	Token    string `sensitive:"true"`
+}
+
+func (suite *CaptureSuite) handler(ctx context.Context, req any) (any, error) {
+	if req.(*captureRequest).Name == "" {
+		return nil, errors.New("name is required")
+	}
+	return &captureResponse{Greeting: "Hello " + req.(*captureRequest).Name, Token: "abc"}, nil
+}
+
+func (suite *CaptureSuite) context() context.Context {
+	ctx := metadata.WithTraceID(context.Background(), "1234")
+	return metadata.WithRoute(ctx, metadata.EndpointRoute{ServiceName: "GreeterService", Name: "Greet"})
+}
+
+func (suite *CaptureSuite) TestCapture_success() {
+	var captures []services.Capture
+	sink := func(ctx context.Context, capture services.Capture) {
+		captures = append(captures, capture)
+	}
+	handler := services.MiddlewareFuncs{services.CaptureMiddleware(sink, nil)}.Then(suite.handler)
+
+	res, err := handler(suite.context(), &captureRequest{Name: "Dude", Password: "abides"})
+	suite.Require().NoError(err)
+	suite.Equal("abc", res.(*captureResponse).Token, "Capturing should not modify the real response")
+
+	suite.Require().Len(captures, 1)
+	suite.Equal("1234", captures[0].TraceID)
+	suite.Equal("GreeterService.Greet", captures[0].Route.QualifiedName())
+	suite.False(captures[0].Start.IsZero())
+	suite.Equal(map[string]any{"Name": "Dude", "Password": services.RedactedValue}, captures[0].Request)
+	suite.Equal(map[string]any{"Greeting": "Hello Dude", "Token": services.RedactedValue}, captures[0].Response)
+	suite.NoError(captures[0].Error)
+}
+
+func (suite *CaptureSuite) TestCapture_error() {
+	var captures []services.Capture
+	sink := func(ctx context.Context, capture services.Capture) {
+		captures = append(captures, capture)
+	}
+	handler := services.MiddlewareFuncs{services.CaptureMiddleware(sink, nil)}.Then(suite.handler)
+
+	_, err := handler(suite.context(), &captureRequest{})
+	suite.Require().Error(err)
+
+	suite.Require().Len(captures, 1)
+	suite.Nil(captures[0].Response)
+	suite.Equal(err, captures[0].Error)
+}
+
+func (suite *CaptureSuite) TestCapture_sampler() {
+	var captures []services.Capture
+	sink := func(ctx context.Context, capture services.Capture) {
+		captures = append(captures, capture)
+	}
+	everyOther := 0
+	sampler := func(ctx context.Context) bool {
+		everyOther++
+		return everyOther%2 == 0
+	}
+	handler := services.MiddlewareFuncs{services.CaptureMiddleware(sink, sampler)}.Then(suite.handler)
+
+	for i := 0; i < 10; i++ {
+		_, err := handler(suite.context(), &captureRequest{Name: "Dude"})
+		suite.Require().NoError(err)
+	}
+	suite.Len(captures, 5)
+}
+
+func (suite *CaptureSuite) TestCapture_nilSink() {
+	handler := services.MiddlewareFuncs{services.CaptureMiddleware(nil, nil)}.Then(suite.handler)
+
+	res, err := handler(suite.context(), &captureRequest{Name: "Dude"})
+	suite.Require().NoError(err)
+	suite.Equal("Hello Dude", res.(*captureResponse).Greeting)
+}
+
+func (suite *CaptureSuite) TestSampleRate() {
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		suite.False(services.SampleRate(0)(ctx))
+		suite.False(services.SampleRate(-1)(ctx))
+		suite.True(services.SampleRate(1)(ctx))
+		suite.True(services.SampleRate(2)(ctx))
+	}
+}
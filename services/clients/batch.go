@@ -0,0 +1,198 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/bridgekit-io/frodo/internal/quiet"
+	"github.com/bridgekit-io/frodo/services"
+)
+
+// NewBatch creates a Batch that sends its calls to the remote service using the given client.
+func NewBatch(client Client) *Batch {
+	return &Batch{client: client}
+}
+
+// Batch collects multiple independent calls to the same service, so you can send them all at once rather than paying
+// for a separate round trip for each one. When the remote API gateway has batching enabled (see apis.WithBatching()),
+// every call is sent in a single HTTP request. Otherwise, we fall back to firing off the calls concurrently. Either
+// way, you get the same results as if you had invoked them one at a time.
+//
+//	batch := clients.NewBatch(clients.NewClient("UserService", "http://user-service:9000"))
+//	batch.Add("GET", "/UserService.Get", &users.GetRequest{ID: "123"}, &userA)
+//	batch.Add("GET", "/UserService.Get", &users.GetRequest{ID: "456"}, &userB)
+//	for i, err := range batch.Run(ctx) {
+//		...
+//	}
+//
+// The method/path for each call should be the same ones that the generated client passes to Client.Invoke().
+type Batch struct {
+	client Client
+	calls  []batchCall
+}
+
+// batchCall is a single service invocation that has been added to the batch.
+type batchCall struct {
+	method          string
+	path            string
+	serviceRequest  any
+	serviceResponse any
+}
+
+// Add includes another call in the batch. The response value is populated when you Run() the batch.
+func (b *Batch) Add(method string, path string, serviceRequest any, serviceResponse any) {
+	b.calls = append(b.calls, batchCall{
+		method:          strings.ToUpper(method),
+		path:            path,
+		serviceRequest:  serviceRequest,
+		serviceResponse: serviceResponse,
+	})
+}
+
+// Len returns the number of calls that have been added to the batch.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Run sends all of the calls in the batch and waits for them to complete. The resulting slice has one
+// error for each call you added (in the same order), which is nil when that particular call succeeded.
+func (b *Batch) Run(ctx context.Context) []error {
+	if len(b.calls) == 0 {
+		return nil
+	}
+
+	errs, supported := b.runBatched(ctx)
+	if !supported {
+		return b.runConcurrent(ctx)
+	}
+	return errs
+}
+
+// runBatched sends every call in a single request to the remote gateway's batch route. The boolean is false when the
+// remote gateway doesn't support batching, so we know that we should fall back to making individual calls.
+func (b *Batch) runBatched(ctx context.Context) ([]error, bool) {
+	c := b.client
+	batch := batchRequest{Requests: make([]batchSubRequest, len(b.calls))}
+	for i, call := range b.calls {
+		body, err := c.createRequestBody(call.method, call.serviceRequest)
+		if err != nil {
+			return b.fail(fmt.Errorf("unable to create request body: %w", err)), true
+		}
+
+		subRequest := batchSubRequest{
+			Method: call.method,
+			Path:   strings.TrimPrefix(c.buildURL(call.method, call.path, call.serviceRequest), c.BaseURL),
+		}
+		if body != nil {
+			subRequest.Body, _ = io.ReadAll(body)
+		}
+		batch.Requests[i] = subRequest
+	}
+
+	body := &bytes.Buffer{}
+	if err := c.codecs.DefaultEncoder().Encode(body, batch); err != nil {
+		return b.fail(fmt.Errorf("unable to create request body: %w", err)), true
+	}
+	requestBody, compressed, err := c.compressRequestBody(body)
+	if err != nil {
+		return b.fail(fmt.Errorf("unable to compress request body: %w", err)), true
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+services.BatchPath, requestBody)
+	if err != nil {
+		return b.fail(fmt.Errorf("unable to create request: %w", err)), true
+	}
+	request.Header.Set("Content-Type", c.codecs.DefaultEncoder().ContentType())
+	if compressed {
+		request.Header.Set("Content-Encoding", "gzip")
+	}
+	response, err := c.roundTrip(request)
+	if err != nil {
+		return b.fail(fmt.Errorf("round trip error: %w", err)), true
+	}
+	defer quiet.Close(response.Body)
+
+	switch {
+	case response.StatusCode == http.StatusNotFound, response.StatusCode == http.StatusMethodNotAllowed:
+		return nil, false
+	case response.StatusCode >= 400:
+		return b.fail(c.decodeError(response)), true
+	}
+
+	batchRes := batchResponse{}
+	if err = json.NewDecoder(response.Body).Decode(&batchRes); err != nil {
+		return b.fail(fmt.Errorf("unable to decode response: %w", err)), true
+	}
+	if len(batchRes.Responses) != len(b.calls) {
+		return b.fail(fmt.Errorf("unable to decode response: expected %d batch responses, got %d", len(b.calls), len(batchRes.Responses))), true
+	}
+
+	errs := make([]error, len(b.calls))
+	for i, subResponse := range batchRes.Responses {
+		res := &http.Response{
+			StatusCode: subResponse.Status,
+			Header:     subResponse.Header,
+			Body:       io.NopCloser(bytes.NewReader(subResponse.Body)),
+		}
+		if res.Header == nil {
+			res.Header = http.Header{}
+		}
+		if err = c.decodeResponse(res, b.calls[i].serviceResponse); err != nil {
+			errs[i] = fmt.Errorf("unable to decode response: %w", err)
+		}
+	}
+	return errs, true
+}
+
+// runConcurrent is the fallback for gateways that don't support batching. It just invokes every call at the same time.
+func (b *Batch) runConcurrent(ctx context.Context) []error {
+	errs := make([]error, len(b.calls))
+	wg := sync.WaitGroup{}
+	for i, call := range b.calls {
+		wg.Add(1)
+		go func(i int, call batchCall) {
+			defer wg.Done()
+			errs[i] = b.client.Invoke(ctx, call.method, call.path, call.serviceRequest, call.serviceResponse)
+		}(i, call)
+	}
+	wg.Wait()
+	return errs
+}
+
+// fail returns a slice where every call in the batch failed w/ the same error.
+func (b *Batch) fail(err error) []error {
+	errs := make([]error, len(b.calls))
+	for i := range errs {
+		errs[i] = err
+	}
+	return errs
+}
+
+// batchRequest is the body we send to the remote gateway's batch route (see services.BatchPath).
+type batchRequest struct {
+	Requests []batchSubRequest
+}
+
+// batchSubRequest is a single call within the batch; the same method, path, and body that we'd have sent on its own.
+type batchSubRequest struct {
+	Method string
+	Path   string
+	Body   []byte
+}
+
+// batchResponse contains the responses for each call in the batch, in the same order that we sent them.
+type batchResponse struct {
+	Responses []batchSubResponse
+}
+
+// batchSubResponse is what the gateway would have responded with if we had sent the call on its own.
+type batchSubResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
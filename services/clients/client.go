@@ -2,21 +2,26 @@ package clients
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bridgekit-io/frodo/codec"
 	"github.com/bridgekit-io/frodo/fail"
 	"github.com/bridgekit-io/frodo/internal/naming"
 	"github.com/bridgekit-io/frodo/internal/quiet"
+	"github.com/bridgekit-io/frodo/internal/slices"
+	"github.com/bridgekit-io/frodo/metadata"
 	"github.com/bridgekit-io/frodo/services"
 )
 
@@ -34,31 +39,39 @@ func NewClient(name string, addr string, options ...ClientOption) Client {
 		addr = "http://" + strings.TrimPrefix(addr, "/")
 	}
 
-	defaultTimeout := 30 * time.Second
-	dialer := &net.Dialer{Timeout: defaultTimeout}
+	defaultHTTP := newHTTPClient(httpSettings{})
 	client := Client{
-		HTTP: &http.Client{
-			Timeout: defaultTimeout,
-			Transport: &http.Transport{
-				DialContext:         dialer.DialContext,
-				TLSHandshakeTimeout: defaultTimeout,
-			},
-		},
-		Name:       name,
-		BaseURL:    strings.TrimSuffix(addr, "/"),
-		codecs:     codec.New(),
-		middleware: clientMiddlewarePipeline{},
+		HTTP:                defaultHTTP,
+		Name:                name,
+		BaseURL:             strings.TrimSuffix(addr, "/"),
+		codecs:              codec.New(),
+		middleware:          clientMiddlewarePipeline{},
+		logger:              slog.Default(),
+		deprecationWarnings: &sync.Map{},
+		traceIDHeader:       metadata.TraceIDHeader,
 	}
 	for _, option := range options {
 		option(&client)
 	}
 
+	// Options like WithTimeout() tweak the default HTTP client. If you supplied your own using
+	// WithHTTPClient(), it's already configured exactly how you want it, so we leave it alone.
+	if client.HTTP == defaultHTTP && client.httpSettings != (httpSettings{}) {
+		client.HTTP = newHTTPClient(client.httpSettings)
+	}
+
 	// Let the user's custom middleware do whatever the hell it wants to the context/request
 	// before our standard middleware finalizes everything.
 	client.middleware = append(client.middleware,
+		writeRequestHeaders(client.headers),
+		writeTraceIDHeader(client.traceIDHeader),
 		writeMetadataHeader,
 		writeAuthorizationHeader,
+		writeCallerHeader(client.callerName),
 	)
+	if client.brotliReader != nil {
+		client.middleware = append(client.middleware, decompressResponse(client.brotliReader))
+	}
 	client.roundTrip = client.middleware.Then(client.HTTP.Do)
 	return client
 }
@@ -67,6 +80,40 @@ func NewClient(name string, addr string, options ...ClientOption) Client {
 // when building one via NewClient().
 type ClientOption func(*Client)
 
+// NewClientContext constructs the same client that NewClient() does, but it also performs any up-front connection
+// setup work, using the context to govern how long that's allowed to take. Currently, that means resolving the
+// remote host's address, so a typo in your config or a DNS outage shows up when your program starts rather than on
+// the first call. You'll get an error if the address can't be resolved or the context ends first.
+//
+// This is mainly useful when constructing your client as part of some startup sequence that has its own deadline.
+// If you don't care about any of that, just use NewClient() instead.
+func NewClientContext(ctx context.Context, name string, addr string, options ...ClientOption) (Client, error) {
+	client := NewClient(name, addr, options...)
+	if err := client.resolve(ctx); err != nil {
+		return Client{}, fmt.Errorf("rpc: unable to create client: %s: %w", name, err)
+	}
+	return client, nil
+}
+
+// resolve performs a DNS lookup on the host in our base URL. There's nothing to resolve when the host is already
+// an IP address (or there's no address at all), but we still honor the context being done.
+func (c Client) resolve(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	address, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return err
+	}
+	host := address.Hostname()
+	if host == "" || net.ParseIP(host) != nil {
+		return nil
+	}
+	_, err = net.DefaultResolver.LookupHost(ctx, host)
+	return err
+}
+
 // Client manages all RPC communication with other frodo-powered services. It uses HTTP under the hood,
 // so you can supply a custom HTTP client by including WithHTTPClient() when calling your client
 // constructor, NewXxxServiceClient().
@@ -83,6 +130,43 @@ type Client struct {
 	// Middleware defines all of the units of work we will apply to the request/response when
 	// round-tripping our RPC call to the remote service.
 	middleware clientMiddlewarePipeline
+	// envelopeField is the field of the response envelope that contains the actual service response. When
+	// this is empty, the gateway isn't wrapping responses in an envelope (see WithResponseEnvelope).
+	envelopeField string
+	// headers are the raw HTTP headers that we include on every request this client makes (see WithHeader).
+	headers http.Header
+	// compressRequests indicates that we should gzip request bodies that are at least compressMinSize bytes.
+	compressRequests bool
+	// compressMinSize is the smallest request body (in bytes) that we'll bother gzipping when compressRequests
+	// is enabled. Compressing tiny bodies usually costs more than it saves.
+	compressMinSize int
+	// brotliReader decompresses "Content-Encoding: br" response bodies. When this is nil, we leave the Accept-Encoding
+	// header (and gzip decompression) to the HTTP transport (see WithBrotli).
+	brotliReader func(io.Reader) io.Reader
+	// timeFormat converts time.Time values in request bodies and query strings to text (see WithTimeFormat). When
+	// this is nil, we use the codec's default (RFC 3339).
+	timeFormat func(time.Time) string
+	// durationFormat converts time.Duration values in request bodies and query strings to text (see WithDurationFormat).
+	// When this is nil, we use the codec's default (integer nanoseconds).
+	durationFormat func(time.Duration) string
+	// bodyMethods are the additional HTTP methods (e.g. DELETE) that send the request in the body rather than
+	// the query string (see WithRequestBody). POST/PUT/PATCH always send a body.
+	bodyMethods []string
+	// callerName is the name we send to the remote service to identify who is calling it. When this is empty,
+	// we use the name of the service whose handler is making the call (see WithCallerName).
+	callerName string
+	// traceIDHeader is the HTTP header that we send the call's trace id in (see WithTraceIDHeader).
+	traceIDHeader string
+	// lightweightErrors indicates that we should build errors from just the response status rather than reading
+	// the body to get the original message (see WithLightweightErrors).
+	lightweightErrors bool
+	// httpSettings are the tweaks to the default HTTP client from options like WithTimeout() and WithTLSConfig().
+	httpSettings httpSettings
+	// logger is where we write warnings, such as when you call a deprecated service function (see WithLogger).
+	logger *slog.Logger
+	// deprecationWarnings tracks the "METHOD /path" of every deprecated function that we've already warned
+	// you about, so that we only do it once. It's a pointer since the client is passed around by value.
+	deprecationWarnings *sync.Map
 	// roundTrip captures all middleware and the actual request dispatching in a single handler
 	// function. This is what we'll call once we've created the HTTP/RPC request when invoking
 	// one of your client's service functions.
@@ -98,23 +182,34 @@ func (c Client) Invoke(ctx context.Context, method string, path string, serviceR
 	// with the remaining service request values.
 	address := c.buildURL(method, path, serviceRequest)
 
-	// Step 2: Create a JSON reader for the request body (POST/PUT/PATCH only).
+	// Step 2: Create a JSON reader for the request body (POST/PUT/PATCH and any WithRequestBody() methods).
 	body, err := c.createRequestBody(method, serviceRequest)
 	if err != nil {
 		return fmt.Errorf("unable to create request body: %w", err)
 	}
 
-	// Step 3: Form the HTTP request
+	// Step 3: Form the HTTP request, gzipping the body if the client is configured to do so.
+	body, compressed, err := c.compressRequestBody(body)
+	if err != nil {
+		return fmt.Errorf("unable to compress request body: %w", err)
+	}
 	request, err := http.NewRequestWithContext(ctx, method, address, body)
 	if err != nil {
 		return fmt.Errorf("unable to create request: %w", err)
 	}
+	if body != nil {
+		request.Header.Set("Content-Type", c.codecs.DefaultEncoder().ContentType())
+	}
+	if compressed {
+		request.Header.Set("Content-Encoding", "gzip")
+	}
 
 	// Step 4: Run the request through all middleware and fire it off.
 	response, err := c.roundTrip(request)
 	if err != nil {
 		return fmt.Errorf("round trip error: %w", err)
 	}
+	c.warnDeprecated(ctx, method, path, response)
 
 	// Step 5: Based on the status code, either populate "out" struct (service response) with the
 	// decoded body/JSON or respond a properly formed error.
@@ -125,28 +220,111 @@ func (c Client) Invoke(ctx context.Context, method string, path string, serviceR
 	return nil
 }
 
+// Ping makes a lightweight request to the remote service's API gateway to confirm that it's up and reachable
+// without invoking any of its actual functions. The request still goes through your client middleware, so any
+// auth/tracing you've set up applies here, too. A nil error means the service responded with a 200.
+//
+// The generated service clients embed the Client, so you can reach this using the Pinger interface:
+//
+//	userService := usergen.UserServiceClient("http://user-service:9000")
+//	if err := userService.(clients.Pinger).Ping(ctx); err != nil {
+//		log.Fatalf("user service is unreachable: %v", err)
+//	}
+func (c Client) Ping(ctx context.Context) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+services.PingPath, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	response, err := c.roundTrip(request)
+	if err != nil {
+		return fmt.Errorf("round trip error: %w", err)
+	}
+	if response.StatusCode >= 400 {
+		return c.decodeError(response)
+	}
+
+	quiet.Close(response.Body)
+	return nil
+}
+
+// Pinger describes anything that can check if the remote service is reachable. The code-generated
+// service clients all implement this.
+type Pinger interface {
+	// Ping returns nil if the remote service is up and reachable.
+	Ping(ctx context.Context) error
+}
+
 func (c Client) decodeResponse(response *http.Response, serviceResponse any) error {
 	if response.StatusCode >= 400 {
 		return c.decodeError(response)
 	}
+	if response.StatusCode == http.StatusNoContent {
+		// Nothing to decode, so just leave the response zeroed out.
+		quiet.Close(response.Body)
+		return nil
+	}
 	if raw, ok := serviceResponse.(services.ContentGetter); ok {
 		return c.decodeResponseStream(response, raw)
 	}
+	if stream, ok := serviceResponse.(services.JSONStreamSetter); ok {
+		// Just like raw content streams, the caller reads the items long after the call returns, so we
+		// can't close the body here. Iterating over the items closes it once they're all read.
+		stream.SetJSONStream(response.Body)
+		return nil
+	}
+	if raw, ok := serviceResponse.(services.RawJSONSetter); ok && c.envelopeField == "" {
+		return c.decodeResponseRaw(response, raw)
+	}
 	return c.decodeResponseValue(response, serviceResponse)
 }
 
+// decodeResponseRaw hands the body to responses that embed services.RawJSON as-is, so we don't bother parsing
+// JSON that the caller just wants to pass along anyway.
+func (c Client) decodeResponseRaw(res *http.Response, rawResponse services.RawJSONSetter) error {
+	defer quiet.Close(res.Body)
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("rpc: unable to decode response: %w", err)
+	}
+	rawResponse.SetRaw(data)
+	return nil
+}
+
 func (c Client) decodeResponseValue(res *http.Response, serviceResponse any) error {
 	defer quiet.Close(res.Body)
 
 	contentType := res.Header.Get("Content-Type")
 	decoder := c.codecs.Decoder(contentType)
 
+	if c.envelopeField != "" {
+		return c.decodeResponseEnvelope(res, decoder, serviceResponse)
+	}
 	if err := decoder.Decode(res.Body, serviceResponse); err != nil {
 		return fmt.Errorf("rpc: unable to decode response: %w", err)
 	}
 	return nil
 }
 
+// decodeResponseEnvelope unwraps responses from gateways that use apis.WithResponseEnvelope(), decoding just the
+// envelope's data field into the service response. An envelope without that field leaves the response empty.
+func (c Client) decodeResponseEnvelope(res *http.Response, decoder codec.Decoder, serviceResponse any) error {
+	envelope := map[string]json.RawMessage{}
+	if err := decoder.Decode(res.Body, &envelope); err != nil {
+		return fmt.Errorf("rpc: unable to decode response envelope: %w", err)
+	}
+
+	data, ok := envelope[c.envelopeField]
+	if !ok {
+		return nil
+	}
+	if err := decoder.Decode(bytes.NewReader(data), serviceResponse); err != nil {
+		return fmt.Errorf("rpc: unable to decode response: %w", err)
+	}
+	return nil
+}
+
 func (c Client) decodeResponseStream(res *http.Response, streamResponse services.ContentGetter) error {
 	switch setter, ok := streamResponse.(services.ContentSetter); ok {
 	case true:
@@ -199,14 +377,61 @@ func (c Client) decodeResponseStream(res *http.Response, streamResponse services
 	return nil
 }
 
-// newStatusError takes the response (assumed to be a 400+ status already) and creates
-// an RPCError with the proper HTTP status as it tries to preserve the original error's message.
+// decodeError takes the response (assumed to be a 400+ status already) and creates an error with the
+// proper HTTP status. When the server told us when to try again (e.g. a 429 w/ "Retry-After"), you can get
+// that delay back out of the error using fail.RetryAfter().
 func (c Client) decodeError(r *http.Response) error {
+	err := c.decodeErrorStatus(r)
+	if retryAfter, ok := parseRetryAfter(r.Header.Get("Retry-After"), time.Now()); ok {
+		return fail.RetryableError{StatusError: err, Delay: retryAfter}
+	}
+	return err
+}
+
+// parseRetryAfter understands both formats of the "Retry-After" header; a number of seconds (e.g. "120") or
+// an HTTP date (e.g. "Wed, 21 Oct 2015 07:28:00 GMT"). Dates in the past mean that you can try again now.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// decodeErrorStatus creates an error with the response's HTTP status as it tries to preserve the
+// original error's message.
+func (c Client) decodeErrorStatus(r *http.Response) fail.StatusError {
 	defer quiet.Close(r.Body)
 
+	if c.lightweightErrors {
+		// Drain (a reasonable amount of) the body, so the connection can go back into the pool for the next call.
+		_, _ = io.Copy(io.Discard, io.LimitReader(r.Body, maxDrainedErrorBytes))
+		return fail.New(r.StatusCode, "%s", http.StatusText(r.StatusCode))
+	}
+
 	errData, _ := io.ReadAll(r.Body)
 	contentType := r.Header.Get("Content-Type")
 
+	// The gateway might be using apis.WithProblemJSON(), so the body is RFC 7807 problem details
+	// like {"type":"about:blank", "title":"Not Found", "status":404, "detail":"not found, dummy"}
+	if strings.HasPrefix(contentType, "application/problem+json") {
+		problem := struct {
+			Title  string `json:"title"`
+			Detail string `json:"detail"`
+		}{}
+		_ = json.Unmarshal(errData, &problem)
+		if problem.Detail == "" {
+			problem.Detail = problem.Title
+		}
+		return fail.New(r.StatusCode, "rpc error: %s", problem.Detail)
+	}
+
 	// If the server didn't return JSON, assume that it's just plain text w/ the message to propagate
 	// as you'd get if you invoked `http.Error()`
 	if !strings.HasPrefix(contentType, "application/json") {
@@ -236,18 +461,67 @@ func (c Client) decodeError(r *http.Response) error {
 }
 
 func (c Client) createRequestBody(method string, serviceRequest any) (io.Reader, error) {
+	if !c.sendsBody(method) {
+		return nil, nil
+	}
+	body := &bytes.Buffer{}
+	err := c.encoder().Encode(body, serviceRequest)
+	return body, err
+}
+
+// encoder returns the codec that writes request bodies and path/query values, honoring any custom time/duration
+// formats (see WithTimeFormat and WithDurationFormat).
+func (c Client) encoder() codec.FormatEncoder {
+	return codec.FormatEncoder{
+		Encoder:        c.codecs.DefaultEncoder(),
+		ValueEncoder:   c.codecs.DefaultValueEncoder(),
+		TimeFormat:     c.timeFormat,
+		DurationFormat: c.durationFormat,
+	}
+}
+
+// sendsBody returns true when requests with this HTTP method should include the service request in the body
+// rather than the query string.
+func (c Client) sendsBody(method string) bool {
 	switch method {
 	case http.MethodPut, http.MethodPost, http.MethodPatch:
-		body := &bytes.Buffer{}
-		err := c.codecs.DefaultEncoder().Encode(body, serviceRequest)
-		return body, err
+		return true
 	default:
-		return nil, nil
+		return slices.Contains(c.bodyMethods, method)
+	}
+}
+
+// compressRequestBody gzips the encoded request body when request compression is enabled and the body is large
+// enough to be worth it. The boolean indicates whether the resulting body is compressed, so the caller knows to
+// include the "Content-Encoding: gzip" header.
+func (c Client) compressRequestBody(body io.Reader) (io.Reader, bool, error) {
+	buf, ok := body.(*bytes.Buffer)
+	if !c.compressRequests || !ok || buf.Len() < c.compressMinSize {
+		return body, false, nil
+	}
+
+	compressed := &bytes.Buffer{}
+	writer := gzip.NewWriter(compressed)
+	if _, err := writer.Write(buf.Bytes()); err != nil {
+		return nil, false, err
 	}
+	if err := writer.Close(); err != nil {
+		return nil, false, err
+	}
+	return compressed, true, nil
+}
+
+// escapePathSegments path-escapes each "/" separated segment of the value, leaving the slashes between them alone.
+func escapePathSegments(value string) string {
+	segments := strings.Split(value, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
 }
 
 func (c Client) buildURL(method string, path string, serviceRequest any) string {
-	attributes := c.codecs.DefaultValueEncoder().EncodeValues(serviceRequest)
+	attributes := c.encoder().EncodeValues(serviceRequest)
 
 	path = strings.Trim(path, "/")
 	pathSegments := naming.TokenizePath(path, '/')
@@ -267,23 +541,27 @@ func (c Client) buildURL(method string, path string, serviceRequest any) string
 		// pattern "/content-type/{ContentType}" and the value for "{ContentType}" is "image/png"
 		// we want the final URL to be "/content-type/image%2Fpng" and not "/content-type/image/png"
 		// because you'd be sneaking in more path segments.
-		paramName := pathSegment[1 : len(pathSegment)-1]
+		paramName := naming.PathVariableName(pathSegment)
 		pathSegments[i] = url.PathEscape(attributes.Get(paramName))
 
+		// The exception is a wildcard like "/files/{Path...}" which captures the rest of the path, so its slashes
+		// are supposed to be there. We still escape the individual segments of the value, though.
+		if naming.IsWildcardPathVariable(pathSegment) {
+			pathSegments[i] = escapePathSegments(attributes.Get(paramName))
+		}
+
 		// Remove the attribute, so it doesn't also get encoded in the query string, also.
 		attributes.Del(paramName)
 	}
 
 	address := c.BaseURL + "/" + strings.Join(pathSegments, "/")
-	switch method {
-	case http.MethodPut, http.MethodPost, http.MethodPatch:
-		// If we're doing a POST/PUT/PATCH, don't bother adding query string arguments. Non-path
-		// values will just be part of the JSON structure in the request's body.
+	if c.sendsBody(method) {
+		// If we're doing a POST/PUT/PATCH (or a DELETE that you told us has a body), don't bother adding
+		// query string arguments. Non-path values will just be part of the JSON structure in the request's body.
 		return address
-	default:
-		// We're doing a GET/DELETE/etc, so all request values must come via query string args.
-		return address + "?" + attributes.Encode()
 	}
+	// We're doing a GET/DELETE/etc, so all request values must come via query string args.
+	return address + "?" + attributes.Encode()
 }
 
 // fixedSegment returns true if the given URL path segment is not wrapped in "{}" indicating that it's a variable.
@@ -300,11 +578,183 @@ func WithMiddleware(funcs ...ClientMiddlewareFunc) ClientOption {
 }
 
 // WithHTTPClient allows you to provide an HTTP client configured to your liking. You do not *need*
-// to supply this. The default client already implements a 30 second timeout, but if you want a
-// different timeout or custom dialer/transport/etc, then you can feed in you custom client here and
-// we'll use that one for all HTTP communication with other services.
+// to supply this. The default client already implements a 30 second timeout, and you can tweak the
+// common settings using WithTimeout(), WithDialTimeout(), WithTLSConfig(), and WithProxy(). If you need
+// full control of the dialer/transport/etc, then you can feed in you custom client here and we'll use
+// that one for all HTTP communication with other services. Those other options don't affect your client.
+// Neither does the default client's redirect policy, which drops our credential headers when a service
+// redirects to some other host, so set your own CheckRedirect if you need that protection.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(rpcClient *Client) {
 		rpcClient.HTTP = httpClient
 	}
 }
+
+// WithHeader includes the given raw HTTP header on every request this client sends to the remote service. This
+// is meant for headers that intermediaries like API gateways or proxies care about rather than values you want
+// to pass along to the remote service (use metadata.WithValue() for that). If you need to vary the header for
+// each call, use WithRequestHeader() on the call's context instead.
+func WithHeader(key string, value string) ClientOption {
+	return func(client *Client) {
+		if client.headers == nil {
+			client.headers = http.Header{}
+		}
+		client.headers.Add(key, value)
+	}
+}
+
+// WithCallerName sets the name that this client sends to identify the caller (see metadata.Caller()). By default,
+// calls made from inside one of your service functions identify themselves as that function's service (e.g.
+// "BillingService"), and calls made anywhere else don't identify themselves at all. Use this when the code making
+// the calls isn't a Frodo service, such as a worker or a CLI tool.
+func WithCallerName(name string) ClientOption {
+	return func(client *Client) {
+		client.callerName = name
+	}
+}
+
+// WithTraceIDHeader changes the HTTP header that the client sends the call's trace id in. By default, we use the
+// X-Request-ID header, but this should match whatever header the remote gateway reads (see apis.WithTraceIDHeader()),
+// such as the W3C "traceparent" header.
+func WithTraceIDHeader(header string) ClientOption {
+	return func(client *Client) {
+		if header != "" {
+			client.traceIDHeader = header
+		}
+	}
+}
+
+// WithResponseEnvelope is the client-side counterpart to apis.WithResponseEnvelope(). When the remote gateway wraps
+// its responses in an envelope like {"data":{...}, "meta":{...}}, this tells the client which field contains the
+// actual service response, so it can unwrap it for you (e.g. "data").
+func WithResponseEnvelope(dataField string) ClientOption {
+	return func(client *Client) {
+		client.envelopeField = dataField
+	}
+}
+
+// WithRequestBody makes the client send the service request as a JSON body for the given HTTP methods, just like it
+// does for POST/PUT/PATCH. By default, methods like DELETE put all of the request values in the query string, which
+// is fine for simple values, but falls apart for nested objects such as complex filter criteria. The API gateway
+// always decodes the body, so this only affects the client.
+//
+//	client := gen.OrderServiceClient(address, clients.WithRequestBody(http.MethodDelete))
+//
+// Keep in mind that some proxies and load balancers strip the bodies of GET requests, so you probably only
+// want this for DELETE.
+func WithRequestBody(methods ...string) ClientOption {
+	return func(client *Client) {
+		for _, method := range methods {
+			client.bodyMethods = append(client.bodyMethods, strings.ToUpper(method))
+		}
+	}
+}
+
+// WithRequestCompression gzips the bodies of outgoing POST/PUT/PATCH (and WithRequestBody) requests that are at least
+// 'minSize' bytes, and sets the "Content-Encoding: gzip" header so that the remote gateway knows to decompress them.
+// This can save a lot of bandwidth for clients that send large payloads. Bodies smaller than 'minSize' are sent as-is.
+func WithRequestCompression(minSize int) ClientOption {
+	return func(client *Client) {
+		client.compressRequests = true
+		client.compressMinSize = minSize
+	}
+}
+
+// WithBrotli lets the client accept Brotli-compressed responses, which are often quite a bit smaller than gzipped ones.
+// Go's standard library can't decompress Brotli, and Frodo doesn't want to force a dependency on every project, so
+// you supply the decompressor. For instance, using github.com/andybalholm/brotli:
+//
+//	client := gen.CatalogServiceClient(address, clients.WithBrotli(func(r io.Reader) io.Reader {
+//		return brotli.NewReader(r)
+//	}))
+//
+// Every request then sends "Accept-Encoding: gzip, br", and we decompress the response based on its Content-Encoding.
+// Since we're asking for gzip ourselves, the HTTP transport no longer decompresses gzipped responses for you, so we
+// take care of those, too. Your client middleware always sees the decompressed response.
+//
+// Frodo's API gateway doesn't compress responses itself, so this only helps when something in front of your service
+// (e.g. a proxy or CDN) does. A nil decompressor leaves the client's default behavior alone.
+func WithBrotli(newReader func(io.Reader) io.Reader) ClientOption {
+	return func(client *Client) {
+		client.brotliReader = newReader
+	}
+}
+
+// WithTimeFormat changes how the client writes time.Time values in request bodies and query strings. The layout
+// is the same as the one you'd give to time.Time.Format(). By default, times are written in RFC 3339 format.
+//
+//	client := gen.BillingServiceClient(address, clients.WithTimeFormat(time.DateOnly))
+//
+// This only affects what the client sends, not how it decodes responses. Types with their own MarshalJSON()
+// are left alone.
+func WithTimeFormat(layout string) ClientOption {
+	return func(client *Client) {
+		client.timeFormat = func(t time.Time) string {
+			return t.Format(layout)
+		}
+	}
+}
+
+// WithDurationFormat changes how the client writes time.Duration values in request bodies and query strings.
+// By default, durations are written as integer nanoseconds, which is how encoding/json does it. Your format
+// function's text is sent as a JSON string. For instance, to talk to an API that expects ISO 8601 durations:
+//
+//	client := gen.BillingServiceClient(address, clients.WithDurationFormat(clients.ISO8601Duration))
+//
+// This only affects what the client sends, not how it decodes responses. Types with their own MarshalJSON()
+// are left alone.
+func WithDurationFormat(format func(time.Duration) string) ClientOption {
+	return func(client *Client) {
+		client.durationFormat = format
+	}
+}
+
+// ISO8601Duration formats the duration as an ISO 8601 duration such as "PT1H30M" or "PT0.5S". It only uses hours,
+// minutes, and seconds since days/months/years aren't a fixed length. Use it with WithDurationFormat().
+func ISO8601Duration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	// Work w/ the magnitude as unsigned, so even math.MinInt64 doesn't overflow when we negate it.
+	sign := ""
+	magnitude := uint64(d)
+	if d < 0 {
+		sign = "-"
+		magnitude = uint64(-(d + 1)) + 1
+	}
+	hours := magnitude / uint64(time.Hour)
+	magnitude -= hours * uint64(time.Hour)
+	minutes := magnitude / uint64(time.Minute)
+	magnitude -= minutes * uint64(time.Minute)
+
+	text := strings.Builder{}
+	text.WriteString(sign + "PT")
+	if hours > 0 {
+		text.WriteString(strconv.FormatUint(hours, 10) + "H")
+	}
+	if minutes > 0 {
+		text.WriteString(strconv.FormatUint(minutes, 10) + "M")
+	}
+	if magnitude > 0 {
+		text.WriteString(strconv.FormatFloat(time.Duration(magnitude).Seconds(), 'f', -1, 64) + "S")
+	}
+	return text.String()
+}
+
+// maxDrainedErrorBytes is the most of an error response body that WithLightweightErrors() will read and discard
+// so that the connection can be reused. Anything bigger than that isn't worth the effort; we just close it.
+const maxDrainedErrorBytes = 64 * 1024
+
+// WithLightweightErrors skips reading and parsing the body of failed (non-2xx) responses. You get back an error that
+// has the response's status code and the standard status text (e.g. "Not Found"), but not the message from the
+// remote service. This saves some work on hot paths where all you care about is the status.
+//
+//	client := gen.InventoryServiceClient(address, clients.WithLightweightErrors())
+//
+// The "Retry-After" header is still honored, so you can still use fail.RetryableError to back off.
+func WithLightweightErrors() ClientOption {
+	return func(client *Client) {
+		client.lightweightErrors = true
+	}
+}
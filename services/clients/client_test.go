@@ -3,18 +3,28 @@
 package clients_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
+	"math"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/bridgekit-io/frodo/fail"
 	"github.com/bridgekit-io/frodo/internal/quiet"
 	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/bridgekit-io/frodo/services"
 	"github.com/bridgekit-io/frodo/services/clients"
 	"github.com/stretchr/testify/suite"
 )
@@ -60,6 +70,64 @@ func (suite *ClientSuite) TestNewClient_options() {
 	assert.Same(httpClient, client.HTTP, "WithHTTPClient should set the client's HTTP client")
 }
 
+// Ensure that the narrow HTTP options tweak the default client rather than replacing it.
+func (suite *ClientSuite) TestNewClient_httpOptions() {
+	assert := suite.Require()
+	tlsConfig := &tls.Config{ServerName: "dude.local"}
+	proxyURL, _ := url.Parse("http://proxy.local:3128")
+
+	client := clients.NewClient("FooService", ":9000",
+		clients.WithTimeout(5*time.Second),
+		clients.WithDialTimeout(2*time.Second),
+		clients.WithTLSConfig(tlsConfig),
+		clients.WithProxy(proxyURL),
+	)
+	assert.Equal(5*time.Second, client.HTTP.Timeout)
+	transport, ok := client.HTTP.Transport.(*http.Transport)
+	assert.True(ok, "Should still use the default transport")
+	assert.Equal(2*time.Second, transport.TLSHandshakeTimeout)
+	assert.Same(tlsConfig, transport.TLSClientConfig)
+	assert.NotNil(transport.DialContext)
+
+	proxy, err := transport.Proxy(httptest.NewRequest(http.MethodGet, "http://localhost:9000/FooService.Bar", nil))
+	assert.NoError(err)
+	assert.Equal("http://proxy.local:3128", proxy.String())
+
+	// Only supplying some options should keep the defaults for the rest.
+	client = clients.NewClient("FooService", ":9000", clients.WithTimeout(5*time.Second))
+	assert.Equal(5*time.Second, client.HTTP.Timeout)
+	assert.Equal(clients.DefaultTimeout, client.HTTP.Transport.(*http.Transport).TLSHandshakeTimeout)
+	assert.Nil(client.HTTP.Transport.(*http.Transport).Proxy)
+
+	// Your own HTTP client is already configured how you want it, regardless of the order of the options.
+	httpClient := &http.Client{Timeout: time.Minute}
+	client = clients.NewClient("FooService", ":9000", clients.WithTimeout(5*time.Second), clients.WithHTTPClient(httpClient))
+	assert.Same(httpClient, client.HTTP)
+	assert.Equal(time.Minute, client.HTTP.Timeout)
+}
+
+// Ensure that the context-aware constructor builds the same client, but fails when it can't finish setting up.
+func (suite *ClientSuite) TestNewClientContext() {
+	assert := suite.Require()
+	client, err := clients.NewClientContext(context.Background(), "FooService", "localhost:9000")
+	assert.NoError(err)
+	assert.Equal("FooService", client.Name)
+	assert.Equal("http://localhost:9000", client.BaseURL)
+	assert.NotNil(client.HTTP)
+
+	// Nothing to resolve for IP addresses or blank addresses.
+	_, err = clients.NewClientContext(context.Background(), "FooService", "127.0.0.1:9000")
+	assert.NoError(err)
+	_, err = clients.NewClientContext(context.Background(), "FooService", ":9000")
+	assert.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client, err = clients.NewClientContext(ctx, "FooService", "127.0.0.1:9000")
+	assert.ErrorIs(err, context.Canceled)
+	assert.Equal("", client.Name, "Should not return a partially set up client")
+}
+
 // Ensures that an RPC client can invoke an HTTP GET endpoint. All of the service request values should
 // be set on the query string.
 func (suite *ClientSuite) TestInvoke_get() {
@@ -109,6 +177,211 @@ func (suite *ClientSuite) TestInvoke_post() {
 	assert.Equal("Loblaw", out.Name)
 }
 
+// Ensures that DELETE requests use the query string by default, but send a body when told to.
+func (suite *ClientSuite) TestInvoke_deleteWithBody() {
+	assert := suite.Require()
+	var query url.Values
+	var body *clientRequest
+	roundTripper := clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		query = r.URL.Query()
+		body = nil
+		if r.Body != nil {
+			body, _ = suite.unmarshal(r)
+		}
+		return suite.respond(200, &clientResponse{ID: "Bob"})
+	})
+
+	in := &clientRequest{ID: "123", Inner: clientInner{Test: "Abide", Skip: 100}}
+
+	client := clients.NewClient("Test", "http://localhost:9000")
+	client.HTTP.Transport = roundTripper
+	assert.NoError(client.Invoke(context.Background(), "DELETE", "/foo/{ID}", in, &clientResponse{}))
+	assert.Equal("Abide", query.Get("Inner.Test"))
+	assert.Nil(body, "DELETE should not send a body by default")
+
+	client = clients.NewClient("Test", "http://localhost:9000", clients.WithRequestBody("delete"))
+	client.HTTP.Transport = roundTripper
+	assert.NoError(client.Invoke(context.Background(), "DELETE", "/foo/{ID}", in, &clientResponse{}))
+	assert.Empty(query, "DELETE w/ a body should not have a query string")
+	assert.NotNil(body)
+	assert.Equal("Abide", body.Inner.Test)
+	assert.Equal(100, body.Inner.Skip)
+
+	// Other methods should be unaffected.
+	assert.NoError(client.Invoke(context.Background(), "GET", "/foo/{ID}", in, &clientResponse{}))
+	assert.Equal("Abide", query.Get("Inner.Test"))
+	assert.Nil(body)
+}
+
+// Ensures that request compression gzips bodies over the threshold and leaves small ones alone.
+func (suite *ClientSuite) TestInvoke_requestCompression() {
+	assert := suite.Require()
+	var encoding string
+	var body *clientRequest
+	roundTripper := clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		defer quiet.Close(r.Body)
+		encoding = r.Header.Get("Content-Encoding")
+		reader := io.Reader(r.Body)
+		if encoding == "gzip" {
+			gzipReader, err := gzip.NewReader(r.Body)
+			assert.NoError(err, "Client.Invoke() - compressed body should be valid gzip")
+			reader = gzipReader
+		}
+		body = &clientRequest{}
+		assert.NoError(json.NewDecoder(reader).Decode(body))
+		return suite.respond(200, &clientResponse{ID: "Bob"})
+	})
+	client := clients.NewClient("Test", "http://localhost:9000", clients.WithRequestCompression(150))
+	client.HTTP.Transport = roundTripper
+
+	err := client.Invoke(context.Background(), "POST", "/foo", &clientRequest{ID: strings.Repeat("x", 200)}, &clientResponse{})
+	assert.NoError(err)
+	assert.Equal("gzip", encoding)
+	assert.Equal(strings.Repeat("x", 200), body.ID)
+
+	err = client.Invoke(context.Background(), "POST", "/foo", &clientRequest{ID: "1"}, &clientResponse{})
+	assert.NoError(err)
+	assert.Equal("", encoding, "Client.Invoke() - should not compress bodies under the minimum size")
+	assert.Equal("1", body.ID)
+}
+
+// Ensures that requests with a body tell the gateway that it's JSON, so WithRequireContentType() gateways accept them.
+func (suite *ClientSuite) TestInvoke_contentType() {
+	assert := suite.Require()
+	var contentType string
+	roundTripper := clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		contentType = r.Header.Get("Content-Type")
+		return suite.respond(200, &clientResponse{ID: "Bob"})
+	})
+	client := clients.NewClient("Test", "http://localhost:9000")
+	client.HTTP.Transport = roundTripper
+
+	assert.NoError(client.Invoke(context.Background(), "POST", "/foo", &clientRequest{ID: "1"}, &clientResponse{}))
+	assert.Equal("application/json", contentType)
+
+	assert.NoError(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{ID: "1"}, &clientResponse{}))
+	assert.Equal("", contentType, "Client.Invoke() - should not set a content type w/o a body")
+}
+
+// Ensures that WithTimeFormat() and WithDurationFormat() change how the request is written in both the query and body.
+func (suite *ClientSuite) TestInvoke_timeAndDurationFormat() {
+	type scheduleRequest struct {
+		Start    time.Time
+		Interval time.Duration
+	}
+
+	assert := suite.Require()
+	var query url.Values
+	var body map[string]any
+	roundTripper := clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		query = r.URL.Query()
+		body = nil
+		if r.Body != nil {
+			assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		}
+		return suite.respond(200, &clientResponse{ID: "Bob"})
+	})
+	in := &scheduleRequest{
+		Start:    time.Date(2024, 11, 15, 12, 30, 0, 0, time.UTC),
+		Interval: 90 * time.Minute,
+	}
+
+	client := clients.NewClient("Test", "http://localhost:9000",
+		clients.WithTimeFormat(time.DateOnly),
+		clients.WithDurationFormat(clients.ISO8601Duration),
+	)
+	client.HTTP.Transport = roundTripper
+
+	assert.NoError(client.Invoke(context.Background(), "POST", "/schedule", in, &clientResponse{}))
+	assert.Equal(map[string]any{"Start": "2024-11-15", "Interval": "PT1H30M"}, body)
+
+	assert.NoError(client.Invoke(context.Background(), "GET", "/schedule", in, &clientResponse{}))
+	assert.Equal("2024-11-15", query.Get("Start"))
+	assert.Equal("PT1H30M", query.Get("Interval"))
+
+	// The defaults are RFC 3339 and nanoseconds.
+	client = clients.NewClient("Test", "http://localhost:9000")
+	client.HTTP.Transport = roundTripper
+
+	assert.NoError(client.Invoke(context.Background(), "POST", "/schedule", in, &clientResponse{}))
+	assert.Equal(map[string]any{"Start": "2024-11-15T12:30:00Z", "Interval": float64(90 * time.Minute)}, body)
+}
+
+func (suite *ClientSuite) TestISO8601Duration() {
+	suite.Equal("PT0S", clients.ISO8601Duration(0))
+	suite.Equal("PT0.5S", clients.ISO8601Duration(500*time.Millisecond))
+	suite.Equal("PT1M", clients.ISO8601Duration(time.Minute))
+	suite.Equal("PT1H30M", clients.ISO8601Duration(90*time.Minute))
+	suite.Equal("PT26H3M4.005S", clients.ISO8601Duration(26*time.Hour+3*time.Minute+4*time.Second+5*time.Millisecond))
+	suite.Equal("-PT1H1S", clients.ISO8601Duration(-time.Hour-time.Second))
+	suite.Equal("-PT2562047H47M16.854775808S", clients.ISO8601Duration(math.MinInt64))
+}
+
+// Ensures that WithBrotli() asks for compressed responses and decompresses both Brotli and gzip bodies.
+func (suite *ClientSuite) TestInvoke_brotli() {
+	assert := suite.Require()
+	var acceptEncoding string
+	responseEncoding := "br"
+	roundTripper := clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		body := &bytes.Buffer{}
+		switch responseEncoding {
+		case "br":
+			// We don't have a real Brotli implementation, so base64 stands in for the compression.
+			body.WriteString(base64.StdEncoding.EncodeToString([]byte(`{"ID":"Bob"}`)))
+		case "gzip":
+			writer := gzip.NewWriter(body)
+			_, _ = writer.Write([]byte(`{"ID":"Bob"}`))
+			_ = writer.Close()
+		default:
+			body.WriteString(`{"ID":"Bob"}`)
+		}
+		header := http.Header{"Content-Encoding": []string{responseEncoding}, "Content-Length": []string{strconv.Itoa(body.Len())}}
+		return &http.Response{StatusCode: 200, Header: header, Body: io.NopCloser(body)}, nil
+	})
+	brotliReader := func(r io.Reader) io.Reader {
+		return base64.NewDecoder(base64.StdEncoding, r)
+	}
+
+	var middlewareEncoding []string
+	client := clients.NewClient("Test", "http://localhost:9000",
+		clients.WithBrotli(brotliReader),
+		clients.WithMiddleware(func(r *http.Request, next clients.RoundTripperFunc) (*http.Response, error) {
+			res, err := next(r)
+			middlewareEncoding = append(middlewareEncoding, res.Header.Get("Content-Encoding"))
+			return res, err
+		}),
+	)
+	client.HTTP.Transport = roundTripper
+
+	for _, encoding := range []string{"br", "gzip", ""} {
+		responseEncoding = encoding
+		out := &clientResponse{}
+		assert.NoError(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{}, out), encoding)
+		assert.Equal("Bob", out.ID, encoding)
+		assert.Equal("gzip, br", acceptEncoding)
+	}
+	assert.Equal([]string{"", "", ""}, middlewareEncoding, "Middleware should see decompressed responses")
+
+	client = suite.newClient(roundTripper)
+	responseEncoding = ""
+	assert.NoError(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{}, &clientResponse{}))
+	assert.Equal("", acceptEncoding, "Should leave Accept-Encoding to the transport by default")
+}
+
+// Ensures that a 204 doesn't try to decode the empty body, leaving the response zeroed out.
+func (suite *ClientSuite) TestInvoke_noContent() {
+	assert := suite.Require()
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 204, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+
+	out := &clientResponse{}
+	err := client.Invoke(context.Background(), "POST", "/foo", &clientRequest{ID: "123"}, out)
+	assert.NoError(err)
+	assert.Equal(clientResponse{}, *out)
+}
+
 // Ensures that an RPC client fills in path params (e.g. "/{id}"->"/1234"). We will make sure
 // that path param substitutions:
 //
@@ -146,6 +419,44 @@ func (suite *ClientSuite) TestInvoke_pathParams() {
 	assert.Equal("Loblaw", out.Name)
 }
 
+// Ensures that a wildcard path param like "{Path...}" keeps the slashes in its value while still escaping
+// each individual segment.
+func (suite *ClientSuite) TestInvoke_wildcardPathParam() {
+	assert := suite.Require()
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		suite.assertURL(r, "http://localhost:9000/files/42/docs/2024/q1%20report.pdf")
+		assert.Empty(r.URL.Query().Get("ID"), "Should not be in the query string when field is in the path")
+		return suite.respond(200, &clientResponse{ID: "Bob"})
+	})
+
+	in := &clientRequest{ID: "docs/2024/q1 report.pdf", Int: 42}
+	err := client.Invoke(context.Background(), "GET", "/files/{Int}/{ID...}", in, &clientResponse{})
+	assert.NoError(err)
+}
+
+// Ensures that we warn you the first time you call a deprecated function, but don't keep nagging you.
+func (suite *ClientSuite) TestInvoke_deprecated() {
+	logs := &strings.Builder{}
+	client := clients.NewClient("Test", "http://localhost:9000", clients.WithLogger(slog.New(slog.NewTextHandler(logs, nil))))
+	client.HTTP.Transport = clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		res, err := suite.respond(200, &clientResponse{ID: "Bob"})
+		if strings.HasPrefix(r.URL.Path, "/old") {
+			res.Header = http.Header{"Deprecation": {"true"}, "Sunset": {"Sun, 01 Jun 2025 00:00:00 GMT"}}
+		}
+		return res, err
+	})
+
+	for i := 0; i < 3; i++ {
+		suite.Require().NoError(client.Invoke(context.Background(), "GET", "/old/{ID}", &clientRequest{ID: "123"}, &clientResponse{}))
+		suite.Require().NoError(client.Invoke(context.Background(), "GET", "/new/{ID}", &clientRequest{ID: "123"}, &clientResponse{}))
+	}
+
+	suite.Equal(1, strings.Count(logs.String(), "deprecated"), "Should only warn once: %s", logs.String())
+	suite.Contains(logs.String(), "level=WARN")
+	suite.Contains(logs.String(), "path=/old/{ID}")
+	suite.Contains(logs.String(), `sunset="Sun, 01 Jun 2025 00:00:00 GMT"`)
+}
+
 // Ensures that an RPC client will translate 4XX/5XX errors into the
 // equivalent status-coded error.
 func (suite *ClientSuite) TestInvoke_httpStatusError() {
@@ -194,6 +505,174 @@ func (suite *ClientSuite) TestInvoke_httpStatusError() {
 	assert.NotContains(err.Error(), "broke as hell", "Client.Invoke() - not include unknown error message formats")
 }
 
+// Ensures that the client understands RFC 7807 errors from gateways using apis.WithProblemJSON().
+func (suite *ClientSuite) TestInvoke_problemJSONError() {
+	assert := suite.Require()
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		typeProblem := http.Header{"Content-Type": []string{"application/problem+json"}}
+		switch r.URL.Path {
+		case "/404":
+			body := `{"type":"about:blank", "title":"Not Found", "status":404, "detail":"not here, dude", "instance":"/404"}`
+			return &http.Response{StatusCode: 404, Header: typeProblem, Body: io.NopCloser(strings.NewReader(body))}, nil
+		case "/409":
+			body := `{"type":"about:blank", "title":"Conflict", "status":409}`
+			return &http.Response{StatusCode: 409, Header: typeProblem, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+		panic("how did you get here?")
+	})
+
+	err := client.Invoke(context.Background(), "POST", "/404", &clientRequest{}, &clientResponse{})
+	assert.Equal(404, fail.Status(err))
+	assert.Contains(err.Error(), "rpc error: not here, dude")
+
+	err = client.Invoke(context.Background(), "POST", "/409", &clientRequest{}, &clientResponse{})
+	assert.Equal(409, fail.Status(err))
+	assert.Contains(err.Error(), "rpc error: Conflict", "Should fall back to the title w/o a detail")
+}
+
+type clientStreamResponse struct {
+	services.JSONStream[clientResponse]
+}
+
+// Ensures that NDJSON responses are handed to the response so you can iterate over the items as they arrive.
+func (suite *ClientSuite) TestInvoke_jsonStream() {
+	assert := suite.Require()
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		header := http.Header{"Content-Type": []string{"application/x-ndjson"}}
+		body := io.NopCloser(strings.NewReader("{\"ID\":\"Dude\"}\n{\"ID\":\"Walter\"}\n"))
+		return &http.Response{StatusCode: 200, Header: header, Body: body}, nil
+	})
+
+	out := &clientStreamResponse{}
+	assert.NoError(client.Invoke(context.Background(), "GET", "/export", &clientRequest{}, out))
+
+	var ids []string
+	for item, err := range out.Items() {
+		assert.NoError(err)
+		ids = append(ids, item.ID)
+	}
+	assert.Equal([]string{"Dude", "Walter"}, ids)
+}
+
+// Ensures that WithLightweightErrors() builds errors from the status alone, but still consumes the body.
+func (suite *ClientSuite) TestInvoke_lightweightErrors() {
+	assert := suite.Require()
+	body := &trackingBody{Reader: strings.NewReader(`{"Status":404, "Message":"not here, dude"}`)}
+	client := clients.NewClient("Test", "http://localhost:9000", clients.WithLightweightErrors())
+	client.HTTP.Transport = clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		header := http.Header{"Content-Type": []string{"application/json"}, "Retry-After": []string{"5"}}
+		return &http.Response{StatusCode: 404, Header: header, Body: body}, nil
+	})
+
+	err := client.Invoke(context.Background(), "POST", "/404", &clientRequest{}, &clientResponse{})
+	assert.Equal(404, fail.Status(err))
+	assert.Contains(err.Error(), "Not Found")
+	assert.NotContains(err.Error(), "not here, dude", "Should not parse the body")
+	assert.True(body.closed, "Should close the body")
+	assert.Zero(body.Len(), "Should drain the body")
+
+	delay, ok := fail.RetryAfter(err)
+	assert.True(ok, "Should still honor Retry-After")
+	assert.Equal(5*time.Second, delay)
+}
+
+// Ensures that following a redirect to some other host (e.g. a signed S3 URL) doesn't leak our credentials.
+func (suite *ClientSuite) TestInvoke_redirectStripsCredentials() {
+	assert := suite.Require()
+	received := map[string]http.Header{}
+	client := clients.NewClient("Test", "http://localhost:9000", clients.WithCallerName("Walter"))
+	client.HTTP.Transport = clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		received[r.URL.Host+r.URL.Path] = r.Header.Clone()
+		switch r.URL.Path {
+		case "/external":
+			return &http.Response{StatusCode: 307, Header: http.Header{"Location": []string{"https://s3.example.com/file?sig=123"}}, Body: http.NoBody}, nil
+		case "/internal":
+			return &http.Response{StatusCode: 307, Header: http.Header{"Location": []string{"/file"}}, Body: http.NoBody}, nil
+		default:
+			typeJSON := http.Header{"Content-Type": []string{"application/json"}}
+			return &http.Response{StatusCode: 200, Header: typeJSON, Body: io.NopCloser(strings.NewReader(`{"ID":"abc"}`))}, nil
+		}
+	})
+
+	ctx := metadata.WithAuthorization(context.Background(), "Bearer 12345")
+	ctx = clients.WithRequestHeader(ctx, "X-Tenant-ID", "dude")
+
+	out := &clientResponse{}
+	assert.NoError(client.Invoke(ctx, "GET", "/external", &clientRequest{}, out))
+	assert.Equal("abc", out.ID, "Should still follow the redirect")
+	assert.Equal("Bearer 12345", received["localhost:9000/external"].Get("Authorization"))
+	assert.NotEmpty(received["localhost:9000/external"].Get(metadata.Header))
+
+	external := received["s3.example.com/file"]
+	assert.Empty(external.Get("Authorization"), "Should not send our credentials to another host")
+	assert.Empty(external.Get(metadata.Header), "Should not send our metadata to another host")
+	assert.Empty(external.Get(metadata.CallerHeader), "Should not send our caller to another host")
+	assert.Equal("dude", external.Get("X-Tenant-ID"), "Should leave your own headers alone")
+
+	assert.NoError(client.Invoke(ctx, "GET", "/internal", &clientRequest{}, out))
+	internal := received["localhost:9000/file"]
+	assert.Equal("Bearer 12345", internal.Get("Authorization"), "Same-origin redirects should keep our credentials")
+	assert.NotEmpty(internal.Get(metadata.Header), "Same-origin redirects should keep our metadata")
+	assert.Equal("Walter", internal.Get(metadata.CallerHeader), "Same-origin redirects should keep our caller")
+}
+
+type trackingBody struct {
+	*strings.Reader
+	closed bool
+}
+
+func (body *trackingBody) Close() error {
+	body.closed = true
+	return nil
+}
+
+// Ensures that the client exposes the server's "Retry-After" header on the error.
+func (suite *ClientSuite) TestInvoke_retryAfter() {
+	assert := suite.Require()
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		typeJSON := http.Header{"Content-Type": []string{"application/json"}}
+		body := io.NopCloser(strings.NewReader(`{"Status":429, "Message":"slow down"}`))
+		switch r.URL.Path {
+		case "/seconds":
+			typeJSON.Set("Retry-After", "120")
+		case "/date":
+			typeJSON.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		case "/past":
+			typeJSON.Set("Retry-After", "Wed, 21 Oct 2015 07:28:00 GMT")
+		case "/garbage":
+			typeJSON.Set("Retry-After", "whenever")
+		}
+		return &http.Response{StatusCode: 429, Header: typeJSON, Body: body}, nil
+	})
+
+	err := client.Invoke(context.Background(), "POST", "/seconds", &clientRequest{}, &clientResponse{})
+	assert.True(fail.IsThrottled(err))
+	assert.Contains(err.Error(), "rpc error: slow down")
+	delay, ok := fail.RetryAfter(err)
+	assert.True(ok)
+	assert.Equal(2*time.Minute, delay)
+
+	err = client.Invoke(context.Background(), "POST", "/date", &clientRequest{}, &clientResponse{})
+	delay, ok = fail.RetryAfter(err)
+	assert.True(ok)
+	assert.InDelta(time.Hour, delay, float64(5*time.Second))
+
+	err = client.Invoke(context.Background(), "POST", "/past", &clientRequest{}, &clientResponse{})
+	delay, ok = fail.RetryAfter(err)
+	assert.True(ok)
+	assert.Equal(time.Duration(0), delay, "Dates in the past mean you can try again now")
+
+	err = client.Invoke(context.Background(), "POST", "/garbage", &clientRequest{}, &clientResponse{})
+	assert.True(fail.IsThrottled(err))
+	_, ok = fail.RetryAfter(err)
+	assert.False(ok)
+
+	err = client.Invoke(context.Background(), "POST", "/none", &clientRequest{}, &clientResponse{})
+	assert.True(fail.IsThrottled(err))
+	_, ok = fail.RetryAfter(err)
+	assert.False(ok)
+}
+
 // Check all of the different ways that Invoke() can fail.
 func (suite *ClientSuite) TestInvoke_roundTripError() {
 	assert := suite.Require()
@@ -267,6 +746,263 @@ func (suite *ClientSuite) TestInvoke_includeHeaders() {
 	))
 }
 
+func (suite *ClientSuite) TestInvoke_responseEnvelope() {
+	assert := suite.Require()
+	body := `{"data":{"ID":"123","Name":"Dude"},"meta":{"traceId":"abc"}}`
+	client := clients.NewClient("Test", "http://localhost:9000", clients.WithResponseEnvelope("data"))
+	client.HTTP.Transport = clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+
+	out := &clientResponse{}
+	assert.NoError(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{}, out))
+	assert.Equal(&clientResponse{ID: "123", Name: "Dude"}, out)
+
+	body = `{"meta":{"traceId":"abc"}}`
+	out = &clientResponse{}
+	assert.NoError(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{}, out))
+	assert.Equal(&clientResponse{}, out, "Missing data should leave the response empty")
+
+	body = `[1, 2, 3]`
+	assert.Error(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{}, out))
+}
+
+type rawClientResponse struct {
+	services.RawJSON
+}
+
+func (suite *ClientSuite) TestInvoke_rawJSON() {
+	assert := suite.Require()
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		body := `{"ID":"123", "Name":"Dude"}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+
+	out := &rawClientResponse{}
+	assert.NoError(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{}, out))
+	assert.Equal(`{"ID":"123", "Name":"Dude"}`, string(out.Raw()), "Should hand over the body as-is")
+
+	// Envelopes need to be unwrapped first, but we should still end up w/ just the data.
+	client = clients.NewClient("Test", "http://localhost:9000", clients.WithResponseEnvelope("data"))
+	client.HTTP.Transport = clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		body := `{"data":{"ID":"123","Name":"Dude"},"meta":{"traceId":"abc"}}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+	out = &rawClientResponse{}
+	assert.NoError(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{}, out))
+	assert.JSONEq(`{"ID":"123","Name":"Dude"}`, string(out.Raw()))
+}
+
+func (suite *ClientSuite) TestInvoke_customHeaders() {
+	assert := suite.Require()
+	headers := http.Header{}
+	client := clients.NewClient("Test", "http://localhost:9000",
+		clients.WithHeader("X-Tenant-ID", "default"),
+		clients.WithHeader("X-Region", "us-east-1"),
+	)
+	client.HTTP.Transport = clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		headers = r.Header.Clone()
+		return suite.respond(200, &clientResponse{ID: "123"})
+	})
+
+	assert.NoError(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{}, &clientResponse{}))
+	assert.Equal("default", headers.Get("X-Tenant-ID"))
+	assert.Equal("us-east-1", headers.Get("X-Region"))
+
+	ctx := clients.WithRequestHeader(context.Background(), "X-Tenant-ID", "abc")
+	ctx = clients.WithRequestHeader(ctx, "X-Request-Source", "mobile")
+	assert.NoError(client.Invoke(ctx, "GET", "/foo", &clientRequest{}, &clientResponse{}))
+	assert.Equal("abc", headers.Get("X-Tenant-ID"), "Per-call headers should override client headers")
+	assert.Equal("us-east-1", headers.Get("X-Region"))
+	assert.Equal("mobile", headers.Get("X-Request-Source"))
+	assert.NotContains(headers.Get(metadata.Header), "abc", "Raw headers should not be treated as metadata")
+}
+
+// Ensures that the client tells the remote service who is calling it.
+func (suite *ClientSuite) TestInvoke_callerHeader() {
+	assert := suite.Require()
+	var caller []string
+	transport := clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		caller = r.Header.Values(metadata.CallerHeader)
+		return suite.respond(200, &clientResponse{ID: "123"})
+	})
+	handlerCtx := metadata.WithRoute(context.Background(), metadata.EndpointRoute{ServiceName: "BillingService", Name: "Charge"})
+
+	client := clients.NewClient("Test", "http://localhost:9000")
+	client.HTTP.Transport = transport
+	assert.NoError(client.Invoke(handlerCtx, "GET", "/foo", &clientRequest{}, &clientResponse{}))
+	assert.Equal([]string{"BillingService"}, caller, "Should identify as the service making the call")
+
+	assert.NoError(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{}, &clientResponse{}))
+	assert.Empty(caller, "Calls from outside a service function don't identify themselves")
+
+	client = clients.NewClient("Test", "http://localhost:9000", clients.WithCallerName("ReportWorker"))
+	client.HTTP.Transport = transport
+	assert.NoError(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{}, &clientResponse{}))
+	assert.Equal([]string{"ReportWorker"}, caller)
+	assert.NoError(client.Invoke(handlerCtx, "GET", "/foo", &clientRequest{}, &clientResponse{}))
+	assert.Equal([]string{"ReportWorker"}, caller, "An explicit name should always win")
+}
+
+// Ensures that the client forwards the call's trace id in the configured header, generating one if necessary.
+func (suite *ClientSuite) TestInvoke_traceIDHeader() {
+	defer metadata.WithTraceIDGenerator(nil)
+	metadata.WithTraceIDGenerator(func() string { return "00-generated-01" })
+
+	assert := suite.Require()
+	var header http.Header
+	transport := clients.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		header = r.Header.Clone()
+		return suite.respond(200, &clientResponse{ID: "123"})
+	})
+	handlerCtx := metadata.WithTraceID(context.Background(), "abc")
+
+	client := clients.NewClient("Test", "http://localhost:9000")
+	client.HTTP.Transport = transport
+	assert.NoError(client.Invoke(handlerCtx, "GET", "/foo", &clientRequest{}, &clientResponse{}))
+	assert.Equal("abc", header.Get("X-Request-ID"), "Should forward the trace id from the context")
+
+	client = clients.NewClient("Test", "http://localhost:9000", clients.WithTraceIDHeader("traceparent"))
+	client.HTTP.Transport = transport
+	assert.NoError(client.Invoke(handlerCtx, "GET", "/foo", &clientRequest{}, &clientResponse{}))
+	assert.Equal("abc", header.Get("traceparent"), "Should forward the trace id in the configured header")
+	assert.Empty(header.Get("X-Request-ID"), "Should not send the default header")
+
+	assert.NoError(client.Invoke(context.Background(), "GET", "/foo", &clientRequest{}, &clientResponse{}))
+	assert.Equal("00-generated-01", header.Get("traceparent"), "Should generate a trace id when there isn't one")
+}
+
+func (suite *ClientSuite) TestWithRequestHeader_doesNotAffectParent() {
+	assert := suite.Require()
+	var tenant string
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		tenant = r.Header.Get("X-Tenant-ID")
+		return suite.respond(200, &clientResponse{ID: "123"})
+	})
+
+	parent := clients.WithRequestHeader(context.Background(), "X-Tenant-ID", "abc")
+	_ = clients.WithRequestHeader(parent, "X-Tenant-ID", "xyz")
+	assert.NoError(client.Invoke(parent, "GET", "/foo", &clientRequest{}, &clientResponse{}))
+	assert.Equal("abc", tenant)
+}
+
+func (suite *ClientSuite) TestPing() {
+	assert := suite.Require()
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		suite.assertURL(r, "http://localhost:9000/_frodo/ping")
+		assert.Equal(http.MethodGet, r.Method)
+		assert.Equal("Hello", r.Header.Get("Authorization"), "Ping should run through the standard client middleware")
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+
+	ctx := metadata.WithAuthorization(context.Background(), "Hello")
+	assert.NoError(client.Ping(ctx))
+
+	var pinger clients.Pinger = client
+	assert.NoError(pinger.Ping(ctx))
+}
+
+func (suite *ClientSuite) TestPing_error() {
+	assert := suite.Require()
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		switch r.Header.Get("Authorization") {
+		case "":
+			return nil, fmt.Errorf("connection refused")
+		default:
+			body := io.NopCloser(strings.NewReader("service unavailable"))
+			return &http.Response{StatusCode: 503, Header: http.Header{"Content-Type": []string{"text/plain"}}, Body: body}, nil
+		}
+	})
+
+	err := client.Ping(context.Background())
+	assert.Error(err, "Ping should fail when the service is unreachable")
+	assert.Contains(err.Error(), "connection refused")
+
+	err = client.Ping(metadata.WithAuthorization(context.Background(), "Hello"))
+	assert.Error(err, "Ping should fail when the service responds w/ a failure status")
+	assert.Contains(err.Error(), "service unavailable")
+}
+
+func (suite *ClientSuite) TestBatch() {
+	assert := suite.Require()
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		suite.assertURL(r, "http://localhost:9000/_frodo/batch")
+		assert.Equal(http.MethodPost, r.Method)
+
+		batch := struct {
+			Requests []struct{ Method, Path string }
+		}{}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&batch))
+		assert.Len(batch.Requests, 2)
+		assert.Equal("GET", batch.Requests[0].Method)
+		assert.True(strings.HasPrefix(batch.Requests[0].Path, "/user/123?"), "Paths should be relative to the base URL")
+		assert.Equal("POST", batch.Requests[1].Method)
+		assert.Equal("/user", batch.Requests[1].Path)
+
+		body := `{"Responses":[
+			{"Status":200, "Body":"eyJJRCI6IjEyMyIsIk5hbWUiOiJEdWRlIn0="},
+			{"Status":409, "Header":{"Content-Type":["text/plain"]}, "Body":"YWxyZWFkeSBleGlzdHM="}
+		]}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})
+
+	outA := &clientResponse{}
+	outB := &clientResponse{}
+	batch := clients.NewBatch(client)
+	batch.Add("get", "/user/{ID}", &clientRequest{ID: "123"}, outA)
+	batch.Add("POST", "/user", &clientRequest{ID: "456"}, outB)
+
+	errs := batch.Run(context.Background())
+	assert.Len(errs, 2)
+	assert.NoError(errs[0])
+	assert.Equal("123", outA.ID)
+	assert.Equal("Dude", outA.Name)
+	assert.Error(errs[1])
+	assert.Contains(errs[1].Error(), "already exists")
+}
+
+func (suite *ClientSuite) TestBatch_fallback() {
+	assert := suite.Require()
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/_frodo/batch":
+			return &http.Response{StatusCode: 404, Body: io.NopCloser(strings.NewReader("not found"))}, nil
+		case "/user/123":
+			return suite.respond(200, &clientResponse{ID: "123", Name: "Dude"})
+		default:
+			return suite.respond(200, &clientResponse{ID: "456", Name: "Walter"})
+		}
+	})
+
+	outA := &clientResponse{}
+	outB := &clientResponse{}
+	batch := clients.NewBatch(client)
+	batch.Add("GET", "/user/{ID}", &clientRequest{ID: "123"}, outA)
+	batch.Add("POST", "/user", &clientRequest{ID: "456"}, outB)
+
+	errs := batch.Run(context.Background())
+	assert.Equal([]error{nil, nil}, errs)
+	assert.Equal("Dude", outA.Name)
+	assert.Equal("Walter", outB.Name)
+}
+
+func (suite *ClientSuite) TestBatch_failure() {
+	assert := suite.Require()
+	client := suite.newClient(func(r *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("connection refused")
+	})
+
+	batch := clients.NewBatch(client)
+	assert.Nil(batch.Run(context.Background()), "An empty batch shouldn't do anything")
+
+	batch.Add("GET", "/user/{ID}", &clientRequest{ID: "123"}, &clientResponse{})
+	batch.Add("POST", "/user", &clientRequest{ID: "456"}, &clientResponse{})
+	errs := batch.Run(context.Background())
+	assert.Len(errs, 2)
+	assert.ErrorContains(errs[0], "connection refused")
+	assert.ErrorContains(errs[1], "connection refused")
+}
+
 func (suite *ClientSuite) newClient(roundTripper clients.RoundTripperFunc) clients.Client {
 	client := clients.NewClient("Test", "http://localhost:9000")
 	client.HTTP.Transport = roundTripper
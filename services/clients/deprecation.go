@@ -0,0 +1,39 @@
+package clients
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// warnDeprecated logs a warning the first time that we call a function whose response includes the "Deprecation"
+// header (see the "DEPRECATED" doc option), so you find out that you need to migrate before the function actually
+// goes away. We only warn once per function for the life of the client, so we don't flood your logs.
+func (c Client) warnDeprecated(ctx context.Context, method string, path string, response *http.Response) {
+	if c.logger == nil || c.deprecationWarnings == nil || response.Header.Get("Deprecation") == "" {
+		return
+	}
+	if _, warned := c.deprecationWarnings.LoadOrStore(method+" "+path, true); warned {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("service", c.Name),
+		slog.String("method", method),
+		slog.String("path", path),
+	}
+	if sunset := response.Header.Get("Sunset"); sunset != "" {
+		attrs = append(attrs, slog.String("sunset", sunset))
+	}
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "[frodo] calling deprecated service function", attrs...)
+}
+
+// WithLogger changes where the client writes warnings, such as when you call a deprecated service function. By
+// default, we use slog.Default().
+//
+//	client := gen.UserServiceClient(address, clients.WithLogger(logger))
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(client *Client) {
+		client.logger = logger
+	}
+}
@@ -0,0 +1,131 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bridgekit-io/frodo/internal/quiet"
+	"github.com/bridgekit-io/frodo/services"
+)
+
+// DownloadProgressFunc is notified as DownloadToFile() writes each chunk of the stream to disk. The 'written' value
+// is the total number of bytes in the file so far (including any bytes from an earlier, resumed download), and
+// 'total' is the expected size of the complete file. The total is 0 when the server didn't tell us the size.
+type DownloadProgressFunc func(written int64, total int64)
+
+// DownloadToFile copies the raw content stream of a response (e.g. your services.StreamResponse) to the file at the
+// given path, closing the stream when it's done. This saves you from juggling buffers, file handles, and progress
+// tracking yourself when downloading large files. You can pass a nil progress callback if you don't need it.
+//
+//	res, err := fileClient.Download(ctx, &files.DownloadRequest{ID: "123"})
+//	...
+//	err = clients.DownloadToFile(ctx, res, "dude.mp4", func(written, total int64) {
+//		fmt.Printf("%d of %d bytes\n", written, total)
+//	})
+//
+// When the response has a Content-Range (see services.ContentRangeGetter) starting after byte 0, we treat it as the
+// continuation of a previous download. Rather than overwriting the file, we write the stream starting at that offset,
+// leaving the bytes you already have alone. Use the size of your partial file to ask the service for the rest:
+//
+//	info, _ := os.Stat("dude.mp4")
+//	ctx = clients.WithRequestHeader(ctx, "Range", fmt.Sprintf("bytes=%d-", info.Size()))
+//	res, err := fileClient.Download(ctx, &files.DownloadRequest{ID: "123"})
+//	...
+//	err = clients.DownloadToFile(ctx, res, "dude.mp4", onProgress)
+//
+// If the context is cancelled or the stream ends before delivering all of the bytes it promised, we return an error
+// but leave the partial file on disk, so you can resume the download later.
+func DownloadToFile(ctx context.Context, stream services.ContentGetter, path string, onProgress DownloadProgressFunc) error {
+	if stream == nil || stream.Content() == nil {
+		return fmt.Errorf("rpc: download error: response has no content stream")
+	}
+	content := stream.Content()
+	defer quiet.Close(content)
+
+	offset, total := downloadRange(stream)
+
+	// Starting from scratch? Clobber anything that's already there. Otherwise, keep what we've already
+	// downloaded, so we can pick up where we left off.
+	flags := os.O_WRONLY | os.O_CREATE
+	if offset == 0 {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("rpc: download error: %w", err)
+	}
+	defer quiet.Close(file)
+
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("rpc: download error: %w", err)
+	}
+
+	copied, err := copyWithProgress(ctx, file, content, offset, total, onProgress)
+	if err != nil {
+		return fmt.Errorf("rpc: download error: %w", err)
+	}
+
+	// The connection might drop mid-download, which looks just like a normal EOF to us. If the service told us
+	// how many bytes to expect, at least make sure that we actually got all of them.
+	if expected := downloadLength(stream); expected > 0 && copied < expected {
+		return fmt.Errorf("rpc: download error: received %d of %d bytes: %w", copied, expected, io.ErrUnexpectedEOF)
+	}
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("rpc: download error: %w", err)
+	}
+	return nil
+}
+
+// copyWithProgress copies the stream to the file, one chunk at a time, notifying the progress callback after
+// each one. It stops early if the context is cancelled. It returns how many bytes it copied from the stream.
+func copyWithProgress(ctx context.Context, file io.Writer, content io.Reader, offset int64, total int64, onProgress DownloadProgressFunc) (int64, error) {
+	buf := make([]byte, 32*1024)
+	copied := int64(0)
+	for {
+		if err := ctx.Err(); err != nil {
+			return copied, err
+		}
+
+		n, readErr := content.Read(buf)
+		if n > 0 {
+			if _, err := file.Write(buf[:n]); err != nil {
+				return copied, err
+			}
+			copied += int64(n)
+			if onProgress != nil {
+				onProgress(offset+copied, total)
+			}
+		}
+
+		switch readErr {
+		case nil:
+			continue
+		case io.EOF:
+			return copied, nil
+		default:
+			return copied, readErr
+		}
+	}
+}
+
+// downloadRange determines where in the file this stream's bytes belong and how big the complete file will
+// be once we're done. Streams without a Content-Range are the whole file, so they start at 0.
+func downloadRange(stream services.ContentGetter) (offset int64, total int64) {
+	if getter, ok := stream.(services.ContentRangeGetter); ok {
+		start, _, size := getter.ContentRange()
+		if size > 0 {
+			return int64(start), int64(size)
+		}
+	}
+	return 0, downloadLength(stream)
+}
+
+// downloadLength returns the number of bytes that should be in the stream, or 0 if we don't know.
+func downloadLength(stream services.ContentGetter) int64 {
+	if getter, ok := stream.(services.ContentLengthGetter); ok && getter.ContentLength() > 0 {
+		return int64(getter.ContentLength())
+	}
+	return 0
+}
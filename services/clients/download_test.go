@@ -0,0 +1,102 @@
+//go:build unit
+
+package clients_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/bridgekit-io/frodo/services/clients"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestDownloadSuite(t *testing.T) {
+	suite.Run(t, new(DownloadSuite))
+}
+
+type DownloadSuite struct {
+	suite.Suite
+}
+
+func (suite *DownloadSuite) stream(content string) *services.StreamResponse {
+	stream := &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader(content)))
+	stream.SetContentLength(len(content))
+	return stream
+}
+
+func (suite *DownloadSuite) read(path string) string {
+	data, err := os.ReadFile(path)
+	suite.Require().NoError(err)
+	return string(data)
+}
+
+func (suite *DownloadSuite) TestDownloadToFile() {
+	path := filepath.Join(suite.T().TempDir(), "dude.txt")
+	suite.Require().NoError(os.WriteFile(path, []byte("This should be overwritten entirely"), 0644))
+
+	var progress []int64
+	err := clients.DownloadToFile(context.Background(), suite.stream("The Dude Abides"), path, func(written int64, total int64) {
+		suite.Equal(int64(15), total)
+		progress = append(progress, written)
+	})
+	suite.Require().NoError(err)
+	suite.Equal("The Dude Abides", suite.read(path))
+	suite.Equal([]int64{15}, progress)
+}
+
+func (suite *DownloadSuite) TestDownloadToFile_nilProgress() {
+	path := filepath.Join(suite.T().TempDir(), "dude.txt")
+	suite.Require().NoError(clients.DownloadToFile(context.Background(), suite.stream("The Dude Abides"), path, nil))
+	suite.Equal("The Dude Abides", suite.read(path))
+}
+
+func (suite *DownloadSuite) TestDownloadToFile_resume() {
+	path := filepath.Join(suite.T().TempDir(), "dude.txt")
+	suite.Require().NoError(os.WriteFile(path, []byte("The Dude "), 0644))
+
+	stream := suite.stream("Abides")
+	stream.SetContentRange(9, 15, 15)
+
+	var progress []int64
+	err := clients.DownloadToFile(context.Background(), stream, path, func(written int64, total int64) {
+		suite.Equal(int64(15), total, "Total should be the size of the whole file, not just this chunk")
+		progress = append(progress, written)
+	})
+	suite.Require().NoError(err)
+	suite.Equal("The Dude Abides", suite.read(path))
+	suite.Equal([]int64{15}, progress)
+}
+
+func (suite *DownloadSuite) TestDownloadToFile_incomplete() {
+	path := filepath.Join(suite.T().TempDir(), "dude.txt")
+
+	stream := suite.stream("The Dude")
+	stream.SetContentLength(15)
+
+	err := clients.DownloadToFile(context.Background(), stream, path, nil)
+	suite.ErrorIs(err, io.ErrUnexpectedEOF)
+	suite.Equal("The Dude", suite.read(path), "Partial downloads should stay on disk so you can resume them")
+}
+
+func (suite *DownloadSuite) TestDownloadToFile_cancelled() {
+	path := filepath.Join(suite.T().TempDir(), "dude.txt")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := clients.DownloadToFile(ctx, suite.stream("The Dude Abides"), path, nil)
+	suite.ErrorIs(err, context.Canceled)
+}
+
+func (suite *DownloadSuite) TestDownloadToFile_noContent() {
+	path := filepath.Join(suite.T().TempDir(), "dude.txt")
+	suite.Error(clients.DownloadToFile(context.Background(), &services.StreamResponse{}, path, nil))
+
+	_, err := os.Stat(path)
+	suite.True(os.IsNotExist(err), "We shouldn't create a file when there's nothing to download")
+}
@@ -0,0 +1,38 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextKeyRequestHeaders struct{}
+
+// WithRequestHeader adds a raw HTTP header to any client calls made using the resulting context. Unlike
+// metadata.WithValue(), which frodo uses to pass values between frodo services, this is for arbitrary headers
+// that intermediaries (API gateways, proxies, etc.) need to see, such as an "X-Tenant-ID" header that some load
+// balancer uses for routing. These headers are only applied to the outgoing request; they are NOT propagated
+// to any calls that the remote service makes.
+//
+//	ctx = clients.WithRequestHeader(ctx, "X-Tenant-ID", tenantID)
+//	user, err := userClient.Get(ctx, &users.GetRequest{ID: "123"})
+func WithRequestHeader(ctx context.Context, key string, value string) context.Context {
+	if ctx == nil {
+		return ctx
+	}
+	// Clone the existing headers so that we don't affect any other contexts derived from the original one.
+	headers := requestHeaders(ctx).Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	headers.Set(key, value)
+	return context.WithValue(ctx, contextKeyRequestHeaders{}, headers)
+}
+
+// requestHeaders returns the raw HTTP headers added to the context using WithRequestHeader().
+func requestHeaders(ctx context.Context) http.Header {
+	if ctx == nil {
+		return nil
+	}
+	headers, _ := ctx.Value(contextKeyRequestHeaders{}).(http.Header)
+	return headers
+}
@@ -1,7 +1,10 @@
 package clients
 
 import (
+	"compress/gzip"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/bridgekit-io/frodo/metadata"
 )
@@ -35,6 +38,36 @@ func (pipeline clientMiddlewarePipeline) Then(handler RoundTripperFunc) RoundTri
 
 }
 
+// writeRequestHeaders applies the raw HTTP headers you supplied using WithHeader() when constructing the client
+// as well as any per-call headers added to the context using WithRequestHeader(). The per-call headers win
+// when both of them specify the same header.
+func writeRequestHeaders(headers http.Header) ClientMiddlewareFunc {
+	return func(request *http.Request, next RoundTripperFunc) (*http.Response, error) {
+		for key, values := range headers {
+			request.Header[key] = append([]string(nil), values...)
+		}
+		for key, values := range requestHeaders(request.Context()) {
+			request.Header[key] = append([]string(nil), values...)
+		}
+		return next(request)
+	}
+}
+
+// writeTraceIDHeader sends the call's trace id in the trace id header (X-Request-ID by default), so that gateways
+// and proxies that don't understand our metadata can still correlate the call. When you make a call outside of a
+// service function, there's no trace id yet, so we generate one using metadata.NewTraceID(). The remote gateway
+// uses the header's id whenever the metadata doesn't propagate one.
+func writeTraceIDHeader(header string) ClientMiddlewareFunc {
+	return func(request *http.Request, next RoundTripperFunc) (*http.Response, error) {
+		traceID := metadata.TraceID(request.Context())
+		if traceID == "" {
+			traceID = metadata.NewTraceID()
+		}
+		request.Header.Set(header, traceID)
+		return next(request)
+	}
+}
+
 // writeMetadataHeader encodes all of the context's (the context on the request) metadata values as
 // JSON and writes that to the "X-RPC-Values" header so that the remote service has access to all
 // of your values as well.
@@ -53,3 +86,75 @@ func writeAuthorizationHeader(request *http.Request, next RoundTripperFunc) (*ht
 	}
 	return next(request)
 }
+
+// writeCallerHeader tells the remote service who is calling it using the "X-RPC-Caller" header. Unless you gave the
+// client an explicit name, we use the service whose function is making the call. That's the route on the context.
+func writeCallerHeader(callerName string) ClientMiddlewareFunc {
+	return func(request *http.Request, next RoundTripperFunc) (*http.Response, error) {
+		caller := callerName
+		if caller == "" {
+			caller = metadata.Route(request.Context()).ServiceName
+		}
+		if caller != "" {
+			request.Header.Set(metadata.CallerHeader, caller)
+		}
+		return next(request)
+	}
+}
+
+// decompressResponse asks for gzip/Brotli responses using the "Accept-Encoding" header and decompresses the response
+// body based on its "Content-Encoding" (see WithBrotli). When you already supplied your own Accept-Encoding header, we
+// leave it alone, but we still decompress whatever comes back.
+func decompressResponse(newBrotliReader func(io.Reader) io.Reader) ClientMiddlewareFunc {
+	return func(request *http.Request, next RoundTripperFunc) (*http.Response, error) {
+		if request.Header.Get("Accept-Encoding") == "" {
+			request.Header.Set("Accept-Encoding", "gzip, br")
+		}
+
+		response, err := next(request)
+		if err != nil || response.Body == nil {
+			return response, err
+		}
+
+		var open func(io.Reader) (io.Reader, error)
+		switch strings.ToLower(strings.TrimSpace(response.Header.Get("Content-Encoding"))) {
+		case "gzip":
+			open = func(body io.Reader) (io.Reader, error) { return gzip.NewReader(body) }
+		case "br":
+			open = func(body io.Reader) (io.Reader, error) { return newBrotliReader(body), nil }
+		default:
+			return response, nil
+		}
+
+		response.Body = &decompressedBody{body: response.Body, open: open}
+		response.Header.Del("Content-Encoding")
+		response.Header.Del("Content-Length")
+		response.ContentLength = -1
+		response.Uncompressed = true
+		return response, nil
+	}
+}
+
+// decompressedBody waits until the first Read() to start decompressing, so empty bodies (e.g. a 204 that still
+// claims to be gzipped) don't fail just because they don't have a gzip header.
+type decompressedBody struct {
+	body   io.ReadCloser
+	open   func(io.Reader) (io.Reader, error)
+	reader io.Reader
+	err    error
+}
+
+func (b *decompressedBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.err == nil {
+		b.reader, b.err = b.open(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+// Close closes the original, compressed body.
+func (b *decompressedBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,123 @@
+package clients
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bridgekit-io/frodo/metadata"
+)
+
+// DefaultTimeout is how long the default HTTP client waits for a response (and for connections/TLS handshakes)
+// unless you supply WithTimeout() or WithDialTimeout().
+const DefaultTimeout = 30 * time.Second
+
+// httpSettings are the tweaks you made to the default HTTP client using options like WithTimeout(). We hold onto
+// them until all of the options have been applied, so they have no effect if you supplied your own WithHTTPClient().
+type httpSettings struct {
+	timeout     time.Duration
+	dialTimeout time.Duration
+	tlsConfig   *tls.Config
+	proxy       *url.URL
+}
+
+// newHTTPClient builds the HTTP client that NewClient() uses unless you supply your own.
+func newHTTPClient(settings httpSettings) *http.Client {
+	timeout := DefaultTimeout
+	if settings.timeout > 0 {
+		timeout = settings.timeout
+	}
+	dialTimeout := DefaultTimeout
+	if settings.dialTimeout > 0 {
+		dialTimeout = settings.dialTimeout
+	}
+
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: dialTimeout,
+		TLSClientConfig:     settings.tlsConfig,
+	}
+	if settings.proxy != nil {
+		transport.Proxy = http.ProxyURL(settings.proxy)
+	}
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     transport,
+		CheckRedirect: stripCredentialsOnRedirect,
+	}
+}
+
+// maxRedirects matches the limit that http.Client uses when you don't supply a CheckRedirect function.
+const maxRedirects = 10
+
+// credentialHeaders are the headers that we add to every call to the frodo service. They're meant for that
+// service alone, so they should never follow a redirect to someone else's server.
+var credentialHeaders = []string{
+	"Authorization",
+	metadata.Header,
+	metadata.CallerHeader,
+}
+
+// stripCredentialsOnRedirect keeps our credentials and metadata from leaking to third parties when a service
+// redirects us somewhere else (e.g. a signed S3 URL for a download). The standard library only drops the
+// Authorization header when the new host isn't a subdomain of the original one, and it forwards every other
+// header as-is, so we drop all of our headers whenever the redirect goes to a different origin.
+func stripCredentialsOnRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+	if sameOrigin(req.URL, via[0].URL) {
+		return nil
+	}
+	for _, header := range credentialHeaders {
+		req.Header.Del(header)
+	}
+	return nil
+}
+
+// sameOrigin returns true when both URLs have the same scheme, host, and port.
+func sameOrigin(a *url.URL, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
+}
+
+// WithTimeout changes how long the default HTTP client waits for the entire call to finish, including reading
+// the response body. This is 30 seconds unless you say otherwise. Like the other HTTP options (WithDialTimeout,
+// WithTLSConfig, and WithProxy), this has no effect if you supply your own client w/ WithHTTPClient().
+//
+//	client := gen.UserServiceClient(address, clients.WithTimeout(5*time.Second))
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(client *Client) {
+		client.httpSettings.timeout = timeout
+	}
+}
+
+// WithDialTimeout changes how long the default HTTP client waits to establish a connection to the remote service,
+// including the TLS handshake. This is 30 seconds unless you say otherwise.
+func WithDialTimeout(timeout time.Duration) ClientOption {
+	return func(client *Client) {
+		client.httpSettings.dialTimeout = timeout
+	}
+}
+
+// WithTLSConfig makes the default HTTP client use your TLS settings when calling "https://" services. This is
+// how you trust a custom CA or present a client certificate for mutual TLS:
+//
+//	client := gen.UserServiceClient(address, clients.WithTLSConfig(&tls.Config{RootCAs: internalCAs}))
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(client *Client) {
+		client.httpSettings.tlsConfig = config
+	}
+}
+
+// WithProxy sends all of the default HTTP client's requests through the given proxy server. By default,
+// the client connects to the remote service directly.
+func WithProxy(proxyURL *url.URL) ClientOption {
+	return func(client *Client) {
+		client.httpSettings.proxy = proxyURL
+	}
+}
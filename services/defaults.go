@@ -0,0 +1,33 @@
+package services
+
+// Defaulter lets your request struct fill in sensible values for any fields that the caller didn't supply, so
+// that every handler doesn't need to repeat the same "if req.PageSize == 0" checks:
+//
+//	func (req *SearchRequest) Defaults() {
+//		if req.PageSize == 0 {
+//			req.PageSize = 20
+//		}
+//		if req.Sort == "" {
+//			req.Sort = "created"
+//		}
+//	}
+//
+// Gateways call Defaults() after they've decoded every incoming value (body, query, path, event payload, etc.),
+// but before they invoke your handler. That means you should only touch fields that are still blank; anything
+// non-zero came from the caller. Make sure that you implement it on the pointer receiver, otherwise your changes
+// won't stick.
+//
+// GATEWAY COMPATABILITY: This works with both the API and Events gateways. Calling a handler directly, such as
+// in a unit test or through Server.Invoke(), does not apply defaults since nothing is being decoded.
+type Defaulter interface {
+	// Defaults populates any unset fields w/ their default values.
+	Defaults()
+}
+
+// ApplyDefaults calls Defaults() on the service request if it implements Defaulter. Gateways call this after
+// decoding each request, so you'll rarely need to call it yourself.
+func ApplyDefaults(serviceRequest any) {
+	if defaulter, ok := serviceRequest.(Defaulter); ok {
+		defaulter.Defaults()
+	}
+}
@@ -36,6 +36,19 @@ type Endpoint struct {
 	// Notice that the roles should be allowed to have path variables that we can fill in
 	// at runtime with the incoming binding data.
 	Roles []string
+	// RequireAllRoles indicates that callers need every one of the Roles to access this endpoint
+	// rather than just one of them (see the "ROLES ALL" doc option).
+	RequireAllRoles bool
+	// Deprecated indicates that this operation is being retired, so callers should stop using it. The API
+	// gateway tells callers about this using the "Deprecation" and "Sunset" response headers.
+	Deprecated bool
+	// Sunset is the date (formatted "2006-01-02") when this Deprecated operation is expected to stop working.
+	// This is empty if the operation isn't deprecated or there's no firm date for its retirement.
+	Sunset string
+	// Schema is the JSON Schema document that describes this operation's request and response. The code generator
+	// fills this in for operations that the API gateway exposes, and the gateway serves it to anyone who asks
+	// when you enable apis.WithSchemas().
+	Schema string
 	// Routes defines the actual ingress routes that allow this service operation to
 	// be invoked by various gateways. For instance, they tell you that you can invoke
 	// the API call "GET /user/{ID}" to invoke it or that it should trigger when the
@@ -72,13 +85,32 @@ type EndpointRoute struct {
 	PathParams []string
 	// Group provides additional routing/grouping info that means different things to different gateways.
 	Group string
+	// Weight is the relative share of the Group's events that this endpoint should handle when the gateway/broker
+	// supports it (e.g. "ON FooService.Bar GROUP Hug WEIGHT 3"). Zero means that no weight was specified.
+	Weight int
 	// Status is mainly used by API gateway routes to determine what HTTP status code we should
 	// return to the caller when this endpoint succeeds. By default, this is 200.
 	Status int
+	// AllowMethods are the HTTP methods from the "HTTP ALLOW" option of the function (or its service). Including
+	// "HEAD" makes the API gateway answer HEAD requests to GET routes w/o producing the body (see apis.WithAutoHead),
+	// and including "OPTIONS" makes it answer OPTIONS requests w/ an "Allow" header listing these methods.
+	AllowMethods []string
+	// CacheControl is the Cache-Control header that the API gateway includes on successful responses, based on
+	// the "HTTP CACHE" option of the function (or its service). Responses that set their own header win.
+	CacheControl string
 	// Roles helps support role-based security by defining role patterns to indicate which
 	// users are allowed to access this endpoint. This is the same as the Roles in the parent Endpoint that
 	// this route belongs to.
 	Roles []string
+	// RequireAllRoles indicates that callers need every one of the Roles rather than just one. This is the
+	// same as the RequireAllRoles in the parent Endpoint that this route belongs to.
+	RequireAllRoles bool
+	// Deprecated indicates that this operation is being retired. This is the same as the Deprecated
+	// value in the parent Endpoint that this route belongs to.
+	Deprecated bool
+	// Sunset is the date (formatted "2006-01-02") when this Deprecated operation is expected to stop
+	// working. This is the same as the Sunset value in the parent Endpoint that this route belongs to.
+	Sunset string
 	// ServiceName is the name of the service that this operation is part of.
 	ServiceName string
 	// Name is the name of the function/operation that this endpoint describes.
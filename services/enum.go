@@ -0,0 +1,30 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/bridgekit-io/frodo/fail"
+)
+
+// ValidateEnum returns a 400 Bad Request error when the value of an enum-style string field is not one of the allowed
+// values. Empty values are always allowed since we can't tell "not one of the allowed values" from "not provided at
+// all". The generated servers/clients call this for every enum field of your request structs, so you'll rarely need
+// to call it yourself.
+//
+//	err := services.ValidateEnum("Status", req.Status, "active", "paused")
+func ValidateEnum[T ~string](fieldName string, value T, allowed ...T) error {
+	if value == "" {
+		return nil
+	}
+	for _, allowedValue := range allowed {
+		if value == allowedValue {
+			return nil
+		}
+	}
+
+	allowedValues := make([]string, len(allowed))
+	for i, allowedValue := range allowed {
+		allowedValues[i] = string(allowedValue)
+	}
+	return fail.BadRequest("invalid %s: '%s' is not one of [%s]", fieldName, value, strings.Join(allowedValues, ", "))
+}
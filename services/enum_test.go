@@ -0,0 +1,36 @@
+//go:build unit
+
+package services_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestEnumSuite(t *testing.T) {
+	suite.Run(t, new(EnumSuite))
+}
+
+type EnumSuite struct {
+	suite.Suite
+}
+
+type enumStatus string
+
+func (suite *EnumSuite) TestValidateEnum() {
+	suite.NoError(services.ValidateEnum("Status", enumStatus("active"), "active", "paused"))
+	suite.NoError(services.ValidateEnum("Status", enumStatus("paused"), "active", "paused"))
+	suite.NoError(services.ValidateEnum("Status", enumStatus(""), "active", "paused"), "Empty values should be allowed")
+
+	err := services.ValidateEnum("Status", enumStatus("deleted"), "active", "paused")
+	suite.Require().Error(err)
+	suite.Equal(http.StatusBadRequest, fail.Status(err))
+	suite.Contains(err.Error(), "invalid Status: 'deleted' is not one of [active, paused]")
+
+	err = services.ValidateEnum("Status", enumStatus("Active"), "active", "paused")
+	suite.Require().Error(err, "Values should be case-sensitive")
+}
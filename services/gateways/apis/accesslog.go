@@ -0,0 +1,168 @@
+package apis
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AccessLogMessage is the message of every record that WithAccessLog() writes, so you can pick them out of
+// the rest of your logs if they share the same logger.
+const AccessLogMessage = "http access"
+
+// accessLogHandler wraps the gateway's entire router, so it records every request that comes in, including the
+// ones that never reach a service (404s, OPTIONS, readiness checks, etc.). We log once the router returns, so
+// we know the final status and how many bytes we actually wrote.
+func accessLogHandler(next http.Handler, logger *slog.Logger, trustedProxies []netip.Prefix) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		start := time.Now()
+		recorder := &accessLogResponseWriter{ResponseWriter: w}
+
+		// Log even when something panics past our recovery middleware; the server will still kill the
+		// connection, but at least you'll know that the request came in.
+		defer func() {
+			logger.LogAttrs(req.Context(), slog.LevelInfo, AccessLogMessage,
+				slog.String("method", req.Method),
+				slog.String("path", req.URL.RequestURI()),
+				slog.String("proto", req.Proto),
+				slog.Int("status", recorder.statusOrDefault()),
+				slog.Int64("bytes", recorder.bytes),
+				slog.Duration("duration", time.Since(start)),
+				slog.String("remote_addr", remoteAddr(req, trustedProxies)),
+				slog.String("referer", req.Referer()),
+				slog.String("user_agent", req.UserAgent()),
+			)
+		}()
+		next.ServeHTTP(recorder, req)
+	})
+}
+
+// accessLogResponseWriter captures the status and the number of body bytes written, so the access log can
+// report them. It supports flushing and hijacking, so streams and websockets still work.
+type accessLogResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+// WriteHeader captures the status code. Like a real response, only the first call counts.
+func (w *accessLogResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *accessLogResponseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(data)
+	w.bytes += int64(n)
+	return n, err
+}
+
+// Unwrap lets http.ResponseController get at the original response writer.
+func (w *accessLogResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush sends any buffered data to the client if the underlying response writer supports it.
+func (w *accessLogResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets websocket upgrades take over the connection. We can't see anything written after that, so
+// the access log just reports the upgrade.
+func (w *accessLogResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	if w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return hijacker.Hijack()
+}
+
+// statusOrDefault returns the status that the handler wrote. Handlers that don't write anything at all
+// result in an empty 200, so that's what we report.
+func (w *accessLogResponseWriter) statusOrDefault() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// NewCombinedLogHandler creates a slog.Handler that writes the gateway's access log records (see WithAccessLog) as
+// lines in the classic Combined Log Format that Apache/nginx use, so existing log tooling can parse them:
+//
+//	203.0.113.9 - - [10/Oct/2024:13:55:36 -0700] "GET /user/123 HTTP/1.1" 200 2326 "-" "curl/8.4.0"
+//
+// Any other records that you send through this handler are ignored, so only use it for the access log. If you'd
+// rather have JSON, give WithAccessLog() a logger that uses slog.NewJSONHandler() instead.
+func NewCombinedLogHandler(w io.Writer) slog.Handler {
+	return &combinedLogHandler{writer: w, mutex: &sync.Mutex{}}
+}
+
+type combinedLogHandler struct {
+	writer io.Writer
+	mutex  *sync.Mutex
+}
+
+func (handler *combinedLogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (handler *combinedLogHandler) Handle(_ context.Context, record slog.Record) error {
+	if record.Message != AccessLogMessage {
+		return nil
+	}
+
+	values := map[string]string{}
+	record.Attrs(func(attr slog.Attr) bool {
+		values[attr.Key] = attr.Value.String()
+		return true
+	})
+	orDash := func(value string) string {
+		if value == "" {
+			return "-"
+		}
+		return value
+	}
+
+	line := fmt.Sprintf("%s - - [%s] %s %s %s %s %s\n",
+		orDash(values["remote_addr"]),
+		record.Time.Format("02/Jan/2006:15:04:05 -0700"),
+		strconv.Quote(values["method"]+" "+values["path"]+" "+values["proto"]),
+		values["status"],
+		values["bytes"],
+		strconv.Quote(orDash(values["referer"])),
+		strconv.Quote(orDash(values["user_agent"])),
+	)
+
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	_, err := io.WriteString(handler.writer, line)
+	return err
+}
+
+// WithAttrs returns the same handler since the Combined Log Format has nowhere to put extra attributes.
+func (handler *combinedLogHandler) WithAttrs([]slog.Attr) slog.Handler {
+	return handler
+}
+
+// WithGroup returns the same handler since the Combined Log Format has nowhere to put extra attributes.
+func (handler *combinedLogHandler) WithGroup(string) slog.Handler {
+	return handler
+}
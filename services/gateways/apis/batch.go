@@ -0,0 +1,154 @@
+package apis
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/services"
+)
+
+// maxBatchRequests is the most sub-requests we'll accept in a single batch. Anything more than this
+// is probably a bug in the caller, and we don't want one request to fan out into unbounded work.
+const maxBatchRequests = 100
+
+// batchRequest is the body of a call to the reserved batch route (see services.BatchPath).
+type batchRequest struct {
+	Requests []batchSubRequest
+}
+
+// batchSubRequest is a single service call within a batch. The path is the exact path (and query string)
+// that the client would have called if it wasn't batching (e.g. "/v2/UserService.Get?ID=123").
+type batchSubRequest struct {
+	Method string
+	Path   string
+	Body   []byte
+}
+
+// batchResponse is the body we respond with when the batch route is invoked. It contains one
+// response for each sub-request, in the same order that they appeared in the batch.
+type batchResponse struct {
+	Responses []batchSubResponse
+}
+
+// batchSubResponse is the status, headers, and body that the gateway would have responded with if the
+// sub-request had been sent on its own.
+type batchSubResponse struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// registerBatch adds the reserved route that clients use to send multiple service calls in a single round trip. We
+// don't call your service functions directly. Each sub-request is routed through the gateway exactly like it would
+// have been on its own, so path/query binding, your custom middleware (e.g. auth), etc. all still apply.
+func (gw *Gateway) registerBatch() {
+	if !gw.batching {
+		return
+	}
+
+	encoder := gw.codecs.DefaultEncoder()
+	errorEncoder := gw.errorEncoder()
+	decoder := gw.codecs.DefaultDecoder()
+	handler := HTTPMiddlewareFuncs{
+		recoverFromPanic(errorEncoder),
+		decompressRequest(errorEncoder, gw.maxDecompressedSize),
+		applyCorsHeaders(gw.cors),
+	}.Then(func(w http.ResponseWriter, req *http.Request) {
+		batch := batchRequest{}
+		switch err := decoder.Decode(req.Body, &batch); {
+		case fail.IsTooLarge(err):
+			respondFailure(w, req, errorEncoder, err)
+			return
+		case err != nil:
+			respondFailure(w, req, errorEncoder, fail.BadRequest("invalid batch request: %v", err))
+			return
+		}
+		if len(batch.Requests) > maxBatchRequests {
+			respondFailure(w, req, errorEncoder, fail.BadRequest("batch has %d requests; the limit is %d", len(batch.Requests), maxBatchRequests))
+			return
+		}
+
+		responses := make([]batchSubResponse, len(batch.Requests))
+		wg := sync.WaitGroup{}
+		for i, subRequest := range batch.Requests {
+			wg.Add(1)
+			go func(i int, subRequest batchSubRequest) {
+				defer wg.Done()
+				responses[i] = gw.serveBatchSubRequest(req, subRequest)
+			}(i, subRequest)
+		}
+		wg.Wait()
+
+		w.Header().Set("Content-Type", encoder.ContentType())
+		w.WriteHeader(http.StatusOK)
+		_ = encoder.Encode(w, batchResponse{Responses: responses})
+	})
+	gw.router.HandleFunc("POST "+services.BatchPath, handler)
+	gw.registerOptions(services.BatchPath, services.EndpointRoute{})
+}
+
+// serveBatchSubRequest runs a single call from the batch through the gateway's router and captures the response. The
+// sub-request inherits the batch request's headers (Authorization, X-RPC-Metadata, etc.) and connection info.
+func (gw *Gateway) serveBatchSubRequest(batchReq *http.Request, subRequest batchSubRequest) batchSubResponse {
+	// Don't let people batch batches or sneak in absolute URLs to some other host.
+	if !strings.HasPrefix(subRequest.Path, "/") || strings.HasPrefix(subRequest.Path, services.BatchPath) {
+		return batchSubResponse{Status: http.StatusBadRequest, Body: []byte("invalid batch path: " + subRequest.Path)}
+	}
+
+	req, err := http.NewRequestWithContext(batchReq.Context(), subRequest.Method, subRequest.Path, bytes.NewReader(subRequest.Body))
+	if err != nil {
+		return batchSubResponse{Status: http.StatusBadRequest, Body: []byte("invalid batch request: " + err.Error())}
+	}
+	req.Header = batchReq.Header.Clone()
+	req.Header.Del("Content-Length")
+	req.Header.Del("Content-Encoding")
+	req.Host = batchReq.Host
+	req.RemoteAddr = batchReq.RemoteAddr
+	req.TLS = batchReq.TLS
+
+	w := &bufferedResponseWriter{header: http.Header{}}
+	gw.router.ServeHTTP(w, req)
+	return batchSubResponse{
+		Status: w.statusOrDefault(),
+		Header: w.header,
+		Body:   w.body.Bytes(),
+	}
+}
+
+// bufferedResponseWriter captures everything written to it in memory, so that we can deliver
+// a sub-request's entire response as part of the batch response.
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// Header returns the headers that will be sent back for this sub-request.
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader captures the status code. Like a real response, only the first call counts.
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Write captures the response body bytes.
+func (w *bufferedResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(p)
+}
+
+func (w *bufferedResponseWriter) statusOrDefault() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
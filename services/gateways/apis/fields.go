@@ -0,0 +1,118 @@
+package apis
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/bridgekit-io/frodo/codec"
+)
+
+// DefaultFieldSelectionParam is the query string parameter that lists the fields to include in the response
+// when you enable WithFieldSelection() without specifying your own.
+const DefaultFieldSelectionParam = "fields"
+
+// fieldSelection is the tree of fields that the caller asked for. The request "?fields=ID,Owner.Name" results
+// in {"ID": nil, "Owner": {"Name": nil}}, where a nil subtree means "include this entire value".
+type fieldSelection map[string]fieldSelection
+
+// parseFieldSelection builds the tree of fields from the comma-separated list of (optionally dotted) field names.
+func parseFieldSelection(fieldList string) fieldSelection {
+	var selection fieldSelection
+	for _, path := range strings.Split(fieldList, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		if selection == nil {
+			selection = fieldSelection{}
+		}
+		selection.add(strings.Split(path, "."))
+	}
+	return selection
+}
+
+func (selection fieldSelection) add(path []string) {
+	name := strings.TrimSpace(path[0])
+	subtree, exists := selection[name]
+
+	switch {
+	case len(path) == 1:
+		// Asking for "Owner" and "Owner.Name" should include the entire owner, not just the name.
+		selection[name] = nil
+	case exists && subtree == nil:
+		// We're already including the entire value, so there's nothing more specific to add.
+	default:
+		if subtree == nil {
+			subtree = fieldSelection{}
+			selection[name] = subtree
+		}
+		subtree.add(path[1:])
+	}
+}
+
+// lookup finds the selected field that matches the JSON key. Callers often don't match the case of the keys in
+// the response (e.g. "id" vs "ID"), so we fall back to a case-insensitive match, just like decoding JSON does.
+func (selection fieldSelection) lookup(key string) (fieldSelection, bool) {
+	if subtree, ok := selection[key]; ok {
+		return subtree, true
+	}
+	for name, subtree := range selection {
+		if strings.EqualFold(name, key) {
+			return subtree, true
+		}
+	}
+	return nil, false
+}
+
+// prune removes every field of the generic JSON value that the caller didn't ask for. Arrays apply the selection
+// to each of their elements, so "?fields=Items.ID" gives you the ID of every item.
+func (selection fieldSelection) prune(value any) any {
+	if selection == nil {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		for key, elem := range v {
+			subtree, ok := selection.lookup(key)
+			if !ok {
+				delete(v, key)
+				continue
+			}
+			v[key] = subtree.prune(elem)
+		}
+		return v
+	case []any:
+		for i, elem := range v {
+			v[i] = selection.prune(elem)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// fieldSelectionEncoder only encodes the fields of each value that the caller asked for using WithFieldSelection().
+type fieldSelectionEncoder struct {
+	codec.Encoder
+	selection fieldSelection
+}
+
+func (e fieldSelectionEncoder) Encode(writer io.Writer, value any) error {
+	jsonValue, ok := toJSONValue(value)
+	if !ok {
+		// Let the encoder report this error when it tries to encode the original value.
+		return e.Encoder.Encode(writer, value)
+	}
+	return e.Encoder.Encode(writer, e.selection.prune(jsonValue))
+}
+
+// fieldSelectionFor parses the fields that the caller asked for in the request's query string. This is nil
+// if you didn't enable WithFieldSelection() or the caller didn't supply any fields.
+func (gw *Gateway) fieldSelectionFor(req *http.Request) fieldSelection {
+	if gw.fieldSelectionParam == "" {
+		return nil
+	}
+	return parseFieldSelection(strings.Join(req.URL.Query()[gw.fieldSelectionParam], ","))
+}
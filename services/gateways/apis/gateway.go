@@ -1,24 +1,50 @@
 package apis
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"log/slog"
+	"math"
+	"mime"
+	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/bridgekit-io/frodo/codec"
 	"github.com/bridgekit-io/frodo/fail"
 	"github.com/bridgekit-io/frodo/internal/naming"
 	"github.com/bridgekit-io/frodo/internal/quiet"
+	"github.com/bridgekit-io/frodo/internal/reflection"
+	"github.com/bridgekit-io/frodo/internal/slices"
+	"github.com/bridgekit-io/frodo/metadata"
 	"github.com/bridgekit-io/frodo/services"
 	"github.com/rs/cors"
 )
 
+const (
+	// DefaultReadHeaderTimeout is how long the gateway waits for a client to send the request headers
+	// unless you supply WithReadHeaderTimeout(). This is your main line of defense against slowloris attacks.
+	DefaultReadHeaderTimeout = 10 * time.Second
+	// DefaultIdleTimeout is how long the gateway keeps an idle keep-alive connection open unless you
+	// supply WithIdleTimeout().
+	DefaultIdleTimeout = 120 * time.Second
+	// DefaultMaxDecompressedSize is the most bytes that the gateway will read from a gzipped request body
+	// once it's decompressed, unless you supply WithMaxDecompressedSize(). This protects you from gzip bombs.
+	DefaultMaxDecompressedSize = 32 << 20
+)
+
 // NewGateway creates a new API Gateway that allows your service to accept incoming requests
 // using RPC over HTTP. This encapsulates a standard net/http server while providing options
 // so that you can customize various aspects of the server, TLS, and middleware as desired.
@@ -26,19 +52,29 @@ func NewGateway(address string, options ...GatewayOption) *Gateway {
 	router := http.NewServeMux()
 	codecs := codec.New()
 	gw := Gateway{
-		router:          router,
-		codecs:          codecs,
-		middleware:      HTTPMiddlewareFuncs{},
-		endpoints:       map[httpRoute]services.Endpoint{},
-		server:          &http.Server{Addr: address, Handler: router},
-		tlsCert:         "",
-		tlsKey:          "",
-		websockets:      newWebsocketRegistry(),
-		notFoundHandler: defaultNotFoundHandler(codecs),
+		router:              router,
+		codecs:              codecs,
+		middleware:          HTTPMiddlewareFuncs{},
+		endpoints:           map[httpRoute]services.Endpoint{},
+		server:              &http.Server{Addr: address, Handler: router, ReadHeaderTimeout: DefaultReadHeaderTimeout, IdleTimeout: DefaultIdleTimeout},
+		tlsCert:             "",
+		tlsKey:              "",
+		websockets:          newWebsocketRegistry(),
+		started:             make(chan struct{}),
+		metadataPolicy:      metadata.DefaultMergePolicy(),
+		traceIDHeader:       metadata.TraceIDHeader,
+		maxDecompressedSize: DefaultMaxDecompressedSize,
+		idempotencyMaxBody:  DefaultMaxIdempotentBodySize,
 	}
 	for _, option := range options {
 		option(&gw)
 	}
+	if gw.notFoundHandler == nil {
+		gw.notFoundHandler = defaultNotFoundHandler(gw.errorEncoder())
+	}
+	if gw.accessLog != nil {
+		gw.server.Handler = accessLogHandler(gw.router, gw.accessLog, gw.trustedProxies)
+	}
 	return &gw
 }
 
@@ -48,16 +84,48 @@ func NewGateway(address string, options ...GatewayOption) *Gateway {
 // DO NOT CREATE THIS DIRECTLY. Use the NewGateway() constructor to properly set up an
 // API gateway in your main() function.
 type Gateway struct {
-	codecs          codec.Registry
-	middleware      HTTPMiddlewareFuncs
-	endpoints       map[httpRoute]services.Endpoint
-	router          *http.ServeMux
-	server          *http.Server
-	tlsCert         string
-	tlsKey          string
-	notFoundHandler http.HandlerFunc
-	websockets      *websocketRegistry
-	cors            *cors.Cors
+	codecs               codec.Registry
+	middleware           HTTPMiddlewareFuncs
+	endpoints            map[httpRoute]services.Endpoint
+	router               *http.ServeMux
+	server               *http.Server
+	tlsCert              string
+	tlsKey               string
+	notFoundHandler      http.HandlerFunc
+	fallback             http.Handler
+	staticFiles          []staticFiles
+	websockets           *websocketRegistry
+	cors                 *cors.Cors
+	metadataPolicy       metadata.MergePolicy
+	traceIDHeader        string
+	traceIDExtractor     TraceIDExtractor
+	clientCAs            *x509.CertPool
+	trustedProxies       []netip.Prefix
+	readinessPath        string
+	readinessCheck       func() bool
+	maxInFlight          int64
+	maxQueryLength       int
+	maxHeaderBytes       int
+	maxDecompressedSize  int64
+	requiredContentTypes []string
+	idempotencyStore     IdempotencyStore
+	idempotencyTTL       time.Duration
+	idempotencyMaxBody   int64
+	inFlight             atomic.Int64
+	responseTiming       bool
+	autoHead             bool
+	batching             bool
+	schemas              bool
+	streamTimeout        StreamTimeoutFunc
+	responseEnvelope     ResponseEnvelopeFunc
+	responseTransforms   []ResponseTransformFunc
+	fieldSelectionParam  string
+	errorMapper          fail.ErrorMapper
+	problemJSON          bool
+	localizer            Localizer
+	accessLog            *slog.Logger
+	started              chan struct{}
+	boundAddress         string
 }
 
 // Type returns "API" to properly tag this type of gateway.
@@ -73,6 +141,9 @@ func (gw *Gateway) Listen(_ context.Context) error {
 	// The Go 1.22 ServeMux doesn't have a special hook for missing routes. You just need to add some "catch-all"
 	// routes that are used when none of your service functions' paths match. We do this here because at this point
 	// all "real" routes should be in place.
+	gw.registerReadinessCheck()
+	gw.registerPing()
+	gw.registerBatch()
 	gw.registerNotFound()
 
 	switch err := gw.listenAndServe(); {
@@ -83,15 +154,77 @@ func (gw *Gateway) Listen(_ context.Context) error {
 	}
 }
 
-// registerNotFound updates our ServeMux to handle any route that is not explicitly defined by a service as a 404.
+// Listening returns a channel that is closed once the gateway has bound its address and is accepting
+// connections. Once that happens, Addr() reports the actual address that the gateway is listening on.
+func (gw *Gateway) Listening() <-chan struct{} {
+	return gw.started
+}
+
+// Addr returns the address that the gateway is listening on. When you create the gateway with a port of 0
+// (e.g. "localhost:0"), the OS picks a free port for you, so use this once the gateway is Listening() to find
+// out which one you got. Before that, this is just the address that you passed to NewGateway().
+func (gw *Gateway) Addr() string {
+	select {
+	case <-gw.started:
+		return gw.boundAddress
+	default:
+		return gw.server.Addr
+	}
+}
+
+// registerReadinessCheck adds the route that load balancers can poll to determine if this instance should receive
+// traffic. We intentionally skip your custom middleware since things like auth would make the check fail.
+func (gw *Gateway) registerReadinessCheck() {
+	if gw.readinessPath == "" || gw.readinessCheck == nil {
+		return
+	}
+
+	encoder := gw.codecs.DefaultEncoder()
+	handler := HTTPMiddlewareFuncs{
+		recoverFromPanic(gw.errorEncoder()),
+		restoreTraceID(gw.metadataPolicy, gw.traceIDHeader, gw.traceIDExtractor),
+	}.Then(func(w http.ResponseWriter, req *http.Request) {
+		status := http.StatusOK
+		if !gw.readinessCheck() {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", encoder.ContentType())
+		w.WriteHeader(status)
+		_ = encoder.Encode(w, readinessResponse{Status: status, Ready: status == http.StatusOK})
+	})
+	gw.router.HandleFunc("GET "+normalizePath(gw.readinessPath), handler)
+}
+
+// registerPing adds the framework-reserved route that clients hit using Ping() to make sure that this service is
+// up and reachable. Unlike the readiness check, this always answers with a 200 as long as we're listening. Like the
+// readiness check, we skip your custom middleware, so things like auth don't get in the way of a liveness probe.
+func (gw *Gateway) registerPing() {
+	handler := HTTPMiddlewareFuncs{
+		recoverFromPanic(gw.errorEncoder()),
+		restoreTraceID(gw.metadataPolicy, gw.traceIDHeader, gw.traceIDExtractor),
+	}.Then(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	gw.router.HandleFunc("GET "+services.PingPath, handler)
+}
+
+// readinessResponse is the body of the readiness check route.
+type readinessResponse struct {
+	Status int
+	Ready  bool
+}
+
+// registerNotFound updates our ServeMux to handle any route that is not explicitly defined by a service. That's
+// a 404 unless you supplied a fallback handler, in which case the fallback gets the first crack at it.
 func (gw *Gateway) registerNotFound() {
 	customFuncs := gw.middleware
 	standardFuncs := HTTPMiddlewareFuncs{
-		recoverFromPanic(gw.codecs.DefaultEncoder()), // If your custom middleware or handler funcs suck, don't die.
+		recoverFromPanic(gw.errorEncoder()), // If your custom middleware or handler funcs suck, don't die.
+		restoreTraceID(gw.metadataPolicy, gw.traceIDHeader, gw.traceIDExtractor),
 		applyCorsHeaders(gw.cors),
 	}
 
-	handler := standardFuncs.Append(customFuncs...).Then(gw.notFoundHandler)
+	handler := standardFuncs.Append(customFuncs...).Then(gw.unmatchedRouteHandler())
 	gw.router.HandleFunc("GET /", handler)
 	gw.router.HandleFunc("PATCH /", handler)
 	gw.router.HandleFunc("POST /", handler)
@@ -100,20 +233,96 @@ func (gw *Gateway) registerNotFound() {
 	gw.router.HandleFunc("OPTIONS /", handler)
 }
 
+// unmatchedRouteHandler returns the handler that should run when a request doesn't match any service route. When
+// you have a fallback, we stash the not-found handler on the request context so that NotFound() can call through to it.
+//
+// Static files (see WithStaticFiles) are served from here, too, rather than their own routes. That way they can't
+// conflict with the catch-all routes above, even when you serve them from the root, and your service routes always
+// win when there's any overlap. Requests that don't match a file carry on to the fallback/not-found handler.
+func (gw *Gateway) unmatchedRouteHandler() http.HandlerFunc {
+	handler := gw.notFoundHandler
+	if gw.fallback != nil {
+		handler = func(w http.ResponseWriter, req *http.Request) {
+			ctx := context.WithValue(req.Context(), notFoundContextKey{}, gw.notFoundHandler)
+			gw.fallback.ServeHTTP(w, req.WithContext(ctx))
+		}
+	}
+	if len(gw.staticFiles) == 0 {
+		return handler
+	}
+	return func(w http.ResponseWriter, req *http.Request) {
+		if !gw.serveStaticFile(w, req) {
+			handler(w, req)
+		}
+	}
+}
+
+// NotFound responds to the request using the gateway's not-found handler; either the default 404 or whatever
+// you supplied using WithNotFound(). This is meant to be called from your WithFallback() handler when it decides
+// that it can't do anything useful with the request after all.
+func NotFound(w http.ResponseWriter, req *http.Request) {
+	if notFoundHandler, ok := req.Context().Value(notFoundContextKey{}).(http.HandlerFunc); ok {
+		notFoundHandler(w, req)
+		return
+	}
+	respondFailure(w, req, codec.JSONEncoder{}, fail.NotFound("not found"))
+}
+
 // listenAndServe determines if we need to start up in plain old HTTP mode or HTTPS
 // using TLS certificates or a TLS config/manager you configured (i.e. lets encrypt).
 // This will block until the server shuts down just like the underlying server does.
 func (gw *Gateway) listenAndServe() error {
+	gw.applyClientCertAuth()
+
+	// We bind the port ourselves rather than using ListenAndServe() so that we can signal Listening() once
+	// connections will actually be accepted and report the real address when you asked for port 0.
+	listener, err := net.Listen("tcp", gw.listenAddress())
+	if err != nil {
+		return err
+	}
+	gw.boundAddress = listener.Addr().String()
+	close(gw.started)
+
 	switch {
 	case gw.UseTLS():
 		// TODO: Server defaults to HTTP2 and our JSON encoding of responses fails, so force HTTP/1.1 when using TLS until I figure that out.
 		gw.server.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
-		return gw.server.ListenAndServeTLS(gw.tlsCert, gw.tlsKey)
+		return gw.server.ServeTLS(listener, gw.tlsCert, gw.tlsKey)
 	default:
-		return gw.server.ListenAndServe()
+		return gw.server.Serve(listener)
 	}
 }
 
+// listenAddress mimics the default port that ListenAndServe() and ListenAndServeTLS() use when the gateway
+// doesn't have an address.
+func (gw *Gateway) listenAddress() string {
+	switch {
+	case gw.server.Addr != "":
+		return gw.server.Addr
+	case gw.UseTLS():
+		return ":https"
+	default:
+		return ":http"
+	}
+}
+
+// applyClientCertAuth makes the server's TLS config require and verify client certificates when you've enabled
+// mutual TLS. We do this right before listening rather than in the option itself so that it doesn't matter if
+// you supplied WithClientCertAuth() before or after WithTLSConfig().
+func (gw *Gateway) applyClientCertAuth() {
+	if gw.clientCAs == nil {
+		return
+	}
+
+	tlsConfig := &tls.Config{}
+	if gw.server.TLSConfig != nil {
+		tlsConfig = gw.server.TLSConfig.Clone()
+	}
+	tlsConfig.ClientCAs = gw.clientCAs
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	gw.server.TLSConfig = tlsConfig
+}
+
 // Shutdown attempts to gracefully shut down the HTTP server. It will wait for any in-progress
 // requests to finish and then shut down (unblocking Listen()). You can provide a context
 // with a deadline to limit how long you want to wait before giving up and shutting down anyway.
@@ -143,16 +352,29 @@ func (gw *Gateway) Register(endpoint services.Endpoint, route services.EndpointR
 	// etc. are all done by the time any of the user's custom middleware or the handler fires.
 	customFuncs := gw.middleware
 	standardFuncs := HTTPMiddlewareFuncs{
-		recoverFromPanic(gw.codecs.DefaultEncoder()),
+		measureResponseTime(gw.responseTiming),
+		writeDeprecationHeaders(route),
+		recoverFromPanic(gw.errorEncoder()),
+		rejectOversizedRequests(gw.errorEncoder(), gw.maxQueryLength, gw.maxHeaderBytes),
+		shedExcessRequests(gw.errorEncoder(), gw.maxInFlight, &gw.inFlight),
+		requireContentType(gw.errorEncoder(), gw.requiredContentTypes),
+		decompressRequest(gw.errorEncoder(), gw.maxDecompressedSize),
 		prepareContext(),
-		restoreMetadata(),
+		restoreMetadata(gw.metadataPolicy),
 		restoreMetadataHeaders(),
+		restoreClientCert(),
+		restoreRequestInfo(gw.trustedProxies),
 		restoreMetadataEndpoint(endpoint, route),
-		restoreTraceID(),
-		restoreAuthorization(),
+		restoreTraceID(gw.metadataPolicy, gw.traceIDHeader, gw.traceIDExtractor),
+		restoreAuthorization(gw.metadataPolicy),
 		applyCorsHeaders(gw.cors),
 	}
-	httpHandler := standardFuncs.Append(customFuncs...).Then(gw.toHTTPHandler(endpoint, route))
+
+	// Idempotency goes after your middleware, so a replayed response still has to pass your authorization.
+	httpHandler := standardFuncs.
+		Append(customFuncs...).
+		Append(enforceIdempotency(gw.errorEncoder(), gw.idempotencyStore, gw.idempotencyTTL, gw.idempotencyMaxBody)).
+		Then(gw.toHTTPHandler(endpoint, route))
 
 	// If you're registering "POST /FooService.Bar" we're going to create a route for
 	// the POST as well as an additional, implicit OPTIONS route. This is so that
@@ -165,14 +387,27 @@ func (gw *Gateway) Register(endpoint services.Endpoint, route services.EndpointR
 	gw.endpoints[httpRoute{Method: method, Path: path}] = endpoint
 	gw.endpoints[httpRoute{Method: http.MethodOptions, Path: path}] = endpoint
 	gw.router.HandleFunc(method+" "+path, httpHandler)
-	gw.registerOptions(path)
+	gw.registerOptions(path, route)
 }
 
-func (gw *Gateway) registerOptions(path string) {
-	// Only do this if the user explicitly enabled CORS
-	if gw.cors == nil {
+func (gw *Gateway) registerOptions(path string, route services.EndpointRoute) {
+	// Only do this if the user explicitly enabled CORS or the service asked us to answer OPTIONS requests
+	// using the "HTTP ALLOW" doc option. CORS wins since its preflight responses already cover this. Schemas
+	// need the route, too, but the OPTIONS requests that don't ask for one still behave like there's no route.
+	var handler http.HandlerFunc
+	switch {
+	case gw.cors != nil:
+		handler = gw.cors.HandlerFunc
+	case slices.Contains(route.AllowMethods, http.MethodOptions):
+		handler = allowMethodsHandler(route.AllowMethods)
+	case gw.schemas:
+		handler = gw.unmatchedRouteHandler()
+	default:
 		return
 	}
+	if gw.schemas {
+		handler = gw.schemaHandler(path, route.Method, handler)
+	}
 
 	// I realize that recovering from panics makes the baby jesus cry. This is to handle the case where you
 	// register multiple service functions with the same path, but different methods. For instance:
@@ -198,7 +433,19 @@ func (gw *Gateway) registerOptions(path string) {
 		recover()
 	}()
 
-	gw.router.HandleFunc("OPTIONS "+path, gw.middleware.Then(gw.cors.HandlerFunc))
+	// Just like every other response, these should include the trace id header.
+	traceFuncs := HTTPMiddlewareFuncs{restoreTraceID(gw.metadataPolicy, gw.traceIDHeader, gw.traceIDExtractor)}
+	gw.router.HandleFunc("OPTIONS "+path, traceFuncs.Append(gw.middleware...).Then(handler))
+}
+
+// allowMethodsHandler answers OPTIONS requests for routes whose "HTTP ALLOW" option includes OPTIONS, letting
+// the caller know which methods the route supports w/o invoking the handler.
+func allowMethodsHandler(methods []string) http.HandlerFunc {
+	allow := strings.Join(methods, ", ")
+	return func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Allow", allow)
+		w.WriteHeader(http.StatusNoContent)
+	}
 }
 
 func (gw *Gateway) toHTTPHandler(endpoint services.Endpoint, route services.EndpointRoute) http.HandlerFunc {
@@ -210,8 +457,16 @@ func (gw *Gateway) toHTTPHandler(endpoint services.Endpoint, route services.Endp
 	encoder := gw.codecs.Encoder("application/json")
 	decoder := gw.codecs.Decoder("application/json")
 	valueDecoder := gw.codecs.ValueDecoder("application/json")
+	errorEncoder := gw.errorEncoder()
+	headerFields := headerFieldsFor(endpoint)
+	autoHead := gw.autoHead || slices.Contains(route.AllowMethods, http.MethodHead)
 
 	return func(w http.ResponseWriter, req *http.Request) {
+		// The mux routes HEAD requests to GET routes, so this is only ever a GET handler responding to a HEAD.
+		if autoHead && req.Method == http.MethodHead {
+			w = &headResponseWriter{ResponseWriter: w}
+		}
+
 		// Create a blank request struct that we will populate w/ request body/path/query data.
 		serviceRequest := endpoint.NewInput()
 
@@ -241,26 +496,87 @@ func (gw *Gateway) toHTTPHandler(endpoint services.Endpoint, route services.Endp
 		// body or query string. The body will override anything defined in the query string. This
 		// way you can't sneak in values to circumvent security while providing a sane set of
 		// binding expectations to your input data.
+		//
+		// Fields tagged `header:"X-Whatever"` sit below all of them. Headers are the easiest values for
+		// proxies and other middlemen to tamper with, so anything explicit in the request wins.
+		if err := valueDecoder.DecodeValues(headerParams(headerFields, req), &serviceRequest); err != nil {
+			respondFailure(w, req, errorEncoder, err)
+			return
+		}
 		if err := valueDecoder.DecodeValues(queryParams(route, req), &serviceRequest); err != nil {
-			respondFailure(w, req, encoder, err)
+			respondFailure(w, req, errorEncoder, err)
 			return
 		}
 		if err := decoder.Decode(req.Body, &serviceRequest); err != nil {
-			respondFailure(w, req, encoder, err)
+			respondFailure(w, req, errorEncoder, err)
 			return
 		}
 		if err := valueDecoder.DecodeValues(pathParams(route, req), &serviceRequest); err != nil {
-			respondFailure(w, req, encoder, err)
+			respondFailure(w, req, errorEncoder, err)
 			return
 		}
+		// Only once every layer has had its say can the request fill in defaults for whatever is still blank.
+		services.ApplyDefaults(serviceRequest)
 
 		serviceResponse, err := endpoint.Handler(req.Context(), serviceRequest)
 		if err != nil {
-			respondFailure(w, req, encoder, err)
+			respondFailure(w, req, errorEncoder, gw.errorMapper.Map(err))
 			return
 		}
-		respondSuccess(w, req, encoder, serviceResponse, route.Status)
+		// Only successful responses get the route's "HTTP CACHE" header; nobody should be caching your errors.
+		if route.CacheControl != "" && w.Header().Get("Cache-Control") == "" {
+			w.Header().Set("Cache-Control", route.CacheControl)
+		}
+		respondSuccess(w, req, gw.successEncoder(req, encoder), serviceResponse, route.Status, gw.streamTimeoutFor(req))
+	}
+}
+
+// successEncoder wraps the encoder used for successful responses, so that encoded responses only include the fields
+// the caller selected (WithFieldSelection), are wrapped in your WithResponseEnvelope() envelope, and are reshaped by
+// your WithResponseTransform() functions; in that order. Redirects, streams, and 204s never encode anything, so
+// they're left alone.
+func (gw *Gateway) successEncoder(req *http.Request, encoder codec.Encoder) codec.Encoder {
+	if len(gw.responseTransforms) > 0 {
+		encoder = transformEncoder{Encoder: encoder, transforms: gw.responseTransforms}
+	}
+	if gw.responseEnvelope != nil {
+		encoder = envelopeEncoder{Encoder: encoder, ctx: req.Context(), envelope: gw.responseEnvelope}
+	}
+	if selection := gw.fieldSelectionFor(req); selection != nil {
+		encoder = fieldSelectionEncoder{Encoder: encoder, selection: selection}
+	}
+	return encoder
+}
+
+// reshapesRawJSON returns true when the encoder changes the structure of the response value (e.g. envelopes or field
+// selection), so we can't just write the bytes of a services.RawJSON response as-is.
+func reshapesRawJSON(encoder codec.Encoder) bool {
+	switch encoder.(type) {
+	case envelopeEncoder, fieldSelectionEncoder:
+		return true
+	default:
+		return false
+	}
+}
+
+// envelopeEncoder encodes the envelope that wraps each value rather than the value itself.
+type envelopeEncoder struct {
+	codec.Encoder
+	ctx      context.Context
+	envelope ResponseEnvelopeFunc
+}
+
+func (e envelopeEncoder) Encode(writer io.Writer, value any) error {
+	return e.Encoder.Encode(writer, e.envelope(e.ctx, value))
+}
+
+// streamTimeoutFor determines how long a streaming response for this request can go without writing anything
+// to the client before we give up on it. Zero means that we'll wait for as long as it takes.
+func (gw *Gateway) streamTimeoutFor(req *http.Request) time.Duration {
+	if gw.streamTimeout == nil {
+		return 0
 	}
+	return gw.streamTimeout(metadata.Route(req.Context()))
 }
 
 // UseTLS returns false (default) when Listen() will fire up in normal HTTP mode. If
@@ -282,15 +598,14 @@ func (gw *Gateway) Middleware() services.MiddlewareFuncs {
 // default OPTIONS handler we use so that you can insert the CORS middleware of your
 // choice should you choose to enable browser-based communication w/ your service.
 func (gw *Gateway) methodNotAllowedHandler(w http.ResponseWriter, req *http.Request) {
-	encoder := gw.codecs.DefaultEncoder()
-	respondFailure(w, req, encoder, fail.MethodNotAllowed("method not allowed: %v", req.Method))
+	respondFailure(w, req, gw.errorEncoder(), fail.MethodNotAllowed("method not allowed: %v", req.Method))
 }
 
 // defaultNotFoundHandler replies with a 404 error status no matter what. The body will match our
 // look like {"Status":404, "Message":"..."} to match our standard error payload.
-func defaultNotFoundHandler(codecs codec.Registry) http.HandlerFunc {
+func defaultNotFoundHandler(encoder codec.Encoder) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
-		respondFailure(w, req, codecs.DefaultEncoder(), fail.NotFound("not found"))
+		respondFailure(w, req, encoder, fail.NotFound("not found"))
 	}
 }
 
@@ -312,8 +627,12 @@ func normalizePath(path string) string {
 }
 
 // normalizePathParamName converts a path segment like "{Foo.BAR}" into a ServeMux friendly "{Foo__DOT__Bar}". See the
-// comment for normalizePath() for full details on why this is necessary.
+// comment for normalizePath() for full details on why this is necessary. The "..." of a wildcard segment that captures
+// the rest of the path (e.g. "{File.Path...}") is left alone since it's not part of the name.
 func normalizePathParamName(paramName string) string {
+	if name, ok := strings.CutSuffix(paramName, "...}"); ok {
+		return strings.ReplaceAll(name, ".", "__DOT__") + "...}"
+	}
 	return strings.ReplaceAll(paramName, ".", "__DOT__")
 }
 
@@ -322,10 +641,33 @@ func queryParams(_ services.EndpointRoute, req *http.Request) map[string][]strin
 	return req.URL.Query()
 }
 
+// headerFieldsFor finds all of the fields in the endpoint's request struct tagged `header:"Some-Header"`. The
+// resulting map is keyed by the binding path of the field (e.g. "Options.Locale"), so the value decoder can
+// bind it just like a query string value.
+func headerFieldsFor(endpoint services.Endpoint) map[string]string {
+	if endpoint.NewInput == nil {
+		return nil
+	}
+	return reflection.TaggedFields(reflect.TypeOf(endpoint.NewInput()), "header")
+}
+
+// headerParams extracts the values of the request headers bound to struct fields in a way that makes the binder
+// happy. Fields whose header wasn't sent at all are left alone.
+func headerParams(headerFields map[string]string, req *http.Request) map[string][]string {
+	values := url.Values{}
+	for fieldPath, headerName := range headerFields {
+		if headerValues := req.Header.Values(headerName); len(headerValues) > 0 {
+			values[fieldPath] = headerValues
+		}
+	}
+	return values
+}
+
 // pathParams extracts the path parameters from the incoming URL path. This makes sure to take into account
 // the "." to "__DOT__" normalization we need to do when registering routes (see normalizePath()). Don't worry
 // the map of params will revert everything back to the original names, so your value map will look something
-// like {"User.ID":"123", "Trans.ID":"ABC"} and not {"User__DOT__ID":"123", "Trans__DOT__ID":"ABC"}.
+// like {"User.ID":"123", "Trans.ID":"ABC"} and not {"User__DOT__ID":"123", "Trans__DOT__ID":"ABC"}. A wildcard
+// param like "{Path...}" gets the entire remainder of the path, slashes and all (e.g. "docs/2024/report.pdf").
 func pathParams(route services.EndpointRoute, req *http.Request) map[string][]string {
 	values := url.Values{}
 	for _, paramName := range route.PathParams {
@@ -343,14 +685,45 @@ type httpRoute struct {
 	Path string
 }
 
-func respondFailure(w http.ResponseWriter, _ *http.Request, encoder codec.Encoder, err error) {
+func respondFailure(w http.ResponseWriter, req *http.Request, encoder codec.Encoder, err error) {
 	status := fail.Status(err)
+	message := err.Error()
+	if localizer, ok := encoder.(localizingEncoder); ok {
+		message = localizer.localize(req, status, err)
+		encoder = localizer.Encoder
+	}
+
 	w.Header().Set("Content-Type", encoder.ContentType())
+	if retryAfter, ok := fail.RetryAfter(err); ok {
+		w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+	}
 	w.WriteHeader(status)
-	_ = encoder.Encode(w, fail.New(status, err.Error()))
+
+	// Problem details can also tell the caller which resource the error was about.
+	if _, ok := encoder.(problemEncoder); ok && req != nil {
+		_ = encoder.Encode(w, newProblemDetails(status, message, req.URL.Path))
+		return
+	}
+	_ = encoder.Encode(w, fail.StatusError{Status: status, Message: message})
+}
+
+// retryAfterSeconds formats the delay as the whole number of seconds that the "Retry-After" header expects. We
+// round up, so the caller never tries again too early, and never go below 1 since "0" would mean "right now".
+func retryAfterSeconds(retryAfter time.Duration) string {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.FormatInt(seconds, 10)
 }
 
-func respondSuccess(w http.ResponseWriter, req *http.Request, encoder codec.Encoder, serviceResponse any, status int) {
+func respondSuccess(w http.ResponseWriter, req *http.Request, encoder codec.Encoder, serviceResponse any, status int, streamTimeout time.Duration) {
+	// Check this first. Your method returning a nil response would make the redirect/stream
+	// checks below blow up when calling methods on a nil pointer.
+	if respondSuccessNoContent(w, serviceResponse) {
+		return
+	}
+
 	// If your response implements either of the redirect getter methods, try to forward on to
 	// the desired address using either a 307/308 as needed.
 	//
@@ -368,13 +741,37 @@ func respondSuccess(w http.ResponseWriter, req *http.Request, encoder codec.Enco
 		return
 	}
 
+	// Your handler can pick the status at runtime (e.g. 201 vs 200), which beats the static "HTTP" doc option.
+	status, explicit := responseStatus(serviceResponse, status)
+
 	// The method's response appears to want to send raw bytes itself rather than relying
 	// on the auto-JSON (or whatever encoding) that we normally use to marshal responses.
 	// Based on the methods implemented by the response struct, we can send a response w/ different
 	// headers in addition to the raw bytes. See the docs for RespondRawRanged, RespondRawSized,
 	// and RespondRaw for more info on what headers we'll include.
 	streamResponse, ok := serviceResponse.(services.ContentGetter)
-	if ok && respondSuccessStream(w, streamResponse, status) {
+	if ok && respondSuccessStream(w, streamResponse, status, explicit, streamTimeout) {
+		return
+	}
+	jsonStreamResponse, ok := serviceResponse.(services.JSONStreamGetter)
+	if ok && respondSuccessJSONStream(w, jsonStreamResponse, encoder, status, streamTimeout) {
+		return
+	}
+
+	// You already have the JSON, so don't waste time re-encoding it. Envelopes and field selection still need to
+	// reshape the raw data, though, so let those go through the encoder; RawJSON marshals itself as the raw bytes anyway.
+	rawResponse, ok := serviceResponse.(services.RawJSONGetter)
+	if ok && !reshapesRawJSON(encoder) && respondSuccessRaw(w, rawResponse, status) {
+		return
+	}
+
+	// For HEAD requests, we still need to encode the response to know its Content-Length; we just don't send it.
+	if isHeadResponse(w) {
+		buf := &bytes.Buffer{}
+		_ = encoder.Encode(buf, serviceResponse)
+		w.Header().Set("Content-Type", encoder.ContentType())
+		w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+		w.WriteHeader(status)
 		return
 	}
 
@@ -384,6 +781,67 @@ func respondSuccess(w http.ResponseWriter, req *http.Request, encoder codec.Enco
 	_ = encoder.Encode(w, serviceResponse)
 }
 
+// headResponseWriter is used when responding to HEAD requests w/ WithAutoHead() enabled. It lets the response
+// functions know that they should write the status and headers, but not bother producing the body. Any body
+// bytes that are written anyway are thrown away.
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+// Write discards the body bytes since HEAD responses can't have one.
+func (w *headResponseWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *headResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func isHeadResponse(w http.ResponseWriter) bool {
+	_, ok := w.(*headResponseWriter)
+	return ok
+}
+
+func respondSuccessNoContent(w http.ResponseWriter, serviceResponse any) bool {
+	if !isNil(serviceResponse) {
+		responder, ok := serviceResponse.(services.NoContentResponder)
+		if !ok || !responder.NoContent() {
+			return false
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
+
+// isNil returns true if the value is nil or a typed nil pointer/map/etc. wrapped in an interface. The latter is
+// what you get when your service method returns "nil, nil" since our handlers return the response as an "any".
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	switch reflected := reflect.ValueOf(value); reflected.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return reflected.IsNil()
+	default:
+		return false
+	}
+}
+
+func respondSuccessRaw(w http.ResponseWriter, rawResponse services.RawJSONGetter, status int) bool {
+	data := rawResponse.Raw()
+	if len(data) == 0 {
+		return false
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+	return true
+}
+
 func respondSuccessRedirect(w http.ResponseWriter, req *http.Request, redirectGetter services.Redirector) bool {
 	redirectURL := redirectGetter.Redirect()
 	if redirectURL == "" {
@@ -404,23 +862,141 @@ func respondSuccessRedirectPermanent(w http.ResponseWriter, req *http.Request, r
 	return true
 }
 
-func respondSuccessStream(w http.ResponseWriter, streamResponse services.ContentGetter, status int) bool {
+func respondSuccessStream(w http.ResponseWriter, streamResponse services.ContentGetter, status int, explicit bool, timeout time.Duration) bool {
 	content := streamResponse.Content()
 	defer quiet.Close(content)
 
+	// Large downloads can legitimately take longer than the WithWriteTimeout() you'd want for normal
+	// responses, so streams are exempt. Not every response writer supports this, but that's fine.
+	controller := http.NewResponseController(w)
+	_ = controller.SetWriteDeadline(time.Time{})
+
 	headers := w.Header()
 	headers.Set("Content-Type", "application/octet-stream")
 
 	writeContentType(headers, streamResponse)
+	body := writeSniffedContentType(headers, streamResponse, content)
 	writeContentLength(headers, streamResponse)
-	writeContentRange(headers, streamResponse) // this can change Content-Length, so do this after writeContentLength()!
+	ranged := writeContentRange(headers, streamResponse) // this can change Content-Length, so do this after writeContentLength()!
 	writeContentFileName(headers, streamResponse)
+	writeContentHeaders(headers, streamResponse) // these are explicit overrides, so they must go last!
+
+	w.WriteHeader(streamStatus(streamResponse, status, explicit, ranged))
+	if !isHeadResponse(w) {
+		copyStream(w, controller, body, timeout)
+	}
+	return true
+}
 
+// respondSuccessJSONStream writes each item of the stream as its own line of JSON, flushing after each one so that
+// the caller can start working on them right away. Field selection and transforms apply to each individual item,
+// but envelopes don't; wrapping every line would just make the stream harder to read.
+func respondSuccessJSONStream(w http.ResponseWriter, streamResponse services.JSONStreamGetter, encoder codec.Encoder, status int, timeout time.Duration) bool {
+	controller := http.NewResponseController(w)
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
 	w.WriteHeader(status)
-	_, _ = io.Copy(w, content)
+	if isHeadResponse(w) {
+		return true
+	}
+
+	var writer io.Writer = w
+	flush := controller.Flush
+	if timeout > 0 {
+		idleWriter := idleTimeoutWriter{writer: w, controller: controller, timeout: timeout}
+		writer = idleWriter
+		flush = func() error { return idleWriter.withDeadline(controller.Flush) }
+	}
+
+	encoder = streamItemEncoder(encoder)
+	buf := &bytes.Buffer{}
+	_ = streamResponse.StreamJSON(func(item any) error {
+		buf.Reset()
+		if err := encoder.Encode(buf, item); err != nil {
+			return err
+		}
+
+		// Each item MUST be exactly one line, so don't rely on the encoder to supply the newline for us.
+		line := append(bytes.TrimRight(buf.Bytes(), "\r\n"), '\n')
+		if _, err := writer.Write(line); err != nil {
+			return err
+		}
+		// Not every response writer can flush. The items still arrive; they're just buffered a bit longer.
+		if err := flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	})
 	return true
 }
 
+// streamItemEncoder strips the envelope from the success encoder (see successEncoder), since each item in a JSON
+// stream is its own top-level value. Everything else still applies to the individual items.
+func streamItemEncoder(encoder codec.Encoder) codec.Encoder {
+	switch wrapped := encoder.(type) {
+	case envelopeEncoder:
+		return streamItemEncoder(wrapped.Encoder)
+	case fieldSelectionEncoder:
+		wrapped.Encoder = streamItemEncoder(wrapped.Encoder)
+		return wrapped
+	default:
+		return encoder
+	}
+}
+
+// copyStream writes the stream's content to the response. When there's a timeout, each individual write must
+// finish before the deadline, so slow clients can take as long as they need as long as they keep reading. A client
+// that stops reading entirely causes the write to fail once the deadline passes, so the connection is released
+// rather than blocking this goroutine forever.
+func copyStream(w http.ResponseWriter, controller *http.ResponseController, body io.Reader, timeout time.Duration) {
+	if timeout <= 0 {
+		_, _ = io.Copy(w, body)
+		return
+	}
+
+	writer := idleTimeoutWriter{writer: w, controller: controller, timeout: timeout}
+	if _, err := io.Copy(writer, body); err == nil {
+		_ = writer.withDeadline(controller.Flush)
+	}
+}
+
+// idleTimeoutWriter applies a write deadline to each individual write (see copyStream).
+type idleTimeoutWriter struct {
+	writer     io.Writer
+	controller *http.ResponseController
+	timeout    time.Duration
+}
+
+func (w idleTimeoutWriter) Write(p []byte) (n int, err error) {
+	err = w.withDeadline(func() error {
+		n, err = w.writer.Write(p)
+		return err
+	})
+	return n, err
+}
+
+// withDeadline runs the write operation w/ the deadline in place. We clear the deadline afterwards, so it
+// doesn't expire while we're waiting on the stream's content or linger on a kept-alive connection.
+func (w idleTimeoutWriter) withDeadline(write func() error) error {
+	_ = w.controller.SetWriteDeadline(time.Now().Add(w.timeout))
+	defer func() { _ = w.controller.SetWriteDeadline(time.Time{}) }()
+	return write()
+}
+
+func writeContentHeaders(headers http.Header, streamResponse services.ContentGetter) {
+	// Only bother if the response struct can supply custom headers.
+	getter, ok := streamResponse.(services.ContentHeadersGetter)
+	if !ok {
+		return
+	}
+
+	// Replace (don't append to) any values we computed, so that the handler can explicitly override them.
+	for name, values := range getter.ContentHeaders() {
+		headers[http.CanonicalHeaderKey(name)] = values
+	}
+}
+
 func writeContentType(headers http.Header, streamResponse services.ContentGetter) {
 	// Your stream response will just use the default content type ("application/octet-stream")
 	// because you aren't capable of telling us otherwise.
@@ -438,6 +1014,38 @@ func writeContentType(headers http.Header, streamResponse services.ContentGetter
 	headers.Set("Content-Type", contentType)
 }
 
+// sniffLength is the maximum number of bytes that http.DetectContentType() considers.
+const sniffLength = 512
+
+// writeSniffedContentType detects the Content-Type of streams that opted into sniffing, but didn't supply an explicit
+// type. Since that requires reading the first chunk of the stream, this returns the reader you should use to send the
+// content; it replays the bytes we already read before continuing w/ the rest of the stream.
+func writeSniffedContentType(headers http.Header, streamResponse services.ContentGetter, content io.Reader) io.Reader {
+	sniffer, ok := streamResponse.(services.ContentTypeSniffer)
+	if !ok || !sniffer.SniffContentType() || content == nil {
+		return content
+	}
+
+	// You told us exactly what this stream contains, so there's nothing to detect.
+	if getter, ok := streamResponse.(services.ContentTypeGetter); ok && strings.TrimSpace(getter.ContentType()) != "" {
+		return content
+	}
+
+	prefix := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, prefix)
+	prefix = prefix[:n]
+	if n > 0 {
+		headers.Set("Content-Type", http.DetectContentType(prefix))
+	}
+
+	// A short stream (EOF/ErrUnexpectedEOF) is totally normal; we just read the whole thing. Any other failure
+	// will surface again when we try to copy the rest of the stream, just like it would have without sniffing.
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return bytes.NewReader(prefix)
+	}
+	return io.MultiReader(bytes.NewReader(prefix), content)
+}
+
 func writeContentLength(headers http.Header, streamResponse services.ContentGetter) {
 	// Only bother if the response struct can supply range information.
 	getter, ok := streamResponse.(services.ContentLengthGetter)
@@ -454,22 +1062,50 @@ func writeContentLength(headers http.Header, streamResponse services.ContentGett
 	headers.Set("Content-Length", strconv.FormatInt(int64(length), 10))
 }
 
-func writeContentRange(headers http.Header, streamResponse services.ContentGetter) {
+// responseStatus returns the status that the response picked for itself (see services.StatusGetter), or the route's
+// status when it didn't pick a valid one. The boolean is true when the response made an explicit choice.
+func responseStatus(serviceResponse any, status int) (int, bool) {
+	getter, ok := serviceResponse.(services.StatusGetter)
+	if !ok {
+		return status, false
+	}
+	if override := getter.Status(); override >= 100 && override <= 599 {
+		return override, true
+	}
+	return status, false
+}
+
+// streamStatus determines the HTTP status code for a raw stream response. The stream's own ContentStatus() always
+// wins. Otherwise, partial content gets a 206 as long as neither the route nor the response (see 'explicit')
+// specified some other success status.
+func streamStatus(streamResponse services.ContentGetter, status int, explicit bool, ranged bool) int {
+	if getter, ok := streamResponse.(services.ContentStatusGetter); ok && getter.ContentStatus() > 0 {
+		return getter.ContentStatus()
+	}
+	if ranged && !explicit && status == http.StatusOK {
+		return http.StatusPartialContent
+	}
+	return status
+}
+
+// writeContentRange adds the Content-Range header when the response includes valid range info. It returns
+// true when it actually wrote the header, meaning that this is a partial content response.
+func writeContentRange(headers http.Header, streamResponse services.ContentGetter) bool {
 	// Only bother if the response struct can supply range information.
 	getter, ok := streamResponse.(services.ContentRangeGetter)
 	if !ok {
-		return
+		return false
 	}
 
 	// The response can supply these values, but they don't appear to have done so for this one.
 	start, end, size := getter.ContentRange()
 	if start <= 0 && end <= 0 && size <= 0 {
-		return
+		return false
 	}
 
 	// You tried to supply meaningful values, but they're garbage.
 	if end <= start || end >= size {
-		return
+		return false
 	}
 
 	sizeValue := "*"
@@ -478,6 +1114,7 @@ func writeContentRange(headers http.Header, streamResponse services.ContentGette
 	}
 	headers.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%s", start, end, sizeValue))
 	headers.Set("Content-Length", strconv.FormatInt(int64(end-start), 10))
+	return true
 }
 
 func writeContentFileName(headers http.Header, streamResponse services.ContentGetter) {
@@ -512,6 +1149,22 @@ func WithMiddleware(funcs ...HTTPMiddlewareFunc) GatewayOption {
 	}
 }
 
+// WithAccessLog writes a classic HTTP access log record for every request the gateway receives: method, path,
+// status, bytes, duration, remote address, etc. Unlike logging middleware for your services, this covers
+// requests that never reach one of your functions, such as 404s, CORS preflight requests, and health checks.
+// Each record's message is AccessLogMessage, and the details are attributes, so the logger's handler decides
+// the format. Use slog.NewJSONHandler() for JSON or NewCombinedLogHandler() for Apache/nginx-style lines:
+//
+//	accessLog := slog.New(apis.NewCombinedLogHandler(os.Stdout))
+//	gateway := apis.NewGateway(":9000", apis.WithAccessLog(accessLog))
+//
+// The remote address respects WithTrustedProxies(). A nil logger disables the access log.
+func WithAccessLog(logger *slog.Logger) GatewayOption {
+	return func(gw *Gateway) {
+		gw.accessLog = logger
+	}
+}
+
 // WithTLSConfig allows the gateway's underlying HTTP server to handle HTTPS requests using
 // the configuration you provide. If you are using the Let's Encrypt auto-cert manager certificate
 // configurations, this is how you can make your gateway adhere to that cert.
@@ -530,6 +1183,341 @@ func WithTLSFiles(certFile string, keyFile string) GatewayOption {
 	}
 }
 
+// WithClientCertAuth enables mutual TLS. Every caller must present a client certificate signed by one of the
+// CAs in the given pool or the TLS handshake fails. The verified certificate's identity (common name, SANs, etc.)
+// is available to your middleware/handlers via metadata.ClientCert(ctx), so you can authorize calls based on the
+// peer's identity rather than a bearer token. You still need to supply the server's own certificate using either
+// WithTLSFiles() or WithTLSConfig().
+func WithClientCertAuth(caPool *x509.CertPool) GatewayOption {
+	return func(gw *Gateway) {
+		gw.clientCAs = caPool
+	}
+}
+
+// WithStrictDecoding makes the gateway reject request bodies that contain fields your request struct doesn't
+// have, responding with a 400 instead. This is great for catching typos in client code early. It only applies to
+// the body; path and query string values that don't match a field are still ignored.
+func WithStrictDecoding() GatewayOption {
+	return func(gw *Gateway) {
+		codec.WithStrictJSON()(&gw.codecs)
+	}
+}
+
+// WithTrustedProxies tells the gateway which load balancers/proxies (IP addresses or CIDR ranges such as
+// "10.0.0.0/8") it can trust to report the real client address. When a request comes directly from one of these,
+// metadata.RemoteAddr() will use the X-Forwarded-For/X-Real-IP headers rather than the proxy's address. Requests
+// from anywhere else never honor those headers since they're trivial to spoof.
+//
+// This panics if any of the addresses can't be parsed; you don't want to find out that your security config is
+// being ignored by looking at your audit logs.
+func WithTrustedProxies(proxies ...string) GatewayOption {
+	prefixes := make([]netip.Prefix, len(proxies))
+	for i, proxy := range proxies {
+		prefixes[i] = parseTrustedProxy(proxy)
+	}
+	return func(gw *Gateway) {
+		gw.trustedProxies = append(gw.trustedProxies, prefixes...)
+	}
+}
+
+func parseTrustedProxy(proxy string) netip.Prefix {
+	proxy = strings.TrimSpace(proxy)
+	if strings.Contains(proxy, "/") {
+		return netip.MustParsePrefix(proxy).Masked()
+	}
+
+	addr := netip.MustParseAddr(proxy).Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen())
+}
+
+// WithReadinessCheck adds a "GET {path}" route that responds with a 200 when the check returns true and a 503 when
+// it returns false. Point your load balancer's readiness probe at it. Typically, you'll use the server's Ready()
+// method as the check, so the route starts failing as soon as the server begins its lame duck period.
+//
+//	var server *services.Server
+//	server = services.NewServer(
+//		services.Listen(apis.NewGateway(":9000",
+//			apis.WithReadinessCheck("/health/ready", func() bool { return server.Ready() }),
+//		)),
+//		services.WithLameDuck(10*time.Second),
+//	)
+func WithReadinessCheck(path string, check func() bool) GatewayOption {
+	return func(gw *Gateway) {
+		gw.readinessPath = path
+		gw.readinessCheck = check
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests the gateway will handle at the same time (across all
+// endpoints). Once that many are in flight, new requests are immediately rejected with a 503 and a "Retry-After"
+// header rather than being queued up. This is load shedding to protect your memory/goroutines under extreme load,
+// not per-caller rate limiting. A limit of zero or less means unlimited, which is the default.
+func WithMaxConcurrentRequests(limit int) GatewayOption {
+	return func(gw *Gateway) {
+		gw.maxInFlight = int64(limit)
+	}
+}
+
+// WithMaxQueryLength rejects requests whose query string is longer than 'length' bytes with a 414 URI Too Long. Big
+// nested GET requests can produce pretty long query strings, so don't be too stingy, but publicly exposed gateways
+// probably don't want to parse megabytes of query string. A limit of zero or less means unlimited, which is the default.
+func WithMaxQueryLength(length int) GatewayOption {
+	return func(gw *Gateway) {
+		gw.maxQueryLength = length
+	}
+}
+
+// WithMaxDecompressedSize limits how many bytes the gateway reads from a gzipped request body (see the
+// "Content-Encoding" header) once it's decompressed. Requests that expand beyond that fail w/ a 413 Request
+// Entity Too Large. A tiny compressed payload can expand into gigabytes, so don't go unlimited unless you trust
+// your callers. The default is DefaultMaxDecompressedSize (32MB), and a size of zero or less means unlimited.
+func WithMaxDecompressedSize(size int64) GatewayOption {
+	return func(gw *Gateway) {
+		gw.maxDecompressedSize = size
+	}
+}
+
+// WithMaxHeaderBytes rejects requests whose headers are bigger than 'size' bytes with a 431 Request Header Fields Too
+// Large. This also sets the underlying server's MaxHeaderBytes, so the server stops reading absurdly large headers
+// before they ever reach the gateway. A limit of zero or less uses the net/http default of 1MB.
+func WithMaxHeaderBytes(size int) GatewayOption {
+	return func(gw *Gateway) {
+		gw.maxHeaderBytes = size
+		gw.server.MaxHeaderBytes = size
+	}
+}
+
+// WithRequireContentType rejects POST, PUT, and PATCH requests whose "Content-Type" header isn't one of these media
+// types with a 415 Unsupported Media Type. Without it, a client that forgets the header (or sends a form) gets a
+// confusing decoding error at best, so this catches misconfigured clients early:
+//
+//	apis.NewGateway(":9000", apis.WithRequireContentType("application/json"))
+//
+// Parameters such as "application/json; charset=utf-8" still match. Requests without a body, and methods that
+// don't send one (GET, DELETE, etc.), are never checked.
+//
+// This panics if any of the media types can't be parsed; a typo would otherwise reject every request w/ a 415
+// or quietly allow types that you never meant to.
+func WithRequireContentType(contentTypes ...string) GatewayOption {
+	mediaTypes := make([]string, len(contentTypes))
+	for i, contentType := range contentTypes {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			panic(fmt.Sprintf("apis.WithRequireContentType: invalid media type '%s': %v", contentType, err))
+		}
+		mediaTypes[i] = mediaType
+	}
+	return func(gw *Gateway) {
+		gw.requiredContentTypes = append(gw.requiredContentTypes, mediaTypes...)
+	}
+}
+
+// WithResponseTiming adds "X-Response-Time" and "Server-Timing" headers to every response, indicating how long
+// the gateway spent handling the request before it started writing the response. The Server-Timing header shows
+// up in your browser's dev tools, which makes it handy for tracking down latency issues.
+//
+//	X-Response-Time: 12.345ms
+//	Server-Timing: app;dur=12.345
+func WithResponseTiming() GatewayOption {
+	return func(gw *Gateway) {
+		gw.responseTiming = true
+	}
+}
+
+// WithAutoHead makes HEAD requests to your GET routes respond w/ the same status and headers that the GET would,
+// without actually producing the body. Go's router already sends HEAD requests to GET routes, but without this
+// option the entire response is still generated and then thrown away. With it, a download endpoint only needs
+// to supply its Content-Length (see services.ContentLengthGetter) rather than reading the whole stream, and
+// encoded responses include an accurate Content-Length header. Your handler still runs, so clients can use HEAD
+// to check whether something exists and how big it is before they download it.
+func WithAutoHead() GatewayOption {
+	return func(gw *Gateway) {
+		gw.autoHead = true
+	}
+}
+
+// WithBatching lets clients send multiple service calls to this gateway in a single round trip using clients.Batch.
+// Each call in the batch is routed through the gateway just like it would have been on its own, so all of your
+// middleware (auth, etc.) still applies to every call. Batches are limited to 100 calls. Clients automatically
+// fall back to making concurrent, individual calls when the gateway doesn't have batching enabled.
+func WithBatching() GatewayOption {
+	return func(gw *Gateway) {
+		gw.batching = true
+	}
+}
+
+// WithErrorMapper translates the errors returned by your service functions before we respond with them. This is
+// how you turn third-party errors that don't have a status code into the appropriate fail.Xxx() error in one place:
+//
+//	apis.WithErrorMapper(func(err error) error {
+//		if errors.Is(err, sql.ErrNoRows) {
+//			return fail.NotFound(err.Error())
+//		}
+//		return err
+//	})
+//
+// If you also use the event gateway, give it the same mapper (see events.WithErrorMapper()), so that the
+// "Service.Function:Error" events and your error listener see the same errors that API callers do.
+func WithErrorMapper(mapper fail.ErrorMapper) GatewayOption {
+	return func(gw *Gateway) {
+		gw.errorMapper = mapper
+	}
+}
+
+// WithProblemJSON makes the gateway respond to failures w/ RFC 7807 "application/problem+json" bodies rather than the
+// standard {"Status":404, "Message":"..."} format. Some consumers of public APIs expect this format, but it's a bit
+// heavier, so it's opt-in. The status comes from fail.Status() and the detail is your error's message:
+//
+//	{
+//	  "type": "about:blank",
+//	  "title": "Not Found",
+//	  "status": 404,
+//	  "detail": "user not found",
+//	  "instance": "/user/123"
+//	}
+//
+// The Go client understands both formats, so you don't need to do anything special to call these services.
+func WithProblemJSON() GatewayOption {
+	return func(gw *Gateway) {
+		gw.problemJSON = true
+	}
+}
+
+// WithLocalizer translates the messages of your error responses into the caller's language, which we take from the
+// "Accept-Language" header. Give your errors a message key using WithKey(), and look that key up in your own catalog:
+//
+//	apis.NewGateway(":8080", apis.WithLocalizer(func(lang string, err fail.StatusError) string {
+//		if message, ok := catalog.Lookup(lang, err.Key); ok {
+//			return message
+//		}
+//		return err.Message
+//	}))
+//
+// Your handlers don't need to know anything about the caller's language; they just return errors like always:
+//
+//	return nil, fail.AlreadyExists("always a conflict").WithKey("errors.conflict")
+//
+// This applies to every error response, including those the gateway generates itself (e.g. 404s for unknown
+// paths), which don't have keys. If the localizer returns an empty string, we use the original message.
+func WithLocalizer(localizer Localizer) GatewayOption {
+	return func(gw *Gateway) {
+		gw.localizer = localizer
+	}
+}
+
+// ResponseEnvelopeFunc wraps a successful service response in some standard structure before we encode it.
+type ResponseEnvelopeFunc func(ctx context.Context, serviceResponse any) any
+
+// WithResponseEnvelope lets you wrap every successful response in a standard envelope rather than responding w/ the
+// bare response value. The context has all of the request's metadata, so you can include things like the trace id:
+//
+//	apis.WithResponseEnvelope(func(ctx context.Context, serviceResponse any) any {
+//		return map[string]any{
+//			"data": serviceResponse,
+//			"meta": map[string]any{"traceId": metadata.TraceID(ctx)},
+//		}
+//	})
+//
+// Errors, redirects, raw/streamed content, and 204 responses are never wrapped since they aren't encoded objects.
+// Go clients calling this gateway need the matching clients.WithResponseEnvelope() option to unwrap responses.
+func WithResponseEnvelope(envelope ResponseEnvelopeFunc) GatewayOption {
+	return func(gw *Gateway) {
+		gw.responseEnvelope = envelope
+	}
+}
+
+// WithResponseTransform reshapes every successful response right before we encode it. This is handy when some of
+// your clients choke on explicit nulls or expect empty arrays rather than null, and you don't want to litter your
+// structs w/ `omitempty` tags or custom marshalers. There are built-in transforms for the most common cases:
+//
+//	apis.NewGateway(":9000", apis.WithResponseTransform(apis.EmptyCollections, apis.OmitNulls))
+//
+// The transforms run in the order that you supply them, and they see exactly what we're about to encode. When you
+// also use WithResponseEnvelope(), that's the entire envelope. Errors, redirects, raw/streamed content, and 204
+// responses never go through your transforms, and neither do services.RawJSON responses unless you use an envelope.
+func WithResponseTransform(transforms ...ResponseTransformFunc) GatewayOption {
+	return func(gw *Gateway) {
+		gw.responseTransforms = append(gw.responseTransforms, transforms...)
+	}
+}
+
+// WithFieldSelection lets callers ask for only the fields of the response that they actually need, which is great
+// for saving bandwidth on mobile clients. They list the fields in the given query string parameter (defaults to
+// DefaultFieldSelectionParam when empty), using dots to select the fields of nested objects:
+//
+//	apis.NewGateway(":9000", apis.WithFieldSelection("fields"))
+//
+//	// GET /user/123?fields=ID,Name,Address.City
+//	// {"ID": "123", "Name": "Dude", "Address": {"City": "Los Angeles"}}
+//
+// Field names match the keys in the JSON response, but they're case-insensitive, so "?fields=id,name" works, too.
+// Selecting a field of an array applies to every element (e.g. "?fields=Items.ID"). When the caller doesn't ask for
+// any fields, you get the entire response like always. This only applies to the response object itself; any
+// WithResponseEnvelope() envelope is added after we select the fields. Errors, redirects, streamed content,
+// and 204 responses are never affected.
+func WithFieldSelection(param string) GatewayOption {
+	return func(gw *Gateway) {
+		gw.fieldSelectionParam = param
+		if gw.fieldSelectionParam == "" {
+			gw.fieldSelectionParam = DefaultFieldSelectionParam
+		}
+	}
+}
+
+// StreamTimeoutFunc decides how long a streaming response (see services.ContentGetter) for the given route can
+// go without successfully writing anything to the client before we give up on it. Return zero to wait forever.
+type StreamTimeoutFunc func(route metadata.EndpointRoute) time.Duration
+
+// WithStreamTimeout protects you from clients that stall in the middle of a download. It's an idle timeout rather
+// than a limit on the entire response, so a slow client is fine as long as it keeps reading. If it stops reading
+// for longer than the timeout, we abandon the response and release the connection. The function lets you give
+// routes different amounts of leeway (e.g. large downloads on slow links). By default, there is no timeout.
+//
+//	apis.WithStreamTimeout(func(route metadata.EndpointRoute) time.Duration {
+//		if route.QualifiedName() == "VideoService.Download" {
+//			return 5 * time.Minute
+//		}
+//		return 30 * time.Second
+//	})
+func WithStreamTimeout(timeout StreamTimeoutFunc) GatewayOption {
+	return func(gw *Gateway) {
+		gw.streamTimeout = timeout
+	}
+}
+
+// WithReadTimeout sets the maximum duration for reading the entire request, including the body. By default,
+// there is no limit since large uploads can take a while. See http.Server.ReadTimeout for details.
+func WithReadTimeout(timeout time.Duration) GatewayOption {
+	return func(gw *Gateway) {
+		gw.server.ReadTimeout = timeout
+	}
+}
+
+// WithReadHeaderTimeout sets the maximum duration for reading the request headers, which protects you from
+// slowloris-style attacks. The default is DefaultReadHeaderTimeout. See http.Server.ReadHeaderTimeout for details.
+func WithReadHeaderTimeout(timeout time.Duration) GatewayOption {
+	return func(gw *Gateway) {
+		gw.server.ReadHeaderTimeout = timeout
+	}
+}
+
+// WithWriteTimeout sets the maximum duration before timing out writes of the response. By default, there is
+// no limit. Raw stream responses (e.g. file downloads) and websockets are exempt from this timeout since
+// they can legitimately take much longer than a normal response. See http.Server.WriteTimeout for details.
+func WithWriteTimeout(timeout time.Duration) GatewayOption {
+	return func(gw *Gateway) {
+		gw.server.WriteTimeout = timeout
+	}
+}
+
+// WithIdleTimeout sets the maximum amount of time to wait for the next request when keep-alives are enabled.
+// The default is DefaultIdleTimeout. See http.Server.IdleTimeout for details.
+func WithIdleTimeout(timeout time.Duration) GatewayOption {
+	return func(gw *Gateway) {
+		gw.server.IdleTimeout = timeout
+	}
+}
+
 // WithNotFound lets you customize what happens when an incoming request doesn't match any of your service's
 // routes. By default, the server will respond w/ a 404 and the body {"status":404, "message":"not found"}, but
 // this allows you to handle that situation however you like.
@@ -539,6 +1527,43 @@ func WithNotFound(handler http.HandlerFunc) GatewayOption {
 	}
 }
 
+// WithFallback lets you supply a catch-all handler that runs when an incoming request doesn't match any of your
+// service's routes. Unlike WithNotFound, this is not a definitive "that doesn't exist" - it's a chance to try
+// something else, such as proxying the request to a legacy backend during a migration. Your handler can call
+// NotFound() to give up and respond using the normal not-found handling. The fallback still runs through the
+// standard recover/CORS middleware as well as your custom middleware.
+func WithFallback(handler http.Handler) GatewayOption {
+	return func(gw *Gateway) {
+		gw.fallback = handler
+	}
+}
+
+// WithStaticFiles serves the files in the file system under the given path prefix, so a single binary can serve your
+// API as well as a companion UI. It works great w/ an embed.FS:
+//
+//	//go:embed admin
+//	var adminUI embed.FS
+//	...
+//	uiFiles, _ := fs.Sub(adminUI, "admin")
+//	apis.NewGateway(":9000", apis.WithStaticFiles("/admin", uiFiles))
+//
+// A request for "/admin/css/site.css" serves "css/site.css" from the file system w/ the appropriate Content-Type,
+// and directories serve their "index.html" if they have one. Static files run through the standard recover/CORS
+// middleware as well as your custom middleware. Your service routes always take precedence over static files, and
+// requests that don't match any file fall through to your WithFallback() handler or the not-found handler. You can
+// include this option more than once to serve different file systems under different prefixes.
+func WithStaticFiles(prefix string, fsys fs.FS) GatewayOption {
+	return func(gw *Gateway) {
+		if fsys == nil {
+			return
+		}
+		gw.staticFiles = append(gw.staticFiles, staticFiles{
+			prefix: "/" + strings.Trim(strings.TrimSpace(prefix), "/"),
+			fsys:   fsys,
+		})
+	}
+}
+
 // WithCORS lets you customize what happens during the CORS preflight OPTIONS request. The default behavior
 // simply returns a 404, but you can enable this to support CORS preflight requests.
 func WithCORS(options PreflightOptions) GatewayOption {
@@ -547,6 +1572,47 @@ func WithCORS(options PreflightOptions) GatewayOption {
 	}
 }
 
+// WithMetadataMergePolicy customizes which value wins when a request supplies the same metadata through
+// both its standard HTTP headers (Authorization, X-Request-ID) and the propagated X-RPC-Metadata header. By
+// default, the Authorization header beats propagated credentials while propagated trace ids and values beat
+// their standard counterparts. See metadata.DefaultMergePolicy() for details.
+func WithMetadataMergePolicy(policy metadata.MergePolicy) GatewayOption {
+	return func(gw *Gateway) {
+		gw.metadataPolicy = policy
+	}
+}
+
+// WithTraceIDHeader changes the HTTP header that the gateway reads the caller's trace id from and echoes it back
+// in. By default, we use the X-Request-ID header, but you can use something like the W3C "traceparent" header
+// instead. Make sure that your clients send the same header (see clients.WithTraceIDHeader()), and use
+// metadata.WithTraceIDGenerator() if you want the ids we generate to use that header's format, too.
+func WithTraceIDHeader(header string) GatewayOption {
+	return func(gw *Gateway) {
+		if header != "" {
+			gw.traceIDHeader = header
+		}
+	}
+}
+
+// WithTraceIDExtractor customizes how the gateway finds the trace id that the caller supplied. By default, we just
+// read the trace id header (see WithTraceIDHeader()), but you can use this when you need to parse the value or
+// look in some other part of the request. Return "" when the request doesn't have a trace id, and the gateway will
+// generate one using metadata.NewTraceID().
+//
+//	apis.WithTraceIDExtractor(func(req *http.Request) string {
+//		return parseTraceParent(req.Header.Get("traceparent"))
+//	})
+//
+// Your metadata merge policy still applies, so by default a trace id propagated from an upstream service
+// wins over whatever your extractor returns.
+func WithTraceIDExtractor(extractor TraceIDExtractor) GatewayOption {
+	return func(gw *Gateway) {
+		if extractor != nil {
+			gw.traceIDExtractor = extractor
+		}
+	}
+}
+
 // PreflightOptions manages the knobs you can turn to control how CORS behaves in your API gateway. Yes, this really
 // is just an alias to the https://github.com/rs/cors options. It's the gold standard for CORS in the Go ecosystem,
 // so we're just providing a convenient way to plug it in.
@@ -555,5 +1621,28 @@ type PreflightOptions cors.Options
 // requestContextKey lets us store the http.Request on the context of incoming requests.
 type requestContextKey struct{}
 
+// HTTPRequest returns the raw HTTP request that triggered the current call. This is an escape hatch for the rare
+// case where you need something that frodo doesn't expose yet (e.g. some obscure header). Prefer the metadata
+// package whenever it has what you need, since those values work no matter which gateway invoked your handler.
+//
+//	if req, ok := apis.HTTPRequest(ctx); ok {
+//		fingerprint := req.Header.Get("X-Device-Fingerprint")
+//		...
+//	}
+//
+// Treat the request as read-only. The gateway has already consumed its body, and changing it won't affect how the
+// gateway handles your call. The 'ok' value is false when the call didn't come through the API gateway (e.g. an
+// event handler or a direct server.Invoke()).
+func HTTPRequest(ctx context.Context) (*http.Request, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	req, ok := ctx.Value(requestContextKey{}).(*http.Request)
+	return req, ok && req != nil
+}
+
 // requestContextKey lets us store the http.ResponseWriter on the context of incoming requests.
 type responseContextKey struct{}
+
+// notFoundContextKey lets us store the gateway's not-found handler on the context of fallback requests.
+type notFoundContextKey struct{}
@@ -0,0 +1,1703 @@
+//go:build unit
+
+package apis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/bridgekit-io/frodo/codec"
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestGatewaySuite(t *testing.T) {
+	suite.Run(t, new(GatewaySuite))
+}
+
+type GatewaySuite struct {
+	suite.Suite
+}
+
+type noContentResponse struct {
+	Name  string
+	empty bool
+}
+
+func (res *noContentResponse) NoContent() bool {
+	return res.empty
+}
+
+func (suite *GatewaySuite) respond(serviceResponse any) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/foo", nil)
+	respondSuccess(w, req, codec.JSONEncoder{}, serviceResponse, http.StatusOK, 0)
+	return w
+}
+
+// respondWith writes the service response using the success encoder of a gateway configured w/ the given options
+// (envelopes, transforms, field selection, etc.), so tests can see exactly what the caller would receive.
+func (suite *GatewaySuite) respondWith(target string, serviceResponse any, options ...GatewayOption) *httptest.ResponseRecorder {
+	gw := NewGateway(":0", options...)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	respondSuccess(w, req, gw.successEncoder(req, codec.JSONEncoder{}), serviceResponse, http.StatusOK, 0)
+	return w
+}
+
+// envelope wraps responses like {"data":{...}, "meta":{"version":2}}.
+func (suite *GatewaySuite) envelope() GatewayOption {
+	return WithResponseEnvelope(func(ctx context.Context, serviceResponse any) any {
+		return map[string]any{"data": serviceResponse, "meta": map[string]any{"version": 2}}
+	})
+}
+
+// register adds a "TestService" function to the gateway at the route (e.g. "GET /user/{ID}"). The handler receives
+// whatever newInput creates (an empty struct when nil), and a nil handler just returns nil, nil. The route responds
+// w/ a 200 and binds any "{param}" segments by default; use the tweaks to customize the endpoint/route further.
+func (suite *GatewaySuite) register(gw *Gateway, route string, newInput func() services.StructPointer, handler services.HandlerFunc, tweaks ...func(*services.Endpoint, *services.EndpointRoute)) {
+	method, path, _ := strings.Cut(route, " ")
+	endpointRoute := services.EndpointRoute{GatewayType: services.GatewayTypeAPI, Method: method, Path: path, Status: http.StatusOK}
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			endpointRoute.PathParams = append(endpointRoute.PathParams, strings.TrimSuffix(segment[1:len(segment)-1], "..."))
+		}
+	}
+
+	endpoint := services.Endpoint{ServiceName: "TestService", Name: route, NewInput: newInput, Handler: handler}
+	if endpoint.NewInput == nil {
+		endpoint.NewInput = func() services.StructPointer { return &struct{}{} }
+	}
+	if endpoint.Handler == nil {
+		endpoint.Handler = func(ctx context.Context, req any) (any, error) { return nil, nil }
+	}
+	for _, tweak := range tweaks {
+		tweak(&endpoint, &endpointRoute)
+	}
+	gw.Register(endpoint, endpointRoute)
+}
+
+// serve runs a request through the gateway's router and records the response. An empty body sends no body at all,
+// and headers are "Name: Value" strings.
+func (suite *GatewaySuite) serve(gw *Gateway, method string, target string, body string, headers ...string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, target, reader)
+	for _, header := range headers {
+		name, value, _ := strings.Cut(header, ":")
+		req.Header.Set(name, strings.TrimSpace(value))
+	}
+	w := httptest.NewRecorder()
+	gw.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *GatewaySuite) TestRespondSuccess_nil() {
+	w := suite.respond(nil)
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Empty(w.Body.String())
+	suite.Empty(w.Header().Get("Content-Type"))
+
+	var typedNil *noContentResponse
+	w = suite.respond(typedNil)
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Empty(w.Body.String())
+	suite.Empty(w.Header().Get("Content-Type"))
+}
+
+func (suite *GatewaySuite) TestRespondSuccess_noContentResponder() {
+	w := suite.respond(&noContentResponse{Name: "Dude", empty: true})
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Empty(w.Body.String())
+	suite.Empty(w.Header().Get("Content-Type"))
+
+	w = suite.respond(&noContentResponse{Name: "Dude", empty: false})
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"Name":"Dude"}`, w.Body.String())
+	suite.Equal("application/json", w.Header().Get("Content-Type"))
+}
+
+type rawResponse struct {
+	services.RawJSON
+}
+
+func (suite *GatewaySuite) TestRespondSuccess_rawJSON() {
+	res := &rawResponse{}
+	res.SetRaw([]byte(`{"Name":"Dude"}`))
+
+	w := suite.respond(res)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`{"Name":"Dude"}`, w.Body.String(), "Should write the raw bytes as-is")
+	suite.Equal("application/json", w.Header().Get("Content-Type"))
+	suite.Equal("15", w.Header().Get("Content-Length"))
+
+	w = suite.respond(&rawResponse{})
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("null\n", w.Body.String(), "No raw JSON should fall back to normal encoding")
+
+	w = suite.respondWith("/foo", res, suite.envelope())
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"data":{"Name":"Dude"},"meta":{"version":2}}`, w.Body.String(), "Envelopes should still wrap raw JSON")
+}
+
+type jsonStreamItem struct {
+	Name  string
+	Drink string
+}
+
+type jsonStreamResponse struct {
+	services.JSONStream[jsonStreamItem]
+}
+
+func (suite *GatewaySuite) jsonStream(items ...jsonStreamItem) *jsonStreamResponse {
+	ch := make(chan jsonStreamItem, len(items))
+	for _, item := range items {
+		ch <- item
+	}
+	close(ch)
+
+	res := &jsonStreamResponse{}
+	res.SetItems(ch)
+	return res
+}
+
+func (suite *GatewaySuite) TestRespondSuccess_jsonStream() {
+	w := suite.respond(suite.jsonStream(
+		jsonStreamItem{Name: "Dude", Drink: "White Russian"},
+		jsonStreamItem{Name: "Walter", Drink: "Oat Soda"},
+	))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("application/x-ndjson", w.Header().Get("Content-Type"))
+	suite.Equal("{\"Name\":\"Dude\",\"Drink\":\"White Russian\"}\n{\"Name\":\"Walter\",\"Drink\":\"Oat Soda\"}\n", w.Body.String())
+	suite.True(w.Flushed, "Should flush each item")
+
+	w = suite.respond(suite.jsonStream())
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Empty(w.Body.String())
+
+	// Envelopes don't apply to the individual items, but field selection does.
+	w = suite.respondWith("/foo", suite.jsonStream(jsonStreamItem{Name: "Dude", Drink: "White Russian"}), suite.envelope())
+	suite.Equal("{\"Name\":\"Dude\",\"Drink\":\"White Russian\"}\n", w.Body.String())
+
+	w = suite.respondWith("/foo?fields=name", suite.jsonStream(
+		jsonStreamItem{Name: "Dude", Drink: "White Russian"},
+		jsonStreamItem{Name: "Walter", Drink: "Oat Soda"},
+	), WithFieldSelection("fields"))
+	suite.Equal("{\"Name\":\"Dude\"}\n{\"Name\":\"Walter\"}\n", w.Body.String())
+}
+
+func (suite *GatewaySuite) rangedStream(start int, end int, size int) *services.StreamResponse {
+	stream := &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader("The Dude Abides")))
+	stream.SetContentRange(start, end, size)
+	return stream
+}
+
+func (suite *GatewaySuite) TestRespondSuccess_partialContent() {
+	w := suite.respond(suite.rangedStream(50, 65, 1024))
+	suite.Equal(http.StatusPartialContent, w.Code, "Ranges should respond w/ a 206")
+	suite.Equal("bytes 50-65/1024", w.Header().Get("Content-Range"))
+	suite.Equal("The Dude Abides", w.Body.String())
+
+	w = suite.respond(suite.rangedStream(0, 0, 0))
+	suite.Equal(http.StatusOK, w.Code, "No range should be a normal 200")
+	suite.Empty(w.Header().Get("Content-Range"))
+
+	w = suite.respond(suite.rangedStream(65, 50, 1024))
+	suite.Equal(http.StatusOK, w.Code, "Invalid ranges are ignored, so it should be a normal 200")
+	suite.Empty(w.Header().Get("Content-Range"))
+}
+
+func (suite *GatewaySuite) TestRespondSuccess_partialContentOverrides() {
+	// The route explicitly asked for some other success status (e.g. "HTTP 202"), so respect that.
+	w := httptest.NewRecorder()
+	respondSuccess(w, httptest.NewRequest(http.MethodGet, "/foo", nil), codec.JSONEncoder{}, suite.rangedStream(50, 65, 1024), http.StatusAccepted, 0)
+	suite.Equal(http.StatusAccepted, w.Code)
+	suite.Equal("bytes 50-65/1024", w.Header().Get("Content-Range"))
+
+	// The handler's status beats everything.
+	stream := suite.rangedStream(50, 65, 1024)
+	stream.SetContentStatus(http.StatusOK)
+	w = suite.respond(stream)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("bytes 50-65/1024", w.Header().Get("Content-Range"))
+
+	stream = suite.rangedStream(0, 0, 0)
+	stream.SetContentStatus(http.StatusCreated)
+	w = suite.respond(stream)
+	suite.Equal(http.StatusCreated, w.Code)
+}
+
+type statusResponse struct {
+	Name   string
+	status int
+}
+
+func (res *statusResponse) Status() int {
+	return res.status
+}
+
+type statusStreamResponse struct {
+	services.StreamResponse
+	status int
+}
+
+func (res *statusStreamResponse) Status() int {
+	return res.status
+}
+
+func (suite *GatewaySuite) TestRespondSuccess_statusGetter() {
+	w := suite.respond(&statusResponse{Name: "Dude", status: http.StatusCreated})
+	suite.Equal(http.StatusCreated, w.Code, "The response's status should beat the route's")
+	suite.JSONEq(`{"Name":"Dude"}`, w.Body.String())
+
+	w = suite.respond(&statusResponse{Name: "Dude"})
+	suite.Equal(http.StatusOK, w.Code, "Zero should fall back to the route's status")
+
+	w = suite.respond(&statusResponse{Name: "Dude", status: 1234})
+	suite.Equal(http.StatusOK, w.Code, "Invalid codes should fall back to the route's status")
+
+	res := &rawResponse{}
+	res.SetRaw([]byte(`{"Name":"Dude"}`))
+	w = httptest.NewRecorder()
+	respondSuccess(w, httptest.NewRequest(http.MethodGet, "/foo", nil), codec.JSONEncoder{}, &struct {
+		*rawResponse
+		*statusResponse
+	}{res, &statusResponse{status: http.StatusAccepted}}, http.StatusOK, 0)
+	suite.Equal(http.StatusAccepted, w.Code, "Raw JSON should respect the response's status")
+}
+
+func (suite *GatewaySuite) TestRespondSuccess_statusGetterStream() {
+	stream := &statusStreamResponse{status: http.StatusCreated}
+	stream.SetContent(io.NopCloser(strings.NewReader("The Dude Abides")))
+	w := suite.respond(stream)
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal("The Dude Abides", w.Body.String())
+
+	// Explicitly asking for a 200 means you don't want the automatic 206 for ranges.
+	stream = &statusStreamResponse{status: http.StatusOK}
+	stream.SetContent(io.NopCloser(strings.NewReader("The Dude Abides")))
+	stream.SetContentRange(50, 65, 1024)
+	w = suite.respond(stream)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("bytes 50-65/1024", w.Header().Get("Content-Range"))
+
+	// The stream's own ContentStatus() is more specific, so it still wins.
+	stream = &statusStreamResponse{status: http.StatusCreated}
+	stream.SetContent(io.NopCloser(strings.NewReader("The Dude Abides")))
+	stream.SetContentStatus(http.StatusAccepted)
+	w = suite.respond(stream)
+	suite.Equal(http.StatusAccepted, w.Code)
+}
+
+// Ensure that Listening() only fires once the port is bound, and that Addr() reports the port the OS picked.
+func (suite *GatewaySuite) TestListening() {
+	gw := NewGateway("localhost:0")
+	suite.Equal("localhost:0", gw.Addr(), "Should report the configured address before listening")
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+
+	select {
+	case <-gw.Listening():
+	case <-time.After(2 * time.Second):
+		suite.FailNow("Gateway never started listening")
+	}
+
+	_, port, err := net.SplitHostPort(gw.Addr())
+	suite.Require().NoError(err)
+	suite.NotEqual("0", port)
+
+	conn, err := net.Dial("tcp", gw.Addr())
+	suite.Require().NoError(err, "Should accept connections as soon as it's listening")
+	_ = conn.Close()
+}
+
+// Ensure that failing to bind the address is reported by Listen() and never signals Listening().
+func (suite *GatewaySuite) TestListening_bindFailure() {
+	listener, err := net.Listen("tcp", "localhost:0")
+	suite.Require().NoError(err)
+	defer func() { _ = listener.Close() }()
+
+	gw := NewGateway(listener.Addr().String())
+	suite.Error(gw.Listen(context.Background()))
+
+	select {
+	case <-gw.Listening():
+		suite.Fail("Gateway should not signal that it's listening")
+	default:
+	}
+}
+
+func (suite *GatewaySuite) TestTimeouts_defaults() {
+	gw := NewGateway(":0")
+	suite.Equal(DefaultReadHeaderTimeout, gw.server.ReadHeaderTimeout)
+	suite.Equal(DefaultIdleTimeout, gw.server.IdleTimeout)
+	suite.Equal(time.Duration(0), gw.server.ReadTimeout, "Uploads shouldn't time out by default")
+	suite.Equal(time.Duration(0), gw.server.WriteTimeout, "Downloads shouldn't time out by default")
+}
+
+func (suite *GatewaySuite) TestTimeouts_options() {
+	gw := NewGateway(":0",
+		WithReadTimeout(1*time.Second),
+		WithReadHeaderTimeout(2*time.Second),
+		WithWriteTimeout(3*time.Second),
+		WithIdleTimeout(4*time.Second),
+	)
+	suite.Equal(1*time.Second, gw.server.ReadTimeout)
+	suite.Equal(2*time.Second, gw.server.ReadHeaderTimeout)
+	suite.Equal(3*time.Second, gw.server.WriteTimeout)
+	suite.Equal(4*time.Second, gw.server.IdleTimeout)
+}
+
+// slowReader waits a bit before returning its content, so a stream takes longer than the write timeout.
+type slowReader struct {
+	delay time.Duration
+	data  io.Reader
+}
+
+func (r slowReader) Read(p []byte) (int, error) {
+	time.Sleep(r.delay)
+	return r.data.Read(p)
+}
+
+func (suite *GatewaySuite) TestTimeouts_streamExempt() {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		stream := &services.StreamResponse{}
+		stream.SetContent(io.NopCloser(slowReader{delay: 100 * time.Millisecond, data: strings.NewReader("Hello")}))
+		respondSuccess(w, req, codec.JSONEncoder{}, stream, http.StatusOK, 0)
+	}))
+	server.Config.WriteTimeout = 20 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	suite.Require().NoError(err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	suite.Require().NoError(err, "Stream should not be cut off by the write timeout")
+	suite.Equal("Hello", string(body))
+}
+
+// endlessReader produces as many bytes as you're willing to read, so the stream never finishes on its own.
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	return len(p), nil
+}
+
+type redirectResponse struct {
+	URL string
+}
+
+func (res redirectResponse) Redirect() string {
+	return res.URL
+}
+
+func (suite *GatewaySuite) TestResponseEnvelope() {
+	w := suite.respondWith("/foo", &noContentResponse{Name: "Dude"}, suite.envelope())
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"data":{"Name":"Dude"},"meta":{"version":2}}`, w.Body.String())
+}
+
+func (suite *GatewaySuite) TestResponseEnvelope_bypassed() {
+	w := suite.respondWith("/foo", &noContentResponse{Name: "Dude", empty: true}, suite.envelope())
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Empty(w.Body.String())
+
+	w = suite.respondWith("/foo", redirectResponse{URL: "https://example.com/foo.jpg"}, suite.envelope())
+	suite.Equal(http.StatusTemporaryRedirect, w.Code)
+	suite.Equal("https://example.com/foo.jpg", w.Header().Get("Location"))
+
+	stream := &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader("Hello")))
+	w = suite.respondWith("/foo", stream, suite.envelope())
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("Hello", w.Body.String())
+}
+
+type transformResponse struct {
+	Name    string
+	Tags    []string
+	Counts  map[string]int
+	Friend  *transformResponse
+	Friends []transformResponse
+	Data    []byte
+	hidden  []string
+}
+
+func (suite *GatewaySuite) TestResponseTransform_emptyCollections() {
+	res := &transformResponse{Name: "Dude", Friends: []transformResponse{{Name: "Walter"}}, hidden: []string{"rug"}}
+	w := suite.respondWith("/foo", res, WithResponseTransform(EmptyCollections))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{
+		"Name": "Dude",
+		"Tags": [],
+		"Counts": {},
+		"Friend": null,
+		"Friends": [{"Name": "Walter", "Tags": [], "Counts": {}, "Friend": null, "Friends": [], "Data": null}],
+		"Data": null
+	}`, w.Body.String())
+
+	suite.Nil(res.Tags, "Should not modify the original response")
+	suite.Nil(res.Friends[0].Tags, "Should not modify the original response")
+	suite.Equal([]string{"rug"}, res.hidden)
+}
+
+func (suite *GatewaySuite) TestResponseTransform_omitNulls() {
+	res := &transformResponse{Name: "Dude", Tags: []string{"abides"}, Friend: &transformResponse{Name: "Walter"}}
+	w := suite.respondWith("/foo", res, WithResponseTransform(OmitNulls))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"Name": "Dude", "Tags": ["abides"], "Friend": {"Name": "Walter"}}`, w.Body.String())
+
+	// Large integers shouldn't lose precision when we round trip them through JSON.
+	w = suite.respondWith("/foo", map[string]any{"ID": int64(9007199254740993), "Nope": nil}, WithResponseTransform(OmitNulls))
+	suite.Equal(`{"ID":9007199254740993}`, strings.TrimSpace(w.Body.String()))
+}
+
+func (suite *GatewaySuite) TestResponseTransform_combined() {
+	res := &transformResponse{Name: "Dude"}
+	w := suite.respondWith("/foo", res,
+		WithResponseTransform(EmptyCollections, OmitNulls),
+		WithResponseEnvelope(func(ctx context.Context, serviceResponse any) any {
+			return map[string]any{"data": serviceResponse, "meta": nil}
+		}),
+	)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"data": {"Name": "Dude", "Tags": [], "Counts": {}, "Friends": []}}`, w.Body.String(), "Transforms should see the envelope")
+}
+
+func (suite *GatewaySuite) TestResponseTransform_bypassed() {
+	transform := WithResponseTransform(func(serviceResponse any) any {
+		suite.Fail("Transform should not run")
+		return serviceResponse
+	})
+
+	w := suite.respondWith("/foo", &noContentResponse{Name: "Dude", empty: true}, transform)
+	suite.Equal(http.StatusNoContent, w.Code)
+
+	w = suite.respondWith("/foo", redirectResponse{URL: "https://example.com/foo.jpg"}, transform)
+	suite.Equal(http.StatusTemporaryRedirect, w.Code)
+
+	stream := &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader("Hello")))
+	w = suite.respondWith("/foo", stream, transform)
+	suite.Equal("Hello", w.Body.String())
+}
+
+func (suite *GatewaySuite) TestFieldSelection() {
+	res := &transformResponse{
+		Name:    "Dude",
+		Tags:    []string{"abides"},
+		Friend:  &transformResponse{Name: "Walter", Tags: []string{"bowling"}},
+		Friends: []transformResponse{{Name: "Donny"}, {Name: "Maude"}},
+	}
+	selected := WithFieldSelection("")
+
+	w := suite.respondWith("/foo?fields=name,Tags", res, selected)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.JSONEq(`{"Name":"Dude", "Tags":["abides"]}`, w.Body.String())
+
+	w = suite.respondWith("/foo?fields=Friend.Name,Friends.Name,Nope", res, selected)
+	suite.JSONEq(`{"Friend":{"Name":"Walter"}, "Friends":[{"Name":"Donny"}, {"Name":"Maude"}]}`, w.Body.String())
+
+	w = suite.respondWith("/foo?fields=Friend.Name&fields=Friend", res, selected)
+	suite.JSONEq(`{"Friend":{"Name":"Walter", "Tags":["bowling"], "Counts":null, "Friend":null, "Friends":null, "Data":null}}`, w.Body.String(),
+		"Selecting the whole object should win over its individual fields")
+
+	w = suite.respondWith("/foo", res, selected)
+	suite.Contains(w.Body.String(), `"Friends":[`, "Should include everything when no fields are selected")
+
+	w = suite.respondWith("/foo?fields=Name", res)
+	suite.Contains(w.Body.String(), `"Friends":[`, "Should be disabled unless you opt in")
+
+	w = suite.respondWith("/foo?only=Name", res, WithFieldSelection("only"))
+	suite.JSONEq(`{"Name":"Dude"}`, w.Body.String())
+}
+
+func (suite *GatewaySuite) TestFieldSelection_envelopeAndRawJSON() {
+	options := []GatewayOption{
+		WithFieldSelection("fields"),
+		WithResponseEnvelope(func(ctx context.Context, serviceResponse any) any {
+			return map[string]any{"data": serviceResponse}
+		}),
+	}
+	w := suite.respondWith("/foo?fields=Name", &transformResponse{Name: "Dude"}, options...)
+	suite.JSONEq(`{"data":{"Name":"Dude"}}`, w.Body.String(), "Should select fields before wrapping the envelope")
+
+	res := &services.RawJSON{}
+	res.SetRaw([]byte(`{"Name":"Dude","Rug":"tied the room together"}`))
+	w = suite.respondWith("/foo?fields=Name", res, WithFieldSelection("fields"))
+	suite.JSONEq(`{"Name":"Dude"}`, w.Body.String(), "Should select fields from raw JSON, too")
+}
+
+func (suite *GatewaySuite) TestFieldSelection_bypassed() {
+	stream := &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader("Hello")))
+	w := suite.respondWith("/foo?fields=Name", stream, WithFieldSelection("fields"))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("Hello", w.Body.String())
+
+	w = suite.respondWith("/foo?fields=Name", redirectResponse{URL: "https://example.com/foo.jpg"}, WithFieldSelection("fields"))
+	suite.Equal(http.StatusTemporaryRedirect, w.Code)
+}
+
+func (suite *GatewaySuite) TestParseFieldSelection() {
+	suite.Nil(parseFieldSelection(""))
+	suite.Nil(parseFieldSelection(" , ,"))
+	suite.Equal(fieldSelection{"ID": nil, "Name": nil}, parseFieldSelection("ID, Name"))
+	suite.Equal(fieldSelection{"Owner": fieldSelection{"Name": nil, "Address": fieldSelection{"City": nil}}}, parseFieldSelection("Owner.Name,Owner.Address.City"))
+	suite.Equal(fieldSelection{"Owner": nil}, parseFieldSelection("Owner.Name,Owner"))
+	suite.Equal(fieldSelection{"Owner": nil}, parseFieldSelection("Owner,Owner.Name"))
+}
+
+func (suite *GatewaySuite) TestStreamTimeout_stalledClient() {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		defer close(done)
+		stream := &services.StreamResponse{}
+		stream.SetContent(io.NopCloser(endlessReader{}))
+		respondSuccess(w, req, codec.JSONEncoder{}, stream, http.StatusOK, 50*time.Millisecond)
+	}))
+	defer server.Close()
+
+	// Make the request, but never read the body. Eventually the socket buffers fill up and the writes block.
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	suite.Require().NoError(err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\n\r\n"))
+	suite.Require().NoError(err)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		suite.Fail("Stream should have been abandoned once the client stopped reading")
+	}
+}
+
+func (suite *GatewaySuite) TestStreamTimeout_slowClient() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		stream := &services.StreamResponse{}
+		stream.SetContent(io.NopCloser(slowReader{delay: 50 * time.Millisecond, data: strings.NewReader("Hello")}))
+		respondSuccess(w, req, codec.JSONEncoder{}, stream, http.StatusOK, 20*time.Millisecond)
+	}))
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	suite.Require().NoError(err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	suite.Require().NoError(err, "The timeout should only apply while we're waiting on the client")
+	suite.Equal("Hello", string(body))
+
+	// Make sure the deadline doesn't linger on the kept-alive connection.
+	time.Sleep(50 * time.Millisecond)
+	res, err = http.Get(server.URL)
+	suite.Require().NoError(err)
+	defer res.Body.Close()
+	body, err = io.ReadAll(res.Body)
+	suite.Require().NoError(err)
+	suite.Equal("Hello", string(body))
+}
+
+func (suite *GatewaySuite) TestStreamTimeoutFor() {
+	gw := NewGateway(":0", WithStreamTimeout(func(route metadata.EndpointRoute) time.Duration {
+		if route.QualifiedName() == "VideoService.Download" {
+			return time.Minute
+		}
+		return time.Second
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	suite.Equal(time.Second, gw.streamTimeoutFor(req))
+
+	ctx := metadata.WithRoute(req.Context(), metadata.EndpointRoute{ServiceName: "VideoService", Name: "Download"})
+	suite.Equal(time.Minute, gw.streamTimeoutFor(req.WithContext(ctx)))
+
+	suite.Equal(time.Duration(0), NewGateway(":0").streamTimeoutFor(req), "There should be no timeout by default")
+}
+
+func (suite *GatewaySuite) TestStream_contentHeaders() {
+	stream := &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader("Hello")))
+	stream.SetContentType("text/plain")
+	stream.SetContentLength(5)
+	stream.SetContentHeader("Cache-Control", "public, max-age=31536000, immutable")
+	stream.SetContentHeader("last-modified", "Wed, 11 Nov 2020 12:00:00 GMT")
+
+	w := httptest.NewRecorder()
+	respondSuccess(w, httptest.NewRequest(http.MethodGet, "/", nil), codec.JSONEncoder{}, stream, http.StatusOK, 0)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("Hello", w.Body.String())
+	suite.Equal("public, max-age=31536000, immutable", w.Header().Get("Cache-Control"))
+	suite.Equal("Wed, 11 Nov 2020 12:00:00 GMT", w.Header().Get("Last-Modified"))
+
+	// The headers we compute ourselves should be left alone.
+	suite.Equal("text/plain", w.Header().Get("Content-Type"))
+	suite.Equal("5", w.Header().Get("Content-Length"))
+}
+
+func (suite *GatewaySuite) TestStream_contentHeadersOverride() {
+	stream := &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader("Hello")))
+	stream.SetContentType("text/plain")
+	stream.SetContentHeader("Content-Type", "text/plain; charset=utf-8")
+
+	w := httptest.NewRecorder()
+	respondSuccess(w, httptest.NewRequest(http.MethodGet, "/", nil), codec.JSONEncoder{}, stream, http.StatusOK, 0)
+	suite.Equal([]string{"text/plain; charset=utf-8"}, w.Header().Values("Content-Type"))
+}
+
+func (suite *GatewaySuite) sniff(stream *services.StreamResponse) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	respondSuccess(w, httptest.NewRequest(http.MethodGet, "/", nil), codec.JSONEncoder{}, stream, http.StatusOK, 0)
+	return w
+}
+
+func (suite *GatewaySuite) TestStream_sniffContentType() {
+	png := "\x89PNG\x0D\x0A\x1A\x0A" + strings.Repeat("x", 1000)
+	stream := &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader(png)))
+	stream.SetSniffContentType(true)
+
+	w := suite.sniff(stream)
+	suite.Equal("image/png", w.Header().Get("Content-Type"))
+	suite.Equal(png, w.Body.String(), "Sniffed bytes should still be included in the response")
+
+	// Streams shorter than the sniff buffer should still be delivered in full.
+	stream = &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader("<html><body>Hello</body></html>")))
+	stream.SetSniffContentType(true)
+
+	w = suite.sniff(stream)
+	suite.Equal("text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	suite.Equal("<html><body>Hello</body></html>", w.Body.String())
+}
+
+func (suite *GatewaySuite) TestStream_sniffContentType_explicit() {
+	stream := &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader("<html><body>Hello</body></html>")))
+	stream.SetContentType("text/plain")
+	stream.SetSniffContentType(true)
+
+	w := suite.sniff(stream)
+	suite.Equal("text/plain", w.Header().Get("Content-Type"), "Explicit content types should not be sniffed")
+	suite.Equal("<html><body>Hello</body></html>", w.Body.String())
+}
+
+func (suite *GatewaySuite) TestStream_sniffContentType_disabled() {
+	stream := &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader("<html><body>Hello</body></html>")))
+
+	w := suite.sniff(stream)
+	suite.Equal("application/octet-stream", w.Header().Get("Content-Type"), "Sniffing should be opt-in")
+	suite.Equal("<html><body>Hello</body></html>", w.Body.String())
+
+	stream = &services.StreamResponse{}
+	stream.SetContent(io.NopCloser(strings.NewReader("")))
+	stream.SetSniffContentType(true)
+
+	w = suite.sniff(stream)
+	suite.Equal("application/octet-stream", w.Header().Get("Content-Type"), "Empty streams have nothing to sniff")
+	suite.Empty(w.Body.String())
+}
+
+func (suite *GatewaySuite) TestPing() {
+	gw := NewGateway(":9000", WithMiddleware(func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		http.Error(w, "custom middleware should not run", http.StatusUnauthorized)
+	}))
+	gw.registerPing()
+
+	w := suite.serve(gw, http.MethodGet, services.PingPath, "", "X-Request-ID: abc")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("abc", w.Header().Get("X-Request-ID"))
+}
+
+func (suite *GatewaySuite) TestWithTraceIDHeader() {
+	gw := NewGateway(":9000", WithTraceIDHeader("traceparent"))
+	gw.registerPing()
+
+	w := suite.serve(gw, http.MethodGet, services.PingPath, "", "traceparent: 00-abc-01", "X-Request-ID: xyz")
+	suite.Equal("00-abc-01", w.Header().Get("traceparent"))
+	suite.Empty(w.Header().Get("X-Request-ID"))
+}
+
+func (suite *GatewaySuite) TestWithRequireContentType() {
+	gw := NewGateway(":9000", WithRequireContentType("application/json; charset=utf-8", "Text/Plain"))
+	suite.Equal([]string{"application/json", "text/plain"}, gw.requiredContentTypes)
+
+	suite.Panics(func() { WithRequireContentType("application/json", "application/json;;;") }, "Typos should fail loudly")
+	suite.Panics(func() { WithRequireContentType("") })
+}
+
+// headGateway creates a gateway w/ "GET /download" and "GET /info" routes that count how many times they were invoked.
+// Use the tweak function to customize the download route (e.g. allowed methods, caching, etc.). When you change its
+// path to "/fail", the download fails w/ a 404 instead.
+func (suite *GatewaySuite) headGateway(tweak func(route *services.EndpointRoute), options ...GatewayOption) (*Gateway, *atomic.Int64) {
+	gw := NewGateway(":9000", options...)
+	invoked := &atomic.Int64{}
+	suite.register(gw, "GET /download", nil, func(ctx context.Context, req any) (any, error) {
+		invoked.Add(1)
+		if metadata.Route(ctx).Path == "/fail" {
+			return nil, fail.NotFound("nope")
+		}
+		stream := &services.StreamResponse{}
+		stream.SetContent(io.NopCloser(strings.NewReader("Hello")))
+		stream.SetContentType("text/plain")
+		stream.SetContentLength(5)
+		return stream, nil
+	}, func(_ *services.Endpoint, route *services.EndpointRoute) {
+		if tweak != nil {
+			tweak(route)
+		}
+	})
+	suite.register(gw, "GET /info", nil, func(ctx context.Context, req any) (any, error) {
+		invoked.Add(1)
+		return &noContentResponse{Name: "Dude"}, nil
+	})
+	return gw, invoked
+}
+
+func (suite *GatewaySuite) TestErrorMapper() {
+	errNoRows := errors.New("no rows")
+	gw := NewGateway(":9000", WithErrorMapper(func(err error) error {
+		if errors.Is(err, errNoRows) {
+			return fail.NotFound("user not found")
+		}
+		return err
+	}))
+	failWith := errNoRows
+	suite.register(gw, "GET /user", nil, func(ctx context.Context, req any) (any, error) {
+		return nil, failWith
+	})
+
+	w := suite.serve(gw, http.MethodGet, "/user", "")
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.JSONEq(`{"Status":404,"Message":"user not found"}`, w.Body.String())
+
+	failWith = errors.New("something else")
+	w = suite.serve(gw, http.MethodGet, "/user", "")
+	suite.Equal(http.StatusInternalServerError, w.Code, "Errors the mapper doesn't know about should be left alone")
+}
+
+type headerRequest struct {
+	ID      string
+	Key     string `header:"Idempotency-Key"`
+	Count   int    `header:"X-Count"`
+	Options struct {
+		Locale string `header:"Accept-Language"`
+	}
+}
+
+func (suite *GatewaySuite) TestHeaderBinding() {
+	received := headerRequest{}
+	gw := NewGateway(":9000")
+	suite.register(gw, "POST /order/{ID}", func() services.StructPointer { return &headerRequest{} }, func(ctx context.Context, req any) (any, error) {
+		received = *req.(*headerRequest)
+		return nil, nil
+	})
+
+	w := suite.serve(gw, http.MethodPost, "/order/123", `{}`, "Idempotency-Key: abc", "X-Count: 5", "Accept-Language: en-US")
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Equal("123", received.ID)
+	suite.Equal("abc", received.Key)
+	suite.Equal(5, received.Count)
+	suite.Equal("en-US", received.Options.Locale)
+}
+
+func (suite *GatewaySuite) TestHeaderBinding_precedence() {
+	received := headerRequest{}
+	gw := NewGateway(":9000")
+	suite.register(gw, "POST /order/{ID}", func() services.StructPointer { return &headerRequest{} }, func(ctx context.Context, req any) (any, error) {
+		received = *req.(*headerRequest)
+		return nil, nil
+	})
+
+	// Query and body values should both win over headers.
+	w := suite.serve(gw, http.MethodPost, "/order/123?Key=query&Count=2", `{"Key":"body"}`, "Idempotency-Key: abc", "X-Count: 5")
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Equal("body", received.Key)
+	suite.Equal(2, received.Count)
+	suite.Equal("", received.Options.Locale, "Missing headers should leave fields alone")
+}
+
+func (suite *GatewaySuite) TestHeaderBinding_invalid() {
+	received := headerRequest{}
+	gw := NewGateway(":9000")
+	suite.register(gw, "POST /order/{ID}", func() services.StructPointer { return &headerRequest{} }, func(ctx context.Context, req any) (any, error) {
+		received = *req.(*headerRequest)
+		return nil, nil
+	})
+
+	w := suite.serve(gw, http.MethodPost, "/order/123", `{}`, "X-Count: lots")
+
+	// Bad header values should fail the same way that bad query string values do.
+	suite.GreaterOrEqual(w.Code, 400)
+	suite.Empty(received.ID, "The handler should not have been invoked")
+}
+
+type deleteRequest struct {
+	ID     string
+	Name   string
+	Filter struct {
+		Status string
+		Tags   []string
+	}
+}
+
+// Bodies aren't just for POST/PUT/PATCH. A DELETE w/ complex criteria should be able to send a body, and it
+// should follow the same query < body < path precedence as everything else.
+func (suite *GatewaySuite) TestBinding_deleteWithBody() {
+	received := deleteRequest{}
+	gw := NewGateway(":9000")
+	suite.register(gw, "DELETE /order/{ID}", func() services.StructPointer { return &deleteRequest{} }, func(ctx context.Context, req any) (any, error) {
+		received = *req.(*deleteRequest)
+		return nil, nil
+	})
+
+	body := `{"ID":"789", "Filter":{"Status":"body", "Tags":["a", "b"]}}`
+	w := suite.serve(gw, http.MethodDelete, "/order/123?ID=456&Name=query&Filter.Status=query", body)
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Equal("123", received.ID, "Path should beat both the body and query")
+	suite.Equal("body", received.Filter.Status, "Body should beat the query")
+	suite.Equal([]string{"a", "b"}, received.Filter.Tags)
+	suite.Equal("query", received.Name, "Query should fill in anything the body didn't")
+}
+
+type wildcardRequest struct {
+	Bucket string
+	File   struct {
+		Path string
+	}
+}
+
+// A "{param...}" wildcard should capture the entire remainder of the path, even when it's a nested field.
+func (suite *GatewaySuite) TestBinding_wildcardPath() {
+	received := wildcardRequest{}
+	gw := NewGateway(":9000")
+	suite.register(gw, "GET /files/{Bucket}/{File.Path...}", func() services.StructPointer { return &wildcardRequest{} }, func(ctx context.Context, req any) (any, error) {
+		received = *req.(*wildcardRequest)
+		return nil, nil
+	})
+
+	w := suite.serve(gw, http.MethodGet, "/files/uploads/docs/2024/q1%20report.pdf", "")
+
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Equal("uploads", received.Bucket)
+	suite.Equal("docs/2024/q1 report.pdf", received.File.Path)
+}
+
+func (suite *GatewaySuite) TestNormalizePath() {
+	suite.Equal("/user/{ID}", normalizePath("/user/{ID}"))
+	suite.Equal("/user/{User__DOT__ID}/{Trans__DOT__ID}", normalizePath("/user/{User.ID}/{Trans.ID}"))
+	suite.Equal("/files/{Path...}", normalizePath("/files/{Path...}"))
+	suite.Equal("/files/{File__DOT__Path...}", normalizePath("/files/{File.Path...}"))
+}
+
+type defaultsRequest struct {
+	ID       string
+	PageSize int
+	Sort     string
+}
+
+func (req *defaultsRequest) Defaults() {
+	if req.PageSize == 0 {
+		req.PageSize = 20
+	}
+	if req.Sort == "" {
+		req.Sort = "created"
+	}
+}
+
+// Defaults() should run after every decoding layer, so it only fills in the values that the caller didn't send.
+func (suite *GatewaySuite) TestDefaults() {
+	received := defaultsRequest{}
+	gw := NewGateway(":9000")
+	suite.register(gw, "POST /order/{ID}/search", func() services.StructPointer { return &defaultsRequest{} }, func(ctx context.Context, req any) (any, error) {
+		received = *req.(*defaultsRequest)
+		return nil, nil
+	})
+
+	suite.serve(gw, http.MethodPost, "/order/123/search?Sort=name", `{}`)
+	suite.Equal(defaultsRequest{ID: "123", PageSize: 20, Sort: "name"}, received)
+
+	suite.serve(gw, http.MethodPost, "/order/123/search", `{"PageSize":5}`)
+	suite.Equal(defaultsRequest{ID: "123", PageSize: 5, Sort: "created"}, received)
+}
+
+func (suite *GatewaySuite) TestMaxQueryLength() {
+	gw := NewGateway(":9000", WithMaxQueryLength(16), WithMaxHeaderBytes(2048))
+	suite.Equal(2048, gw.server.MaxHeaderBytes, "Should also limit headers on the underlying server")
+
+	suite.register(gw, "GET /order/{ID}/search", func() services.StructPointer { return &defaultsRequest{} }, nil)
+
+	w := suite.serve(gw, http.MethodGet, "/order/123/search?Sort=name", "")
+	suite.Equal(http.StatusNoContent, w.Code)
+
+	w = suite.serve(gw, http.MethodGet, "/order/123/search?Sort="+strings.Repeat("name", 10), "")
+	suite.Equal(http.StatusRequestURITooLong, w.Code)
+
+	w = suite.serve(gw, http.MethodGet, "/order/123/search", "", "X-Dude: "+strings.Repeat("abide", 500))
+	suite.Equal(http.StatusRequestHeaderFieldsTooLarge, w.Code)
+}
+
+func (suite *GatewaySuite) TestProblemJSON() {
+	gw := NewGateway(":9000", WithProblemJSON())
+	suite.register(gw, "GET /user/{ID}", nil, func(ctx context.Context, req any) (any, error) {
+		return nil, fail.NotFound("user not found")
+	})
+
+	w := suite.serve(gw, http.MethodGet, "/user/123", "")
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Equal("application/problem+json", w.Header().Get("Content-Type"))
+	suite.JSONEq(`{"type":"about:blank", "title":"Not Found", "status":404, "detail":"user not found", "instance":"/user/123"}`, w.Body.String())
+
+	// Errors generated by the gateway itself should use the same format.
+	w = httptest.NewRecorder()
+	gw.notFoundHandler(w, httptest.NewRequest(http.MethodGet, "/nope", nil))
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Equal("application/problem+json", w.Header().Get("Content-Type"))
+	suite.JSONEq(`{"type":"about:blank", "title":"Not Found", "status":404, "detail":"not found", "instance":"/nope"}`, w.Body.String())
+}
+
+func (suite *GatewaySuite) TestProblemJSON_disabled() {
+	w := httptest.NewRecorder()
+	respondFailure(w, httptest.NewRequest(http.MethodGet, "/user/123", nil), codec.JSONEncoder{}, fail.NotFound("user not found"))
+	suite.Equal("application/json", w.Header().Get("Content-Type"))
+	suite.JSONEq(`{"Status":404, "Message":"user not found"}`, w.Body.String())
+}
+
+func (suite *GatewaySuite) TestProblemJSON_panic() {
+	w := httptest.NewRecorder()
+	recoverFromPanic(problemEncoder{Encoder: codec.JSONEncoder{}})(w, httptest.NewRequest(http.MethodGet, "/", nil), func(w http.ResponseWriter, req *http.Request) {
+		panic("don't")
+	})
+	suite.Equal(http.StatusInternalServerError, w.Code)
+	suite.Equal("application/problem+json", w.Header().Get("Content-Type"))
+	suite.JSONEq(`{"type":"about:blank", "title":"Internal Server Error", "status":500, "detail":"don't"}`, w.Body.String())
+}
+
+func (suite *GatewaySuite) TestLocalizer() {
+	localizer := func(lang string, err fail.StatusError) string {
+		switch {
+		case lang == "fr-CA" && err.Key == "errors.conflict":
+			return "toujours un conflit"
+		case lang == "fr-CA" && err.Status == http.StatusNotFound:
+			return "introuvable"
+		default:
+			return ""
+		}
+	}
+	gw := NewGateway(":9000", WithLocalizer(localizer))
+	suite.register(gw, "POST /user", nil, func(ctx context.Context, req any) (any, error) {
+		return nil, fail.AlreadyExists("always a conflict").WithKey("errors.conflict")
+	})
+
+	w := suite.serve(gw, http.MethodPost, "/user", "", "Accept-Language: en-US;q=0.5, fr-CA")
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.JSONEq(`{"Status":409, "Message":"toujours un conflit"}`, w.Body.String())
+
+	// No translation, so we should fall back to the original message.
+	w = suite.serve(gw, http.MethodPost, "/user", "", "Accept-Language: de")
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.JSONEq(`{"Status":409, "Message":"always a conflict"}`, w.Body.String())
+
+	w = suite.serve(gw, http.MethodPost, "/user", "")
+	suite.JSONEq(`{"Status":409, "Message":"always a conflict"}`, w.Body.String())
+
+	// Errors generated by the gateway itself should be localized, too.
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/nope", nil)
+	req.Header.Set("Accept-Language", "fr-CA")
+	gw.notFoundHandler(w, req)
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.JSONEq(`{"Status":404, "Message":"introuvable"}`, w.Body.String())
+}
+
+func (suite *GatewaySuite) TestLocalizer_problemJSON() {
+	localizer := func(lang string, err fail.StatusError) string {
+		return lang + ":" + err.Message
+	}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/user/123", nil)
+	req.Header.Set("Accept-Language", "es")
+	encoder := localizingEncoder{Encoder: problemEncoder{Encoder: codec.JSONEncoder{}}, localizer: localizer}
+	respondFailure(w, req, encoder, fail.NotFound("user not found"))
+
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Equal("application/problem+json", w.Header().Get("Content-Type"))
+	suite.JSONEq(`{"type":"about:blank", "title":"Not Found", "status":404, "detail":"es:user not found", "instance":"/user/123"}`, w.Body.String())
+}
+
+func (suite *GatewaySuite) TestAcceptLanguage() {
+	assert := func(header string, expected string) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept-Language", header)
+		suite.Equal(expected, acceptLanguage(req), header)
+	}
+	assert("", "")
+	assert("fr", "fr")
+	assert("fr-CA, fr;q=0.9, en;q=0.8", "fr-CA")
+	assert("en;q=0.8, fr;q=0.9", "fr")
+	assert("en;q=0.8, *", "en")
+	assert(" de ; q=0.2 , es;q=bogus", "es")
+	assert("*", "")
+}
+
+func (suite *GatewaySuite) TestAutoHead_stream() {
+	gw, invoked := suite.headGateway(nil, WithAutoHead())
+
+	w := suite.serve(gw, http.MethodHead, "/download", "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("text/plain", w.Header().Get("Content-Type"))
+	suite.Equal("5", w.Header().Get("Content-Length"))
+	suite.Empty(w.Body.String())
+	suite.EqualValues(1, invoked.Load())
+
+	// Make sure that normal GET requests are unaffected.
+	w = suite.serve(gw, http.MethodGet, "/download", "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("Hello", w.Body.String())
+}
+
+func (suite *GatewaySuite) TestAutoHead_encoded() {
+	gw, _ := suite.headGateway(nil, WithAutoHead())
+
+	w := suite.serve(gw, http.MethodGet, "/info", "")
+	suite.Equal(http.StatusOK, w.Code)
+	body := w.Body.String()
+
+	w = suite.serve(gw, http.MethodHead, "/info", "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("application/json", w.Header().Get("Content-Type"))
+	suite.Equal(strconv.Itoa(len(body)), w.Header().Get("Content-Length"))
+	suite.Empty(w.Body.String())
+}
+
+func (suite *GatewaySuite) TestAutoHead_disabled() {
+	gw, _ := suite.headGateway(nil)
+
+	// The router still sends HEAD to the GET route, but we generate the whole body. The real
+	// HTTP server is what discards it, so the recorder sees everything.
+	w := suite.serve(gw, http.MethodHead, "/download", "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("Hello", w.Body.String())
+}
+
+func (suite *GatewaySuite) TestAllowMethods_head() {
+	gw, _ := suite.headGateway(func(route *services.EndpointRoute) {
+		route.AllowMethods = []string{http.MethodGet, http.MethodHead}
+	})
+
+	w := suite.serve(gw, http.MethodHead, "/download", "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("5", w.Header().Get("Content-Length"))
+	suite.Empty(w.Body.String(), "HEAD in the route's allowed methods should behave like WithAutoHead()")
+
+	w = suite.serve(gw, http.MethodOptions, "/download", "")
+	suite.Equal(http.StatusMethodNotAllowed, w.Code, "Should not answer OPTIONS unless it's allowed")
+}
+
+func (suite *GatewaySuite) TestAllowMethods_options() {
+	gw, _ := suite.headGateway(func(route *services.EndpointRoute) {
+		route.AllowMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions}
+	})
+
+	w := suite.serve(gw, http.MethodOptions, "/download", "")
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Equal("GET, HEAD, OPTIONS", w.Header().Get("Allow"))
+
+	// CORS preflight handling takes priority when it's enabled.
+	gw, _ = suite.headGateway(func(route *services.EndpointRoute) {
+		route.AllowMethods = []string{http.MethodGet, http.MethodOptions}
+	}, WithCORS(PreflightOptions{AllowedOrigins: []string{"*"}}))
+
+	w = suite.serve(gw, http.MethodOptions, "/download", "")
+	suite.Empty(w.Header().Get("Allow"))
+}
+
+// schema gives the endpoint a JSON schema whose title is its route (e.g. {"title":"GET /user/{ID}"}).
+func (suite *GatewaySuite) schema(endpoint *services.Endpoint, route *services.EndpointRoute) {
+	endpoint.Schema = `{"title":"` + route.Method + " " + route.Path + `"}`
+}
+
+func (suite *GatewaySuite) TestSchemas() {
+	gw := NewGateway(":9000", WithSchemas())
+	suite.register(gw, "GET /user/{ID}", nil, nil, suite.schema)
+	suite.register(gw, "DELETE /user/{ID}", nil, nil, suite.schema)
+
+	w := suite.serve(gw, http.MethodOptions, "/user/123", "", "Accept: application/json, application/schema+json")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("application/schema+json", w.Header().Get("Content-Type"))
+	suite.Equal(`{"title":"GET /user/{ID}"}`, w.Body.String(), "Should default to the first endpoint on the path")
+
+	w = suite.serve(gw, http.MethodOptions, "/user/123?method=delete", "", "Accept: application/json, application/schema+json")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`{"title":"DELETE /user/{ID}"}`, w.Body.String())
+
+	w = suite.serve(gw, http.MethodOptions, "/user/123?method=PATCH", "", "Accept: application/json, application/schema+json")
+	suite.Equal(http.StatusNotFound, w.Code)
+
+	// Plain OPTIONS requests don't get the schema.
+	w = suite.serve(gw, http.MethodOptions, "/user/123", "")
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.NotEqual("application/schema+json", w.Header().Get("Content-Type"))
+}
+
+func (suite *GatewaySuite) TestSchemas_disabled() {
+	gw := NewGateway(":9000")
+	suite.register(gw, "GET /user/{ID}", nil, nil, suite.schema)
+
+	w := suite.serve(gw, http.MethodOptions, "/user/123", "", "Accept: application/json, application/schema+json")
+	suite.Equal(http.StatusMethodNotAllowed, w.Code)
+}
+
+func (suite *GatewaySuite) TestSchemas_cors() {
+	gw := NewGateway(":9000", WithSchemas(), WithCORS(PreflightOptions{
+		AllowedOrigins: []string{"https://example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodDelete},
+	}))
+	suite.register(gw, "GET /user/{ID}", nil, nil, suite.schema)
+	suite.register(gw, "DELETE /user/{ID}", nil, nil, suite.schema)
+
+	// The schema request is a cross-origin request like any other, so the browser needs to see the CORS headers.
+	w := suite.serve(gw, http.MethodOptions, "/user/123", "",
+		"Accept: application/json, application/schema+json",
+		"Origin: https://example.com",
+	)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("https://example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	suite.Equal(`{"title":"GET /user/{ID}"}`, w.Body.String())
+
+	// Preflights are still CORS' business, even when they accept the schema.
+	w = suite.serve(gw, http.MethodOptions, "/user/123", "",
+		"Accept: application/json, application/schema+json",
+		"Origin: https://example.com",
+		"Access-Control-Request-Method: DELETE",
+	)
+	suite.Equal(http.StatusNoContent, w.Code)
+	suite.Equal("https://example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	suite.Contains(w.Header().Get("Access-Control-Allow-Methods"), http.MethodDelete)
+	suite.Empty(w.Body.String())
+}
+
+// created makes the route respond w/ a 201 instead of a 200.
+func (suite *GatewaySuite) created(_ *services.Endpoint, route *services.EndpointRoute) {
+	route.Status = http.StatusCreated
+}
+
+func (suite *GatewaySuite) TestIdempotency() {
+	calls := &atomic.Int64{}
+	gw := NewGateway(":9000", WithIdempotency(NewMemoryIdempotencyStore(), time.Minute))
+	for _, route := range []string{"POST /PlaceOrder", "POST /RefundOrder"} {
+		suite.register(gw, route, nil, func(ctx context.Context, req any) (any, error) {
+			return map[string]any{"Call": calls.Add(1)}, nil
+		}, suite.created)
+	}
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		return suite.serve(gw, http.MethodPost, path, `{}`, IdempotencyKeyHeader+": abc")
+	}
+
+	w := serve("/PlaceOrder")
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.JSONEq(`{"Call":1}`, w.Body.String())
+
+	w = serve("/PlaceOrder")
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.JSONEq(`{"Call":1}`, w.Body.String())
+	suite.Equal("true", w.Header().Get(IdempotentReplayedHeader))
+
+	w = serve("/RefundOrder")
+	suite.JSONEq(`{"Call":2}`, w.Body.String(), "Keys should be scoped to the service function")
+	suite.Equal(int64(2), calls.Load())
+}
+
+func (suite *GatewaySuite) TestIdempotency_defaults() {
+	gw := NewGateway(":9000", WithIdempotency(NewMemoryIdempotencyStore(), 0))
+	suite.Equal(DefaultIdempotencyTTL, gw.idempotencyTTL, "A TTL of zero should not expire entries immediately")
+	suite.Equal(int64(DefaultMaxIdempotentBodySize), gw.idempotencyMaxBody)
+
+	gw = NewGateway(":9000", WithIdempotency(NewMemoryIdempotencyStore(), -time.Minute), WithMaxIdempotentBodySize(10))
+	suite.Equal(DefaultIdempotencyTTL, gw.idempotencyTTL, "A negative TTL should not expire entries immediately")
+	suite.Equal(int64(10), gw.idempotencyMaxBody)
+}
+
+func (suite *GatewaySuite) TestIdempotency_afterMiddleware() {
+	calls := &atomic.Int64{}
+	revoked := &atomic.Bool{}
+	authorize := func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		if revoked.Load() {
+			http.Error(w, "no soup for you!", http.StatusForbidden)
+			return
+		}
+		next(w, req)
+	}
+
+	gw := NewGateway(":9000", WithIdempotency(NewMemoryIdempotencyStore(), time.Minute), WithMiddleware(authorize))
+	suite.register(gw, "POST /PlaceOrder", nil, func(ctx context.Context, req any) (any, error) {
+		return map[string]any{"Call": calls.Add(1)}, nil
+	}, suite.created)
+
+	serve := func() *httptest.ResponseRecorder {
+		return suite.serve(gw, http.MethodPost, "/PlaceOrder", `{}`, IdempotencyKeyHeader+": abc")
+	}
+
+	suite.Equal(http.StatusCreated, serve().Code)
+	suite.Equal(http.StatusCreated, serve().Code)
+	suite.Equal(int64(1), calls.Load())
+
+	revoked.Store(true)
+	w := serve()
+	suite.Equal(http.StatusForbidden, w.Code, "Replays should not skip your authorization middleware")
+	suite.Empty(w.Header().Get(IdempotentReplayedHeader))
+}
+
+// echoInput and echo make a service function that responds w/ the {"Name":"..."} request it decoded.
+func (suite *GatewaySuite) echoInput() services.StructPointer {
+	return &noContentResponse{}
+}
+
+func (suite *GatewaySuite) echo(ctx context.Context, req any) (any, error) {
+	return req, nil
+}
+
+func (suite *GatewaySuite) TestStrictDecoding() {
+	gw := NewGateway(":9000")
+	suite.register(gw, "POST /user", suite.echoInput, suite.echo)
+	suite.Equal(http.StatusOK, suite.serve(gw, http.MethodPost, "/user", `{"Name":"Dude", "Typo":"Abides"}`).Code)
+
+	gw = NewGateway(":9000", WithStrictDecoding())
+	suite.register(gw, "POST /user", suite.echoInput, suite.echo)
+	suite.Equal(http.StatusOK, suite.serve(gw, http.MethodPost, "/user", `{"Name":"Dude"}`).Code)
+	w := suite.serve(gw, http.MethodPost, "/user", `{"Name":"Dude", "Typo":"Abides"}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "Typo")
+}
+
+func (suite *GatewaySuite) TestMaxDecompressedSize() {
+	gw := NewGateway(":9000", WithMaxDecompressedSize(1024))
+	suite.register(gw, "POST /user", suite.echoInput, suite.echo)
+	serve := func(body string) *httptest.ResponseRecorder {
+		compressed := &bytes.Buffer{}
+		writer := gzip.NewWriter(compressed)
+		_, _ = writer.Write([]byte(body))
+		suite.Require().NoError(writer.Close())
+		return suite.serve(gw, http.MethodPost, "/user", compressed.String(), "Content-Encoding: gzip")
+	}
+
+	suite.Equal(http.StatusOK, serve(`{"Name":"Dude"}`).Code)
+	suite.Equal(http.StatusRequestEntityTooLarge, serve(`{"Name":"`+strings.Repeat("a", 1<<20)+`"}`).Code)
+}
+
+func (suite *GatewaySuite) TestCacheControl() {
+	gw, _ := suite.headGateway(func(route *services.EndpointRoute) {
+		route.CacheControl = "public, max-age=60"
+	})
+	w := suite.serve(gw, http.MethodGet, "/download", "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("public, max-age=60", w.Header().Get("Cache-Control"))
+
+	gw, _ = suite.headGateway(func(route *services.EndpointRoute) {
+		route.Path = "/fail"
+		route.CacheControl = "public, max-age=60"
+	})
+	w = suite.serve(gw, http.MethodGet, "/fail", "")
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Empty(w.Header().Get("Cache-Control"), "Errors should never be cached")
+}
+
+func (suite *GatewaySuite) TestBatch() {
+	gw, invoked := suite.headGateway(nil, WithBatching())
+	gw.registerBatch()
+	w := suite.serve(gw, http.MethodPost, services.BatchPath, `{"Requests":[
+		{"Method":"GET", "Path":"/info"},
+		{"Method":"GET", "Path":"/download"},
+		{"Method":"GET", "Path":"/nope"},
+		{"Method":"GET", "Path":"http://evil.com/info"},
+		{"Method":"POST", "Path":"/_frodo/batch"}
+	]}`)
+	suite.Require().Equal(http.StatusOK, w.Code)
+
+	res := batchResponse{}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	suite.Require().Len(res.Responses, 5)
+	suite.Equal(http.StatusOK, res.Responses[0].Status)
+	suite.JSONEq(`{"Name":"Dude"}`, string(res.Responses[0].Body))
+	suite.Equal(http.StatusOK, res.Responses[1].Status)
+	suite.Equal("text/plain", res.Responses[1].Header.Get("Content-Type"))
+	suite.Equal("Hello", string(res.Responses[1].Body))
+	suite.Equal(http.StatusNotFound, res.Responses[2].Status)
+	suite.Equal(http.StatusBadRequest, res.Responses[3].Status)
+	suite.Equal(http.StatusBadRequest, res.Responses[4].Status)
+	suite.EqualValues(2, invoked.Load())
+}
+
+func (suite *GatewaySuite) TestBatch_invalid() {
+	gw, _ := suite.headGateway(nil, WithBatching())
+	gw.registerBatch()
+	suite.Equal(http.StatusBadRequest, suite.serve(gw, http.MethodPost, services.BatchPath, `{"Requests":`).Code)
+
+	tooMany := `{"Requests":[` + strings.Repeat(`{"Method":"GET", "Path":"/info"},`, maxBatchRequests) + `{"Method":"GET", "Path":"/info"}]}`
+	suite.Equal(http.StatusBadRequest, suite.serve(gw, http.MethodPost, services.BatchPath, tooMany).Code)
+}
+
+func (suite *GatewaySuite) TestBatch_disabled() {
+	gw, _ := suite.headGateway(nil)
+	gw.registerBatch()
+	gw.registerNotFound()
+	suite.Equal(http.StatusNotFound, suite.serve(gw, http.MethodPost, services.BatchPath, `{"Requests":[]}`).Code)
+}
+
+func (suite *GatewaySuite) TestAccessLog() {
+	buf := &bytes.Buffer{}
+	gw, _ := suite.headGateway(nil, WithAccessLog(slog.New(slog.NewJSONHandler(buf, nil))))
+	gw.registerNotFound()
+
+	serve := func(method string, path string) map[string]any {
+		buf.Reset()
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "203.0.113.9:1234"
+		req.Header.Set("User-Agent", "Walter")
+		gw.server.Handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		record := map[string]any{}
+		suite.Require().NoError(json.Unmarshal(buf.Bytes(), &record), "Should write exactly one JSON record")
+		return record
+	}
+
+	record := serve(http.MethodGet, "/download?foo=bar")
+	suite.Equal(AccessLogMessage, record["msg"])
+	suite.Equal("GET", record["method"])
+	suite.Equal("/download?foo=bar", record["path"])
+	suite.Equal("HTTP/1.1", record["proto"])
+	suite.Equal(float64(http.StatusOK), record["status"])
+	suite.Equal(float64(5), record["bytes"])
+	suite.Equal("203.0.113.9", record["remote_addr"])
+	suite.Equal("Walter", record["user_agent"])
+	suite.Contains(record, "duration")
+
+	record = serve(http.MethodGet, "/nope")
+	suite.Equal(float64(http.StatusNotFound), record["status"], "Should log requests that don't match any endpoint")
+
+	record = serve(http.MethodPost, "/download")
+	suite.Equal(float64(http.StatusNotFound), record["status"], "Should log requests that don't match any endpoint")
+
+	record = serve(http.MethodOptions, "/download")
+	suite.Equal("OPTIONS", record["method"], "Should log OPTIONS requests even though no endpoint handles them")
+}
+
+func (suite *GatewaySuite) TestAccessLog_disabled() {
+	gw := NewGateway(":9000")
+	suite.Same(gw.router, gw.server.Handler, "Should not wrap the router unless you ask for an access log")
+}
+
+func (suite *GatewaySuite) TestCombinedLogHandler() {
+	buf := &bytes.Buffer{}
+	gw, _ := suite.headGateway(nil, WithAccessLog(slog.New(NewCombinedLogHandler(buf))))
+
+	req := httptest.NewRequest(http.MethodGet, "/download", nil)
+	req.RemoteAddr = "203.0.113.9:1234"
+	req.Header.Set("User-Agent", "Walter")
+	gw.server.Handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	suite.Regexp(`^203\.0\.113\.9 - - \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "GET /download HTTP/1\.1" 200 5 "-" "Walter"\n$`, buf.String())
+
+	buf.Reset()
+	slog.New(NewCombinedLogHandler(buf)).Info("something else")
+	suite.Empty(buf.String(), "Should ignore records that aren't from the access log")
+}
+
+func (suite *GatewaySuite) TestFallback() {
+	fallback := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !strings.HasPrefix(req.URL.Path, "/legacy/") {
+			NotFound(w, req)
+			return
+		}
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte("legacy:" + req.URL.Path))
+	})
+
+	gw := NewGateway(":0", WithFallback(fallback))
+	gw.registerNotFound()
+
+	w := suite.serve(gw, http.MethodGet, "/legacy/foo", "")
+	suite.Equal(http.StatusTeapot, w.Code)
+	suite.Equal("legacy:/legacy/foo", w.Body.String())
+
+	w = suite.serve(gw, http.MethodGet, "/v2/foo", "")
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.JSONEq(`{"Status":404, "Message":"not found"}`, w.Body.String())
+}
+
+func (suite *GatewaySuite) TestFallback_customNotFound() {
+	fallback := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		NotFound(w, req)
+	})
+	notFound := func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}
+
+	gw := NewGateway(":0", WithFallback(fallback), WithNotFound(notFound))
+	gw.registerNotFound()
+
+	w := suite.serve(gw, http.MethodGet, "/v2/foo", "")
+	suite.Equal(http.StatusGone, w.Code, "NotFound() should call through to the WithNotFound() handler")
+}
+
+func (suite *GatewaySuite) TestFallback_panic() {
+	fallback := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		panic("not the rug!")
+	})
+
+	gw := NewGateway(":0", WithFallback(fallback))
+	gw.registerNotFound()
+
+	w := suite.serve(gw, http.MethodGet, "/legacy/foo", "")
+	suite.Equal(http.StatusInternalServerError, w.Code, "Fallback should still run through the standard middleware")
+}
+
+// adminFiles are the static files that tests serve under "/admin/".
+var adminFiles = fstest.MapFS{
+	"index.html":      {Data: []byte("<h1>Admin</h1>")},
+	"css/site.css":    {Data: []byte("body {}")},
+	"docs/index.html": {Data: []byte("<h1>Docs</h1>")},
+	"empty/.keep":     {Data: []byte("")},
+}
+
+func (suite *GatewaySuite) TestTraceID_unmatchedRoutes() {
+	serve := func(gw *Gateway, method string, path string) string {
+		return suite.serve(gw, method, path, "", "X-Request-ID: abc").Header().Get("X-Request-ID")
+	}
+
+	gw := NewGateway(":0", WithStaticFiles("/admin/", adminFiles))
+	gw.registerNotFound()
+	suite.Equal("abc", serve(gw, http.MethodGet, "/nope"), "404s should echo the trace id")
+	suite.Equal("abc", serve(gw, http.MethodGet, "/admin/css/site.css"), "Static files should echo the trace id")
+
+	fallback := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	gw = NewGateway(":0", WithFallback(fallback))
+	gw.registerNotFound()
+	suite.Equal("abc", serve(gw, http.MethodGet, "/legacy/foo"), "Fallbacks should echo the trace id")
+
+	gw, _ = suite.headGateway(func(route *services.EndpointRoute) {
+		route.AllowMethods = []string{http.MethodGet, http.MethodOptions}
+	})
+	suite.Equal("abc", serve(gw, http.MethodOptions, "/download"), "OPTIONS should echo the trace id")
+
+	gw = NewGateway(":0", WithReadinessCheck("/ready", func() bool { return true }))
+	gw.registerReadinessCheck()
+	suite.Equal("abc", serve(gw, http.MethodGet, "/ready"), "Readiness checks should echo the trace id")
+}
+
+func (suite *GatewaySuite) TestStaticFiles() {
+	gw := NewGateway(":0", WithStaticFiles("/admin/", adminFiles))
+	gw.registerNotFound()
+
+	w := suite.serve(gw, http.MethodGet, "/admin/css/site.css", "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("body {}", w.Body.String())
+	suite.Contains(w.Header().Get("Content-Type"), "text/css")
+
+	w = suite.serve(gw, http.MethodGet, "/admin/", "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("<h1>Admin</h1>", w.Body.String())
+	suite.Contains(w.Header().Get("Content-Type"), "text/html")
+
+	w = suite.serve(gw, http.MethodGet, "/admin/docs/", "")
+	suite.Equal("<h1>Docs</h1>", w.Body.String(), "Subdirectories should serve their index, too")
+
+	w = suite.serve(gw, http.MethodHead, "/admin/css/site.css", "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Empty(w.Body.String())
+}
+
+func (suite *GatewaySuite) TestStaticFiles_directoryRedirect() {
+	gw := NewGateway(":0", WithStaticFiles("/admin/", adminFiles))
+	gw.registerNotFound()
+
+	w := suite.serve(gw, http.MethodGet, "/admin/docs?page=2", "")
+	suite.Equal(http.StatusMovedPermanently, w.Code)
+	suite.Equal("/admin/docs/?page=2", w.Header().Get("Location"))
+}
+
+func (suite *GatewaySuite) TestStaticFiles_notFound() {
+	notFound := func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}
+	gw := NewGateway(":0", WithStaticFiles("/admin/", adminFiles), WithNotFound(notFound))
+	gw.registerNotFound()
+
+	suite.Equal(http.StatusGone, suite.serve(gw, http.MethodGet, "/admin/nope.js", "").Code)
+	suite.Equal(http.StatusGone, suite.serve(gw, http.MethodGet, "/admin/empty/", "").Code, "Should not list directories")
+	suite.Equal(http.StatusGone, suite.serve(gw, http.MethodGet, "/administrator/css/site.css", "").Code, "Should match whole segments")
+	suite.Equal(http.StatusGone, suite.serve(gw, http.MethodPost, "/admin/css/site.css", "").Code, "Should only serve GET/HEAD")
+	suite.Equal(http.StatusGone, suite.serve(gw, http.MethodGet, "/css/site.css", "").Code)
+}
+
+func (suite *GatewaySuite) TestStaticFiles_middleware() {
+	gw := NewGateway(":0", WithStaticFiles("/admin/", adminFiles), WithMiddleware(func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		w.Header().Set("X-Dude", "Abides")
+		next(w, req)
+	}))
+	gw.registerNotFound()
+
+	w := suite.serve(gw, http.MethodGet, "/admin/css/site.css", "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("Abides", w.Header().Get("X-Dude"), "Static files should run through custom middleware")
+}
+
+func (suite *GatewaySuite) TestStaticFiles_root() {
+	files := fstest.MapFS{"robots.txt": {Data: []byte("User-agent: *")}}
+	gw := NewGateway(":0", WithStaticFiles("/", files))
+	suite.register(gw, "GET /api/robots.txt", nil, nil)
+	gw.registerNotFound()
+
+	w := suite.serve(gw, http.MethodGet, "/robots.txt", "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("User-agent: *", w.Body.String())
+
+	w = suite.serve(gw, http.MethodGet, "/api/robots.txt", "")
+	suite.Equal(http.StatusNoContent, w.Code, "Service routes should win over static files")
+}
+
+// issueCert creates a certificate for the common name, signed by the parent (or self-signed when the parent is nil).
+func (suite *GatewaySuite) issueCert(commonName string, parent *tls.Certificate, isCA bool) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"Lebowski Inc"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	parentCert, parentKey := template, any(key)
+	if parent != nil {
+		parentCert, parentKey = parent.Leaf, parent.PrivateKey
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	suite.Require().NoError(err)
+	leaf, err := x509.ParseCertificate(der)
+	suite.Require().NoError(err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// mtlsServer starts a TLS server for a gateway that requires client certs signed by the CA. Its only endpoint
+// responds w/ the client certificate that the gateway put in the metadata.
+func (suite *GatewaySuite) mtlsServer(ca tls.Certificate) (*httptest.Server, *x509.CertPool) {
+	caPool := x509.NewCertPool()
+	caPool.AddCert(ca.Leaf)
+
+	gw := NewGateway(":0", WithClientCertAuth(caPool))
+	suite.register(gw, "GET /whoami", nil, func(ctx context.Context, req any) (any, error) {
+		return metadata.ClientCert(ctx), nil
+	})
+	gw.applyClientCertAuth()
+
+	server := httptest.NewUnstartedServer(gw.router)
+	server.TLS = gw.server.TLSConfig
+	server.TLS.Certificates = []tls.Certificate{suite.issueCert("server", &ca, false)}
+	server.StartTLS()
+	suite.T().Cleanup(server.Close)
+	return server, caPool
+}
+
+func (suite *GatewaySuite) mtlsGet(server *httptest.Server, caPool *x509.CertPool, clientCerts ...tls.Certificate) (metadata.ClientCertificate, error) {
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      caPool,
+		Certificates: clientCerts,
+	}}}
+	res, err := client.Get(server.URL + "/whoami")
+	if err != nil {
+		return metadata.ClientCertificate{}, err
+	}
+	defer res.Body.Close()
+
+	cert := metadata.ClientCertificate{}
+	suite.Require().Equal(http.StatusOK, res.StatusCode)
+	suite.Require().NoError(json.NewDecoder(res.Body).Decode(&cert))
+	return cert, nil
+}
+
+func (suite *GatewaySuite) TestClientCertAuth() {
+	ca := suite.issueCert("Test CA", nil, true)
+	server, caPool := suite.mtlsServer(ca)
+
+	cert, err := suite.mtlsGet(server, caPool, suite.issueCert("order-service", &ca, false))
+	suite.Require().NoError(err)
+	suite.True(cert.Verified)
+	suite.Equal("order-service", cert.CommonName)
+	suite.Equal([]string{"Lebowski Inc"}, cert.Organization)
+	suite.Equal([]string{"127.0.0.1"}, cert.IPAddresses)
+}
+
+func (suite *GatewaySuite) TestClientCertAuth_noCert() {
+	ca := suite.issueCert("Test CA", nil, true)
+	server, caPool := suite.mtlsServer(ca)
+
+	_, err := suite.mtlsGet(server, caPool)
+	suite.Error(err, "The handshake should fail when the client doesn't present a certificate")
+}
+
+func (suite *GatewaySuite) TestClientCertAuth_untrusted() {
+	ca := suite.issueCert("Test CA", nil, true)
+	server, caPool := suite.mtlsServer(ca)
+
+	otherCA := suite.issueCert("Other CA", nil, true)
+	_, err := suite.mtlsGet(server, caPool, suite.issueCert("order-service", &otherCA, false))
+	suite.Error(err, "The handshake should fail when the client's certificate isn't signed by a trusted CA")
+}
+
+func (suite *GatewaySuite) TestClientCertAuth_keepsTLSConfig() {
+	gw := NewGateway(":0",
+		WithTLSConfig(&tls.Config{MinVersion: tls.VersionTLS13}),
+		WithClientCertAuth(x509.NewCertPool()),
+	)
+	gw.applyClientCertAuth()
+	suite.Equal(tls.RequireAndVerifyClientCert, gw.server.TLSConfig.ClientAuth)
+	suite.NotNil(gw.server.TLSConfig.ClientCAs)
+	suite.Equal(uint16(tls.VersionTLS13), gw.server.TLSConfig.MinVersion)
+
+	gw = NewGateway(":0")
+	gw.applyClientCertAuth()
+	suite.Nil(gw.server.TLSConfig, "Should not touch TLS when client cert auth is disabled")
+}
@@ -0,0 +1,412 @@
+package apis
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bridgekit-io/frodo/codec"
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/internal/slices"
+	"github.com/bridgekit-io/frodo/metadata"
+)
+
+// IdempotencyKeyHeader is the request header that clients use to tag a POST/PATCH w/ a unique key, so that the
+// gateway can tell a retry apart from a brand-new request (see WithIdempotency).
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set to "true" on responses that we replayed from the IdempotencyStore rather than
+// running your handler again, so clients can tell that their retry didn't actually do anything new.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// DefaultIdempotencyTTL is how long we keep captured responses when you don't give WithIdempotency() a TTL.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// DefaultMaxIdempotentBodySize is the biggest request/response body that WithIdempotency() holds in memory,
+// unless you supply WithMaxIdempotentBodySize().
+const DefaultMaxIdempotentBodySize = 1 << 20
+
+// maxIdempotencyKeyLength keeps people from using giant keys that would bloat the store; a UUID is only 36 bytes.
+const maxIdempotencyKeyLength = 255
+
+// IdempotentResponse is the response that we captured the first time we saw an idempotency key, so that we can
+// replay it for any retries. It's a plain struct, so stores that live outside the process (e.g. Redis) can easily
+// encode it as JSON.
+type IdempotentResponse struct {
+	// Status is the HTTP status code of the original response.
+	Status int
+	// Header contains only the headers that the handler set. Headers set by the gateway (e.g. CORS or response
+	// timing) or your custom middleware are recalculated for every response.
+	Header http.Header
+	// Body is the raw response body, exactly as we sent it the first time.
+	Body []byte
+	// RequestHash is the hex-encoded SHA-256 of the original request body, so that we can reject retries that
+	// reuse the key for a different request.
+	RequestHash string
+}
+
+// IdempotencyStore is where WithIdempotency() keeps the responses it has captured, and it coordinates concurrent
+// requests that use the same key. The keys already include the route and caller, so stores don't need to worry
+// about that. Use NewMemoryIdempotencyStore() for a simple, in-process store.
+//
+// When you run more than one instance of your gateway, you need a shared store so that a retry that lands on
+// another instance still sees the original response. For Redis, Lock() is "SET lock:{key} 1 NX PX {timeout}",
+// Unlock() is "DEL lock:{key}", and Get()/Set() just read/write the JSON-encoded response with a "PX {ttl}". Give
+// the lock an expiration that's longer than your slowest request, so that an instance that dies mid-request
+// doesn't block that key forever.
+type IdempotencyStore interface {
+	// Get returns the response that we captured for the key, or false when there isn't one (or it has expired).
+	Get(ctx context.Context, key string) (IdempotentResponse, bool, error)
+	// Set stores the response for the key, so that Get() returns it for roughly the next 'ttl'.
+	Set(ctx context.Context, key string, res IdempotentResponse, ttl time.Duration) error
+	// Lock claims the key for the request that is about to run the handler. It returns false, rather than
+	// blocking, when another request is already holding the lock for that key.
+	Lock(ctx context.Context, key string) (bool, error)
+	// Unlock releases the key once the request that claimed it has finished.
+	Unlock(ctx context.Context, key string) error
+}
+
+// WithIdempotency lets clients safely retry non-idempotent requests (e.g. "create order") by including a unique
+// "Idempotency-Key" header. The first POST/PATCH with a given key runs your handler as usual, and we store its
+// response for 'ttl'. Any retries w/ the same key get that same status, headers, and body back (plus the header
+// "Idempotent-Replayed: true") without running your handler again:
+//
+//	gateway := apis.NewGateway(":8080",
+//		apis.WithIdempotency(apis.NewMemoryIdempotencyStore(), 24*time.Hour),
+//	)
+//
+// Keys are scoped to the service function and the caller's Authorization header, so one user can't get their
+// hands on another user's response by guessing their key. If a retry shows up while the original request is still
+// running, it fails w/ a 409 and a "Retry-After" header rather than running the handler a second time. Reusing a
+// key w/ a different request body fails w/ a 422, since that's a bug in the client, not a retry.
+//
+// This runs after your WithMiddleware() functions, so a retry still has to get past any authorization that you do
+// there. We only store successful (2xx) responses, so retries of failures (401/403 included) actually get a new
+// attempt. Requests w/o the header and other methods (GET, PUT, DELETE, etc. are idempotent already) behave like
+// they would without this option. A nil store disables it, and a 'ttl' of zero or less uses DefaultIdempotencyTTL.
+//
+// We hold the request and response bodies in memory, so both are limited by WithMaxIdempotentBodySize(). Streamed
+// responses (see services.ContentGetter and services.JSONStream) are never stored, so their retries run again.
+func WithIdempotency(store IdempotencyStore, ttl time.Duration) GatewayOption {
+	return func(gw *Gateway) {
+		if ttl <= 0 {
+			ttl = DefaultIdempotencyTTL
+		}
+		gw.idempotencyStore = store
+		gw.idempotencyTTL = ttl
+	}
+}
+
+// WithMaxIdempotentBodySize limits how many bytes of each body WithIdempotency() holds in memory. Requests that
+// include an "Idempotency-Key" and a bigger body fail w/ a 413 Request Entity Too Large. Responses bigger than
+// that are still sent, but they're not stored, so retries run the handler again. The default is
+// DefaultMaxIdempotentBodySize (1MB), and a size of zero or less means unlimited.
+func WithMaxIdempotentBodySize(size int64) GatewayOption {
+	return func(gw *Gateway) {
+		gw.idempotencyMaxBody = size
+	}
+}
+
+// enforceIdempotency replays the stored response for requests whose "Idempotency-Key" we've already seen (see
+// WithIdempotency), and captures the response of the ones that we haven't, so that we can replay it later. It
+// runs after all of the other HTTP middleware (yours included), so we know which service function the request is
+// for, and replays don't skip any of your authorization checks. We buffer the request body (up to maxBodySize) in
+// order to hash it.
+func enforceIdempotency(encoder codec.Encoder, store IdempotencyStore, ttl time.Duration, maxBodySize int64) HTTPMiddlewareFunc {
+	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		idempotencyKey := req.Header.Get(IdempotencyKeyHeader)
+		switch {
+		case store == nil || idempotencyKey == "":
+			next(w, req)
+			return
+		case req.Method != http.MethodPost && req.Method != http.MethodPatch:
+			next(w, req)
+			return
+		case len(idempotencyKey) > maxIdempotencyKeyLength:
+			respondFailure(w, req, encoder, fail.BadRequest("%s exceeds %d bytes", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+			return
+		}
+
+		requestHash, err := hashRequestBody(w, req, maxBodySize)
+		if err != nil {
+			respondFailure(w, req, encoder, err)
+			return
+		}
+
+		ctx := req.Context()
+		key := idempotencyStoreKey(req, idempotencyKey)
+		switch replayed, err := replayIdempotentResponse(ctx, w, store, key, requestHash); {
+		case err != nil:
+			respondFailure(w, req, encoder, err)
+			return
+		case replayed:
+			return
+		}
+
+		locked, err := store.Lock(ctx, key)
+		if err != nil {
+			respondFailure(w, req, encoder, fail.Unexpected("idempotency store: %v", err))
+			return
+		}
+		if !locked {
+			w.Header().Set("Retry-After", "1")
+			respondFailure(w, req, encoder, fail.New(http.StatusConflict, "a request with the same %s is still in progress", IdempotencyKeyHeader))
+			return
+		}
+		defer func() { _ = store.Unlock(context.WithoutCancel(ctx), key) }()
+
+		// The original request might have finished between our first check and grabbing the lock.
+		switch replayed, err := replayIdempotentResponse(ctx, w, store, key, requestHash); {
+		case err != nil:
+			respondFailure(w, req, encoder, err)
+			return
+		case replayed:
+			return
+		}
+
+		recorder := &idempotencyResponseWriter{ResponseWriter: w, gatewayHeader: w.Header().Clone(), maxBodySize: maxBodySize}
+		next(recorder, req)
+
+		// We already sent the response, so if we can't store it, the next retry just runs the handler again.
+		if res, ok := recorder.response(); ok {
+			res.RequestHash = requestHash
+			_ = store.Set(context.WithoutCancel(ctx), key, res, ttl)
+		}
+	}
+}
+
+// replayIdempotentResponse writes the stored response for the key, if there is one, returning true when it did.
+// It fails w/ a 422 rather than replaying anything when the stored response was for a different request body.
+func replayIdempotentResponse(ctx context.Context, w http.ResponseWriter, store IdempotencyStore, key string, requestHash string) (bool, error) {
+	res, ok, err := store.Get(ctx, key)
+	switch {
+	case err != nil:
+		return false, fail.Unexpected("idempotency store: %v", err)
+	case !ok:
+		return false, nil
+	case res.RequestHash != requestHash:
+		return false, fail.New(http.StatusUnprocessableEntity, "%s was already used for a request with a different body", IdempotencyKeyHeader)
+	}
+
+	for name, values := range res.Header {
+		w.Header()[name] = slices.Clone(values)
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.Header().Set("Content-Length", strconv.Itoa(len(res.Body)))
+	w.WriteHeader(res.Status)
+	_, _ = w.Write(res.Body)
+	return true, nil
+}
+
+// hashRequestBody reads the whole request body to get its SHA-256, and then puts the bytes back so that the
+// handler can still decode them. Bodies bigger than maxBodySize fail w/ a 413 rather than eating up memory.
+func hashRequestBody(w http.ResponseWriter, req *http.Request, maxBodySize int64) (string, error) {
+	var body []byte
+	if req.Body != nil {
+		reader := req.Body
+		if maxBodySize > 0 {
+			reader = http.MaxBytesReader(w, req.Body, maxBodySize)
+		}
+
+		data, err := io.ReadAll(reader)
+		var maxBytesErr *http.MaxBytesError
+		switch {
+		case errors.As(err, &maxBytesErr):
+			return "", fail.TooLarge("requests with an %s can't exceed %d bytes", IdempotencyKeyHeader, maxBytesErr.Limit)
+		case fail.IsTooLarge(err):
+			return "", err
+		case err != nil:
+			return "", fail.BadRequest("unable to read request body: %v", err)
+		}
+		body = data
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	hash := sha256.Sum256(body)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// idempotencyStoreKey scopes the client's key to the service function and caller, which looks something like
+// "OrderService.PlaceOrder:{sha256 of Authorization}:{client key}". We hash the credentials, so we're not
+// leaving tokens lying around in your store.
+func idempotencyStoreKey(req *http.Request, idempotencyKey string) string {
+	route := metadata.Route(req.Context()).QualifiedName()
+	caller := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return route + ":" + hex.EncodeToString(caller[:]) + ":" + idempotencyKey
+}
+
+// idempotencyResponseWriter passes everything through to the real response writer while keeping a copy of
+// the status, headers, and body, so that we can store the response once the handler is done. Responses that
+// get flushed or grow past maxBodySize are streams, so we stop keeping a copy and never store them.
+type idempotencyResponseWriter struct {
+	http.ResponseWriter
+	// gatewayHeader is a snapshot of the headers that were set before the handler ran (CORS, etc.), so that we
+	// only store the headers that the handler set.
+	gatewayHeader http.Header
+	header        http.Header
+	status        int
+	body          bytes.Buffer
+	maxBodySize   int64
+	streamed      bool
+	hijacked      bool
+}
+
+func (w *idempotencyResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+		w.header = http.Header{}
+		for name, values := range w.Header() {
+			if !slices.Equal(values, w.gatewayHeader[name]) {
+				w.header[name] = slices.Clone(values)
+			}
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *idempotencyResponseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.streamed && w.maxBodySize > 0 && int64(w.body.Len()+len(data)) > w.maxBodySize {
+		w.stopRecording()
+	}
+	if !w.streamed {
+		w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// stopRecording throws away the copy of the body, since we're not going to store the response.
+func (w *idempotencyResponseWriter) stopRecording() {
+	w.streamed = true
+	w.body = bytes.Buffer{}
+}
+
+// response returns what the handler sent, or false when it's not something that we should replay later.
+func (w *idempotencyResponseWriter) response() (IdempotentResponse, bool) {
+	switch {
+	case w.hijacked, w.streamed:
+		return IdempotentResponse{}, false
+	case w.status == 0:
+		// The handler didn't write anything, so net/http responds w/ an empty 200.
+		return IdempotentResponse{Status: http.StatusOK, Header: http.Header{}}, true
+	case w.status < 200 || w.status >= 300:
+		// Failures might not happen the next time (the caller fixed their credentials, the service came
+		// back up, etc.), so the retry deserves a real attempt.
+		return IdempotentResponse{}, false
+	default:
+		return IdempotentResponse{Status: w.status, Header: w.header, Body: w.body.Bytes()}, true
+	}
+}
+
+// Unwrap lets http.ResponseController get at the original response writer.
+func (w *idempotencyResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush sends any buffered data to the client if the underlying response writer supports it. Only streams flush
+// in the middle of a response, so there's nothing to replay after that.
+func (w *idempotencyResponseWriter) Flush() {
+	w.stopRecording()
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets websocket upgrades take over the connection. There's no response to replay after that.
+func (w *idempotencyResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	w.hijacked = true
+	return hijacker.Hijack()
+}
+
+// NewMemoryIdempotencyStore creates an IdempotencyStore that keeps responses and locks in memory. It's great for a
+// single instance of your gateway, but retries that land on another instance won't see its responses. Expired
+// entries are removed as new ones come in.
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		entries: map[string]memoryIdempotencyEntry{},
+		locks:   map[string]struct{}{},
+	}
+}
+
+// MemoryIdempotencyStore is an IdempotencyStore that keeps responses in memory. Use NewMemoryIdempotencyStore()
+// to create one.
+type MemoryIdempotencyStore struct {
+	mutex     sync.Mutex
+	entries   map[string]memoryIdempotencyEntry
+	locks     map[string]struct{}
+	nextPrune time.Time
+}
+
+type memoryIdempotencyEntry struct {
+	res     IdempotentResponse
+	expires time.Time
+}
+
+// Get returns the response that we captured for the key, or false when there isn't one (or it has expired).
+func (store *MemoryIdempotencyStore) Get(_ context.Context, key string) (IdempotentResponse, bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	entry, ok := store.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return IdempotentResponse{}, false, nil
+	}
+	return entry.res, true, nil
+}
+
+// Set stores the response for the key, so that Get() returns it for the next 'ttl'.
+func (store *MemoryIdempotencyStore) Set(_ context.Context, key string, res IdempotentResponse, ttl time.Duration) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := time.Now()
+	store.entries[key] = memoryIdempotencyEntry{res: res, expires: now.Add(ttl)}
+
+	// Just like MemoryCache, we only sweep the whole map at most once per TTL.
+	if now.Before(store.nextPrune) {
+		return nil
+	}
+	for k, entry := range store.entries {
+		if now.After(entry.expires) {
+			delete(store.entries, k)
+		}
+	}
+	store.nextPrune = now.Add(ttl)
+	return nil
+}
+
+// Lock claims the key, returning false when another request already has it.
+func (store *MemoryIdempotencyStore) Lock(_ context.Context, key string) (bool, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if _, ok := store.locks[key]; ok {
+		return false, nil
+	}
+	store.locks[key] = struct{}{}
+	return true, nil
+}
+
+// Unlock releases the key so that the next request w/ the same key can claim it.
+func (store *MemoryIdempotencyStore) Unlock(_ context.Context, key string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.locks, key)
+	return nil
+}
@@ -0,0 +1,71 @@
+package apis
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bridgekit-io/frodo/codec"
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/metadata"
+)
+
+// Localizer translates an error's message into the caller's preferred language. The 'lang' is the caller's
+// most preferred language from the "Accept-Language" header (e.g. "fr-CA"), or an empty string if they
+// didn't send one. The error has the status, original message, and the key you supplied w/ WithKey(), so
+// you can look the message up in your catalog. Return the error's Message if you don't have a translation.
+type Localizer func(lang string, err fail.StatusError) string
+
+// localizingEncoder wraps the encoder that we use for error responses when you enable WithLocalizer(). It
+// encodes values exactly like the encoder it wraps; it just carries the localizer to respondFailure().
+type localizingEncoder struct {
+	codec.Encoder
+	localizer Localizer
+}
+
+// localize runs the error through your localizer using the language preference of the request.
+func (e localizingEncoder) localize(req *http.Request, status int, err error) string {
+	message := err.Error()
+	if e.localizer == nil || req == nil {
+		return message
+	}
+
+	localized := e.localizer(acceptLanguage(req), fail.StatusError{
+		Status:  status,
+		Message: message,
+		Key:     fail.MessageKey(err),
+	})
+	if localized == "" {
+		return message
+	}
+	return localized
+}
+
+// acceptLanguage returns the language tag w/ the highest quality value in the request's "Accept-Language" header.
+// Failures from early middleware happen before we've restored the header metadata, so fall back to the raw header.
+func acceptLanguage(req *http.Request) string {
+	header := metadata.RequestHeader(req.Context(), "Accept-Language")
+	if header == "" {
+		header = req.Header.Get("Accept-Language")
+	}
+
+	lang, bestQuality := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if q, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = q
+			}
+		}
+		if quality > bestQuality {
+			lang, bestQuality = tag, quality
+		}
+	}
+	return lang
+}
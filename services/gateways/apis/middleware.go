@@ -1,11 +1,25 @@
 package apis
 
 import (
+	"bufio"
+	"compress/gzip"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
 	"net/http"
+	"net/netip"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/bridgekit-io/frodo/codec"
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/internal/quiet"
+	"github.com/bridgekit-io/frodo/internal/slices"
 	"github.com/bridgekit-io/frodo/metadata"
 	"github.com/bridgekit-io/frodo/services"
 	"github.com/rs/cors"
@@ -58,14 +72,202 @@ func recoverFromPanic(encoder codec.Encoder) HTTPMiddlewareFunc {
 	}
 }
 
+// measureResponseTime wraps the response writer, so that we can add the X-Response-Time and Server-Timing
+// headers right before the status is written. We can't just set them after 'next' returns because the
+// headers will have already been sent by then.
+func measureResponseTime(enabled bool) HTTPMiddlewareFunc {
+	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		if !enabled {
+			next(w, req)
+			return
+		}
+		next(&timingResponseWriter{ResponseWriter: w, start: time.Now()}, req)
+	}
+}
+
+// timingResponseWriter is an http.ResponseWriter that writes the response timing headers when
+// the status code is written. It supports flushing and hijacking, so websockets still work.
+type timingResponseWriter struct {
+	http.ResponseWriter
+	start       time.Time
+	wroteHeader bool
+}
+
+func (w *timingResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		elapsed := strconv.FormatFloat(float64(time.Since(w.start).Microseconds())/1000, 'f', 3, 64)
+		w.Header().Set("X-Response-Time", elapsed+"ms")
+		w.Header().Set("Server-Timing", "app;dur="+elapsed)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *timingResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Unwrap lets http.ResponseController get at the original response writer.
+func (w *timingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Flush sends any buffered data to the client if the underlying response writer supports it.
+func (w *timingResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets websocket upgrades take over the connection.
+func (w *timingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+// shedExcessRequests rejects the request w/ a 503 when the gateway is already handling 'limit' requests. The
+// counter is decremented in a defer, so a panicking handler still frees up its slot on the way back up to
+// recoverFromPanic. A limit of zero or less disables this check entirely.
+func shedExcessRequests(encoder codec.Encoder, limit int64, inFlight *atomic.Int64) HTTPMiddlewareFunc {
+	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		if limit <= 0 {
+			next(w, req)
+			return
+		}
+
+		defer inFlight.Add(-1)
+		if inFlight.Add(1) > limit {
+			respondFailure(w, req, encoder, fail.UnavailableAfter(time.Second, "server is too busy; try again later"))
+			return
+		}
+		next(w, req)
+	}
+}
+
+// rejectOversizedRequests fails requests whose query string is longer than 'maxQueryLength' with a 414 or whose headers
+// are bigger than 'maxHeaderBytes' with a 431. The net/http server already enforces its own MaxHeaderBytes, but it
+// does so loosely (it allows some extra slop) and responds w/ a plain text error. This gives callers a precise limit
+// and the same error format as every other failure. A limit of zero or less means that we don't check it.
+func rejectOversizedRequests(encoder codec.Encoder, maxQueryLength int, maxHeaderBytes int) HTTPMiddlewareFunc {
+	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		if maxQueryLength > 0 && len(req.URL.RawQuery) > maxQueryLength {
+			respondFailure(w, req, encoder, fail.New(http.StatusRequestURITooLong, "query string exceeds %d bytes", maxQueryLength))
+			return
+		}
+		if maxHeaderBytes > 0 && headerSize(req.Header) > maxHeaderBytes {
+			respondFailure(w, req, encoder, fail.New(http.StatusRequestHeaderFieldsTooLarge, "request headers exceed %d bytes", maxHeaderBytes))
+			return
+		}
+		next(w, req)
+	}
+}
+
+// headerSize approximates how many bytes the headers took up on the wire (e.g. "Name: Value\r\n").
+func headerSize(header http.Header) int {
+	size := 0
+	for name, values := range header {
+		for _, value := range values {
+			size += len(name) + len(value) + 4
+		}
+	}
+	return size
+}
+
+// requireContentType rejects POST/PUT/PATCH requests whose "Content-Type" isn't one of the media types you require
+// (see WithRequireContentType) with a 415, so misconfigured clients find out right away rather than getting some
+// confusing decoding error. Parameters like "charset=utf-8" don't matter. Requests w/o a body are left alone since
+// there's nothing to decode. No content types means that we accept anything, which is the default.
+func requireContentType(encoder codec.Encoder, contentTypes []string) HTTPMiddlewareFunc {
+	if len(contentTypes) == 0 {
+		return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+			next(w, req)
+		}
+	}
+
+	expected := strings.Join(contentTypes, ", ")
+	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		switch {
+		case req.Method != http.MethodPost && req.Method != http.MethodPut && req.Method != http.MethodPatch:
+			next(w, req)
+			return
+		case req.Body == nil || req.Body == http.NoBody || req.ContentLength == 0:
+			next(w, req)
+			return
+		}
+
+		contentType := req.Header.Get("Content-Type")
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || !slices.Contains(contentTypes, mediaType) {
+			respondFailure(w, req, encoder, fail.UnsupportedFormat("unsupported content type '%s': expected %s", contentType, expected))
+			return
+		}
+		next(w, req)
+	}
+}
+
+// decompressRequest transparently un-gzips request bodies sent w/ the "Content-Encoding: gzip" header, so the rest
+// of the pipeline (binding, stream uploads, etc.) never knows that the body was compressed. Malformed gzip data
+// results in a 400. We leave any other encodings alone in case your own middleware knows what to do with them.
+//
+// A few KB of gzip can expand into gigabytes, so reading more than 'maxSize' decompressed bytes fails w/ a 413
+// (see WithMaxDecompressedSize). A max size of zero or less means unlimited.
+func decompressRequest(encoder codec.Encoder, maxSize int64) HTTPMiddlewareFunc {
+	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		encoding := strings.TrimSpace(req.Header.Get("Content-Encoding"))
+		if !strings.EqualFold(encoding, "gzip") || req.Body == nil || req.ContentLength == 0 {
+			next(w, req)
+			return
+		}
+
+		body, err := gzip.NewReader(req.Body)
+		if err != nil {
+			respondFailure(w, req, encoder, fail.BadRequest("invalid gzip request body: %v", err))
+			return
+		}
+		defer quiet.Close(body)
+
+		// The length/encoding described the compressed bytes, not what the handler is going to read.
+		req.Body = body
+		if maxSize > 0 {
+			req.Body = decompressedBody{ReadCloser: http.MaxBytesReader(w, body, maxSize)}
+		}
+		req.ContentLength = -1
+		req.Header.Del("Content-Encoding")
+		req.Header.Del("Content-Length")
+		next(w, req)
+	}
+}
+
+// decompressedBody translates the error we get when a decompressed body is too big into a 413 that the gateway
+// can respond with (binding wraps reader errors, so the status survives).
+type decompressedBody struct {
+	io.ReadCloser
+}
+
+func (body decompressedBody) Read(p []byte) (int, error) {
+	n, err := body.ReadCloser.Read(p)
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return n, fail.TooLarge("decompressed request body exceeds %d bytes", tooLarge.Limit)
+	}
+	return n, err
+}
+
 // restoreMetadata looks for the X-RPC-Metadata header, decodes it, and places the appropriate
 // metadata values back onto the request context so the rest of the operation already has access
 // to them. This is how Service B automatically has access to the same auth/values/etc. when
-// called from Service A.
-func restoreMetadata() HTTPMiddlewareFunc {
+// called from Service A. Any values already on the context are merged using the policy's rules.
+func restoreMetadata(policy metadata.MergePolicy) HTTPMiddlewareFunc {
 	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
-		encodedMetadata := metadata.EncodedBytes(req.Header.Get("X-RPC-Metadata"))
-		ctx := metadata.Decode(req.Context(), encodedMetadata)
+		encodedMetadata := metadata.EncodedBytes(req.Header.Get(metadata.Header))
+		ctx := metadata.Merge(req.Context(), encodedMetadata, policy)
 		next(w, req.WithContext(ctx))
 	}
 }
@@ -83,13 +285,16 @@ func restoreMetadataEndpoint(endpoint services.Endpoint, route services.Endpoint
 			Method:      route.Method,
 			Path:        req.URL.Path, // should be the resolved path (e.g. "/user/{ID}" --> "/user/12345")
 			Status:      route.Status,
+			Deprecated:  route.Deprecated,
+			Sunset:      route.Sunset,
 		})
 		next(w, req.WithContext(ctx))
 	}
 }
 
-// restoreAuthorization applies the Authorization HTTP header to your context metadata.
-func restoreAuthorization() HTTPMiddlewareFunc {
+// restoreAuthorization applies the Authorization HTTP header to your context metadata. When the
+// request has both an Authorization header and propagated credentials, the policy decides which wins.
+func restoreAuthorization(policy metadata.MergePolicy) HTTPMiddlewareFunc {
 	headerAuthorization := http.CanonicalHeaderKey("Authorization")
 	headerWebsocketProtocol := http.CanonicalHeaderKey("Sec-WebSocket-Protocol")
 	readStandardAuth := func(req *http.Request) string {
@@ -134,14 +339,14 @@ func restoreAuthorization() HTTPMiddlewareFunc {
 
 	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
 		// It is possible to get authorization from the metadata attributes header as well
-		// as the standard Authorization header. We will use the metadata value if there's
-		// not one in the standard header.
+		// as the standard Authorization header. By default, we will use the metadata value
+		// only if there's not one in the standard header.
 		//
 		// This allows you to authorize a request using one set of credentials and then that
 		// call can invoke a call on another service using a completely different set of
 		// credentials. If we used the metadata attrs as the authoritative value, you'd be
 		// stuck using one set of credentials for everything this request may require which
-		// is not what we want.
+		// is not what we want. You can flip that behavior w/ the gateway's merge policy, though.
 		//
 		// Otherwise, we prefer to get credentials from the standard HTTP Authorization header, and
 		// that works 99% of the time. For websockets in the browser, however, they have the limitation
@@ -149,42 +354,50 @@ func restoreAuthorization() HTTPMiddlewareFunc {
 		// we will look through all of its values and use the one that looks like "Authorization: XXX".
 		// For instance, if the header values is ["foo", "bar", "Authorization: baz"], we'll use the
 		// value "baz" as the context's authorization value.
-		if auth := readStandardAuth(req); auth != "" {
-			next(w, req.WithContext(metadata.WithAuthorization(req.Context(), auth)))
-			return
-		}
-		if auth := readWebsocketAuth(req); auth != "" {
-			next(w, req.WithContext(metadata.WithAuthorization(req.Context(), auth)))
-			return
+		standardAuth := readStandardAuth(req)
+		if standardAuth == "" {
+			standardAuth = readWebsocketAuth(req)
 		}
-		next(w, req.WithContext(req.Context()))
+
+		auth := policy.Authorization.Choose(standardAuth, metadata.Authorization(req.Context()))
+		next(w, req.WithContext(metadata.WithAuthorization(req.Context(), auth)))
 	}
 }
 
 // restoreTraceID ensures that this request ALWAYS has a unique request/trace id for use in
 // your logging/observability code. It will restore the value provided by some downstream
-// service if present; otherwise it will generate a unique-enough value for you.
-func restoreTraceID() HTTPMiddlewareFunc {
-	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
-		// We're already carrying over a request id from a previous call in this chain.
-		if traceID := metadata.TraceID(req.Context()); traceID != "" {
-			next(w, req)
-			return
+// service or the one pulled from the request by the extractor - the trace id header by
+// default (the policy decides which wins if there are both); otherwise it will use
+// metadata.NewTraceID() to create a unique-enough value for you.
+//
+// The resolved id is echoed back to the caller in the same trace id header. We set it before
+// the handler runs, so every response (success, failure, redirect, stream, etc.) includes it and
+// clients can always log the id for correlation.
+func restoreTraceID(policy metadata.MergePolicy, header string, extractor TraceIDExtractor) HTTPMiddlewareFunc {
+	if extractor == nil {
+		extractor = func(req *http.Request) string {
+			return req.Header.Get(header)
 		}
+	}
 
-		// This is probably the primordial service call, so use the HTTP header value if
-		// there is one - otherwise, generate one for us to use. All requests should have one.
-		switch traceID := req.Header.Get("X-Request-ID"); traceID {
-		case "":
-			ctx := metadata.WithTraceID(req.Context(), metadata.NewTraceID())
-			next(w, req.WithContext(ctx))
-		default:
-			ctx := metadata.WithTraceID(req.Context(), traceID)
-			next(w, req.WithContext(ctx))
+	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		// By default, we're carrying over the request id from a previous call in this chain. If this is
+		// the primordial service call, use the HTTP header value if there is one - otherwise, generate
+		// one for us to use. All requests should have one.
+		traceID := policy.TraceID.Choose(extractor(req), metadata.TraceID(req.Context()))
+		if traceID == "" {
+			traceID = metadata.NewTraceID()
 		}
+
+		w.Header().Set(header, traceID)
+		next(w, req.WithContext(metadata.WithTraceID(req.Context(), traceID)))
 	}
 }
 
+// TraceIDExtractor pulls the trace id that the caller supplied out of the incoming request. It should
+// return "" when the request doesn't have one, so that the gateway generates a new one.
+type TraceIDExtractor func(req *http.Request) string
+
 // restoreMetadataHeaders places the HTTP header map into the request metadata. This way
 // you can tweak service behavior based on things like Cache-Control or things like that.
 func restoreMetadataHeaders() HTTPMiddlewareFunc {
@@ -194,11 +407,102 @@ func restoreMetadataHeaders() HTTPMiddlewareFunc {
 	}
 }
 
-// prepareContext stores the request and response writer on the context for the duration of the call. We will
-// not provide the user direct access to these as we really want to promote the idea that you shouldn't be dealing
-// with the HTTP request/response as much as possible. Instead, these are in place so that we can inject our own
-// logic by privately accessing these to do things like upgrade your connection to a websocket. The user never sees
-// the HTTP-ness of it. They just ask for a socket and get one.
+// restoreRequestInfo places the transport-level details of the request (method, client address, TLS) and the
+// name of the calling service into the request metadata. The X-Forwarded-For/X-Real-IP headers are only honored
+// when the request came directly from one of your trusted proxies; otherwise, anyone could spoof their address by
+// sending those headers themselves.
+func restoreRequestInfo(trustedProxies []netip.Prefix) HTTPMiddlewareFunc {
+	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		info := metadata.RequestInfo{
+			Method:     req.Method,
+			RemoteAddr: remoteAddr(req, trustedProxies),
+		}
+		if req.TLS != nil {
+			info.TLS = true
+			info.TLSVersion = req.TLS.Version
+		}
+		ctx := metadata.WithRequestInfo(req.Context(), info)
+		ctx = metadata.WithCaller(ctx, strings.TrimSpace(req.Header.Get(metadata.CallerHeader)))
+		next(w, req.WithContext(ctx))
+	}
+}
+
+// remoteAddr determines the IP address of the client that made the request. If the peer that connected to us is
+// a trusted proxy, we walk the X-Forwarded-For chain from right to left (the right-most entries were added by the
+// proxies closest to us) and use the first address that is not another trusted proxy. Failing that, we'll use the
+// X-Real-IP header before giving up and using the peer's address.
+func remoteAddr(req *http.Request, trustedProxies []netip.Prefix) string {
+	peer := req.RemoteAddr
+	if host, _, err := net.SplitHostPort(peer); err == nil {
+		peer = host
+	}
+	if !trustedProxy(peer, trustedProxies) {
+		return peer
+	}
+
+	var forwarded []string
+	for _, value := range req.Header.Values("X-Forwarded-For") {
+		forwarded = append(forwarded, strings.Split(value, ",")...)
+	}
+
+	client := ""
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addr := strings.TrimSpace(forwarded[i])
+		if addr == "" {
+			continue
+		}
+		client = addr
+		if !trustedProxy(addr, trustedProxies) {
+			return addr
+		}
+	}
+	if client != "" {
+		// Every hop was one of our proxies, so the left-most address is the best guess we've got.
+		return client
+	}
+	if realIP := strings.TrimSpace(req.Header.Get("X-Real-IP")); realIP != "" {
+		return realIP
+	}
+	return peer
+}
+
+// trustedProxy returns true if the address is contained in any of the trusted proxy ranges.
+func trustedProxy(address string, trustedProxies []netip.Prefix) bool {
+	if len(trustedProxies) == 0 {
+		return false
+	}
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return false
+	}
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreClientCert places the identity of the verified mutual TLS client certificate (if there is one)
+// into the request metadata. We only trust certificates that the TLS layer actually verified against
+// your client CAs, so a certificate that was merely presented by the caller is ignored.
+func restoreClientCert() HTTPMiddlewareFunc {
+	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+			next(w, req)
+			return
+		}
+
+		cert := metadata.NewClientCertificate(req.TLS.VerifiedChains[0][0])
+		next(w, req.WithContext(metadata.WithClientCert(req.Context(), cert)))
+	}
+}
+
+// prepareContext stores the request and response writer on the context for the duration of the call. We really
+// want to promote the idea that you shouldn't be dealing with the HTTP request/response as much as possible, so the
+// response writer stays private. It's in place so that we can inject our own logic to do things like upgrade your
+// connection to a websocket. The user never sees the HTTP-ness of it. They just ask for a socket and get one. The
+// request is available via HTTPRequest() as a read-only escape hatch for the odd thing that we don't expose yet.
 func prepareContext() HTTPMiddlewareFunc {
 	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
 		ctx := req.Context()
@@ -219,3 +523,22 @@ func applyCorsHeaders(c *cors.Cors) HTTPMiddlewareFunc {
 		next(w, req)
 	}
 }
+
+// writeDeprecationHeaders lets callers of a deprecated endpoint know that it's being retired using the standard
+// "Deprecation" header as well as the RFC 8594 "Sunset" header when we know the date that it's going away. We
+// write these before doing anything else, so even error responses let the caller know.
+func writeDeprecationHeaders(route services.EndpointRoute) HTTPMiddlewareFunc {
+	sunset := ""
+	if date, err := time.Parse(time.DateOnly, route.Sunset); err == nil {
+		sunset = date.UTC().Format(http.TimeFormat)
+	}
+	return func(w http.ResponseWriter, req *http.Request, next http.HandlerFunc) {
+		if route.Deprecated {
+			w.Header().Set("Deprecation", "true")
+		}
+		if route.Deprecated && sunset != "" {
+			w.Header().Set("Sunset", sunset)
+		}
+		next(w, req)
+	}
+}
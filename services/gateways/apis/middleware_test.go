@@ -0,0 +1,767 @@
+//go:build unit
+
+package apis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bridgekit-io/frodo/codec"
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewareSuite))
+}
+
+type MiddlewareSuite struct {
+	suite.Suite
+}
+
+func (suite *MiddlewareSuite) request(remoteAddr string, headers map[string]string) *http.Request {
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/foo", nil)
+	req.RemoteAddr = remoteAddr
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return req
+}
+
+func (suite *MiddlewareSuite) TestRestoreRequestInfo_caller() {
+	caller := func(headers map[string]string) string {
+		var result string
+		restoreRequestInfo(nil)(httptest.NewRecorder(), suite.request("1.2.3.4:5555", headers), func(w http.ResponseWriter, req *http.Request) {
+			result = metadata.Caller(req.Context())
+		})
+		return result
+	}
+
+	suite.Equal("BillingService", caller(map[string]string{"X-RPC-Caller": "BillingService"}))
+	suite.Equal("BillingService", caller(map[string]string{"X-RPC-Caller": "  BillingService "}))
+	suite.Equal("", caller(nil))
+}
+
+func (suite *MiddlewareSuite) TestRemoteAddr_untrusted() {
+	// No trusted proxies, so forwarding headers are spoofable garbage.
+	req := suite.request("1.2.3.4:5555", map[string]string{
+		"X-Forwarded-For": "6.6.6.6",
+		"X-Real-IP":       "7.7.7.7",
+	})
+	suite.Equal("1.2.3.4", remoteAddr(req, nil))
+
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	suite.Equal("1.2.3.4", remoteAddr(req, trusted))
+}
+
+func (suite *MiddlewareSuite) TestRemoteAddr_trusted() {
+	trusted := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		parseTrustedProxy("192.168.1.1"),
+	}
+
+	req := suite.request("10.1.1.1:5555", map[string]string{"X-Forwarded-For": "1.2.3.4"})
+	suite.Equal("1.2.3.4", remoteAddr(req, trusted))
+
+	// The left-most value could have been spoofed by the client, so take the right-most untrusted one.
+	req = suite.request("10.1.1.1:5555", map[string]string{"X-Forwarded-For": "6.6.6.6, 1.2.3.4, 192.168.1.1, 10.2.2.2"})
+	suite.Equal("1.2.3.4", remoteAddr(req, trusted))
+
+	req = suite.request("10.1.1.1:5555", map[string]string{"X-Real-IP": "1.2.3.4"})
+	suite.Equal("1.2.3.4", remoteAddr(req, trusted))
+
+	req = suite.request("10.1.1.1:5555", nil)
+	suite.Equal("10.1.1.1", remoteAddr(req, trusted))
+}
+
+func (suite *MiddlewareSuite) TestParseTrustedProxy() {
+	suite.Equal(netip.MustParsePrefix("10.0.0.0/8"), parseTrustedProxy("10.1.2.3/8"))
+	suite.Equal(netip.MustParsePrefix("10.1.2.3/32"), parseTrustedProxy(" 10.1.2.3 "))
+	suite.Equal(netip.MustParsePrefix("::1/128"), parseTrustedProxy("::1"))
+	suite.Panics(func() { parseTrustedProxy("not-an-ip") })
+}
+
+func (suite *MiddlewareSuite) TestShedExcessRequests() {
+	inFlight := &atomic.Int64{}
+	middleware := shedExcessRequests(codec.JSONEncoder{}, 2, inFlight)
+
+	release := make(chan struct{})
+	started := sync.WaitGroup{}
+	finished := sync.WaitGroup{}
+	blocking := func(w http.ResponseWriter, req *http.Request) {
+		started.Done()
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}
+
+	// Fill up all of the available slots w/ requests that won't finish until we say so.
+	for i := 0; i < 2; i++ {
+		started.Add(1)
+		finished.Add(1)
+		go func() {
+			defer finished.Done()
+			middleware(httptest.NewRecorder(), suite.request("127.0.0.1:1234", nil), blocking)
+		}()
+	}
+	started.Wait()
+
+	w := httptest.NewRecorder()
+	middleware(w, suite.request("127.0.0.1:1234", nil), func(w http.ResponseWriter, req *http.Request) {
+		suite.Fail("Handler should not fire when the limit is exceeded")
+	})
+	suite.Equal(http.StatusServiceUnavailable, w.Code)
+	suite.Equal("1", w.Header().Get("Retry-After"))
+
+	close(release)
+	finished.Wait()
+	suite.Equal(int64(0), inFlight.Load(), "All slots should be freed once requests finish")
+
+	w = httptest.NewRecorder()
+	middleware(w, suite.request("127.0.0.1:1234", nil), func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *MiddlewareSuite) TestRespondFailure_retryAfter() {
+	respond := func(err error) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		respondFailure(w, suite.request("127.0.0.1:1234", nil), codec.JSONEncoder{}, err)
+		return w
+	}
+
+	w := respond(fail.ThrottledAfter(30*time.Second, "slow down"))
+	suite.Equal(http.StatusTooManyRequests, w.Code)
+	suite.Equal("30", w.Header().Get("Retry-After"))
+	suite.JSONEq(`{"Status":429, "Message":"slow down"}`, w.Body.String())
+
+	w = respond(fmt.Errorf("wrapped: %w", fail.UnavailableAfter(1500*time.Millisecond, "busy")))
+	suite.Equal(http.StatusServiceUnavailable, w.Code)
+	suite.Equal("2", w.Header().Get("Retry-After"), "Should round up to the next whole second")
+
+	w = respond(fail.ThrottledAfter(0, "slow down"))
+	suite.Equal("1", w.Header().Get("Retry-After"), "Should never tell the caller to retry immediately")
+
+	w = respond(fail.Throttled("slow down"))
+	suite.Equal(http.StatusTooManyRequests, w.Code)
+	suite.Empty(w.Header().Get("Retry-After"))
+}
+
+func (suite *MiddlewareSuite) TestShedExcessRequests_panic() {
+	inFlight := &atomic.Int64{}
+	handler := HTTPMiddlewareFuncs{
+		recoverFromPanic(codec.JSONEncoder{}),
+		shedExcessRequests(codec.JSONEncoder{}, 1, inFlight),
+	}.Then(func(w http.ResponseWriter, req *http.Request) {
+		panic("bowling is not a sport")
+	})
+
+	w := httptest.NewRecorder()
+	handler(w, suite.request("127.0.0.1:1234", nil))
+	suite.Equal(http.StatusInternalServerError, w.Code)
+	suite.Equal(int64(0), inFlight.Load(), "Panics should still free up the slot")
+}
+
+func (suite *MiddlewareSuite) TestShedExcessRequests_unlimited() {
+	inFlight := &atomic.Int64{}
+	middleware := shedExcessRequests(codec.JSONEncoder{}, 0, inFlight)
+
+	w := httptest.NewRecorder()
+	middleware(w, suite.request("127.0.0.1:1234", nil), func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(int64(0), inFlight.Load(), "Unlimited shouldn't bother tracking requests")
+}
+
+func (suite *MiddlewareSuite) rejectOversized(maxQueryLength int, maxHeaderBytes int, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	w := httptest.NewRecorder()
+	rejectOversizedRequests(codec.JSONEncoder{}, maxQueryLength, maxHeaderBytes)(w, req, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return w
+}
+
+func (suite *MiddlewareSuite) TestRejectOversizedRequests_query() {
+	suite.Equal(http.StatusOK, suite.rejectOversized(10, 0, "/foo?Name=Dude", nil).Code)
+	suite.Equal(http.StatusOK, suite.rejectOversized(10, 0, "/foo?Name=Dude1", nil).Code, "Exactly the limit is fine")
+
+	w := suite.rejectOversized(10, 0, "/foo?Name=Lebowski", nil)
+	suite.Equal(http.StatusRequestURITooLong, w.Code)
+	suite.Contains(w.Body.String(), "query string exceeds 10 bytes")
+}
+
+func (suite *MiddlewareSuite) TestRejectOversizedRequests_headers() {
+	// "X-Name: Dude\r\n" is 14 bytes
+	header := http.Header{"X-Name": {"Dude"}}
+	suite.Equal(http.StatusOK, suite.rejectOversized(0, 14, "/foo", header).Code)
+
+	w := suite.rejectOversized(0, 13, "/foo", header)
+	suite.Equal(http.StatusRequestHeaderFieldsTooLarge, w.Code)
+	suite.Contains(w.Body.String(), "request headers exceed 13 bytes")
+
+	header = http.Header{"X-Name": {"Dude", "Walter"}}
+	suite.Equal(http.StatusRequestHeaderFieldsTooLarge, suite.rejectOversized(0, 20, "/foo", header).Code, "Should count every value")
+}
+
+func (suite *MiddlewareSuite) TestRejectOversizedRequests_unlimited() {
+	header := http.Header{"X-Name": {strings.Repeat("Dude", 1000)}}
+	suite.Equal(http.StatusOK, suite.rejectOversized(0, 0, "/foo?Name="+strings.Repeat("Dude", 1000), header).Code)
+}
+
+func (suite *MiddlewareSuite) deprecationHeaders(route services.EndpointRoute) http.Header {
+	w := httptest.NewRecorder()
+	writeDeprecationHeaders(route)(w, httptest.NewRequest(http.MethodGet, "/foo", nil), func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return w.Header()
+}
+
+func (suite *MiddlewareSuite) TestWriteDeprecationHeaders() {
+	header := suite.deprecationHeaders(services.EndpointRoute{Deprecated: true, Sunset: "2025-06-01"})
+	suite.Equal("true", header.Get("Deprecation"))
+	suite.Equal("Sun, 01 Jun 2025 00:00:00 GMT", header.Get("Sunset"))
+
+	header = suite.deprecationHeaders(services.EndpointRoute{Deprecated: true})
+	suite.Equal("true", header.Get("Deprecation"))
+	suite.Empty(header.Values("Sunset"), "Should not include a sunset when there's no date")
+
+	header = suite.deprecationHeaders(services.EndpointRoute{})
+	suite.Empty(header.Values("Deprecation"))
+	suite.Empty(header.Values("Sunset"))
+}
+
+func (suite *MiddlewareSuite) gzipRequest(body []byte) *http.Request {
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost/foo", bytes.NewReader(body))
+	req.Header.Set("Content-Encoding", "gzip")
+	return req
+}
+
+func (suite *MiddlewareSuite) decompress(req *http.Request) (*httptest.ResponseRecorder, string) {
+	var body string
+	w := httptest.NewRecorder()
+	decompressRequest(codec.JSONEncoder{}, DefaultMaxDecompressedSize)(w, req, func(w http.ResponseWriter, req *http.Request) {
+		suite.Empty(req.Header.Get("Content-Encoding"))
+		data, err := io.ReadAll(req.Body)
+		suite.Require().NoError(err)
+		body = string(data)
+		w.WriteHeader(http.StatusOK)
+	})
+	return w, body
+}
+
+func (suite *MiddlewareSuite) TestDecompressRequest() {
+	compressed := &bytes.Buffer{}
+	writer := gzip.NewWriter(compressed)
+	_, _ = writer.Write([]byte(`{"Name":"Dude"}`))
+	suite.Require().NoError(writer.Close())
+
+	w, body := suite.decompress(suite.gzipRequest(compressed.Bytes()))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`{"Name":"Dude"}`, body)
+}
+
+func (suite *MiddlewareSuite) TestDecompressRequest_malformed() {
+	w, _ := suite.decompress(suite.gzipRequest([]byte(`{"Name":"Dude"}`)))
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *MiddlewareSuite) TestDecompressRequest_tooLarge() {
+	// 1MB of zeros compresses down to about 1KB, which is exactly the sort of thing that a gzip bomb does.
+	compressed := &bytes.Buffer{}
+	writer := gzip.NewWriter(compressed)
+	_, _ = writer.Write(make([]byte, 1<<20))
+	suite.Require().NoError(writer.Close())
+	suite.Require().Less(compressed.Len(), 4096)
+
+	var readErr error
+	w := httptest.NewRecorder()
+	decompressRequest(codec.JSONEncoder{}, 1024)(w, suite.gzipRequest(compressed.Bytes()), func(w http.ResponseWriter, req *http.Request) {
+		_, readErr = io.ReadAll(req.Body)
+	})
+	suite.Require().Error(readErr)
+	suite.True(fail.IsTooLarge(readErr), "Reading past the limit should fail w/ a 413")
+}
+
+func (suite *MiddlewareSuite) TestDecompressRequest_uncompressed() {
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost/foo", strings.NewReader(`{"Name":"Dude"}`))
+	w, body := suite.decompress(req)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`{"Name":"Dude"}`, body)
+}
+
+func (suite *MiddlewareSuite) requireContentType(method string, contentType string, body string, contentTypes ...string) int {
+	req := httptest.NewRequest(method, "http://localhost/foo", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	requireContentType(codec.JSONEncoder{}, contentTypes)(w, req, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return w.Code
+}
+
+func (suite *MiddlewareSuite) TestRequireContentType() {
+	body := `{"Name":"Dude"}`
+	suite.Equal(http.StatusOK, suite.requireContentType(http.MethodPost, "application/json", body, "application/json"))
+	suite.Equal(http.StatusOK, suite.requireContentType(http.MethodPut, "application/json; charset=utf-8", body, "application/json"))
+	suite.Equal(http.StatusOK, suite.requireContentType(http.MethodPatch, "text/plain", body, "application/json", "text/plain"))
+
+	suite.Equal(http.StatusUnsupportedMediaType, suite.requireContentType(http.MethodPost, "", body, "application/json"))
+	suite.Equal(http.StatusUnsupportedMediaType, suite.requireContentType(http.MethodPut, "text/plain", body, "application/json"))
+	suite.Equal(http.StatusUnsupportedMediaType, suite.requireContentType(http.MethodPatch, "application/json;;;", body, "application/json"))
+}
+
+func (suite *MiddlewareSuite) TestRequireContentType_noBody() {
+	suite.Equal(http.StatusOK, suite.requireContentType(http.MethodGet, "", "", "application/json"))
+	suite.Equal(http.StatusOK, suite.requireContentType(http.MethodDelete, "text/plain", "Hello", "application/json"))
+	suite.Equal(http.StatusOK, suite.requireContentType(http.MethodPost, "", "", "application/json"))
+}
+
+func (suite *MiddlewareSuite) TestRequireContentType_disabled() {
+	suite.Equal(http.StatusOK, suite.requireContentType(http.MethodPost, "", `{"Name":"Dude"}`))
+	suite.Equal(http.StatusOK, suite.requireContentType(http.MethodPost, "text/plain", `{"Name":"Dude"}`))
+}
+
+func (suite *MiddlewareSuite) idempotentRequest(method string, key string, authorization string) *http.Request {
+	return suite.idempotentRequestBody(method, key, authorization, `{"Item":"Rug"}`)
+}
+
+func (suite *MiddlewareSuite) idempotentRequestBody(method string, key string, authorization string, body string) *http.Request {
+	req := suite.request("127.0.0.1:1234", map[string]string{IdempotencyKeyHeader: key, "Authorization": authorization})
+	req.Method = method
+	req.Body = io.NopCloser(strings.NewReader(body))
+	return req
+}
+
+// idempotentHandler counts how many times it actually runs, creating a new "order" every time.
+func (suite *MiddlewareSuite) idempotentHandler(calls *atomic.Int64, status int) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		call := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Order", fmt.Sprintf("order-%d", call))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"ID":"order-%d"}`, call)))
+	}
+}
+
+func (suite *MiddlewareSuite) enforceIdempotency(store IdempotencyStore, req *http.Request, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	w.Header().Set("Access-Control-Allow-Origin", "*") // pretend that CORS ran first
+	enforceIdempotency(codec.JSONEncoder{}, store, time.Minute, 64)(w, req, handler)
+	return w
+}
+
+func (suite *MiddlewareSuite) TestEnforceIdempotency() {
+	calls := &atomic.Int64{}
+	store := NewMemoryIdempotencyStore()
+	handler := suite.idempotentHandler(calls, http.StatusCreated)
+
+	w := suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), handler)
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal(`{"ID":"order-1"}`, w.Body.String())
+	suite.Empty(w.Header().Get(IdempotentReplayedHeader))
+
+	w = suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), handler)
+	suite.Equal(int64(1), calls.Load(), "Retries should not run the handler again")
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal(`{"ID":"order-1"}`, w.Body.String())
+	suite.Equal("order-1", w.Header().Get("X-Order"))
+	suite.Equal("application/json", w.Header().Get("Content-Type"))
+	suite.Equal("true", w.Header().Get(IdempotentReplayedHeader))
+
+	res, ok, err := store.Get(context.Background(), idempotencyStoreKey(suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), "abc"))
+	suite.Require().NoError(err)
+	suite.Require().True(ok)
+	suite.Empty(res.Header.Get("Access-Control-Allow-Origin"), "Headers set before the handler ran should not be stored")
+
+	// PATCH is just as non-idempotent as POST.
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPatch, "def", "Token 1"), handler)
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPatch, "def", "Token 1"), handler)
+	suite.Equal(int64(2), calls.Load())
+}
+
+func (suite *MiddlewareSuite) TestEnforceIdempotency_differentKeys() {
+	calls := &atomic.Int64{}
+	store := NewMemoryIdempotencyStore()
+	handler := suite.idempotentHandler(calls, http.StatusCreated)
+
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), handler)
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "def", "Token 1"), handler)
+	suite.Equal(int64(2), calls.Load(), "Different keys should run the handler")
+
+	w := suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "abc", "Token 2"), handler)
+	suite.Equal(int64(3), calls.Load(), "Keys should be scoped to the caller")
+	suite.Equal(`{"ID":"order-3"}`, w.Body.String())
+}
+
+func (suite *MiddlewareSuite) TestEnforceIdempotency_ignored() {
+	calls := &atomic.Int64{}
+	store := NewMemoryIdempotencyStore()
+	handler := suite.idempotentHandler(calls, http.StatusOK)
+
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "", "Token 1"), handler)
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "", "Token 1"), handler)
+	suite.Equal(int64(2), calls.Load(), "Requests w/o a key should always run")
+
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPut, "abc", "Token 1"), handler)
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPut, "abc", "Token 1"), handler)
+	suite.Equal(int64(4), calls.Load(), "Idempotent methods should always run")
+
+	suite.enforceIdempotency(nil, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), handler)
+	suite.enforceIdempotency(nil, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), handler)
+	suite.Equal(int64(6), calls.Load(), "A nil store should disable the middleware")
+}
+
+func (suite *MiddlewareSuite) TestEnforceIdempotency_notStored() {
+	store := NewMemoryIdempotencyStore()
+
+	calls := &atomic.Int64{}
+	handler := suite.idempotentHandler(calls, http.StatusServiceUnavailable)
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), handler)
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), handler)
+	suite.Equal(int64(2), calls.Load(), "Server errors should be retried")
+
+	calls = &atomic.Int64{}
+	handler = suite.idempotentHandler(calls, http.StatusTooManyRequests)
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "def", "Token 1"), handler)
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "def", "Token 1"), handler)
+	suite.Equal(int64(2), calls.Load(), "Rate limited requests should be retried")
+
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound} {
+		calls = &atomic.Int64{}
+		handler = suite.idempotentHandler(calls, status)
+		suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "ghi", "Token 1"), handler)
+		w := suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "ghi", "Token 1"), handler)
+		suite.Equal(int64(2), calls.Load(), "Client errors should be retried: %d", status)
+		suite.Equal(status, w.Code)
+		suite.Empty(w.Header().Get(IdempotentReplayedHeader))
+	}
+}
+
+func (suite *MiddlewareSuite) TestEnforceIdempotency_differentBody() {
+	calls := &atomic.Int64{}
+	store := NewMemoryIdempotencyStore()
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		suite.Require().NoError(err)
+		suite.Equal(`{"Item":"Rug"}`, string(body), "The handler should still get the whole body")
+		suite.idempotentHandler(calls, http.StatusCreated)(w, req)
+	}
+
+	suite.enforceIdempotency(store, suite.idempotentRequestBody(http.MethodPost, "abc", "Token 1", `{"Item":"Rug"}`), handler)
+	w := suite.enforceIdempotency(store, suite.idempotentRequestBody(http.MethodPost, "abc", "Token 1", `{"Item":"Car"}`), handler)
+	suite.Equal(http.StatusUnprocessableEntity, w.Code, "Reusing a key for a different request is a client bug")
+	suite.Empty(w.Header().Get(IdempotentReplayedHeader))
+	suite.Equal(int64(1), calls.Load())
+
+	w = suite.enforceIdempotency(store, suite.idempotentRequestBody(http.MethodPost, "abc", "Token 1", `{"Item":"Rug"}`), handler)
+	suite.Equal(http.StatusCreated, w.Code, "The original body should still replay")
+	suite.Equal("true", w.Header().Get(IdempotentReplayedHeader))
+	suite.Equal(int64(1), calls.Load())
+}
+
+func (suite *MiddlewareSuite) TestEnforceIdempotency_requestTooLarge() {
+	calls := &atomic.Int64{}
+	store := NewMemoryIdempotencyStore()
+	handler := suite.idempotentHandler(calls, http.StatusCreated)
+
+	body := fmt.Sprintf(`{"Item":"%s"}`, strings.Repeat("x", 64))
+	w := suite.enforceIdempotency(store, suite.idempotentRequestBody(http.MethodPost, "abc", "Token 1", body), handler)
+	suite.Equal(http.StatusRequestEntityTooLarge, w.Code, "We shouldn't buffer giant bodies just to hash them")
+	suite.Equal(int64(0), calls.Load())
+
+	// Requests w/o a key don't get buffered, so they're not limited.
+	w = suite.enforceIdempotency(store, suite.idempotentRequestBody(http.MethodPost, "", "Token 1", body), handler)
+	suite.Equal(http.StatusCreated, w.Code)
+}
+
+func (suite *MiddlewareSuite) TestEnforceIdempotency_streamed() {
+	store := NewMemoryIdempotencyStore()
+
+	calls := &atomic.Int64{}
+	tooLarge := func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(strings.Repeat("x", 40)))
+		_, _ = w.Write([]byte(strings.Repeat("x", 40)))
+	}
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), tooLarge)
+	w := suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), tooLarge)
+	suite.Equal(int64(2), calls.Load(), "Responses bigger than the limit should not be stored")
+	suite.Equal(strings.Repeat("x", 80), w.Body.String(), "Responses bigger than the limit should still be sent")
+
+	calls = &atomic.Int64{}
+	flushed := func(w http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"ID":1}` + "\n"))
+		_ = http.NewResponseController(w).Flush()
+		_, _ = w.Write([]byte(`{"ID":2}` + "\n"))
+	}
+	suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "def", "Token 1"), flushed)
+	w = suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "def", "Token 1"), flushed)
+	suite.Equal(int64(2), calls.Load(), "Streamed responses should not be stored")
+	suite.Equal(`{"ID":1}`+"\n"+`{"ID":2}`+"\n", w.Body.String())
+}
+
+func (suite *MiddlewareSuite) TestEnforceIdempotency_concurrent() {
+	calls := &atomic.Int64{}
+	store := NewMemoryIdempotencyStore()
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	handler := func(w http.ResponseWriter, req *http.Request) {
+		close(started)
+		<-finish
+		suite.idempotentHandler(calls, http.StatusCreated)(w, req)
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), handler)
+	}()
+	<-started
+
+	w := suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), handler)
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.Equal("1", w.Header().Get("Retry-After"))
+
+	close(finish)
+	wg.Wait()
+
+	w = suite.enforceIdempotency(store, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), handler)
+	suite.Equal(http.StatusCreated, w.Code, "Should replay once the original request finishes")
+	suite.Equal("true", w.Header().Get(IdempotentReplayedHeader))
+	suite.Equal(int64(1), calls.Load())
+}
+
+func (suite *MiddlewareSuite) TestEnforceIdempotency_failures() {
+	calls := &atomic.Int64{}
+	handler := suite.idempotentHandler(calls, http.StatusCreated)
+
+	w := suite.enforceIdempotency(NewMemoryIdempotencyStore(), suite.idempotentRequest(http.MethodPost, strings.Repeat("x", 256), "Token 1"), handler)
+	suite.Equal(http.StatusBadRequest, w.Code)
+
+	w = suite.enforceIdempotency(failingIdempotencyStore{}, suite.idempotentRequest(http.MethodPost, "abc", "Token 1"), handler)
+	suite.Equal(http.StatusInternalServerError, w.Code)
+	suite.Equal(int64(0), calls.Load())
+}
+
+func (suite *MiddlewareSuite) TestMemoryIdempotencyStore() {
+	ctx := context.Background()
+	store := NewMemoryIdempotencyStore()
+
+	locked, _ := store.Lock(ctx, "abc")
+	suite.True(locked)
+	locked, _ = store.Lock(ctx, "abc")
+	suite.False(locked, "Should not lock a key twice")
+	suite.NoError(store.Unlock(ctx, "abc"))
+	locked, _ = store.Lock(ctx, "abc")
+	suite.True(locked, "Should lock again once unlocked")
+
+	suite.NoError(store.Set(ctx, "abc", IdempotentResponse{Status: http.StatusCreated}, time.Minute))
+	suite.NoError(store.Set(ctx, "def", IdempotentResponse{Status: http.StatusOK}, time.Nanosecond))
+	time.Sleep(time.Millisecond)
+
+	res, ok, err := store.Get(ctx, "abc")
+	suite.NoError(err)
+	suite.True(ok)
+	suite.Equal(http.StatusCreated, res.Status)
+
+	_, ok, _ = store.Get(ctx, "def")
+	suite.False(ok, "Should not return expired responses")
+}
+
+type failingIdempotencyStore struct{}
+
+func (failingIdempotencyStore) Get(context.Context, string) (IdempotentResponse, bool, error) {
+	return IdempotentResponse{}, false, fmt.Errorf("connection refused")
+}
+
+func (failingIdempotencyStore) Set(context.Context, string, IdempotentResponse, time.Duration) error {
+	return fmt.Errorf("connection refused")
+}
+
+func (failingIdempotencyStore) Lock(context.Context, string) (bool, error) {
+	return false, fmt.Errorf("connection refused")
+}
+
+func (failingIdempotencyStore) Unlock(context.Context, string) error {
+	return fmt.Errorf("connection refused")
+}
+
+func (suite *MiddlewareSuite) TestMeasureResponseTime() {
+	w := httptest.NewRecorder()
+	measureResponseTime(true)(w, suite.request("127.0.0.1:1234", nil), func(w http.ResponseWriter, req *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		respondFailure(w, req, codec.JSONEncoder{}, fail.NotFound("nope"))
+	})
+	suite.Equal(http.StatusNotFound, w.Code)
+	suite.Regexp(`^\d+\.\d{3}ms$`, w.Header().Get("X-Response-Time"))
+	suite.Regexp(`^app;dur=\d+\.\d{3}$`, w.Header().Get("Server-Timing"))
+
+	elapsed, err := time.ParseDuration(w.Header().Get("X-Response-Time"))
+	suite.Require().NoError(err)
+	suite.GreaterOrEqual(elapsed, 5*time.Millisecond)
+}
+
+func (suite *MiddlewareSuite) TestMeasureResponseTime_implicitStatus() {
+	w := httptest.NewRecorder()
+	measureResponseTime(true)(w, suite.request("127.0.0.1:1234", nil), func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte("Hello"))
+	})
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("Hello", w.Body.String())
+	suite.NotEmpty(w.Header().Get("X-Response-Time"))
+	suite.NotEmpty(w.Header().Get("Server-Timing"))
+}
+
+func (suite *MiddlewareSuite) TestMeasureResponseTime_disabled() {
+	w := httptest.NewRecorder()
+	measureResponseTime(false)(w, suite.request("127.0.0.1:1234", nil), func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	suite.Empty(w.Header().Get("X-Response-Time"))
+	suite.Empty(w.Header().Get("Server-Timing"))
+}
+
+func (suite *MiddlewareSuite) restoreTraceID(req *http.Request) (string, *httptest.ResponseRecorder) {
+	return suite.restoreTraceIDWith(req, metadata.TraceIDHeader, nil)
+}
+
+func (suite *MiddlewareSuite) restoreTraceIDWith(req *http.Request, header string, extractor TraceIDExtractor) (string, *httptest.ResponseRecorder) {
+	var traceID string
+	w := httptest.NewRecorder()
+	restoreTraceID(metadata.DefaultMergePolicy(), header, extractor)(w, req, func(w http.ResponseWriter, req *http.Request) {
+		traceID = metadata.TraceID(req.Context())
+		w.WriteHeader(http.StatusTeapot)
+	})
+	return traceID, w
+}
+
+func (suite *MiddlewareSuite) restoreClientCert(state *tls.ConnectionState) metadata.ClientCertificate {
+	req := suite.request("127.0.0.1:1234", nil)
+	req.TLS = state
+
+	var cert metadata.ClientCertificate
+	restoreClientCert()(httptest.NewRecorder(), req, func(w http.ResponseWriter, req *http.Request) {
+		cert = metadata.ClientCert(req.Context())
+	})
+	return cert
+}
+
+func (suite *MiddlewareSuite) TestRestoreClientCert() {
+	leaf := &x509.Certificate{Subject: pkix.Name{CommonName: "order-service"}, SerialNumber: big.NewInt(42)}
+
+	cert := suite.restoreClientCert(&tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{leaf},
+		VerifiedChains:   [][]*x509.Certificate{{leaf}},
+	})
+	suite.True(cert.Verified)
+	suite.Equal("order-service", cert.CommonName)
+	suite.Equal("42", cert.SerialNumber)
+}
+
+func (suite *MiddlewareSuite) TestRestoreClientCert_unverified() {
+	leaf := &x509.Certificate{Subject: pkix.Name{CommonName: "order-service"}}
+
+	suite.Equal(metadata.ClientCertificate{}, suite.restoreClientCert(nil), "Plain HTTP has no cert")
+	suite.Equal(metadata.ClientCertificate{}, suite.restoreClientCert(&tls.ConnectionState{}), "TLS w/o a client cert")
+	suite.Equal(metadata.ClientCertificate{}, suite.restoreClientCert(&tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{leaf},
+	}), "Presented certs that weren't verified should be ignored")
+}
+
+func (suite *MiddlewareSuite) TestRestoreTraceID_generated() {
+	traceID, w := suite.restoreTraceID(suite.request("1.2.3.4:5555", nil))
+	suite.NotEmpty(traceID)
+	suite.Equal(traceID, w.Header().Get("X-Request-ID"))
+	suite.Equal(http.StatusTeapot, w.Code)
+}
+
+func (suite *MiddlewareSuite) TestRestoreTraceID_header() {
+	traceID, w := suite.restoreTraceID(suite.request("1.2.3.4:5555", map[string]string{"X-Request-ID": "abc"}))
+	suite.Equal("abc", traceID)
+	suite.Equal("abc", w.Header().Get("X-Request-ID"))
+}
+
+func (suite *MiddlewareSuite) TestRestoreTraceID_propagated() {
+	req := suite.request("1.2.3.4:5555", map[string]string{"X-Request-ID": "abc"})
+	req = req.WithContext(metadata.WithTraceID(req.Context(), "xyz"))
+
+	traceID, w := suite.restoreTraceID(req)
+	suite.Equal("xyz", traceID)
+	suite.Equal("xyz", w.Header().Get("X-Request-ID"))
+}
+
+func (suite *MiddlewareSuite) TestRestoreTraceID_customHeader() {
+	defer metadata.WithTraceIDGenerator(nil)
+	metadata.WithTraceIDGenerator(func() string {
+		return "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	})
+
+	// The caller supplied a traceparent, so we should use that (and ignore X-Request-ID).
+	req := suite.request("1.2.3.4:5555", map[string]string{
+		"traceparent":  "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"X-Request-ID": "abc",
+	})
+	traceID, w := suite.restoreTraceIDWith(req, "traceparent", nil)
+	suite.Equal("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", traceID)
+	suite.Equal(traceID, w.Header().Get("traceparent"), "Should echo the id in the configured header")
+	suite.Empty(w.Header().Get("X-Request-ID"), "Should not echo the id in the default header")
+
+	// No traceparent, so we should generate one using the custom generator.
+	req = suite.request("1.2.3.4:5555", map[string]string{"X-Request-ID": "abc"})
+	traceID, w = suite.restoreTraceIDWith(req, "traceparent", nil)
+	suite.Equal("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", traceID)
+	suite.Equal(traceID, w.Header().Get("traceparent"))
+}
+
+func (suite *MiddlewareSuite) TestRestoreTraceID_customExtractor() {
+	extractor := func(req *http.Request) string {
+		return strings.TrimPrefix(req.Header.Get("traceparent"), "00-")
+	}
+
+	req := suite.request("1.2.3.4:5555", map[string]string{"traceparent": "00-0af7651916cd43dd8448eb211c80319c"})
+	traceID, w := suite.restoreTraceIDWith(req, "traceparent", extractor)
+	suite.Equal("0af7651916cd43dd8448eb211c80319c", traceID)
+	suite.Equal(traceID, w.Header().Get("traceparent"))
+}
+
+func (suite *MiddlewareSuite) TestPrepareContext_httpRequest() {
+	req := suite.request("1.2.3.4:5555", map[string]string{"X-Device-Fingerprint": "abc"})
+
+	var found *http.Request
+	var ok bool
+	prepareContext()(httptest.NewRecorder(), req, func(w http.ResponseWriter, req *http.Request) {
+		found, ok = HTTPRequest(req.Context())
+	})
+	suite.Require().True(ok)
+	suite.Equal("abc", found.Header.Get("X-Device-Fingerprint"))
+
+	_, ok = HTTPRequest(context.Background())
+	suite.False(ok, "Calls that didn't come through the API gateway have no request")
+}
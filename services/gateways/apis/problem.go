@@ -0,0 +1,69 @@
+package apis
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bridgekit-io/frodo/codec"
+	"github.com/bridgekit-io/frodo/fail"
+)
+
+// ProblemContentType is the content type of the RFC 7807 error responses you get w/ WithProblemJSON().
+const ProblemContentType = "application/problem+json"
+
+// problemDetails is the standard RFC 7807 body that describes an error. We always use "about:blank" as the type
+// since our errors are just HTTP statuses w/ messages, so the title is always the status text.
+type problemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance,omitempty"`
+}
+
+func newProblemDetails(status int, detail string, instance string) problemDetails {
+	return problemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+	}
+}
+
+// problemEncoder wraps the encoder that we use for error responses when you enable WithProblemJSON(), so that errors
+// come back as "application/problem+json" rather than our standard {"Status":404, "Message":"..."} format.
+type problemEncoder struct {
+	codec.Encoder
+}
+
+func (e problemEncoder) ContentType() string {
+	return ProblemContentType
+}
+
+// Encode converts whatever error value we're responding with into problem details. We recover from panics using
+// this encoder, too, so the value isn't necessarily an error; it's whatever you passed to panic().
+func (e problemEncoder) Encode(writer io.Writer, value any) error {
+	switch v := value.(type) {
+	case problemDetails:
+		return e.Encoder.Encode(writer, v)
+	case error:
+		return e.Encoder.Encode(writer, newProblemDetails(fail.Status(v), v.Error(), ""))
+	default:
+		return e.Encoder.Encode(writer, newProblemDetails(http.StatusInternalServerError, fmt.Sprintf("%v", v), ""))
+	}
+}
+
+// errorEncoder returns the encoder that we should use for all error responses; either the standard encoder or
+// the RFC 7807 one if you enabled WithProblemJSON(). It also carries your WithLocalizer() function if you have one.
+func (gw *Gateway) errorEncoder() codec.Encoder {
+	var encoder codec.Encoder = gw.codecs.DefaultEncoder()
+	if gw.problemJSON {
+		encoder = problemEncoder{Encoder: encoder}
+	}
+	if gw.localizer != nil {
+		encoder = localizingEncoder{Encoder: encoder, localizer: gw.localizer}
+	}
+	return encoder
+}
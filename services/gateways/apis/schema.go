@@ -0,0 +1,73 @@
+package apis
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/bridgekit-io/frodo/fail"
+)
+
+// SchemaContentType is the media type that callers put in their "Accept" header to ask an endpoint for its
+// JSON Schema (see WithSchemas), and the content type of the schema that we respond with.
+const SchemaContentType = "application/schema+json"
+
+// WithSchemas lets callers discover the shape of each endpoint's request and response at runtime, so tools like
+// form builders don't need a copy of your code. Send an OPTIONS request to any of the endpoint's paths with the
+// header "Accept: application/schema+json", and the gateway responds with the JSON Schema that Frodo generated
+// for it. All other OPTIONS requests (e.g. CORS preflights) behave exactly like they would without this option.
+//
+//	curl -X OPTIONS -H "Accept: application/schema+json" http://localhost:8080/UserService.GetUser
+//
+// When more than one endpoint shares a path (e.g. "GET /user/{ID}" and "DELETE /user/{ID}"), you get the schema
+// of the one registered first, so add "?method=DELETE" to pick a specific one.
+func WithSchemas() GatewayOption {
+	return func(gw *Gateway) {
+		gw.schemas = true
+	}
+}
+
+// schemaHandler answers the OPTIONS requests that ask for the schema of the endpoint(s) at this path, leaving
+// everything else to the 'next' handler (CORS, "HTTP ALLOW", etc.). We register one OPTIONS route per path, so
+// defaultMethod is the method of the first endpoint that we registered at this path.
+func (gw *Gateway) schemaHandler(path string, defaultMethod string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		// Preflights always go to CORS, even if the browser happened to include our media type.
+		if req.Header.Get("Access-Control-Request-Method") != "" || !acceptsSchema(req) {
+			next(w, req)
+			return
+		}
+
+		method := strings.ToUpper(req.URL.Query().Get("method"))
+		if method == "" {
+			method = strings.ToUpper(defaultMethod)
+		}
+		endpoint, ok := gw.endpoints[httpRoute{Method: method, Path: path}]
+		if !ok || endpoint.Schema == "" {
+			respondFailure(w, req, gw.errorEncoder(), fail.NotFound("no schema for %s %s", method, req.URL.Path))
+			return
+		}
+
+		// This isn't a preflight, so CORS treats it like any other cross-origin request; this just adds
+		// the headers that let the browser see the response.
+		if gw.cors != nil {
+			gw.cors.HandlerFunc(w, req)
+		}
+		w.Header().Set("Content-Type", SchemaContentType)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(endpoint.Schema))
+	}
+}
+
+// acceptsSchema returns true when the request's "Accept" header asks for a JSON Schema.
+func acceptsSchema(req *http.Request) bool {
+	for _, accept := range req.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, _, err := mime.ParseMediaType(mediaRange)
+			if err == nil && mediaType == SchemaContentType {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package apis
+
+import (
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// staticFiles is a file system whose files the gateway serves under a path prefix (see WithStaticFiles).
+type staticFiles struct {
+	// prefix is the normalized path that all of the files live under (e.g. "/admin" or just "/").
+	prefix string
+	// fsys contains the actual files that we're serving.
+	fsys fs.FS
+}
+
+// fileName converts the incoming request path into the name of the file in the file system that we should
+// serve. The 'ok' value is false when the path isn't under this prefix at all.
+func (static staticFiles) fileName(urlPath string) (string, bool) {
+	switch {
+	case static.prefix == "/":
+	case urlPath == static.prefix:
+		urlPath = ""
+	case strings.HasPrefix(urlPath, static.prefix+"/"):
+		urlPath = strings.TrimPrefix(urlPath, static.prefix)
+	default:
+		return "", false
+	}
+
+	// Cleaning the rooted path resolves any "../" shenanigans before we let the path anywhere near the file system.
+	name := strings.TrimPrefix(path.Clean("/"+urlPath), "/")
+	if name == "" {
+		return ".", true
+	}
+	return name, true
+}
+
+// serveStaticFile writes the static file that matches the request if there is one. It returns false when none of
+// your static file systems have a match, so the caller can carry on w/ the normal not-found handling.
+func (gw *Gateway) serveStaticFile(w http.ResponseWriter, req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+
+	for _, static := range gw.staticFiles {
+		name, ok := static.fileName(req.URL.Path)
+		if !ok {
+			continue
+		}
+		info, err := fs.Stat(static.fsys, name)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			http.ServeFileFS(w, req, static.fsys, name)
+			return true
+		}
+
+		// We don't list directory contents, but we do serve their index.html the same way http.FileServer does. That
+		// includes redirecting "/admin" to "/admin/" so that relative links in the page resolve to the right place.
+		index := path.Join(name, "index.html")
+		if info, err = fs.Stat(static.fsys, index); err != nil || info.IsDir() {
+			continue
+		}
+		if !strings.HasSuffix(req.URL.Path, "/") {
+			redirectToDirectory(w, req)
+			return true
+		}
+		http.ServeFileFS(w, req, static.fsys, index)
+		return true
+	}
+	return false
+}
+
+// redirectToDirectory redirects the request to the same path w/ a trailing slash, keeping the query string.
+func redirectToDirectory(w http.ResponseWriter, req *http.Request) {
+	target := req.URL.Path + "/"
+	if req.URL.RawQuery != "" {
+		target += "?" + req.URL.RawQuery
+	}
+	http.Redirect(w, req, target, http.StatusMovedPermanently)
+}
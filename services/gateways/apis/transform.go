@@ -0,0 +1,170 @@
+package apis
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/bridgekit-io/frodo/codec"
+)
+
+// ResponseTransformFunc reshapes a successful response value right before we encode it. Return the value that you
+// want to encode, which can be the original value, a modified copy, or something else entirely.
+type ResponseTransformFunc func(serviceResponse any) any
+
+// transformEncoder runs your WithResponseTransform() functions on each value before encoding it.
+type transformEncoder struct {
+	codec.Encoder
+	transforms []ResponseTransformFunc
+}
+
+func (e transformEncoder) Encode(writer io.Writer, value any) error {
+	for _, transform := range e.transforms {
+		value = transform(value)
+	}
+	return e.Encoder.Encode(writer, value)
+}
+
+// OmitNulls is a response transform that removes every field whose value would have been encoded as a JSON null,
+// no matter how deeply it's nested. It works off of the JSON representation of the response, so the value that
+// we end up encoding is a generic map rather than your response struct.
+//
+//	apis.NewGateway(":9000", apis.WithResponseTransform(apis.OmitNulls))
+//
+// Null elements of arrays are left alone since removing them would shift the positions of everything after them.
+// If you also use EmptyCollections, make sure that it runs first, or there won't be any nil slices/maps left to fix.
+func OmitNulls(serviceResponse any) any {
+	value, ok := toJSONValue(serviceResponse)
+	if !ok {
+		// Let the encoder report this error when it tries to encode the original value.
+		return serviceResponse
+	}
+	return omitNulls(value)
+}
+
+// toJSONValue round trips the value through JSON, so you get the generic maps/slices/etc. that represent it. We
+// use json.Number so that we don't lose precision on large integers by converting them to float64.
+func toJSONValue(value any) (any, bool) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+
+	var result any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&result); err != nil {
+		return nil, false
+	}
+	return result, true
+}
+
+func omitNulls(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, elem := range v {
+			if elem == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = omitNulls(elem)
+		}
+		return v
+	case []any:
+		for i, elem := range v {
+			v[i] = omitNulls(elem)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// EmptyCollections is a response transform that encodes nil slices and maps as [] and {} rather than null, so
+// that clients can always iterate over collections without checking for null first.
+//
+//	apis.NewGateway(":9000", apis.WithResponseTransform(apis.EmptyCollections))
+//
+// We build a copy of the response rather than filling in the empty collections in place, since your handler might
+// have returned a value that something else is still using (e.g. a cached record). Values that marshal themselves
+// (e.g. anything implementing json.Marshaler) are left as-is.
+func EmptyCollections(serviceResponse any) any {
+	if serviceResponse == nil {
+		return nil
+	}
+	return emptyCollections(reflect.ValueOf(serviceResponse)).Interface()
+}
+
+var jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+
+func emptyCollections(value reflect.Value) reflect.Value {
+	if value.Type().Implements(jsonMarshalerType) {
+		return value
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() || value.Type().Elem().Implements(jsonMarshalerType) {
+			return value
+		}
+		result := reflect.New(value.Type().Elem())
+		result.Elem().Set(emptyCollections(value.Elem()))
+		return result
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		result := reflect.New(value.Type()).Elem()
+		result.Set(emptyCollections(value.Elem()))
+		return result
+
+	case reflect.Struct:
+		// Copying the whole struct first carries over the unexported fields, which we can't set individually.
+		result := reflect.New(value.Type()).Elem()
+		result.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if result.Field(i).CanSet() {
+				result.Field(i).Set(emptyCollections(value.Field(i)))
+			}
+		}
+		return result
+
+	case reflect.Slice:
+		// Byte slices are encoded as base64 strings rather than arrays, so they're not collections as far as JSON
+		// is concerned. Leave them alone.
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return value
+		}
+		if value.IsNil() {
+			return reflect.MakeSlice(value.Type(), 0, 0)
+		}
+		result := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			result.Index(i).Set(emptyCollections(value.Index(i)))
+		}
+		return result
+
+	case reflect.Array:
+		result := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			result.Index(i).Set(emptyCollections(value.Index(i)))
+		}
+		return result
+
+	case reflect.Map:
+		if value.IsNil() {
+			return reflect.MakeMap(value.Type())
+		}
+		result := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			result.SetMapIndex(iter.Key(), emptyCollections(iter.Value()))
+		}
+		return result
+
+	default:
+		return value
+	}
+}
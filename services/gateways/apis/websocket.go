@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net"
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/bridgekit-io/frodo/fail"
 	"github.com/bridgekit-io/frodo/internal/quiet"
@@ -34,9 +37,44 @@ func WalkWebsockets(ctx context.Context, socketPrefix string, handler func(ctx c
 	if socketPrefix == "" {
 		return fail.BadRequest("walking websockets requires a non-empty prefix")
 	}
+	return walkWebsockets(ctx, registry, socketPrefix, nil, handler)
+}
+
+// WalkWebsocketsFunc invokes your callback/handler on all registered websockets that your filter accepts. This is
+// the flexible version of WalkWebsockets() for when a connection ID prefix doesn't describe the sockets that you're
+// after. For instance, you can push a promotion to every user on the "pro" plan using the Metadata that you
+// attached to each socket when you connected it:
+//
+//	apis.WalkWebsocketsFunc(ctx,
+//		func(id string, socket *apis.Websocket) bool {
+//			return socket.Options.Metadata["plan"] == "pro"
+//		},
+//		func(ctx context.Context, socket *apis.Websocket) error {
+//			return socket.WriteJSON(promotion)
+//		},
+//	)
+//
+// The filter runs on every registered socket, one at a time, so keep it fast. Just like WalkWebsockets(), the
+// callbacks are executed in separate goroutines, so expect these to run in parallel for all matching sockets.
+func WalkWebsocketsFunc(ctx context.Context, filter func(id string, websocket *Websocket) bool, handler func(ctx context.Context, websocket *Websocket) error) error {
+	registry, ok := ctx.Value(websocketRegistryContextKey{}).(*websocketRegistry)
+	if !ok {
+		return fail.Unexpected("error connecting websocket: missing websocket registry")
+	}
+	if filter == nil {
+		return fail.BadRequest("walking websockets requires a filter")
+	}
+	return walkWebsockets(ctx, registry, "", filter, handler)
+}
 
+// walkWebsockets runs the handler in its own goroutine for every socket that matches the prefix and filter (when
+// there is one), waiting for them all to finish.
+func walkWebsockets(ctx context.Context, registry *websocketRegistry, socketPrefix string, filter func(id string, websocket *Websocket) bool, handler func(ctx context.Context, websocket *Websocket) error) error {
 	errs, _ := fail.NewGroup(ctx)
 	registry.walk(socketPrefix, func(websocket *Websocket) {
+		if filter != nil && !filter(websocket.ID, websocket) {
+			return
+		}
 		errs.Go(func() error {
 			// Ignore errors where the socket was already closed. Yes, the startListening() loop should auto-close the socket once the
 			// conn is bad, but it's possible that this handler fires before it can fully break out of the loop, so let's give it a hand.
@@ -50,7 +88,6 @@ func WalkWebsockets(ctx context.Context, socketPrefix string, handler func(ctx c
 		})
 	})
 	return errs.Wait()
-
 }
 
 // ConnectWebsocket hijacks the HTTP connection and makes it so that the user can have duplex communication with
@@ -81,6 +118,11 @@ func ConnectWebsocket(ctx context.Context, connectionID string, opts WebsocketOp
 		return nil, fmt.Errorf("error connecting websocket: %w", err)
 	}
 
+	// The hijacked connection still has whatever read/write deadlines the HTTP server's timeouts
+	// set for the upgrade request. Websockets are long-lived, so clear them. We handle dead peers
+	// using pings instead.
+	_ = conn.SetDeadline(time.Time{})
+
 	// Message handlers should be able to walk the websocket registry.
 	newMessageContext := func() context.Context {
 		return context.WithValue(context.Background(), websocketRegistryContextKey{}, sockets)
@@ -90,7 +132,7 @@ func ConnectWebsocket(ctx context.Context, connectionID string, opts WebsocketOp
 	socket := Websocket{Conn: conn, ID: connectionID, Options: opts.applyDefaults(), newMessageContext: newMessageContext}
 	customOnClose := socket.Options.OnClose
 	socket.Options.OnClose = func() {
-		sockets.remove(connectionID)
+		sockets.remove(connectionID, &socket)
 		customOnClose()
 	}
 
@@ -103,6 +145,7 @@ func ConnectWebsocket(ctx context.Context, connectionID string, opts WebsocketOp
 	}
 
 	socket.startListening()
+	socket.startPinging()
 	return &socket, nil
 }
 
@@ -118,8 +161,24 @@ type WebsocketOptions struct {
 	OnReadContinuation func(ctx context.Context, socket *Websocket, data []byte)
 	// OnClose provides a custom handler that fires when this websocket is closed for any reason.
 	OnClose func()
+	// MaxMessageSize is the largest message (in bytes) that we'll accept from the client. If the client sends
+	// something bigger, we'll close the socket w/ a 1009 "message too big" status. The default is 1MB. Use a
+	// negative value to allow messages of any size.
+	MaxMessageSize int64
+	// PingInterval is how often we send a ping frame to the client to make sure that it's still there. The default
+	// is 30 seconds. Use a negative value to disable pings entirely.
+	PingInterval time.Duration
+	// PongTimeout is how long we'll wait for the client to respond to a ping before assuming that the connection
+	// is dead and closing the socket. The default is 10 seconds.
+	PongTimeout time.Duration
+	// Metadata lets you attach your own info to the socket when you connect it (e.g. the user's ID or plan), so you
+	// can decide which sockets to push to when you walk them later (see WalkWebsocketsFunc). Walks from different
+	// goroutines read it at the same time, so don't modify it once the socket is connected.
+	Metadata map[string]any
 }
 
+// applyDefaults fills in any callbacks/settings you did not supply. Handlers default to no-ops, messages
+// are capped at 1MB, and we ping the client every 30 seconds, giving it 10 seconds to pong back.
 func (opts WebsocketOptions) applyDefaults() WebsocketOptions {
 	if opts.OnReadText == nil {
 		opts.OnReadText = func(ctx context.Context, socket *Websocket, data []byte) {}
@@ -133,6 +192,15 @@ func (opts WebsocketOptions) applyDefaults() WebsocketOptions {
 	if opts.OnClose == nil {
 		opts.OnClose = func() {}
 	}
+	if opts.MaxMessageSize == 0 {
+		opts.MaxMessageSize = 1024 * 1024
+	}
+	if opts.PingInterval == 0 {
+		opts.PingInterval = 30 * time.Second
+	}
+	if opts.PongTimeout <= 0 {
+		opts.PongTimeout = 10 * time.Second
+	}
 	return opts
 }
 
@@ -148,11 +216,18 @@ type Websocket struct {
 	Options WebsocketOptions
 	// newMessageContext is used internally to create a context intended to be used for the handling of a single message written to the socket.
 	newMessageContext func() context.Context
+	// writeMutex makes sure that frames written by different goroutines (your handlers, pings, pongs) don't interleave.
+	writeMutex sync.Mutex
+	// lastPong is the unix nano timestamp of the most recent pong frame we received from the client.
+	lastPong atomic.Int64
+	// closed is flipped exactly once by Close(). The reader, pinger, and your handlers can all decide to close the
+	// socket at the same time, so this makes sure that only one of them actually does it (and fires OnClose).
+	closed atomic.Bool
 }
 
 // Active returns true if the underlying connection has NOT been closed yet.
 func (socket *Websocket) Active() bool {
-	return socket.Conn != nil
+	return socket.Conn != nil && !socket.closed.Load()
 }
 
 // WriteText writes a frame of binary data to the client on the other end of the socket.
@@ -161,7 +236,7 @@ func (socket *Websocket) Write(data []byte) error {
 		return fail.Unavailable("socket closed")
 	}
 
-	if err := wsutil.WriteServerBinary(socket.Conn, data); err != nil {
+	if err := socket.writeMessage(ws.OpBinary, data); err != nil {
 		quiet.Close(socket)
 		return fmt.Errorf("error writing to websocket: %s: %w", socket.ID, err)
 	}
@@ -174,7 +249,7 @@ func (socket *Websocket) WriteClose(data []byte) error {
 		return fail.Unavailable("socket closed")
 	}
 
-	if err := wsutil.WriteServerMessage(socket.Conn, ws.OpClose, data); err != nil {
+	if err := socket.writeMessage(ws.OpClose, data); err != nil {
 		quiet.Close(socket)
 		return fmt.Errorf("error writing to websocket: %s: %w", socket.ID, err)
 	}
@@ -187,7 +262,7 @@ func (socket *Websocket) WriteText(data string) error {
 		return fail.Unavailable("socket closed")
 	}
 
-	if err := wsutil.WriteServerText(socket.Conn, []byte(data)); err != nil {
+	if err := socket.writeMessage(ws.OpText, []byte(data)); err != nil {
 		quiet.Close(socket)
 		return fmt.Errorf("error writing to websocket: %s: %w", socket.ID, err)
 	}
@@ -204,7 +279,7 @@ func (socket *Websocket) WriteJSON(value any) error {
 	if err != nil {
 		return fmt.Errorf("error writing to websocket: %w", err)
 	}
-	if err := wsutil.WriteServerText(socket.Conn, data); err != nil {
+	if err := socket.writeMessage(ws.OpText, data); err != nil {
 		quiet.Close(socket)
 		return fmt.Errorf("error writing to websocket: %s: %w", socket.ID, err)
 	}
@@ -213,7 +288,7 @@ func (socket *Websocket) WriteJSON(value any) error {
 
 // Close kills the current connection. This will also trigger your OnClose handler.
 func (socket *Websocket) Close() error {
-	if !socket.Active() {
+	if socket.Conn == nil || !socket.closed.CompareAndSwap(false, true) {
 		return nil
 	}
 
@@ -222,7 +297,6 @@ func (socket *Websocket) Close() error {
 	}
 
 	quiet.Close(socket.Conn)
-	socket.Conn = nil // make sure this socket reference is not seen as 'Active' anymore.
 	socket.Options.OnClose()
 	return nil
 }
@@ -240,33 +314,158 @@ func (socket *Websocket) startListening() {
 		logger := socket.Options.Logger
 
 		for socket.Active() {
-			data, op, err := wsutil.ReadClientData(socket.Conn)
+			data, op, err := socket.readClientData()
+			if errors.Is(err, wsutil.ErrFrameTooLarge) {
+				if logger != nil {
+					logger.Debug("client message too large, closing connection",
+						"max_message_size", socket.Options.MaxMessageSize,
+						"websocket_id", socket.ID,
+					)
+				}
+				_ = socket.WriteClose(ws.NewCloseFrameBody(ws.StatusMessageTooBig, "message too big"))
+				break
+			}
 			if err != nil {
-				logger.Debug("error reading client data, closing connection",
-					"error", err,
-					"websocket_id", socket.ID,
-				)
+				if logger != nil {
+					logger.Debug("error reading client data, closing connection",
+						"error", err,
+						"websocket_id", socket.ID,
+					)
+				}
 				break
 			}
 
 			switch op {
-			case ws.OpClose:
-				break
 			case ws.OpText:
 				socket.Options.OnReadText(socket.newMessageContext(), socket, data)
 			case ws.OpBinary:
 				socket.Options.OnReadBinary(socket.newMessageContext(), socket, data)
 			case ws.OpContinuation:
 				socket.Options.OnReadContinuation(socket.newMessageContext(), socket, data)
-			case ws.OpPing:
-				_ = wsutil.WriteServerMessage(socket.Conn, ws.OpPong, data)
-			case ws.OpPong:
-				// Ignore... clients shouldn't be sending pongs, anyway.
 			}
 		}
 	}()
 }
 
+// readClientData reads the next text/binary message from the client. It's basically wsutil.ReadClientData(), but
+// it enforces the MaxMessageSize option across all of the message's frames and records when we receive pongs so
+// that startPinging() knows the client is still alive. Pings/closes are handled automatically by the control handler.
+func (socket *Websocket) readClientData() ([]byte, ws.OpCode, error) {
+	conn := socket.Conn
+	if !socket.Active() {
+		return nil, 0, net.ErrClosed
+	}
+
+	controlHandler := wsutil.ControlFrameHandler(websocketWriter{socket: socket}, ws.StateServerSide)
+	handleControl := func(hdr ws.Header, r io.Reader) error {
+		if hdr.OpCode == ws.OpPong {
+			socket.lastPong.Store(time.Now().UnixNano())
+		}
+		return controlHandler(hdr, r)
+	}
+
+	reader := wsutil.Reader{
+		Source:         conn,
+		State:          ws.StateServerSide,
+		CheckUTF8:      true,
+		OnIntermediate: handleControl,
+	}
+	maxSize := socket.Options.MaxMessageSize
+	if maxSize > 0 {
+		reader.MaxFrameSize = maxSize
+	}
+
+	for {
+		hdr, err := reader.NextFrame()
+		if err != nil {
+			return nil, 0, err
+		}
+		if hdr.OpCode.IsControl() {
+			if err = handleControl(hdr, &reader); err != nil {
+				return nil, 0, err
+			}
+			continue
+		}
+		if maxSize <= 0 {
+			data, err := io.ReadAll(&reader)
+			return data, hdr.OpCode, err
+		}
+
+		// A message can span multiple frames that are each under the limit, so cap the whole thing. We read
+		// one extra byte so that we can tell the difference between "exactly the max" and "too big".
+		data, err := io.ReadAll(io.LimitReader(&reader, maxSize+1))
+		if int64(len(data)) > maxSize {
+			return nil, hdr.OpCode, wsutil.ErrFrameTooLarge
+		}
+		return data, hdr.OpCode, err
+	}
+}
+
+// startPinging fires off a separate goroutine that periodically pings the client. If the client doesn't pong
+// back within the PongTimeout, we assume that the connection is dead and close the socket; that way dead connections
+// don't pile up in the websocket registry. This exits automatically when the socket is closed.
+func (socket *Websocket) startPinging() {
+	interval := socket.Options.PingInterval
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if !socket.Active() {
+				return
+			}
+
+			pingSent := time.Now().UnixNano()
+			if err := socket.writeMessage(ws.OpPing, nil); err != nil {
+				quiet.Close(socket)
+				return
+			}
+
+			time.AfterFunc(socket.Options.PongTimeout, func() {
+				if socket.lastPong.Load() >= pingSent {
+					return
+				}
+				if socket.Options.Logger != nil {
+					socket.Options.Logger.Debug("websocket pong timeout, closing connection", "websocket_id", socket.ID)
+				}
+				quiet.Close(socket)
+			})
+		}
+	}()
+}
+
+// writeMessage writes a single message frame to the client, making sure that no other goroutine is writing
+// to the connection at the same time.
+func (socket *Websocket) writeMessage(op ws.OpCode, data []byte) error {
+	socket.writeMutex.Lock()
+	defer socket.writeMutex.Unlock()
+
+	if !socket.Active() {
+		return net.ErrClosed
+	}
+	return wsutil.WriteServerMessage(socket.Conn, op, data)
+}
+
+// websocketWriter lets the gobwas control frame handler write pongs/closes to the client while still
+// respecting the socket's write lock.
+type websocketWriter struct {
+	socket *Websocket
+}
+
+func (w websocketWriter) Write(data []byte) (int, error) {
+	w.socket.writeMutex.Lock()
+	defer w.socket.writeMutex.Unlock()
+
+	if !w.socket.Active() {
+		return 0, net.ErrClosed
+	}
+	return w.socket.Conn.Write(data)
+}
+
 type websocketRegistryContextKey struct{}
 
 // websocketRegistryMiddleware ensures that WalkWebsockets and ConnectWebsocket have access to the gateway's
@@ -281,14 +480,16 @@ func websocketRegistryMiddleware(websockets *websocketRegistry) services.Middlew
 
 func newWebsocketRegistry() *websocketRegistry {
 	return &websocketRegistry{
-		mutex:   &sync.Mutex{},
+		mutex:   &sync.RWMutex{},
 		sockets: radix.New[*Websocket](),
 	}
 }
 
-// websocketRegistry contains a mapping of all currently-open connections and their IDs.
+// websocketRegistry contains a mapping of all currently-open connections and their IDs. The radix tree
+// is not safe for concurrent use on its own, so every operation goes through the registry's lock. Sockets
+// connect and disconnect while other goroutines are walking/broadcasting, so this is not optional.
 type websocketRegistry struct {
-	mutex   *sync.Mutex
+	mutex   *sync.RWMutex
 	sockets radix.Tree[*Websocket]
 }
 
@@ -299,21 +500,35 @@ func (registry *websocketRegistry) add(socketID string, socket *Websocket) (*Web
 	return registry.sockets.Insert(socketID, socket)
 }
 
-func (registry *websocketRegistry) remove(socketID string) {
+// remove drops the socket from the registry, but only if it's still the one registered under that id. When
+// a new connection replaces an old one w/ the same id, closing the old one shouldn't unregister the new one.
+func (registry *websocketRegistry) remove(socketID string, socket *Websocket) {
 	registry.mutex.Lock()
 	defer registry.mutex.Unlock()
 
-	registry.sockets.Delete(socketID)
+	if current, ok := registry.sockets.Get(socketID); ok && current == socket {
+		registry.sockets.Delete(socketID)
+	}
 }
 
-// walk invokes the visitorFunc on all websockets whose ids start w/ the given prefix. Beware! This locks
-// the entire registry while doing this, so make sure that your visitor is not doing anything intensive.
+// walk invokes the visitorFunc on all websockets whose ids start w/ the given prefix. We only hold the lock
+// long enough to grab the matching sockets, so your visitor is free to do slow things like writing to the
+// socket or even closing it (which removes it from the registry) without blocking other connections.
 func (registry *websocketRegistry) walk(socketPrefix string, visitorFunc func(*Websocket)) {
-	registry.mutex.Lock()
-	defer registry.mutex.Unlock()
+	for _, socket := range registry.match(socketPrefix) {
+		visitorFunc(socket)
+	}
+}
 
+// match returns a snapshot of all websockets whose ids start w/ the given prefix.
+func (registry *websocketRegistry) match(socketPrefix string) []*Websocket {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	var matches []*Websocket
 	registry.sockets.WalkPrefix(socketPrefix, func(_ string, socket *Websocket) bool {
-		visitorFunc(socket)
+		matches = append(matches, socket)
 		return false
 	})
+	return matches
 }
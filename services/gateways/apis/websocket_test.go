@@ -0,0 +1,317 @@
+//go:build unit
+
+package apis
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestWebsocketRegistrySuite(t *testing.T) {
+	suite.Run(t, new(WebsocketRegistrySuite))
+}
+
+type WebsocketRegistrySuite struct {
+	suite.Suite
+}
+
+func (suite *WebsocketRegistrySuite) ids(registry *websocketRegistry, prefix string) []string {
+	var ids []string
+	registry.walk(prefix, func(socket *Websocket) {
+		ids = append(ids, socket.ID)
+	})
+	return ids
+}
+
+func (suite *WebsocketRegistrySuite) TestAddRemoveWalk() {
+	registry := newWebsocketRegistry()
+	a := &Websocket{ID: "user.1.a"}
+	b := &Websocket{ID: "user.1.b"}
+	c := &Websocket{ID: "user.2.a"}
+
+	registry.add(a.ID, a)
+	registry.add(b.ID, b)
+	registry.add(c.ID, c)
+	suite.ElementsMatch([]string{"user.1.a", "user.1.b"}, suite.ids(registry, "user.1."))
+	suite.ElementsMatch([]string{"user.1.a", "user.1.b", "user.2.a"}, suite.ids(registry, "user."))
+	suite.Empty(suite.ids(registry, "user.3."))
+
+	registry.remove(b.ID, b)
+	suite.ElementsMatch([]string{"user.1.a"}, suite.ids(registry, "user.1."))
+}
+
+// Closing a socket that has already been replaced by a newer connection w/ the same id should not
+// remove the newer connection from the registry.
+func (suite *WebsocketRegistrySuite) TestRemove_replaced() {
+	registry := newWebsocketRegistry()
+	oldSocket := &Websocket{ID: "user.1.a"}
+	newSocket := &Websocket{ID: "user.1.a"}
+
+	registry.add(oldSocket.ID, oldSocket)
+	replaced, ok := registry.add(newSocket.ID, newSocket)
+	suite.True(ok)
+	suite.Same(oldSocket, replaced)
+
+	registry.remove(oldSocket.ID, oldSocket)
+	registry.walk("user.1.", func(socket *Websocket) {
+		suite.Same(newSocket, socket)
+	})
+	suite.Len(suite.ids(registry, "user.1."), 1)
+
+	registry.remove(newSocket.ID, newSocket)
+	suite.Empty(suite.ids(registry, "user.1."))
+}
+
+// Visitors run outside of the lock, so they must be able to modify the registry themselves (e.g. a
+// broadcast that fails to write and closes the socket) without deadlocking.
+func (suite *WebsocketRegistrySuite) TestWalk_visitorModifiesRegistry() {
+	registry := newWebsocketRegistry()
+	for i := 0; i < 10; i++ {
+		socket := &Websocket{ID: "user." + strconv.Itoa(i)}
+		registry.add(socket.ID, socket)
+	}
+
+	registry.walk("user.", func(socket *Websocket) {
+		registry.remove(socket.ID, socket)
+	})
+	suite.Empty(suite.ids(registry, "user."))
+}
+
+// Hammers the registry w/ connects, disconnects, and walks all at once. Run w/ -race to make sure that
+// the underlying tree is never touched without the lock.
+func (suite *WebsocketRegistrySuite) TestConcurrentAccess() {
+	registry := newWebsocketRegistry()
+	wg := sync.WaitGroup{}
+
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(2)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				socket := &Websocket{ID: "user." + strconv.Itoa(worker) + "." + strconv.Itoa(i)}
+				registry.add(socket.ID, socket)
+				if i%2 == 0 {
+					registry.remove(socket.ID, socket)
+				}
+			}
+		}(worker)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				registry.walk("user.", func(socket *Websocket) {
+					_ = socket.ID
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	suite.Len(suite.ids(registry, "user."), 8*100)
+}
+
+func (suite *WebsocketRegistrySuite) TestWalkWebsocketsFunc() {
+	registry := newWebsocketRegistry()
+	free := &Websocket{ID: "user.1.a", Options: WebsocketOptions{Metadata: map[string]any{"plan": "free"}}}
+	pro1 := &Websocket{ID: "user.2.a", Options: WebsocketOptions{Metadata: map[string]any{"plan": "pro"}}}
+	pro2 := &Websocket{ID: "user.3.a", Options: WebsocketOptions{Metadata: map[string]any{"plan": "pro"}}}
+	none := &Websocket{ID: "user.4.a"}
+	for _, socket := range []*Websocket{free, pro1, pro2, none} {
+		registry.add(socket.ID, socket)
+	}
+	ctx := context.WithValue(context.Background(), websocketRegistryContextKey{}, registry)
+
+	mutex := sync.Mutex{}
+	var visited []string
+	err := WalkWebsocketsFunc(ctx,
+		func(id string, socket *Websocket) bool {
+			return socket.Options.Metadata["plan"] == "pro"
+		},
+		func(ctx context.Context, socket *Websocket) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			visited = append(visited, socket.ID)
+			return nil
+		},
+	)
+	suite.Require().NoError(err)
+	suite.ElementsMatch([]string{"user.2.a", "user.3.a"}, visited)
+}
+
+func (suite *WebsocketRegistrySuite) TestWalkWebsocketsFunc_errors() {
+	noop := func(ctx context.Context, socket *Websocket) error { return nil }
+	all := func(id string, socket *Websocket) bool { return true }
+
+	suite.Error(WalkWebsocketsFunc(context.Background(), all, noop), "Should fail without a registry")
+
+	ctx := context.WithValue(context.Background(), websocketRegistryContextKey{}, newWebsocketRegistry())
+	suite.Error(WalkWebsocketsFunc(ctx, nil, noop), "Should fail without a filter")
+	suite.NoError(WalkWebsocketsFunc(ctx, all, noop), "Should be fine with no sockets")
+}
+
+func TestWebsocketSuite(t *testing.T) {
+	suite.Run(t, new(WebsocketSuite))
+}
+
+type WebsocketSuite struct {
+	suite.Suite
+}
+
+// connect registers an endpoint that upgrades to a websocket w/ the given options, dials it, and returns both
+// ends of the connection: the client's raw conn and the server's socket.
+func (suite *WebsocketSuite) connect(opts WebsocketOptions) (net.Conn, *Websocket) {
+	sockets := make(chan *Websocket, 1)
+	gw := NewGateway(":0")
+	gw.Register(services.Endpoint{
+		ServiceName: "ChatService",
+		Name:        "Connect",
+		NewInput:    func() services.StructPointer { return &struct{}{} },
+		Handler: gw.Middleware().Then(func(ctx context.Context, req any) (any, error) {
+			socket, err := ConnectWebsocket(ctx, "user.1", opts)
+			if err != nil {
+				return nil, err
+			}
+			sockets <- socket
+			return nil, nil
+		}),
+	}, services.EndpointRoute{
+		GatewayType: services.GatewayTypeAPI,
+		Method:      http.MethodGet,
+		Path:        "/connect",
+		Status:      http.StatusOK,
+	})
+
+	server := httptest.NewServer(gw.router)
+	suite.T().Cleanup(server.Close)
+
+	conn, _, _, err := ws.Dial(context.Background(), "ws"+strings.TrimPrefix(server.URL, "http")+"/connect")
+	suite.Require().NoError(err)
+	suite.T().Cleanup(func() { _ = conn.Close() })
+
+	select {
+	case socket := <-sockets:
+		return conn, socket
+	case <-time.After(time.Second):
+		suite.FailNow("websocket never connected")
+		return nil, nil
+	}
+}
+
+// closed returns a channel that is closed once the server's socket fires its OnClose.
+func (suite *WebsocketSuite) closed(opts *WebsocketOptions) <-chan struct{} {
+	done := make(chan struct{})
+	once := sync.Once{}
+	opts.OnClose = func() { once.Do(func() { close(done) }) }
+	return done
+}
+
+func (suite *WebsocketSuite) TestMaxMessageSize() {
+	opts := WebsocketOptions{MaxMessageSize: 10, PingInterval: -1}
+	done := suite.closed(&opts)
+	conn, _ := suite.connect(opts)
+
+	suite.Require().NoError(wsutil.WriteClientText(conn, []byte(strings.Repeat("x", 100))))
+
+	// Read the raw frame; the server hangs up right after, so letting wsutil reply to the close would fail.
+	frame, err := ws.ReadFrame(conn)
+	suite.Require().NoError(err)
+	suite.Require().Equal(ws.OpClose, frame.Header.OpCode)
+	code, _ := ws.ParseCloseFrameData(frame.Payload)
+	suite.Equal(ws.StatusMessageTooBig, code)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		suite.Fail("Socket should close after an oversized message")
+	}
+}
+
+func (suite *WebsocketSuite) TestMaxMessageSize_underLimit() {
+	received := make(chan string, 1)
+	opts := WebsocketOptions{
+		MaxMessageSize: 10,
+		PingInterval:   -1,
+		OnReadText: func(ctx context.Context, socket *Websocket, data []byte) {
+			received <- string(data)
+		},
+	}
+	conn, _ := suite.connect(opts)
+
+	suite.Require().NoError(wsutil.WriteClientText(conn, []byte("0123456789")))
+	select {
+	case data := <-received:
+		suite.Equal("0123456789", data)
+	case <-time.After(time.Second):
+		suite.Fail("Messages at the limit should be delivered")
+	}
+}
+
+func (suite *WebsocketSuite) TestPongTimeout() {
+	opts := WebsocketOptions{PingInterval: 20 * time.Millisecond, PongTimeout: 20 * time.Millisecond}
+	done := suite.closed(&opts)
+	suite.connect(opts)
+
+	// The client never reads, so it never answers our pings.
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		suite.Fail("Socket should close when the client never pongs")
+	}
+}
+
+func (suite *WebsocketSuite) TestPongTimeout_alive() {
+	opts := WebsocketOptions{PingInterval: 20 * time.Millisecond, PongTimeout: 20 * time.Millisecond}
+	done := suite.closed(&opts)
+	conn, socket := suite.connect(opts)
+
+	// Reading on the client answers every ping w/ a pong for us.
+	go func() {
+		for {
+			if _, err := wsutil.ReadServerText(conn); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+		suite.Fail("Socket should stay open while the client pongs")
+	case <-time.After(200 * time.Millisecond):
+	}
+	suite.True(socket.Active())
+}
+
+func (suite *WebsocketSuite) TestConcurrentWrites() {
+	conn, socket := suite.connect(WebsocketOptions{PingInterval: 5 * time.Millisecond})
+
+	const writers, writes = 8, 50
+	wg := sync.WaitGroup{}
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < writes; j++ {
+				suite.NoError(socket.WriteText("Hello, Dude"))
+			}
+		}()
+	}
+
+	// Interleaved frames would corrupt the stream, so the client would fail to read a valid message.
+	for i := 0; i < writers*writes; i++ {
+		data, err := wsutil.ReadServerText(conn)
+		suite.Require().NoError(err)
+		suite.Require().Equal("Hello, Dude", string(data))
+	}
+	wg.Wait()
+}
@@ -0,0 +1,43 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bridgekit-io/frodo/metadata"
+)
+
+// ErrMaxCallDepth is the error your error listener receives when the gateway refuses to publish an event
+// because the chain of events has already gone WithMaxCallDepth() hops deep.
+var ErrMaxCallDepth = fmt.Errorf("maximum event call depth exceeded")
+
+// WithMaxCallDepth stops chains of events from cascading more than 'depth' hops from the call that started them.
+// Every time a call publishes an event, its handlers run one hop deeper than that call (see metadata.CallDepth).
+// When a call that is already 'depth' hops deep finishes, the gateway refuses to publish its event, and your
+// WithErrorListener() callback receives an error wrapping ErrMaxCallDepth instead.
+//
+//	events.NewGateway(
+//		events.WithBroker(broker),
+//		events.WithMaxCallDepth(10),
+//	)
+//
+// This is your safety net for accidental cycles (e.g. "ON FooService.A" triggers B and "ON FooService.B" triggers A),
+// which would otherwise flood your broker with events until someone notices. Pick a depth comfortably larger than
+// your longest legitimate chain. When you use WithSynchronousChain(), the error comes back from the call that
+// tried to publish instead, so it also makes its way back to your original call.
+//
+// A depth of zero or less means there's no limit, which is the default.
+func WithMaxCallDepth(depth int) GatewayOption {
+	return func(gw *Gateway) {
+		gw.maxCallDepth = depth
+	}
+}
+
+// checkCallDepth fails when publishing an event for the current call would run its handlers deeper than the
+// maximum call depth.
+func checkCallDepth(ctx context.Context, maxCallDepth int) error {
+	if maxCallDepth <= 0 || metadata.CallDepth(ctx) < maxCallDepth {
+		return nil
+	}
+	return fmt.Errorf("event publish error: %s: %w (%d)", metadata.Route(ctx).QualifiedName(), ErrMaxCallDepth, maxCallDepth)
+}
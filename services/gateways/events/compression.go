@@ -0,0 +1,65 @@
+package events
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+
+	"github.com/bridgekit-io/frodo/codec"
+	"github.com/bridgekit-io/frodo/internal/quiet"
+)
+
+// compressionEncoder wraps the encoder you configured for your events (JSON by default), gzipping the encoded bytes
+// when there are at least 'minSize' of them. Smaller events are published as-is since compressing them usually
+// costs more than it saves.
+//
+// We don't need a flag in the message envelope to tell subscribers that the event is compressed. The envelope is
+// part of what we compress, so subscribers couldn't read the flag anyway. Instead, they look for the header bytes
+// that every gzip stream starts with (see decompressPayload()).
+type compressionEncoder struct {
+	codec.Encoder
+	minSize int
+}
+
+// Encode encodes the value using the underlying encoder, and then gzips the result if it's big enough.
+func (e compressionEncoder) Encode(writer io.Writer, value any) error {
+	buf := &bytes.Buffer{}
+	if err := e.Encoder.Encode(buf, value); err != nil {
+		return err
+	}
+	if buf.Len() < e.minSize {
+		_, err := writer.Write(buf.Bytes())
+		return err
+	}
+
+	gzipWriter := gzip.NewWriter(writer)
+	if _, err := gzipWriter.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// compressEvents wraps the encoder so that it gzips event payloads of at least 'minSize' bytes. When compression
+// is disabled, you get back the original encoder.
+func compressEvents(encoder codec.Encoder, enabled bool, minSize int) codec.Encoder {
+	if !enabled {
+		return encoder
+	}
+	return compressionEncoder{Encoder: encoder, minSize: minSize}
+}
+
+// decompressPayload un-gzips the event payload if a producer compressed it (see WithCompression()). We always check,
+// even when this gateway doesn't compress its own events, so that you can roll out compression to your producers
+// and consumers in any order. Payloads that don't start w/ the gzip header are returned as-is.
+func decompressPayload(payload []byte) ([]byte, error) {
+	if len(payload) < 2 || payload[0] != 0x1f || payload[1] != 0x8b {
+		return payload, nil
+	}
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	defer quiet.Close(gzipReader)
+	return io.ReadAll(gzipReader)
+}
@@ -7,6 +7,8 @@ import (
 	"log"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/bridgekit-io/frodo/codec"
 	"github.com/bridgekit-io/frodo/eventsource"
@@ -25,13 +27,15 @@ func NewGateway(options ...GatewayOption) *Gateway {
 	jsonEncoder := codec.JSONEncoder{}
 	jsonDecoder := codec.JSONDecoder{Loose: true}
 	gw := Gateway{
-		encoder:        jsonEncoder,
-		decoder:        jsonDecoder,
-		valueEncoder:   jsonEncoder,
-		valueDecoder:   jsonDecoder,
-		broker:         local.Broker(),
-		listening:      &sync.WaitGroup{},
-		activeRequests: &sync.WaitGroup{},
+		encoder:          jsonEncoder,
+		decoder:          jsonDecoder,
+		valueEncoder:     jsonEncoder,
+		valueDecoder:     jsonDecoder,
+		keyNaming:        defaultKeyNaming,
+		listening:        &sync.WaitGroup{},
+		started:          make(chan struct{}),
+		activeRequests:   &sync.WaitGroup{},
+		scheduleLocation: time.Local,
 		errorListener: func(route metadata.EndpointRoute, err error) {
 			log.Printf("[events error] [%s] %v\n", route.QualifiedName(), err)
 		},
@@ -39,21 +43,48 @@ func NewGateway(options ...GatewayOption) *Gateway {
 	for _, option := range options {
 		option(&gw)
 	}
+	if gw.broker == nil && gw.synchronous {
+		gw.broker = local.Broker(local.WithSynchronousDispatch())
+	}
+	if gw.broker == nil {
+		gw.broker = local.Broker()
+	}
+	gw.stopSchedules, gw.cancelSchedules = context.WithCancel(context.Background())
 	return &gw
 }
 
 // Gateway encapsulates the logic to invoke service operations based on event sourcing. You
 // should not create one of these yourself - use the NewGateway() constructor instead.
 type Gateway struct {
-	encoder        codec.Encoder
-	decoder        codec.Decoder
-	valueEncoder   codec.ValueEncoder
-	valueDecoder   codec.ValueDecoder
-	broker         eventsource.Broker
-	errorListener  ErrorListener
-	routes         []*route
-	listening      *sync.WaitGroup
-	activeRequests *sync.WaitGroup
+	encoder          codec.Encoder
+	decoder          codec.Decoder
+	valueEncoder     codec.ValueEncoder
+	valueDecoder     codec.ValueDecoder
+	broker           eventsource.Broker
+	brokerRouter     BrokerRouter
+	keyNaming        KeyNamingFunc
+	publishFilter    PublishFilter
+	errorListener    ErrorListener
+	routes           []*route
+	schedules        []*schedule
+	listening        *sync.WaitGroup
+	started          chan struct{}
+	activeRequests   *sync.WaitGroup
+	activeMutex      sync.Mutex
+	shuttingDown     bool
+	runningHandlers  atomic.Int64
+	subsMutex        sync.Mutex
+	scheduleLocation *time.Location
+	stopSchedules    context.Context
+	cancelSchedules  context.CancelFunc
+	synchronous      bool
+	errorMapper      fail.ErrorMapper
+	handlerTimeout   time.Duration
+	eventFilters     map[string][]EventFilter
+	shadowRoutes     map[string]bool
+	maxCallDepth     int
+	compress         bool
+	compressMinSize  int
 }
 
 // Type returns "EVENTS" to indicate the tagging value for this gateway.
@@ -67,6 +98,10 @@ func (gw *Gateway) Register(endpoint services.Endpoint, endpointRoute services.E
 	if endpointRoute.GatewayType != services.GatewayTypeEvents {
 		return
 	}
+	if endpointRoute.Method == "SCHEDULE" {
+		gw.registerSchedule(endpoint, endpointRoute)
+		return
+	}
 
 	// We use the fully qualified endpoint name as the group to create a "consumer group".
 	// This prevents more than one instance of the service method handling the same event.
@@ -122,47 +157,32 @@ func (gw *Gateway) Register(endpoint services.Endpoint, endpointRoute services.E
 	}
 
 	gw.routes = append(gw.routes, &route{
-		key:     endpointRoute.Path,
+		key:     resolveKey(gw.keyNaming, endpointRoute.Path),
 		group:   consumerGroup,
+		weight:  endpointRoute.Weight,
 		handler: gw.toStreamHandler(endpoint, endpointRoute),
 	})
 }
 
 func (gw *Gateway) toStreamHandler(endpoint services.Endpoint, route services.EndpointRoute) eventsource.EventHandlerFunc {
 	return func(ctx context.Context, msg *eventsource.EventMessage) error {
-		gw.activeRequests.Add(1)
+		// Returning an error lets brokers that support redelivery hand the event to another instance.
+		if !gw.beginRequest() {
+			return fail.Unavailable("event gateway is shutting down")
+		}
 		defer gw.activeRequests.Done()
 
-		event := message{}
 		serviceRequest := endpoint.NewInput()
-
-		// Take the broker's message and read in the service event 'message' data from it.
-		if err := gw.decoder.Decode(bytes.NewBuffer(msg.Payload), &event); err != nil {
-			gw.errorListener(event.Route, fmt.Errorf("event decode error: %w", err))
+		event, err := gw.decodeEvent(msg, serviceRequest)
+		if err != nil {
+			gw.errorListener(event.Route, err)
 			return nil
 		}
+		services.ApplyDefaults(serviceRequest)
 
-		// If this is a subscriber to a failure key, make sure we can bind the original error details to the
-		// request of the handler.
-		if event.ErrorHandler() {
-			// Allow you to define your error as simply the field 'Error string' on your request or an error struct
-			// that has either an Error or Message string attribute.
-			event.Values.Set("Error", event.ErrorMessage)
-			event.Values.Set("Error.Error", event.ErrorMessage)
-			event.Values.Set("Error.Message", event.ErrorMessage)
-
-			// We support binding the status code to any of the interfaces supported by the 'fail' package.
-			status := strconv.FormatInt(int64(event.ErrorStatus), 10)
-			event.Values.Set("Error.Code", status)
-			event.Values.Set("Error.Status", status)
-			event.Values.Set("Error.StatusCode", status)
-			event.Values.Set("Error.HTTPStatusCode", status)
-		}
-
-		// The message contains the raw encoded bytes for the response of the service
-		// method that triggered the event. Overlay that data on this handler's input.
-		if err := gw.valueDecoder.DecodeValues(event.Values, &serviceRequest); err != nil {
-			gw.errorListener(event.Route, fmt.Errorf("event payload decode error: %w", err))
+		// Not every event is one that this handler cares about (see WithFilter()). We return nil rather than an
+		// error, so the broker treats the message as handled and doesn't try to redeliver it.
+		if !gw.acceptsEvent(endpoint, serviceRequest) {
 			return nil
 		}
 
@@ -174,24 +194,129 @@ func (gw *Gateway) toStreamHandler(endpoint services.Endpoint, route services.En
 
 		// This is a new invocation so the route should indicate THIS function, not the
 		// thing that triggered us to execute.
-		ctx = metadata.WithRoute(ctx, metadata.EndpointRoute{
-			ServiceName: endpoint.ServiceName,
-			Name:        endpoint.Name,
-			Type:        gw.Type().String(),
-			Method:      route.Method,
-			Path:        route.Path,
-			Group:       route.Group,
-			Status:      200, // we don't have a doc option for setting this on event routes, so use sane default.
-		})
+		ctx = metadata.WithRoute(ctx, gw.toMetadataRoute(endpoint, route))
+
+		// Whoever published the event is the one that "called" this handler. That's nobody for events
+		// that came from outside of Frodo, which also clears out a caller left over from the publisher.
+		ctx = metadata.WithCaller(ctx, event.Route.ServiceName)
 
-		if _, err := endpoint.Handler(ctx, serviceRequest); err != nil {
+		shadow := gw.shadowRoutes[endpoint.QualifiedName()]
+		if shadow {
+			ctx = withShadow(ctx)
+		}
+
+		if err := gw.invokeHandler(ctx, endpoint, serviceRequest); err != nil {
+			err = gw.errorMapper.Map(err)
 			gw.errorListener(event.Route, err)
+
+			// Shadow handlers are still on probation (see WithShadow()), so their failures should never
+			// make the broker redeliver the event or send it to the dead letter queue.
+			if shadow {
+				return nil
+			}
 			return err
 		}
 		return nil
 	}
 }
 
+// acceptsEvent returns true when the decoded request passes every filter you registered for the endpoint using
+// WithFilter(). Endpoints without any filters accept every event.
+func (gw *Gateway) acceptsEvent(endpoint services.Endpoint, serviceRequest any) bool {
+	for _, filter := range gw.eventFilters[endpoint.QualifiedName()] {
+		if !filter(serviceRequest) {
+			return false
+		}
+	}
+	return true
+}
+
+// invokeHandler runs the endpoint's handler. When you've supplied WithHandlerTimeout(), we give up on handlers
+// that take too long and return a 408 error instead. We can't actually stop a handler that ignores its context,
+// though, so it keeps running in the background. It still counts as an active request until it returns, so
+// that a graceful shutdown gives it a chance to finish.
+func (gw *Gateway) invokeHandler(ctx context.Context, endpoint services.Endpoint, serviceRequest any) error {
+	if gw.handlerTimeout <= 0 {
+		return gw.runHandler(ctx, endpoint, serviceRequest)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, gw.handlerTimeout)
+	defer cancel()
+
+	result := make(chan error, 1)
+	gw.activeRequests.Add(1)
+	go func() {
+		defer gw.activeRequests.Done()
+		result <- gw.runHandler(ctx, endpoint, serviceRequest)
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return fail.Timeout("event handler timed out after %v", gw.handlerTimeout)
+	}
+}
+
+// toMetadataRoute describes the endpoint route that an event/schedule triggered, so handlers can tell what invoked them.
+func (gw *Gateway) toMetadataRoute(endpoint services.Endpoint, route services.EndpointRoute) metadata.EndpointRoute {
+	return metadata.EndpointRoute{
+		ServiceName: endpoint.ServiceName,
+		Name:        endpoint.Name,
+		Type:        gw.Type().String(),
+		Method:      route.Method,
+		Path:        route.Path,
+		Group:       route.Group,
+		Status:      200, // we don't have a doc option for setting this on event routes, so use sane default.
+		Deprecated:  route.Deprecated,
+		Sunset:      route.Sunset,
+	}
+}
+
+// decodeEvent reads the broker's message and applies its data to the handler's request. Events published by
+// Frodo services always use our 'message' envelope, whose Key matches the key it was published to. Anything else
+// came from some system outside of Frodo (e.g. "ON payment.succeeded"), so we treat the entire payload as the
+// encoded request for this handler instead. In that case, the returned message is empty; there's no metadata
+// or source route to carry over.
+func (gw *Gateway) decodeEvent(msg *eventsource.EventMessage, serviceRequest any) (message, error) {
+	payload, err := decompressPayload(msg.Payload)
+	if err != nil {
+		return message{}, fmt.Errorf("event decompress error: %s: %w", msg.Key, err)
+	}
+
+	event := message{}
+	if err = gw.decoder.Decode(bytes.NewBuffer(payload), &event); err != nil || event.Key != msg.Key {
+		if err = gw.decoder.Decode(bytes.NewBuffer(payload), serviceRequest); err != nil {
+			return message{}, fmt.Errorf("event decode error: %s: %w", msg.Key, err)
+		}
+		return message{}, nil
+	}
+
+	// If this is a subscriber to a failure key, make sure we can bind the original error details to the
+	// request of the handler.
+	if event.ErrorHandler() {
+		// Allow you to define your error as simply the field 'Error string' on your request or an error struct
+		// that has either an Error or Message string attribute.
+		event.Values.Set("Error", event.ErrorMessage)
+		event.Values.Set("Error.Error", event.ErrorMessage)
+		event.Values.Set("Error.Message", event.ErrorMessage)
+
+		// We support binding the status code to any of the interfaces supported by the 'fail' package.
+		status := strconv.FormatInt(int64(event.ErrorStatus), 10)
+		event.Values.Set("Error.Code", status)
+		event.Values.Set("Error.Status", status)
+		event.Values.Set("Error.StatusCode", status)
+		event.Values.Set("Error.HTTPStatusCode", status)
+	}
+
+	// The message contains the raw encoded bytes for the response of the service
+	// method that triggered the event. Overlay that data on this handler's input.
+	if err := gw.valueDecoder.DecodeValues(event.Values, serviceRequest); err != nil {
+		return event, fmt.Errorf("event payload decode error: %w", err)
+	}
+	return event, nil
+}
+
 // Middleware returns the middleware functions that ALL server routes should include in order
 // to make sure that this gateway actually works. For instance, one of the middleware functions
 // publishes the service operation's success/failure to the event source/stream. This happens
@@ -200,10 +325,16 @@ func (gw *Gateway) toStreamHandler(endpoint services.Endpoint, route services.En
 // just the event gateway.
 func (gw *Gateway) Middleware() services.MiddlewareFuncs {
 	return services.MiddlewareFuncs{
-		publishMiddleware(gw.broker, gw.encoder, gw.valueEncoder, gw.errorListener),
+		publishMiddleware(gw),
 	}
 }
 
+// Listening returns a channel that is closed once the gateway has subscribed to all of its routes, so
+// events published after that point are guaranteed to reach your handlers.
+func (gw *Gateway) Listening() <-chan struct{} {
+	return gw.started
+}
+
 // Listen causes the gateway to start subscribing/listening for events from the broker. This
 // will block until we're told to stop by calling Shutdown().
 func (gw *Gateway) Listen(ctx context.Context) error {
@@ -214,39 +345,60 @@ func (gw *Gateway) Listen(ctx context.Context) error {
 		// https://github.com/golang/go/discussions/56010
 		r := gatewayRoute
 
-		errs.Go(func() (err error) {
+		errs.Go(func() error {
+			var subs eventsource.Subscription
+			var err error
+			broker := gw.brokerFor(r.key)
 			switch r.group {
 			case "":
 				// The interface had "ON FooService.Bar GROUP *"
-				r.subs, err = gw.broker.Subscribe(ctx, r.key, r.handler)
-				return err
+				subs, err = broker.Subscribe(ctx, r.key, r.handler)
 			default:
-				// The interface had "ON FooService.Bar" without specifying a group to get the default grouping behavior.
-				r.subs, err = gw.broker.SubscribeGroup(ctx, r.key, r.group, r.handler)
-				return err
+				// The interface had "ON FooService.Bar" without specifying a group to get the default grouping behavior
+				// (or a specific group). Brokers that don't support weights will just ignore the "WEIGHT" option.
+				subs, err = eventsource.SubscribeWeightedGroup(ctx, broker, r.key, r.group, r.weight, r.handler)
 			}
+
+			// Shutdown() might be called while we're still subscribing, so don't let it read a half-written route.
+			gw.subsMutex.Lock()
+			r.subs = subs
+			gw.subsMutex.Unlock()
+			return err
 		})
 	}
 
 	if err := errs.Wait(); err != nil {
 		return fmt.Errorf("event gateway error: listen: %w", err)
 	}
-
 	gw.listening.Add(1)
+	close(gw.started)
+	for _, s := range gw.schedules {
+		go gw.runSchedule(ctx, s)
+	}
 	gw.listening.Wait()
 	return nil
 }
 
 // Shutdown gracefully stops the event gateway. It will allow all of the in-progress requests
 // to finish up before doing so. You can provide a deadline to the context parameter to limit
-// how much time you're willing to give them before shutting down anyway.
+// how much time you're willing to give them before shutting down anyway. When that happens, you
+// get an error that tells you how many event handlers were still running, so you can alert on it.
 func (gw *Gateway) Shutdown(ctx context.Context) error {
+	gw.cancelSchedules()
+
+	// Stop accepting new deliveries, so nothing gets added to activeRequests while we wait on it below.
+	gw.activeMutex.Lock()
+	gw.shuttingDown = true
+	gw.activeMutex.Unlock()
+
 	errs, _ := fail.NewGroup(ctx)
+	gw.subsMutex.Lock()
 	for _, r := range gw.routes {
 		if r.subs != nil {
 			errs.Go(r.subs.Close)
 		}
 	}
+	gw.subsMutex.Unlock()
 
 	// Make sure that we have stopped listening for all of our registered events.
 	if err := errs.Wait(); err != nil {
@@ -260,12 +412,66 @@ func (gw *Gateway) Shutdown(ctx context.Context) error {
 	// context's deadline/cancellation is reached or the process receives
 	// another SIGINT/SIGTERM signal. We'll exit once one of those 3 things happens.
 	wait.ContextOrGroupOrInterrupt(ctx, gw.activeRequests)
+
+	// If we gave up waiting, let the caller know how much work we walked away from. Those events were already
+	// pulled off of the broker, so depending on the broker, they may never be handled.
+	if abandoned := gw.runningHandlers.Load(); abandoned > 0 {
+		return fmt.Errorf("event gateway error: shutdown: %d in-flight event handlers did not finish within the grace period", abandoned)
+	}
 	return nil
 }
 
+// Replay re-delivers the events for the key that were published at or after 'since' to the given consumer group. This
+// is an operational recovery tool; use it when a bug caused your handlers to mishandle a bunch of events and you need
+// them to take another crack at them once you've deployed the fix. Keys are resolved just like "ON" routes, so you can
+// use "Service.Method" even if you customized WithKeyNaming(). The group for a plain "ON" route is the subscriber's
+// fully qualified name (e.g. "EmailService.SendConfirmation"), or whatever you specified w/ "GROUP".
+//
+//	count, err := gw.Replay(ctx, "OrderService.PlaceOrder", "EmailService.SendConfirmation", time.Now().Add(-time.Hour))
+//
+// The handlers see every replayed event a second time, so they should be idempotent. This only works when the broker
+// retains messages after delivering them (see eventsource.Replayable); otherwise, you'll get an error that wraps
+// eventsource.ErrReplayNotSupported. It returns the number of events that were re-delivered.
+func (gw *Gateway) Replay(ctx context.Context, key string, group string, since time.Time) (int, error) {
+	if group == "" {
+		return 0, fmt.Errorf("event gateway error: replay: %s: missing consumer group", key)
+	}
+
+	key = resolveKey(gw.keyNaming, key)
+	count, err := eventsource.Replay(ctx, gw.brokerFor(key), key, group, since)
+	if err != nil {
+		return count, fmt.Errorf("event gateway error: replay: %w", err)
+	}
+	return count, nil
+}
+
+// beginRequest counts a new event delivery or scheduled job as an active request, so that Shutdown() waits for
+// it. It returns false once Shutdown() has started, in which case the caller should not run the handler at all.
+func (gw *Gateway) beginRequest() bool {
+	gw.activeMutex.Lock()
+	defer gw.activeMutex.Unlock()
+
+	if gw.shuttingDown {
+		return false
+	}
+	gw.activeRequests.Add(1)
+	return true
+}
+
+// runHandler invokes the endpoint's handler, keeping track of how many are running at any given moment. Shutdown
+// uses this to report how many handlers it abandoned when it runs out of time.
+func (gw *Gateway) runHandler(ctx context.Context, endpoint services.Endpoint, serviceRequest any) error {
+	gw.runningHandlers.Add(1)
+	defer gw.runningHandlers.Add(-1)
+
+	_, err := endpoint.Handler(ctx, serviceRequest)
+	return err
+}
+
 type route struct {
 	key     string
 	group   string
+	weight  int
 	handler eventsource.EventHandlerFunc
 	subs    eventsource.Subscription
 }
@@ -275,7 +481,8 @@ type GatewayOption func(gw *Gateway)
 
 // WithBroker defines the broker that the gateway will use to publish and listen for events. By
 // default, the gateway will use a local broker that can only broadcast events to other services
-// running inside the same services.Server instance.
+// running inside the same services.Server instance. When you route events to multiple brokers using
+// WithBrokerRouter(), this is the broker for any key that the router doesn't send elsewhere.
 func WithBroker(broker eventsource.Broker) GatewayOption {
 	return func(gw *Gateway) {
 		gw.broker = broker
@@ -291,6 +498,129 @@ func WithEncoding(encoder codec.Encoder, decoder codec.Decoder) GatewayOption {
 	}
 }
 
+// WithCompression gzips the events that the gateway publishes when they're at least 'minSize' bytes once encoded. This
+// can save a lot when your events are large (e.g. full entity snapshots) and your broker charges by the byte. It works
+// with whatever encoding you've configured using WithEncoding(); we compress the bytes that your encoder produces.
+//
+// Gateways always decompress the events they receive, whether or not you gave them this option, so you can turn
+// on compression for your services in any order. Just make sure that every subscriber is running a version of
+// Frodo that understands compressed events before you enable it.
+func WithCompression(minSize int) GatewayOption {
+	return func(gw *Gateway) {
+		gw.compress = true
+		gw.compressMinSize = minSize
+	}
+}
+
+// WithKeyNaming customizes the keys/topics that the gateway publishes and subscribes to on the broker. By default,
+// the completion of FooService.Bar is published to the key "FooService.Bar", but some brokers (or orgs) have their
+// own naming rules. For instance, this publishes/subscribes to "fooservice_bar" instead:
+//
+//	events.WithKeyNaming(func(serviceName, methodName string) string {
+//		return strings.ToLower(serviceName + "_" + methodName)
+//	})
+//
+// Your "ON FooService.Bar" doc options are run through the same function, so you don't have to change your service
+// definitions. Keys that don't look like "Service.Method" (e.g. "ON payment.succeeded") are left alone. Make sure
+// that every gateway/publisher sharing your broker uses the same naming, or they won't hear each other's events.
+func WithKeyNaming(naming KeyNamingFunc) GatewayOption {
+	return func(gw *Gateway) {
+		if naming != nil {
+			gw.keyNaming = naming
+		}
+	}
+}
+
+// WithPublishFilter lets you opt some endpoints out of automatically publishing their "Service.Method" events. Your
+// filter is given the route that was just invoked, and we only publish when it returns true. This is handy for
+// high-frequency, read-heavy endpoints that nobody subscribes to anyway; there's no reason to flood your broker
+// with those events. Filtered endpoints can still subscribe to other events as normal.
+//
+//	events.WithPublishFilter(func(route metadata.EndpointRoute) bool {
+//		return !strings.HasPrefix(route.Name, "Get") && !strings.HasPrefix(route.Name, "List")
+//	})
+//
+// By default, every invocation is published.
+func WithPublishFilter(filter PublishFilter) GatewayOption {
+	return func(gw *Gateway) {
+		gw.publishFilter = filter
+	}
+}
+
+// WithScheduleLocation sets the time zone used to evaluate the cron expressions of your "SCHEDULE" doc options. For
+// instance, "SCHEDULE 0 2 * * *" runs at 2am in this location. By default, we use the server's local time zone.
+func WithScheduleLocation(location *time.Location) GatewayOption {
+	return func(gw *Gateway) {
+		if location != nil {
+			gw.scheduleLocation = location
+		}
+	}
+}
+
+// WithSynchronousChain makes service calls wait until their events are published before returning, and any
+// failure to publish is returned to the caller rather than the error listener. When you're using the default
+// local broker, the gateway also runs subscribers synchronously, so the entire chain of "ON Service.Method"
+// handlers has finished by the time your original call returns. Any errors from those handlers come back
+// from the original call, too. This is great for tests; no more sleeping and hoping that your events fired.
+//
+// Distributed brokers like NATS can't run subscribers synchronously, so you only find out that the event
+// made it to the broker. If you supply your own local broker using WithBroker(), create it with the
+// local.WithSynchronousDispatch() option to get the same behavior as the default.
+func WithSynchronousChain() GatewayOption {
+	return func(gw *Gateway) {
+		gw.synchronous = true
+	}
+}
+
+// WithErrorMapper translates the errors returned by your service functions before they're published as
+// "Service.Function:Error" events or sent to your error listener. Use the same mapper that you give to
+// apis.WithErrorMapper(), so that the status of the error events matches what your API callers see.
+func WithErrorMapper(mapper fail.ErrorMapper) GatewayOption {
+	return func(gw *Gateway) {
+		gw.errorMapper = mapper
+	}
+}
+
+// WithHandlerTimeout limits how long each event/schedule handler can run. The handler's context is canceled once
+// the timeout expires, and the gateway reports a 408 error rather than waiting any longer. Just like any other
+// failure, that error goes to your error listener and your broker's redelivery/dead letter handling. This keeps a
+// single hung handler from tying up one of your workers forever. A timeout of zero or less means no timeout,
+// which is the default.
+func WithHandlerTimeout(timeout time.Duration) GatewayOption {
+	return func(gw *Gateway) {
+		gw.handlerTimeout = timeout
+	}
+}
+
+// PublishFilter decides whether the completion of the given route should be published to the event broker.
+type PublishFilter func(route metadata.EndpointRoute) bool
+
+// WithFilter only runs the "Service.Method" event handler for events whose payload you actually care about. Your
+// filter receives the handler's request after we've decoded the event onto it, and the handler only runs when
+// it returns true. Skipped events are still acknowledged, so your broker won't try to redeliver them.
+//
+//	// ShippingService.ShipDomestic has the doc option "ON OrderService.PlaceOrder"
+//	events.WithFilter("ShippingService.ShipDomestic", func(req any) bool {
+//		return req.(*shipping.ShipDomesticRequest).Region == "us"
+//	})
+//
+// You can supply multiple filters for the same handler, and it only runs when all of them return true. The
+// filters apply to every "ON" event that the handler subscribes to.
+func WithFilter(qualifiedName string, filter EventFilter) GatewayOption {
+	return func(gw *Gateway) {
+		if filter == nil {
+			return
+		}
+		if gw.eventFilters == nil {
+			gw.eventFilters = map[string][]EventFilter{}
+		}
+		gw.eventFilters[qualifiedName] = append(gw.eventFilters[qualifiedName], filter)
+	}
+}
+
+// EventFilter decides whether an event handler should run, given the request that we decoded from the event.
+type EventFilter func(req any) bool
+
 // WithErrorListener sets a custom callback function that is invoked any time we encounter an error
 // publishing an event, receiving an event, or executing a service handler. These are all invoked
 // asynchronously, so this is the only way you can perform any custom error handling in those cases.
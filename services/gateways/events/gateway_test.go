@@ -0,0 +1,616 @@
+//go:build unit
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bridgekit-io/frodo/codec"
+	"github.com/bridgekit-io/frodo/eventsource"
+	"github.com/bridgekit-io/frodo/eventsource/local"
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestGatewaySuite(t *testing.T) {
+	suite.Run(t, new(GatewaySuite))
+}
+
+type GatewaySuite struct {
+	suite.Suite
+}
+
+// weightedBroker is a local broker that records the weighted group subscriptions it receives.
+type weightedBroker struct {
+	eventsource.Broker
+	mutex    sync.Mutex
+	weighted []string
+}
+
+func (b *weightedBroker) SubscribeWeightedGroup(ctx context.Context, key string, group string, weight int, handlerFunc eventsource.EventHandlerFunc) (eventsource.Subscription, error) {
+	b.mutex.Lock()
+	b.weighted = append(b.weighted, fmt.Sprintf("%s:%s:%d", key, group, weight))
+	b.mutex.Unlock()
+	return b.Broker.SubscribeGroup(ctx, key, group, handlerFunc)
+}
+
+func (b *weightedBroker) subscriptions() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return append([]string{}, b.weighted...)
+}
+
+func (suite *GatewaySuite) TestListen_weightedGroups() {
+	broker := &weightedBroker{Broker: local.Broker()}
+	gw := NewGateway(WithBroker(broker))
+
+	endpoint := services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Strike",
+		NewInput:    func() services.StructPointer { return &struct{}{} },
+		Handler:     func(ctx context.Context, req any) (any, error) { return nil, nil },
+	}
+	route := func(group string, weight int) services.EndpointRoute {
+		return services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "LeagueService.Roll", Group: group, Weight: weight}
+	}
+	gw.Register(endpoint, route("League", 3))
+	gw.Register(endpoint, route("", 2))
+	gw.Register(endpoint, route("Other", 0))
+	gw.Register(endpoint, route("*", 0))
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+
+	suite.Eventually(func() bool { return len(broker.subscriptions()) == 2 }, time.Second, 5*time.Millisecond)
+	suite.ElementsMatch([]string{
+		"LeagueService.Roll:League:3",
+		"LeagueService.Roll:LeagueService.Strike:2",
+	}, broker.subscriptions(), "Only groups w/ a weight should use weighted subscriptions")
+}
+
+type defaultsRequest struct {
+	Name     string
+	PageSize int
+}
+
+func (req *defaultsRequest) Defaults() {
+	if req.PageSize == 0 {
+		req.PageSize = 20
+	}
+	if req.Name == "" {
+		req.Name = "Dude"
+	}
+}
+
+func (suite *GatewaySuite) TestListen_defaults() {
+	broker := local.Broker(local.WithSynchronousDispatch())
+	gw := NewGateway(WithBroker(broker))
+
+	received := make(chan defaultsRequest, 10)
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Pay",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler: func(ctx context.Context, req any) (any, error) {
+			received <- *req.(*defaultsRequest)
+			return nil, nil
+		},
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "payment.succeeded"})
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+
+	// Keep publishing until the gateway has subscribed. Dispatch is synchronous, so the handler has already run.
+	suite.Eventually(func() bool {
+		_ = broker.Publish(context.Background(), "payment.succeeded", []byte(`{"Name":"Walter"}`))
+		return len(received) > 0
+	}, time.Second, 5*time.Millisecond)
+
+	suite.Equal(defaultsRequest{Name: "Walter", PageSize: 20}, <-received, "Defaults should only fill in blank fields")
+}
+
+func (suite *GatewaySuite) TestHandlerTimeout() {
+	errs := make(chan error, 10)
+	broker := local.Broker(local.WithSynchronousDispatch())
+	gw := NewGateway(WithBroker(broker), WithHandlerTimeout(20*time.Millisecond), WithErrorListener(func(route metadata.EndpointRoute, err error) {
+		errs <- err
+	}))
+
+	started := make(chan context.Context, 10)
+	release := make(chan struct{})
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Hang",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler: func(ctx context.Context, req any) (any, error) {
+			started <- ctx
+			<-release // ignore the context like a truly hung handler would
+			return nil, nil
+		},
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "payment.succeeded"})
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	suite.Eventually(func() bool {
+		_ = broker.Publish(context.Background(), "payment.succeeded", []byte(`{}`))
+		return len(started) > 0
+	}, time.Second, 5*time.Millisecond)
+
+	err := <-errs
+	suite.Equal(408, fail.Status(err), "Hung handlers should fail w/ a timeout")
+	suite.ErrorIs((<-started).Err(), context.DeadlineExceeded, "Handler's context should be canceled")
+
+	// The handler is still running, so shutdown should wait for it.
+	shutdownDone := make(chan struct{})
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = gw.Shutdown(ctx)
+		close(shutdownDone)
+	}()
+	select {
+	case <-shutdownDone:
+		suite.Fail("Shutdown should wait for handlers that timed out but are still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-shutdownDone:
+	case <-time.After(time.Second):
+		suite.Fail("Shutdown should finish once the hung handler returns")
+	}
+}
+
+func (suite *GatewaySuite) TestShutdown_abandonedHandlers() {
+	broker := local.Broker()
+	gw := NewGateway(WithBroker(broker))
+
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	defer close(release)
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Hang",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler: func(ctx context.Context, req any) (any, error) {
+			started <- struct{}{}
+			<-release
+			return nil, nil
+		},
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "payment.succeeded", Group: "*"})
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	<-gw.Listening()
+
+	// Only shut down once the one handler is definitely running, so that it's the only thing we abandon.
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.succeeded", []byte(`{}`)))
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		suite.FailNow("Handler never started")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := gw.Shutdown(ctx)
+	suite.Require().Error(err, "Shutdown should report handlers that never finished")
+	suite.Contains(err.Error(), "1 in-flight event handlers did not finish within the grace period")
+}
+
+func (suite *GatewaySuite) TestShutdown_finishedHandlers() {
+	broker := local.Broker(local.WithSynchronousDispatch())
+	gw := NewGateway(WithBroker(broker))
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Fast",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler: func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		},
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "payment.succeeded"})
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.succeeded", []byte(`{}`)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	suite.NoError(gw.Shutdown(ctx))
+}
+
+func (suite *GatewaySuite) TestReplay() {
+	broker := local.Broker(local.WithSynchronousDispatch(), local.WithRetention(time.Hour))
+	gw := NewGateway(WithBroker(broker))
+
+	var received []string
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Bowl",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler: func(ctx context.Context, req any) (any, error) {
+			received = append(received, req.(*defaultsRequest).Name)
+			return nil, nil
+		},
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "payment.succeeded"})
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+	<-gw.Listening()
+
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.succeeded", []byte(`{"Name":"Walter"}`)))
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.succeeded", []byte(`{"Name":"Donny"}`)))
+	suite.Equal([]string{"Walter", "Donny"}, received)
+
+	count, err := gw.Replay(context.Background(), "payment.succeeded", "LeagueService.Bowl", time.Now().Add(-time.Minute))
+	suite.Require().NoError(err)
+	suite.Equal(2, count)
+	suite.Equal([]string{"Walter", "Donny", "Walter", "Donny"}, received)
+
+	_, err = gw.Replay(context.Background(), "payment.succeeded", "", time.Time{})
+	suite.ErrorContains(err, "missing consumer group")
+}
+
+func (suite *GatewaySuite) TestReplay_notSupported() {
+	gw := NewGateway(WithBroker(local.Broker(local.WithSynchronousDispatch())))
+	_, err := gw.Replay(context.Background(), "payment.succeeded", "LeagueService.Bowl", time.Time{})
+	suite.ErrorIs(err, eventsource.ErrReplayNotSupported)
+}
+
+func (suite *GatewaySuite) TestBrokerRouter() {
+	defaultBroker := local.Broker(local.WithSynchronousDispatch())
+	orderBroker := local.Broker(local.WithSynchronousDispatch(), local.WithRetention(time.Hour))
+	gw := NewGateway(
+		WithBroker(defaultBroker),
+		WithSynchronousChain(),
+		WithBrokerRouter(func(key string) eventsource.Broker {
+			if strings.HasPrefix(key, "OrderService.") {
+				return orderBroker
+			}
+			return nil
+		}),
+	)
+
+	var received []string
+	gw.Register(services.Endpoint{
+		ServiceName: "NotificationService",
+		Name:        "Notify",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler: gw.Middleware().Then(func(ctx context.Context, req any) (any, error) {
+			received = append(received, req.(*defaultsRequest).Name)
+			return &defaultsRequest{Name: "Sent"}, nil
+		}),
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "OrderService.PlaceOrder"})
+
+	published := map[string][]string{}
+	for name, broker := range map[string]eventsource.Broker{"default": defaultBroker, "order": orderBroker} {
+		_, err := broker.Subscribe(context.Background(), "NotificationService.Notify", func(ctx context.Context, msg *eventsource.EventMessage) error {
+			published[name] = append(published[name], msg.Key)
+			return nil
+		})
+		suite.Require().NoError(err)
+	}
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+	<-gw.Listening()
+
+	suite.Require().NoError(NewPublisher(defaultBroker).Publish(context.Background(), "OrderService", "PlaceOrder", &defaultsRequest{Name: "Walter"}))
+	suite.Empty(received, "Should not subscribe to order events on the default broker")
+
+	suite.Require().NoError(NewPublisher(orderBroker).Publish(context.Background(), "OrderService", "PlaceOrder", &defaultsRequest{Name: "Donny"}))
+	suite.Equal([]string{"Donny"}, received, "Should subscribe to order events on the order broker")
+	suite.Equal(map[string][]string{"default": {"NotificationService.Notify"}}, published, "Should publish keys the router doesn't handle to the default broker")
+
+	// Only the order broker retains events, so this only works if we replay using the routed broker.
+	count, err := gw.Replay(context.Background(), "OrderService.PlaceOrder", "NotificationService.Notify", time.Now().Add(-time.Minute))
+	suite.Require().NoError(err)
+	suite.Equal(1, count)
+	suite.Equal([]string{"Donny", "Donny"}, received)
+}
+
+func (suite *GatewaySuite) TestHandlerTimeout_fastHandler() {
+	broker := local.Broker(local.WithSynchronousDispatch())
+	gw := NewGateway(WithBroker(broker), WithHandlerTimeout(time.Second))
+
+	received := make(chan error, 10)
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Pay",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler: func(ctx context.Context, req any) (any, error) {
+			_, hasDeadline := ctx.Deadline()
+			suite.True(hasDeadline, "Handler's context should have the deadline")
+			return nil, fail.BadRequest("gutterball")
+		},
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "payment.succeeded"})
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+
+	suite.Eventually(func() bool {
+		if err := broker.Publish(context.Background(), "payment.succeeded", []byte(`{}`)); err != nil {
+			received <- err
+			return true
+		}
+		return false
+	}, time.Second, 5*time.Millisecond)
+	suite.ErrorContains(<-received, "gutterball", "Should return the handler's own error")
+}
+
+func (suite *GatewaySuite) TestFilter() {
+	broker := local.Broker(local.WithSynchronousDispatch())
+	errs := make(chan error, 10)
+	gw := NewGateway(
+		WithBroker(broker),
+		WithErrorListener(func(route metadata.EndpointRoute, err error) { errs <- err }),
+		WithFilter("LeagueService.Bowl", func(req any) bool {
+			return req.(*defaultsRequest).Name != "Jesus"
+		}),
+		WithFilter("LeagueService.Bowl", func(req any) bool {
+			return req.(*defaultsRequest).PageSize == 20 // make sure that we apply defaults before filtering
+		}),
+		WithFilter("LeagueService.Other", func(req any) bool { return false }),
+		WithFilter("LeagueService.Bowl", nil),
+	)
+
+	var received []string
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Bowl",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler: func(ctx context.Context, req any) (any, error) {
+			received = append(received, req.(*defaultsRequest).Name)
+			return nil, nil
+		},
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "payment.succeeded"})
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+	<-gw.Listening()
+
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.succeeded", []byte(`{"Name":"Walter"}`)))
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.succeeded", []byte(`{"Name":"Jesus"}`)), "Skipped events should be acknowledged")
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.succeeded", []byte(`{"Name":"Donny", "PageSize":5}`)))
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.succeeded", []byte(`{"Name":"Dude"}`)))
+
+	suite.Equal([]string{"Walter", "Dude"}, received, "Handler should only run when every filter passes")
+	suite.Empty(errs, "Skipped events are not errors")
+}
+
+func (suite *GatewaySuite) TestShadow() {
+	broker := local.Broker(local.WithSynchronousDispatch())
+	var errs []error
+	gw := NewGateway(
+		WithBroker(broker),
+		WithSynchronousChain(),
+		WithErrorListener(func(route metadata.EndpointRoute, err error) { errs = append(errs, err) }),
+		WithShadow("LeagueService.Bowl"),
+	)
+
+	var shadowed []bool
+	handler := func(ctx context.Context, req any) (any, error) {
+		shadowed = append(shadowed, Shadowed(ctx))
+		if req.(*defaultsRequest).Name == "Jesus" {
+			return nil, fmt.Errorf("nobody f***s with the jesus")
+		}
+		return &defaultsRequest{Name: "Strike"}, nil
+	}
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Bowl",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler:     gw.Middleware().Then(handler),
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "payment.succeeded"})
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Practice",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler:     gw.Middleware().Then(handler),
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "payment.failed"})
+
+	var published []string
+	for _, key := range []string{"LeagueService.Bowl", "LeagueService.Bowl:Error", "LeagueService.Practice"} {
+		_, err := broker.Subscribe(context.Background(), key, func(ctx context.Context, msg *eventsource.EventMessage) error {
+			published = append(published, msg.Key)
+			return nil
+		})
+		suite.Require().NoError(err)
+	}
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+	<-gw.Listening()
+
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.succeeded", []byte(`{"Name":"Walter"}`)))
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.succeeded", []byte(`{"Name":"Jesus"}`)), "Shadow failures should not make the broker redeliver")
+	suite.Len(errs, 1, "Shadow failures should still go to the error listener")
+	suite.Empty(published, "Shadow handlers should not publish their events")
+
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.failed", []byte(`{"Name":"Walter"}`)))
+	suite.Equal([]string{"LeagueService.Practice"}, published, "Other handlers should publish as usual")
+	suite.Equal([]bool{true, true, false}, shadowed)
+
+	err := NewPublisher(broker).Publish(withShadow(context.Background()), "LeagueService", "Bowl", &defaultsRequest{})
+	suite.NoError(err)
+	suite.Equal([]string{"LeagueService.Practice"}, published, "Publishers should not publish from shadow handlers")
+}
+
+func (suite *GatewaySuite) TestMaxCallDepth() {
+	broker := local.Broker(local.WithSynchronousDispatch())
+	var errs []error
+	gw := NewGateway(
+		WithBroker(broker),
+		WithSynchronousChain(),
+		WithErrorListener(func(route metadata.EndpointRoute, err error) { errs = append(errs, err) }),
+		WithMaxCallDepth(3),
+	)
+
+	// Bowl and Practice trigger each other, so without a limit, this would go on forever.
+	var depths []int
+	handler := func(ctx context.Context, req any) (any, error) {
+		depths = append(depths, metadata.CallDepth(ctx))
+		return &defaultsRequest{Name: "Strike"}, nil
+	}
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Bowl",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler:     gw.Middleware().Then(handler),
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "LeagueService.Practice"})
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Practice",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler:     gw.Middleware().Then(handler),
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "LeagueService.Bowl"})
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+	<-gw.Listening()
+
+	err := NewPublisher(broker).Publish(context.Background(), "LeagueService", "Bowl", &defaultsRequest{})
+	suite.Require().ErrorIs(err, ErrMaxCallDepth, "The synchronous chain should report the cycle to the original caller")
+	suite.Equal([]int{1, 2, 3}, depths, "Handlers at the max depth run, but don't publish")
+	suite.Require().NotEmpty(errs)
+	suite.ErrorIs(errs[0], ErrMaxCallDepth)
+	suite.Contains(errs[0].Error(), "LeagueService.Practice")
+}
+
+func (suite *GatewaySuite) TestMaxCallDepth_async() {
+	var errs []error
+	listener := func(route metadata.EndpointRoute, err error) { errs = append(errs, err) }
+	broker := local.Broker(local.WithSynchronousDispatch())
+	middleware := publishMiddleware(NewGateway(WithBroker(broker), WithErrorListener(listener), WithMaxCallDepth(2)))
+	handler := services.MiddlewareFuncs{middleware}.Then(func(ctx context.Context, req any) (any, error) {
+		return &defaultsRequest{}, nil
+	})
+
+	ctx := metadata.WithRoute(context.Background(), metadata.EndpointRoute{ServiceName: "LeagueService", Name: "Bowl"})
+	_, err := handler(metadata.WithCallDepth(ctx, 2), &defaultsRequest{})
+	suite.NoError(err, "The call itself succeeded, so it should not fail just because we didn't publish")
+	suite.Require().Len(errs, 1)
+	suite.ErrorIs(errs[0], ErrMaxCallDepth)
+}
+
+func (suite *GatewaySuite) TestCaller() {
+	broker := local.Broker(local.WithSynchronousDispatch())
+	gw := NewGateway(WithBroker(broker))
+
+	var callers []string
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Bowl",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler: func(ctx context.Context, req any) (any, error) {
+			callers = append(callers, metadata.Caller(ctx))
+			return nil, nil
+		},
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "OrderService.PlaceOrder"})
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+	<-gw.Listening()
+
+	// Publish the event just like the OrderService would when one of its functions completes, but from
+	// a context that claims some other caller. The handler should see the publisher as its caller.
+	ctx := metadata.WithCaller(context.Background(), "SomeoneElse")
+	ctx = metadata.WithRoute(ctx, metadata.EndpointRoute{ServiceName: "OrderService", Name: "PlaceOrder"})
+	middleware := publishMiddleware(NewGateway(WithBroker(broker), WithSynchronousChain()))
+	_, err := middleware(ctx, &struct{}{}, func(ctx context.Context, req any) (any, error) {
+		return &struct{}{}, nil
+	})
+	suite.Require().NoError(err)
+
+	// Events from outside of Frodo don't have a caller.
+	suite.Require().NoError(broker.Publish(ctx, "OrderService.PlaceOrder", []byte(`{"Name":"Walter"}`)))
+
+	suite.Equal([]string{"OrderService", ""}, callers)
+}
+
+func (suite *GatewaySuite) TestCompression() {
+	// Keep the payloads well clear of the threshold on either side so that tweaks to the
+	// message envelope don't flip which one gets compressed.
+	const threshold = 1024
+	small := "Dude"
+	large := strings.Repeat("Walter", threshold)
+
+	broker := local.Broker(local.WithSynchronousDispatch())
+	gw := NewGateway(WithBroker(broker), WithCompression(threshold), WithSynchronousChain())
+
+	var received []defaultsRequest
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Bowl",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler: func(ctx context.Context, req any) (any, error) {
+			received = append(received, *req.(*defaultsRequest))
+			return nil, nil
+		},
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "OrderService.PlaceOrder"})
+
+	// Peek at the raw bytes that actually go over the broker.
+	var payloads [][]byte
+	_, _ = broker.Subscribe(context.Background(), "OrderService.PlaceOrder", func(ctx context.Context, msg *eventsource.EventMessage) error {
+		payloads = append(payloads, msg.Payload)
+		return nil
+	})
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+	<-gw.Listening()
+
+	publish := func(name string) {
+		ctx := metadata.WithRoute(context.Background(), metadata.EndpointRoute{ServiceName: "OrderService", Name: "PlaceOrder"})
+		_, err := gw.Middleware().Then(func(ctx context.Context, req any) (any, error) {
+			return &defaultsRequest{Name: name, PageSize: 5}, nil
+		})(ctx, &struct{}{})
+		suite.Require().NoError(err)
+	}
+
+	publish(large)
+	publish(small)
+
+	suite.Require().Len(payloads, 2)
+	suite.Less(len(payloads[1]), threshold/2, "Small event should be well under the threshold")
+	suite.Equal([]byte{0x1f, 0x8b}, payloads[0][:2], "Large events should be gzipped")
+	suite.Equal(byte('{'), payloads[1][0], "Small events should be published as-is")
+	suite.Equal([]defaultsRequest{
+		{Name: large, PageSize: 5},
+		{Name: small, PageSize: 5},
+	}, received)
+}
+
+func (suite *GatewaySuite) TestCompression_decompressWithoutOption() {
+	broker := local.Broker(local.WithSynchronousDispatch())
+	gw := NewGateway(WithBroker(broker))
+
+	var received []defaultsRequest
+	gw.Register(services.Endpoint{
+		ServiceName: "LeagueService",
+		Name:        "Bowl",
+		NewInput:    func() services.StructPointer { return &defaultsRequest{} },
+		Handler: func(ctx context.Context, req any) (any, error) {
+			received = append(received, *req.(*defaultsRequest))
+			return nil, nil
+		},
+	}, services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "payment.succeeded"})
+
+	go func() { _ = gw.Listen(context.Background()) }()
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+	<-gw.Listening()
+
+	compressed := &bytes.Buffer{}
+	encoder := compressEvents(codec.JSONEncoder{}, true, 0)
+	suite.Require().NoError(encoder.Encode(compressed, map[string]any{"Name": "Walter", "PageSize": 5}))
+	suite.Require().NoError(broker.Publish(context.Background(), "payment.succeeded", compressed.Bytes()))
+
+	suite.Equal([]defaultsRequest{{Name: "Walter", PageSize: 5}}, received, "Subscribers should always understand compressed events")
+}
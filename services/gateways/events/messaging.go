@@ -3,6 +3,8 @@ package events
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"go/token"
 	"net/url"
 	"strings"
 	"time"
@@ -18,6 +20,36 @@ import (
 // should route to error listeners, not success listeners.
 const errorKeySuffix = ":Error"
 
+// KeyNamingFunc converts a service/method pair into the key/topic that we publish and subscribe to on the
+// broker. The default naming is "Service.Method", but you can supply your own (see WithKeyNaming()) when your
+// broker dislikes dots or your organization has a topic naming convention.
+type KeyNamingFunc func(serviceName string, methodName string) string
+
+// defaultKeyNaming is the standard "Service.Method" key format that matches the "ON Service.Method" doc options.
+func defaultKeyNaming(serviceName string, methodName string) string {
+	return serviceName + "." + methodName
+}
+
+// resolveKey takes the subscription path from an "ON" doc option (e.g. "FooService.Bar" or "FooService.Bar:Error")
+// and runs it through the key naming function, so subscriptions line up with what publishMiddleware() publishes.
+// Anything that isn't a "Service.Method" pair of exported Go identifiers (e.g. "payment.succeeded") is an external
+// key that some other system publishes to, so we leave it exactly as you wrote it.
+func resolveKey(naming KeyNamingFunc, path string) string {
+	key, isErrorKey := strings.CutSuffix(path, errorKeySuffix)
+	serviceName, methodName, ok := strings.Cut(key, ".")
+	if !ok || !isExportedIdentifier(serviceName) || !isExportedIdentifier(methodName) {
+		return path
+	}
+	if isErrorKey {
+		return naming(serviceName, methodName) + errorKeySuffix
+	}
+	return naming(serviceName, methodName)
+}
+
+func isExportedIdentifier(name string) bool {
+	return token.IsIdentifier(name) && token.IsExported(name)
+}
+
 // message is the envelope used by the event gateway to broadcast events to other services
 // that might want to perform other tasks based on this event. It contains all of the information
 // required for a subscriber to know what event occurred, the return value of the original call,
@@ -58,17 +90,52 @@ func (m message) ErrorHandler() bool {
 
 // publishMiddleware defines the unit of work that every service endpoint should perform to publish
 // their "I just finished this service function" event; the thing that drives our event gateway.
-func publishMiddleware(broker eventsource.Broker, encoder codec.Encoder, valueEncoder codec.ValueEncoder, errorListener ErrorListener) services.MiddlewareFunc {
+// The gateway's publish filter lets you skip publishing for some routes entirely (see WithPublishFilter()),
+// and handlers can skip publishing for an individual call using metadata.SuppressEvent(). The error mapper
+// translates failures before they're published (see WithErrorMapper()). When the chain is synchronous, we
+// publish before returning, and publishing failures are returned to the caller (see WithSynchronousChain()).
+// Calls that are already maxCallDepth events deep don't publish at all (see WithMaxCallDepth()).
+func publishMiddleware(gw *Gateway) services.MiddlewareFunc {
+	broker := routedPublisher{gw: gw}
+	encoder := compressEvents(gw.encoder, gw.compress, gw.compressMinSize)
+
 	return func(ctx context.Context, req any, next services.HandlerFunc) (any, error) {
+		ctx = metadata.WithEventSuppression(ctx)
 		response, err := next(ctx, req)
 
+		// Don't even bother spinning up the goroutine or encoding anything for routes you opted out of.
+		if gw.publishFilter != nil && !gw.publishFilter(metadata.Route(ctx)) {
+			return response, err
+		}
+		if metadata.EventSuppressed(ctx) || Shadowed(ctx) {
+			return response, err
+		}
+		if depthErr := checkCallDepth(ctx, gw.maxCallDepth); depthErr != nil {
+			if gw.synchronous && err == nil {
+				return response, depthErr
+			}
+			gw.errorListener(metadata.Route(ctx), depthErr)
+			return response, err
+		}
+
+		// The caller wants to know that the event made it to the broker (and possibly that the whole chain of
+		// subscribers completed), so make them wait. We don't want a publishing failure to hide the error of
+		// a call that already failed, though.
+		eventErr := gw.errorMapper.Map(err)
+		if gw.synchronous {
+			msg := newMessage(ctx, metadata.Route(ctx), gw.keyNaming, gw.valueEncoder, req, response, eventErr)
+			if pubErr := publishMessage(context.WithoutCancel(ctx), broker, encoder, msg); pubErr != nil && err == nil {
+				return response, fmt.Errorf("event publish error: %s: %w", msg.Key, pubErr)
+			}
+			return response, err
+		}
+
 		// We want the successful invocation to be propagated back to the caller as quickly
 		// as possible, so don't wait for event publishing to happen in order to do that. This
 		// does mean, however, that we need to perform asynchronous error handling w/ callbacks.
 		// Even if we screw up the publishing portion, we still want the successful result to
 		// make it back to the original caller.
 		go func() {
-			encodedMetadata := metadata.Encode(ctx)
 			endpoint := metadata.Route(ctx)
 
 			// We need a context separate from the overall request context. The original one
@@ -79,32 +146,53 @@ func publishMiddleware(broker eventsource.Broker, encoder codec.Encoder, valueEn
 			pubCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second) // make configurable?
 			defer cancel()
 
-			msg := message{
-				Route:    endpoint,
-				Metadata: encodedMetadata,
-			}
-
-			switch {
-			case err == nil:
-				msg.Key = endpoint.QualifiedName()
-				msg.Values = valueEncoder.EncodeValues(response)
-			case err != nil:
-				msg.Key = endpoint.QualifiedName() + errorKeySuffix
-				msg.Values = valueEncoder.EncodeValues(req)
-				msg.ErrorStatus = fail.Status(err)
-				msg.ErrorMessage = err.Error()
-			}
-
-			buf := &bytes.Buffer{}
-			if err = encoder.Encode(buf, msg); err != nil {
-				errorListener(endpoint, err)
-				return
-			}
-			if err = broker.Publish(pubCtx, msg.Key, buf.Bytes()); err != nil {
-				errorListener(endpoint, err)
-				return
+			msg := newMessage(ctx, endpoint, gw.keyNaming, gw.valueEncoder, req, response, eventErr)
+			if err := publishMessage(pubCtx, broker, encoder, msg); err != nil {
+				gw.errorListener(endpoint, err)
 			}
 		}()
 		return response, err
 	}
 }
+
+// newMessage builds the envelope that describes the completion of the service function at the given route. When
+// the call succeeded, subscribers receive the response values. When it failed, the message is routed to the
+// "Service.Function:Error" key and subscribers receive the original request values along with the error details.
+// The keyNaming function determines what "Service.Function" actually looks like on the broker. Subscribers run
+// one hop deeper than the current call, so that's the call depth we send along with the rest of the metadata.
+func newMessage(ctx context.Context, endpoint metadata.EndpointRoute, keyNaming KeyNamingFunc, valueEncoder codec.ValueEncoder, req any, response any, err error) message {
+	msg := message{
+		Route:    endpoint,
+		Metadata: metadata.Encode(metadata.WithCallDepth(ctx, metadata.CallDepth(ctx)+1)),
+	}
+
+	switch {
+	case err == nil:
+		msg.Key = keyNaming(endpoint.ServiceName, endpoint.Name)
+		msg.Values = valueEncoder.EncodeValues(response)
+	case err != nil:
+		msg.Key = keyNaming(endpoint.ServiceName, endpoint.Name) + errorKeySuffix
+		msg.Values = valueEncoder.EncodeValues(req)
+		msg.ErrorStatus = fail.Status(err)
+		msg.ErrorMessage = err.Error()
+	}
+	return msg
+}
+
+// publishMessage encodes the message envelope and hands it off to the broker.
+func publishMessage(ctx context.Context, broker eventsource.Publisher, encoder codec.Encoder, msg message) error {
+	payload, err := encodeMessage(encoder, msg)
+	if err != nil {
+		return err
+	}
+	return broker.Publish(ctx, msg.Key, payload)
+}
+
+// encodeMessage encodes the message envelope into the payload that we hand off to the broker.
+func encodeMessage(encoder codec.Encoder, msg message) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := encoder.Encode(buf, msg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
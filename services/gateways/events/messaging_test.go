@@ -0,0 +1,142 @@
+//go:build unit
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/eventsource"
+	"github.com/bridgekit-io/frodo/eventsource/local"
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMessagingSuite(t *testing.T) {
+	suite.Run(t, new(MessagingSuite))
+}
+
+type MessagingSuite struct {
+	suite.Suite
+}
+
+func (suite *MessagingSuite) TestPublishMiddleware_errorMapper() {
+	errNoRows := errors.New("no rows")
+	mapper := func(err error) error {
+		if errors.Is(err, errNoRows) {
+			return fail.NotFound("user not found")
+		}
+		return err
+	}
+
+	var published message
+	broker := local.Broker(local.WithSynchronousDispatch())
+	_, _ = broker.Subscribe(context.Background(), "UserService.Get:Error", func(ctx context.Context, msg *eventsource.EventMessage) error {
+		return json.Unmarshal(msg.Payload, &published)
+	})
+
+	middleware := publishMiddleware(NewGateway(WithBroker(broker), WithErrorMapper(mapper), WithSynchronousChain()))
+	ctx := metadata.WithRoute(context.Background(), metadata.EndpointRoute{ServiceName: "UserService", Name: "Get"})
+	_, err := middleware(ctx, &struct{}{}, func(ctx context.Context, req any) (any, error) {
+		return nil, errNoRows
+	})
+
+	suite.Equal(errNoRows, err, "The mapper should only affect the published event, not the caller's error")
+	suite.Equal(404, published.ErrorStatus)
+	suite.Equal("user not found", published.ErrorMessage)
+}
+
+func (suite *MessagingSuite) TestPublishMiddleware_suppressEvent() {
+	var published []string
+	broker := local.Broker(local.WithSynchronousDispatch())
+	_, _ = broker.Subscribe(context.Background(), "UserService.Update", func(ctx context.Context, msg *eventsource.EventMessage) error {
+		published = append(published, msg.Key)
+		return nil
+	})
+
+	middleware := publishMiddleware(NewGateway(WithBroker(broker), WithSynchronousChain()))
+	ctx := metadata.WithRoute(context.Background(), metadata.EndpointRoute{ServiceName: "UserService", Name: "Update"})
+
+	response := &struct{ Name string }{Name: "Dude"}
+	invoke := func(suppress bool) {
+		res, err := middleware(ctx, &struct{}{}, func(ctx context.Context, req any) (any, error) {
+			if suppress {
+				metadata.SuppressEvent(ctx)
+			}
+			return response, nil
+		})
+		suite.Require().NoError(err)
+		suite.Same(response, res, "Suppressing the event should not affect the response")
+	}
+
+	invoke(true)
+	suite.Empty(published, "Suppressed calls should not publish their event")
+
+	invoke(false)
+	suite.Equal([]string{"UserService.Update"}, published, "Suppression should only apply to the call that asked for it")
+	suite.False(metadata.EventSuppressed(ctx), "Suppression should not leak into the caller's context")
+}
+
+func (suite *MessagingSuite) TestPublishBatch() {
+	broker := &batchBroker{}
+	publisher := NewPublisher(broker)
+
+	err := publisher.PublishBatch(context.Background(), "UserService", "Created", &struct{ ID string }{ID: "1"}, &struct{ ID string }{ID: "2"})
+	suite.Require().NoError(err)
+	suite.Require().Len(broker.batches, 1, "Brokers that support batching should receive all of the events at once")
+	suite.Empty(broker.published, "Batched events should not also be published one at a time")
+
+	batch := broker.batches[0]
+	suite.Require().Len(batch, 2)
+	for i, id := range []string{"1", "2"} {
+		var msg message
+		suite.Equal("UserService.Created", batch[i].Key)
+		suite.Require().NoError(json.Unmarshal(batch[i].Payload, &msg))
+		suite.Equal(id, msg.Values.Get("ID"))
+	}
+}
+
+func (suite *MessagingSuite) TestPublishBatch_fallback() {
+	var published []string
+	broker := local.Broker(local.WithSynchronousDispatch())
+	_, _ = broker.Subscribe(context.Background(), "UserService.Created", func(ctx context.Context, msg *eventsource.EventMessage) error {
+		published = append(published, msg.Key)
+		return nil
+	})
+	publisher := NewPublisher(broker)
+
+	err := publisher.PublishBatch(context.Background(), "UserService", "Created", &struct{}{}, &struct{}{}, &struct{}{})
+	suite.Require().NoError(err)
+	suite.Len(published, 3, "Brokers that don't support batching should publish each event individually")
+}
+
+func (suite *MessagingSuite) TestPublishBatch_shadowed() {
+	broker := &batchBroker{}
+	publisher := NewPublisher(broker)
+
+	err := publisher.PublishBatch(withShadow(context.Background()), "UserService", "Created", &struct{}{})
+	suite.Require().NoError(err)
+	suite.Empty(broker.batches, "Shadowed calls should not publish any events")
+
+	suite.Require().NoError(publisher.PublishBatch(context.Background(), "UserService", "Created"))
+	suite.Empty(broker.batches, "Empty batches should not bother the broker")
+}
+
+// batchBroker is a publisher that implements eventsource.BatchPublisher, recording everything it receives.
+type batchBroker struct {
+	published []string
+	batches   [][]eventsource.EventMessage
+}
+
+func (b *batchBroker) Publish(_ context.Context, key string, _ []byte) error {
+	b.published = append(b.published, key)
+	return nil
+}
+
+func (b *batchBroker) PublishBatch(_ context.Context, messages []eventsource.EventMessage) error {
+	b.batches = append(b.batches, messages)
+	return nil
+}
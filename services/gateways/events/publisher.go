@@ -0,0 +1,126 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bridgekit-io/frodo/codec"
+	"github.com/bridgekit-io/frodo/eventsource"
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/bridgekit-io/frodo/services"
+)
+
+// NewPublisher creates a Publisher that broadcasts events directly to the broker. Use the same broker (and
+// encoding) that the event gateways of your subscribing services use, so they actually receive the events.
+func NewPublisher(broker eventsource.Publisher, options ...PublisherOption) Publisher {
+	jsonEncoder := codec.JSONEncoder{}
+	publisher := Publisher{
+		broker:       broker,
+		keyNaming:    defaultKeyNaming,
+		encoder:      jsonEncoder,
+		valueEncoder: jsonEncoder,
+	}
+	for _, option := range options {
+		option(&publisher)
+	}
+	return publisher
+}
+
+// Publisher lets you fire off the same events that the event gateway publishes when a service function completes,
+// but without actually invoking the function. Any "ON Service.Function" subscribers will fire just like the function
+// succeeded and returned the value you publish. This is how a batch job or CLI tool can kick off an event-driven
+// flow without an API gateway (or the service itself) running anywhere.
+//
+// You typically won't use this directly. The publisher code generated by "frodo publisher" wraps this with
+// strongly-typed functions for each of your service's functions.
+type Publisher struct {
+	broker          eventsource.Publisher
+	keyNaming       KeyNamingFunc
+	encoder         codec.Encoder
+	valueEncoder    codec.ValueEncoder
+	compress        bool
+	compressMinSize int
+}
+
+// Publish broadcasts the event for "serviceName.functionName" completing successfully. The value is delivered
+// to subscribers as though the function returned it, and any metadata (authorization, trace id, values) on the
+// context follows the event to the subscribers just like it would for a real invocation. When you publish from
+// a shadow handler (see WithShadow), this quietly does nothing.
+func (p Publisher) Publish(ctx context.Context, serviceName string, functionName string, value any) error {
+	if Shadowed(ctx) {
+		return nil
+	}
+
+	endpoint := metadata.EndpointRoute{
+		ServiceName: serviceName,
+		Name:        functionName,
+		Type:        services.GatewayTypeEvents.String(),
+	}
+
+	msg := newMessage(ctx, endpoint, p.keyNaming, p.valueEncoder, nil, value, nil)
+	encoder := compressEvents(p.encoder, p.compress, p.compressMinSize)
+	if err := publishMessage(ctx, p.broker, encoder, msg); err != nil {
+		return fmt.Errorf("event publish error: %s: %w", msg.Key, err)
+	}
+	return nil
+}
+
+// PublishBatch broadcasts one "serviceName.functionName" event for each of the values, just like calling Publish()
+// for each one. When the broker implements eventsource.BatchPublisher (e.g. NATS pipelines them), they all go out in
+// a single batch, which is much faster when you produce a lot of events at once (e.g. one per row of an import).
+func (p Publisher) PublishBatch(ctx context.Context, serviceName string, functionName string, values ...any) error {
+	if Shadowed(ctx) || len(values) == 0 {
+		return nil
+	}
+
+	endpoint := metadata.EndpointRoute{
+		ServiceName: serviceName,
+		Name:        functionName,
+		Type:        services.GatewayTypeEvents.String(),
+	}
+
+	encoder := compressEvents(p.encoder, p.compress, p.compressMinSize)
+	messages := make([]eventsource.EventMessage, len(values))
+	for i, value := range values {
+		msg := newMessage(ctx, endpoint, p.keyNaming, p.valueEncoder, nil, value, nil)
+		payload, err := encodeMessage(encoder, msg)
+		if err != nil {
+			return fmt.Errorf("event publish error: %s: %w", msg.Key, err)
+		}
+		messages[i] = eventsource.EventMessage{Key: msg.Key, Payload: payload}
+	}
+	if err := eventsource.PublishBatch(ctx, p.broker, messages); err != nil {
+		return fmt.Errorf("event publish error: %w", err)
+	}
+	return nil
+}
+
+// PublisherOption defines a functional parameter that you can use to set up a Publisher.
+type PublisherOption func(publisher *Publisher)
+
+// WithPublisherEncoding customizes how events are marshaled before we hand them to the broker. This should
+// match the encoder you gave your event gateways using WithEncoding(). By default, we use standard library JSON.
+func WithPublisherEncoding(encoder codec.Encoder) PublisherOption {
+	return func(publisher *Publisher) {
+		publisher.encoder = encoder
+	}
+}
+
+// WithPublisherKeyNaming customizes the keys/topics that events are published to. This should match the naming
+// you gave your event gateways using WithKeyNaming(). By default, we publish to "Service.Function" keys.
+func WithPublisherKeyNaming(naming KeyNamingFunc) PublisherOption {
+	return func(publisher *Publisher) {
+		if naming != nil {
+			publisher.keyNaming = naming
+		}
+	}
+}
+
+// WithPublisherCompression gzips the events that you publish when they're at least 'minSize' bytes once encoded. This
+// is the publisher's version of the gateway's WithCompression() option.
+func WithPublisherCompression(minSize int) PublisherOption {
+	return func(publisher *Publisher) {
+		publisher.compress = true
+		publisher.compressMinSize = minSize
+	}
+}
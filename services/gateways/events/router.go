@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+
+	"github.com/bridgekit-io/frodo/eventsource"
+)
+
+// BrokerRouter picks the broker that should carry the events for the given key. The key is exactly what we publish
+// and subscribe to on the broker, so it's been through your WithKeyNaming() function, and failure events have the
+// ":Error" suffix (e.g. "OrderService.PlaceOrder:Error"). Return nil to use the gateway's default broker.
+type BrokerRouter func(key string) eventsource.Broker
+
+// WithBrokerRouter lets a single gateway spread its events across multiple brokers, so you can segment your event
+// traffic by criticality/volume without running separate server processes. The router decides which broker each
+// event key goes to, and the gateway uses that same broker to publish, subscribe to, and replay that key:
+//
+//	events.NewGateway(
+//		events.WithBroker(localBroker),
+//		events.WithBrokerRouter(func(key string) eventsource.Broker {
+//			if strings.HasPrefix(key, "OrderService.") {
+//				return kafkaBroker
+//			}
+//			return nil // everything else goes to localBroker
+//		}),
+//	)
+//
+// Every service that publishes or subscribes to a key must route it to the same broker, or they won't hear each
+// other's events. The router is called a lot (at least once per event published), so keep it fast.
+func WithBrokerRouter(router BrokerRouter) GatewayOption {
+	return func(gw *Gateway) {
+		gw.brokerRouter = router
+	}
+}
+
+// brokerFor returns the broker that carries the events for this key; whatever the router says or the default broker.
+func (gw *Gateway) brokerFor(key string) eventsource.Broker {
+	if gw.brokerRouter == nil {
+		return gw.broker
+	}
+	if broker := gw.brokerRouter(key); broker != nil {
+		return broker
+	}
+	return gw.broker
+}
+
+// routedPublisher publishes each event to the broker that the gateway routes its key to (see WithBrokerRouter).
+type routedPublisher struct {
+	gw *Gateway
+}
+
+// Publish hands the event off to the broker for this key.
+func (publisher routedPublisher) Publish(ctx context.Context, key string, payload []byte) error {
+	return publisher.gw.brokerFor(key).Publish(ctx, key, payload)
+}
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/bridgekit-io/frodo/internal/cron"
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/bridgekit-io/frodo/services"
+)
+
+// schedule is a single "SCHEDULE 0 2 * * *" route that the gateway invokes periodically rather than in response
+// to some event on the broker.
+type schedule struct {
+	next    func(t time.Time) time.Time
+	handler func(ctx context.Context)
+}
+
+// registerSchedule captures the handler info for an endpoint w/ the "SCHEDULE" doc option. Like subscriptions, we
+// don't actually start the clock until Listen() is fired on this gateway.
+func (gw *Gateway) registerSchedule(endpoint services.Endpoint, endpointRoute services.EndpointRoute) {
+	cronSchedule, err := cron.Parse(endpointRoute.Path)
+	if err != nil {
+		gw.errorListener(gw.toMetadataRoute(endpoint, endpointRoute), err)
+		return
+	}
+	gw.schedules = append(gw.schedules, &schedule{
+		next:    cronSchedule.Next,
+		handler: gw.toScheduleHandler(endpoint, endpointRoute),
+	})
+}
+
+// toScheduleHandler creates the function that invokes the endpoint every time its schedule fires. Since there's
+// no event that triggered this, the handler receives an empty request (plus any Defaults()) and a brand-new trace id.
+func (gw *Gateway) toScheduleHandler(endpoint services.Endpoint, endpointRoute services.EndpointRoute) func(ctx context.Context) {
+	route := gw.toMetadataRoute(endpoint, endpointRoute)
+
+	return func(ctx context.Context) {
+		if !gw.beginRequest() {
+			return
+		}
+		defer gw.activeRequests.Done()
+
+		ctx = metadata.WithTraceID(ctx, metadata.NewTraceID())
+		ctx = metadata.WithRoute(ctx, route)
+		serviceRequest := endpoint.NewInput()
+		services.ApplyDefaults(serviceRequest)
+		if err := gw.invokeHandler(ctx, endpoint, serviceRequest); err != nil {
+			gw.errorListener(route, gw.errorMapper.Map(err))
+		}
+	}
+}
+
+// runSchedule invokes the scheduled handler every time the cron schedule fires until the gateway shuts down. We
+// run the handler synchronously, so a slow job never overlaps with itself; if it runs past its next scheduled
+// time, we just skip that run and wait for the one after that.
+func (gw *Gateway) runSchedule(ctx context.Context, s *schedule) {
+	// Shutting down stops us from starting new runs, but it should not cancel a job that's already running. The
+	// Shutdown() call gives in-progress jobs a chance to finish just like in-progress events.
+	jobCtx := context.WithoutCancel(ctx)
+
+	for {
+		next := s.next(time.Now().In(gw.scheduleLocation))
+		if next.IsZero() {
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-gw.stopSchedules.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if gw.stopSchedules.Err() != nil {
+				return
+			}
+			s.handler(jobCtx)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+//go:build unit
+
+package events
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestScheduleSuite(t *testing.T) {
+	suite.Run(t, new(ScheduleSuite))
+}
+
+type ScheduleSuite struct {
+	suite.Suite
+}
+
+type scheduleRequest struct {
+	Name string
+}
+
+func (suite *ScheduleSuite) endpoint(handler services.HandlerFunc) services.Endpoint {
+	return services.Endpoint{
+		ServiceName: "JanitorService",
+		Name:        "Cleanup",
+		NewInput:    func() services.StructPointer { return &scheduleRequest{} },
+		Handler:     handler,
+	}
+}
+
+func (suite *ScheduleSuite) route(expression string) services.EndpointRoute {
+	return services.EndpointRoute{GatewayType: services.GatewayTypeEvents, Method: "SCHEDULE", Path: expression}
+}
+
+// start registers the endpoint, but fires the schedule every 10ms rather than waiting for the real cron
+// schedule, so we're not waiting around for minutes.
+func (suite *ScheduleSuite) start(gw *Gateway, endpoint services.Endpoint) {
+	gw.Register(endpoint, suite.route("* * * * *"))
+	suite.Require().Len(gw.schedules, 1)
+	gw.schedules[0].next = func(t time.Time) time.Time { return t.Add(10 * time.Millisecond) }
+	go func() { _ = gw.Listen(context.Background()) }()
+}
+
+func (suite *ScheduleSuite) TestSchedule() {
+	calls := atomic.Int64{}
+	routes := make(chan metadata.EndpointRoute, 100)
+	traceIDs := make(chan string, 100)
+	endpoint := suite.endpoint(func(ctx context.Context, req any) (any, error) {
+		suite.Equal(&scheduleRequest{}, req, "Scheduled calls should receive an empty request")
+		routes <- metadata.Route(ctx)
+		traceIDs <- metadata.TraceID(ctx)
+		calls.Add(1)
+		return nil, nil
+	})
+
+	gw := NewGateway()
+	suite.start(gw, endpoint)
+	suite.Eventually(func() bool { return calls.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	suite.Require().NoError(gw.Shutdown(context.Background()))
+
+	// Give any stray timers a chance to fire. They shouldn't.
+	stopped := calls.Load()
+	time.Sleep(50 * time.Millisecond)
+	suite.Equal(stopped, calls.Load(), "Schedules should stop firing after shutdown")
+
+	route := <-routes
+	suite.Equal("JanitorService.Cleanup", route.QualifiedName())
+	suite.Equal("SCHEDULE", route.Method)
+	suite.Equal("* * * * *", route.Path)
+
+	traceA, traceB := <-traceIDs, <-traceIDs
+	suite.NotEmpty(traceA)
+	suite.NotEqual(traceA, traceB, "Every run should get its own trace id")
+}
+
+func (suite *ScheduleSuite) TestSchedule_error() {
+	errs := make(chan error, 100)
+	gw := NewGateway(WithErrorListener(func(route metadata.EndpointRoute, err error) {
+		errs <- err
+	}))
+	suite.start(gw, suite.endpoint(func(ctx context.Context, req any) (any, error) {
+		return nil, context.DeadlineExceeded
+	}))
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+
+	select {
+	case err := <-errs:
+		suite.ErrorIs(err, context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		suite.Fail("Failed scheduled jobs should be reported to the error listener")
+	}
+}
+
+func (suite *ScheduleSuite) TestSchedule_errorMapper() {
+	errs := make(chan error, 100)
+	gw := NewGateway(
+		WithErrorListener(func(route metadata.EndpointRoute, err error) {
+			errs <- err
+		}),
+		WithErrorMapper(func(err error) error {
+			return fail.Unavailable("mapped: %v", err)
+		}),
+	)
+	suite.start(gw, suite.endpoint(func(ctx context.Context, req any) (any, error) {
+		return nil, context.DeadlineExceeded
+	}))
+	defer func() { _ = gw.Shutdown(context.Background()) }()
+
+	select {
+	case err := <-errs:
+		suite.Equal(503, fail.Status(err))
+		suite.EqualError(err, "mapped: context deadline exceeded")
+	case <-time.After(time.Second):
+		suite.Fail("Failed scheduled jobs should be reported to the error listener")
+	}
+}
+
+func (suite *ScheduleSuite) TestSchedule_invalid() {
+	errs := make(chan error, 1)
+	gw := NewGateway(WithErrorListener(func(route metadata.EndpointRoute, err error) {
+		errs <- err
+	}))
+	gw.Register(suite.endpoint(nil), suite.route("61 * * * *"))
+	suite.Empty(gw.schedules)
+	suite.Error(<-errs)
+}
+
+// Shutting down should stop scheduling new runs, but it should let the current one finish.
+func (suite *ScheduleSuite) TestSchedule_shutdownWaitsForJob() {
+	started := make(chan struct{}, 100)
+	finished := atomic.Bool{}
+	gw := NewGateway()
+	suite.start(gw, suite.endpoint(func(ctx context.Context, req any) (any, error) {
+		started <- struct{}{}
+		time.Sleep(100 * time.Millisecond)
+		suite.NoError(ctx.Err(), "Shutdown should not cancel in-progress jobs")
+		finished.Store(true)
+		return nil, nil
+	}))
+
+	<-started
+	suite.Require().NoError(gw.Shutdown(context.Background()))
+	suite.True(finished.Load(), "Shutdown should wait for in-progress jobs")
+}
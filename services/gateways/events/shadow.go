@@ -0,0 +1,57 @@
+package events
+
+import (
+	"context"
+)
+
+type contextKeyShadow struct{}
+
+// WithShadow runs the given "ON" event handlers in shadow mode, so you can validate a new consumer against real
+// traffic before you trust it. Name each handler using its "Service.Function" name:
+//
+//	events.NewGateway(
+//		events.WithBroker(broker),
+//		events.WithShadow("ShippingService.ShipDomestic"),
+//	)
+//
+// Shadow handlers still receive, decode, and handle every event like normal, but:
+//
+//   - Nothing they do publishes events; not their own "Service.Function" event, nor the events of any functions
+//     that they invoke in the same process. Calls to other processes (e.g. using a generated client) are
+//     handled like any other call, though, so they publish their own events as usual.
+//   - Failures only go to your WithErrorListener() callback. The broker sees the event as handled, so it won't
+//     redeliver it or send it to your dead letter queue.
+//
+// The handler itself still runs for real, so use Shadowed() to skip any other side effects (database writes,
+// emails, etc.) while you're still testing it. Schedules ("SCHEDULE" doc option) are never shadowed.
+func WithShadow(qualifiedNames ...string) GatewayOption {
+	return func(gw *Gateway) {
+		if gw.shadowRoutes == nil {
+			gw.shadowRoutes = map[string]bool{}
+		}
+		for _, qualifiedName := range qualifiedNames {
+			gw.shadowRoutes[qualifiedName] = true
+		}
+	}
+}
+
+// Shadowed returns true when the current call is running in shadow mode (see WithShadow), so your handler
+// can skip the side effects that it shouldn't perform until you're confident that it works.
+//
+//	if events.Shadowed(ctx) {
+//		log.Printf("would have shipped order %s", req.OrderID)
+//		return &ShipDomesticResponse{}, nil
+//	}
+func Shadowed(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	shadowed, _ := ctx.Value(contextKeyShadow{}).(bool)
+	return shadowed
+}
+
+// withShadow marks the context as belonging to a shadow handler, so that Shadowed() returns true for
+// it and every in-process call made from it.
+func withShadow(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextKeyShadow{}, true)
+}
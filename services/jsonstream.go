@@ -0,0 +1,130 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+)
+
+// JSONStreamGetter lets your response send back a (potentially huge) list of items one at a time rather than
+// encoding the whole thing up front. The API gateway writes each item as its own line of newline-delimited JSON
+// (Content-Type "application/x-ndjson"), flushing after each one.
+type JSONStreamGetter interface {
+	// StreamJSON calls 'write' with each item in the stream, in order. It stops early and returns the
+	// error when 'write' fails (e.g. the caller went away).
+	StreamJSON(write func(item any) error) error
+}
+
+// JSONStreamSetter lets the code-generated Go client hand the newline-delimited JSON body of the response to the
+// response value, so the caller can decode the items as they arrive.
+type JSONStreamSetter interface {
+	// SetJSONStream applies the raw body that the response should decode its items from.
+	SetJSONStream(body io.ReadCloser)
+}
+
+// JSONStream lets you respond with a stream of items rather than building a giant slice in memory (e.g. exporting
+// millions of rows). Embed it in your response struct, and have your handler push items to a channel. The API
+// gateway writes each item as newline-delimited JSON as soon as you send it:
+//
+//	type ExportUsersResponse struct {
+//		services.JSONStream[User]
+//	}
+//
+//	func (svc UserServiceHandler) ExportUsers(ctx context.Context, req *ExportUsersRequest) (*ExportUsersResponse, error) {
+//		items := make(chan User)
+//		go func() {
+//			defer close(items)
+//			for rows.Next() {
+//				select {
+//				case items <- toUser(rows):
+//				case <-ctx.Done():
+//					return // the caller went away, so stop producing
+//				}
+//			}
+//		}()
+//
+//		res := &ExportUsersResponse{}
+//		res.SetItems(items)
+//		return res, nil
+//	}
+//
+// You MUST close the channel when you're done, or the response never finishes. Since the gateway has already written
+// a 200 by the time you send the first item, there's no way to report an error mid-stream. When the caller can't tell
+// a failure from the end of the list, include something in the items that lets them know (e.g. a final summary item).
+//
+// On the client side, the generated Go client gives the body to SetJSONStream(), so you can range over Items() to
+// decode them as they arrive:
+//
+//	res, err := client.ExportUsers(ctx, &ExportUsersRequest{})
+//	...
+//	for user, err := range res.Items() {
+//		...
+//	}
+//
+// GATEWAY COMPATABILITY: Only the API gateway streams the items. Other gateways such as "Events" encode your response
+// like any other value, and since there are no exported fields, the items aren't included.
+type JSONStream[T any] struct {
+	items <-chan T
+	body  io.ReadCloser
+}
+
+// SetItems supplies the channel that the gateway reads items from. Close it once you've sent the last item.
+func (stream *JSONStream[T]) SetItems(items <-chan T) {
+	stream.items = items
+}
+
+// StreamJSON calls 'write' with each item you send on the SetItems() channel until you close it.
+func (stream *JSONStream[T]) StreamJSON(write func(item any) error) error {
+	if stream.items == nil {
+		return nil
+	}
+	for item := range stream.items {
+		if err := write(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetJSONStream applies the newline-delimited JSON body that Items() decodes the items from.
+func (stream *JSONStream[T]) SetJSONStream(body io.ReadCloser) {
+	stream.body = body
+}
+
+// Items iterates over every item in the stream. On the client, it decodes each item from the response body as it
+// arrives, closing the body once you're done (even if you break out of the loop early). If an item fails to decode,
+// you get that error and iteration stops. When you call your handler directly, it just reads from the channel.
+func (stream *JSONStream[T]) Items() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if stream.body == nil {
+			for item := range stream.items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			return
+		}
+
+		defer stream.body.Close()
+		decoder := json.NewDecoder(stream.body)
+		for {
+			var item T
+			err := decoder.Decode(&item)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if !yield(item, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Close releases the response body when you don't intend to read the items with Items().
+func (stream *JSONStream[T]) Close() error {
+	if stream.body == nil {
+		return nil
+	}
+	return stream.body.Close()
+}
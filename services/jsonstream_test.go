@@ -0,0 +1,125 @@
+//go:build unit
+
+package services_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/stretchr/testify/require"
+)
+
+type streamedDrink struct {
+	Name string
+}
+
+type drinksResponse struct {
+	services.JSONStream[streamedDrink]
+}
+
+func TestJSONStream_StreamJSON(t *testing.T) {
+	assert := require.New(t)
+
+	items := make(chan streamedDrink, 3)
+	items <- streamedDrink{Name: "White Russian"}
+	items <- streamedDrink{Name: "Oat Soda"}
+	close(items)
+
+	res := &drinksResponse{}
+	res.SetItems(items)
+
+	var written []any
+	assert.NoError(res.StreamJSON(func(item any) error {
+		written = append(written, item)
+		return nil
+	}))
+	assert.Equal([]any{streamedDrink{Name: "White Russian"}, streamedDrink{Name: "Oat Soda"}}, written)
+
+	assert.NoError((&drinksResponse{}).StreamJSON(func(item any) error { return nil }), "Should not block w/o a channel")
+}
+
+func TestJSONStream_StreamJSON_writeError(t *testing.T) {
+	assert := require.New(t)
+
+	items := make(chan streamedDrink, 3)
+	items <- streamedDrink{Name: "White Russian"}
+	items <- streamedDrink{Name: "Oat Soda"}
+	close(items)
+
+	res := &drinksResponse{}
+	res.SetItems(items)
+
+	calls := 0
+	err := res.StreamJSON(func(item any) error {
+		calls++
+		return io.ErrClosedPipe
+	})
+	assert.ErrorIs(err, io.ErrClosedPipe)
+	assert.Equal(1, calls, "Should stop at the first failed write")
+}
+
+type trackedBody struct {
+	io.Reader
+	closed bool
+}
+
+func (body *trackedBody) Close() error {
+	body.closed = true
+	return nil
+}
+
+func TestJSONStream_Items(t *testing.T) {
+	assert := require.New(t)
+
+	body := &trackedBody{Reader: strings.NewReader("{\"Name\":\"White Russian\"}\n{\"Name\":\"Oat Soda\"}\n")}
+	res := &drinksResponse{}
+	res.SetJSONStream(body)
+
+	var names []string
+	for drink, err := range res.Items() {
+		assert.NoError(err)
+		names = append(names, drink.Name)
+	}
+	assert.Equal([]string{"White Russian", "Oat Soda"}, names)
+	assert.True(body.closed, "Should close the body once all items are read")
+}
+
+func TestJSONStream_Items_decodeError(t *testing.T) {
+	assert := require.New(t)
+
+	res := &drinksResponse{}
+	res.SetJSONStream(&trackedBody{Reader: strings.NewReader("{\"Name\":\"White Russian\"}\n{\"Name\":\n")})
+
+	var names []string
+	var errs []error
+	for drink, err := range res.Items() {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		names = append(names, drink.Name)
+	}
+	assert.Equal([]string{"White Russian"}, names)
+	assert.Len(errs, 1, "Should stop after the first decoding error")
+}
+
+func TestJSONStream_Items_channel(t *testing.T) {
+	assert := require.New(t)
+
+	items := make(chan streamedDrink, 3)
+	items <- streamedDrink{Name: "White Russian"}
+	items <- streamedDrink{Name: "Oat Soda"}
+	close(items)
+
+	res := &drinksResponse{}
+	res.SetItems(items)
+
+	var names []string
+	for drink, err := range res.Items() {
+		assert.NoError(err)
+		names = append(names, drink.Name)
+	}
+	assert.Equal([]string{"White Russian", "Oat Soda"}, names)
+}
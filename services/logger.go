@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/bridgekit-io/frodo/internal/reflection"
+	"github.com/bridgekit-io/frodo/metadata"
+)
+
+type contextKeyLogger struct{}
+
+// Logger returns the request-scoped logger for the current service call. It's the server's logger (see
+// WithLogger()) already decorated with the "trace_id" and "route" of the call, so you don't have to keep
+// threading the trace id through all of your log statements yourself:
+//
+//	func (svc UserServiceHandler) Create(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+//		services.Logger(ctx).Info("creating user", "email", req.Email)
+//		...
+//	}
+//
+// If you call this outside of a service call (e.g. in a unit test), you'll get slog.Default().
+func Logger(ctx context.Context) *slog.Logger {
+	if ctx == nil {
+		return slog.Default()
+	}
+	if logger, ok := ctx.Value(contextKeyLogger{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// loggerMiddleware derives a child logger tagged w/ the trace id and route of the current call, so that
+// handlers can fetch it using Logger(ctx). This is endpoint middleware, so it works for every gateway.
+func loggerMiddleware(logger *slog.Logger) MiddlewareFunc {
+	return func(ctx context.Context, req any, next HandlerFunc) (any, error) {
+		requestLogger := logger.With(
+			"trace_id", metadata.TraceID(ctx),
+			"route", metadata.Route(ctx).QualifiedName(),
+		)
+		return next(context.WithValue(ctx, contextKeyLogger{}, requestLogger), req)
+	}
+}
+
+// RedactedValue is the placeholder that Redact() uses in place of sensitive values.
+const RedactedValue = "***"
+
+// Redact returns a version of your value that is safe to dump in a log. Structs are converted to a map keyed
+// by each field's binding name (i.e. it honors `json` tags) and any field tagged `sensitive:"true"` has its
+// value replaced by "***". Nested structs, pointers, slices, and maps are followed, so a password buried in some
+// user struct within your request is still hidden. Tagging an embedded struct as sensitive hides all of its fields.
+//
+//	type LoginRequest struct {
+//		Email    string
+//		Password string `sensitive:"true"`
+//	}
+//
+//	services.Logger(ctx).Info("logging in", "request", services.Redact(req))
+//
+// Types that control their own encoding via json.Marshaler or encoding.TextMarshaler (e.g. time.Time) are
+// left as-is.
+func Redact(value any) any {
+	if value == nil {
+		return nil
+	}
+	return redactValue(reflect.ValueOf(value))
+}
+
+func redactValue(value reflect.Value) any {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return redactValue(value.Elem())
+
+	case reflect.Struct:
+		if isSelfEncoding(value.Type()) {
+			return value.Interface()
+		}
+		out := map[string]any{}
+		redactFields(value, out, false)
+		return out
+
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return value.Interface()
+		}
+		if !mayHoldStructs(value.Type().Elem()) {
+			return value.Interface()
+		}
+		out := make([]any, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			out[i] = redactValue(value.Index(i))
+		}
+		return out
+
+	case reflect.Map:
+		if value.IsNil() || !mayHoldStructs(value.Type().Elem()) {
+			return value.Interface()
+		}
+		out := make(map[string]any, value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			out[fmt.Sprint(iter.Key().Interface())] = redactValue(iter.Value())
+		}
+		return out
+
+	default:
+		return value.Interface()
+	}
+}
+
+// redactFields writes the redacted version of every exported field on the struct to the output map. The fields
+// of embedded structs are written to the same map, just like they'd appear in the struct's JSON. When the struct
+// itself is sensitive (i.e. it's embedded w/ a `sensitive` tag), every one of its fields is redacted.
+func redactFields(structValue reflect.Value, out map[string]any, sensitive bool) {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := structValue.Field(i)
+
+		switch {
+		case field.Tag.Get("json") == "-":
+			continue
+		case field.Anonymous && reflection.IsStructOrPointerTo(field.Type):
+			if fieldValue = reflect.Indirect(fieldValue); fieldValue.IsValid() {
+				redactFields(fieldValue, out, sensitive || reflection.IsSensitive(field))
+			}
+		case !field.IsExported() || !fieldValue.CanInterface():
+			continue
+		case sensitive || reflection.IsSensitive(field):
+			out[reflection.BindingName(field)] = RedactedValue
+		default:
+			out[reflection.BindingName(field)] = redactValue(fieldValue)
+		}
+	}
+}
+
+// mayHoldStructs returns true if values of this slice/map element type could be structs that we need to redact.
+func mayHoldStructs(t reflect.Type) bool {
+	return t.Kind() == reflect.Interface || reflection.IsStructOrPointerTo(t)
+}
+
+// isSelfEncoding returns true if the type controls its own encoding, so we shouldn't pick it apart field by field.
+func isSelfEncoding(t reflect.Type) bool {
+	pointerType := reflect.PointerTo(t)
+	return pointerType.Implements(jsonMarshalerType) || pointerType.Implements(textMarshalerType)
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
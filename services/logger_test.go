@@ -0,0 +1,142 @@
+//go:build unit
+
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/stretchr/testify/require"
+)
+
+type redactRequest struct {
+	redactPaging
+	Token    string `sensitive:"true"`
+	User     redactUser
+	UserPtr  *redactUser
+	Users    []redactUser
+	Tags     []string
+	Created  time.Time
+	Ignored  string `json:"-"`
+	internal string
+}
+
+type redactPaging struct {
+	Page int
+}
+
+type redactUser struct {
+	Name     string `json:"name"`
+	Password string `json:"password" sensitive:"true"`
+	PIN      *int   `sensitive:"true"`
+}
+
+func TestRedact(t *testing.T) {
+	assert := require.New(t)
+
+	pin := 1234
+	created := time.Date(2020, time.November, 11, 12, 0, 0, 0, time.UTC)
+	redacted := services.Redact(&redactRequest{
+		redactPaging: redactPaging{Page: 3},
+		Token:        "abc123",
+		User:         redactUser{Name: "Dude", Password: "hunter2", PIN: &pin},
+		UserPtr:      &redactUser{Name: "Walter", Password: "shomer shabbos"},
+		Users:        []redactUser{{Name: "Donny", Password: "bowling"}},
+		Tags:         []string{"a", "b"},
+		Created:      created,
+		Ignored:      "Ignore me",
+		internal:     "Ignore me, too",
+	})
+
+	assert.Equal(map[string]any{
+		"Page":  3,
+		"Token": services.RedactedValue,
+		"User": map[string]any{
+			"name":     "Dude",
+			"password": services.RedactedValue,
+			"PIN":      services.RedactedValue,
+		},
+		"UserPtr": map[string]any{
+			"name":     "Walter",
+			"password": services.RedactedValue,
+			"PIN":      services.RedactedValue,
+		},
+		"Users": []any{
+			map[string]any{
+				"name":     "Donny",
+				"password": services.RedactedValue,
+				"PIN":      services.RedactedValue,
+			},
+		},
+		"Tags":    []string{"a", "b"},
+		"Created": created,
+	}, redacted)
+}
+
+func TestRedact_nonStructs(t *testing.T) {
+	assert := require.New(t)
+
+	var nilRequest *redactRequest
+	assert.Nil(services.Redact(nil))
+	assert.Nil(services.Redact(nilRequest))
+	assert.Equal("Abide", services.Redact("Abide"))
+	assert.Equal(42, services.Redact(42))
+}
+
+func TestRedact_maps(t *testing.T) {
+	assert := require.New(t)
+
+	redacted := services.Redact(map[string]any{
+		"users": map[int]redactUser{
+			1: {Name: "Dude", Password: "hunter2"},
+		},
+		"admin": &redactUser{Name: "Walter", Password: "shomer shabbos"},
+		"rug":   "really tied the room together",
+	})
+
+	assert.Equal(map[string]any{
+		"users": map[string]any{
+			"1": map[string]any{
+				"name":     "Dude",
+				"password": services.RedactedValue,
+				"PIN":      services.RedactedValue,
+			},
+		},
+		"admin": map[string]any{
+			"name":     "Walter",
+			"password": services.RedactedValue,
+			"PIN":      services.RedactedValue,
+		},
+		"rug": "really tied the room together",
+	}, redacted)
+
+	tags := map[string]string{"a": "b"}
+	assert.Equal(tags, services.Redact(tags), "Maps that can't hold structs should be left as-is")
+}
+
+func TestRedact_sensitiveEmbedded(t *testing.T) {
+	type credentials struct {
+		Username string
+		Password string
+	}
+	type loginRequest struct {
+		credentials `sensitive:"true"`
+		redactPaging
+		Remember bool
+	}
+	assert := require.New(t)
+
+	redacted := services.Redact(loginRequest{
+		credentials:  credentials{Username: "dude", Password: "hunter2"},
+		redactPaging: redactPaging{Page: 1},
+		Remember:     true,
+	})
+
+	assert.Equal(map[string]any{
+		"Username": services.RedactedValue,
+		"Password": services.RedactedValue,
+		"Page":     1,
+		"Remember": true,
+	}, redacted)
+}
@@ -39,8 +39,10 @@ func (funcs MiddlewareFuncs) Append(mw ...MiddlewareFunc) MiddlewareFuncs {
 }
 
 // recoverMiddleware gets added as our outermost middleware to ensure that any accidental panic()
-// calls at any level are gracefully caught without killing our server/process.
-func recoverMiddleware(handler OnPanicFunc) MiddlewareFunc {
+// calls at any level are gracefully caught without killing our server/process. When 'propagate' is
+// true (see WithPanicPropagation), we still notify the handler, but then we re-panic with the original
+// value rather than turning it into an error.
+func recoverMiddleware(handler OnPanicFunc, propagate bool) MiddlewareFunc {
 	toError := func(recovery any) error {
 		switch val := recovery.(type) {
 		case error:
@@ -60,6 +62,10 @@ func recoverMiddleware(handler OnPanicFunc) MiddlewareFunc {
 				// This changes the 'err' return value so the request fails as expected.
 				err = toError(recovery)
 				handler(err, debug.Stack())
+
+				if propagate {
+					panic(recovery)
+				}
 			}
 		}()
 		return next(ctx, req)
@@ -69,6 +75,7 @@ func recoverMiddleware(handler OnPanicFunc) MiddlewareFunc {
 // rolesMiddleware takes the raw doc option roles list such as ["admin.write", "group.{ID}.write"] and populates
 // the path variables w/ runtime values, so you end up with a roles list like ["admin.write", "group.123.write"]. For
 // any path variables that can't be properly mapped to a runtime value, those will end up blank (e.g. "group..write").
+// It also tells the route whether the caller needs all of those roles or just one of them (see "ROLES ALL").
 func rolesMiddleware(endpoint Endpoint) MiddlewareFunc {
 	return func(ctx context.Context, req any, next HandlerFunc) (any, error) {
 		populateRole := func(role string) string {
@@ -81,6 +88,7 @@ func rolesMiddleware(endpoint Endpoint) MiddlewareFunc {
 
 		route := metadata.Route(ctx)
 		route.Roles = slices.Map(endpoint.Roles, populateRole)
+		route.RequireAllRoles = endpoint.RequireAllRoles
 		return next(metadata.WithRoute(ctx, route), req)
 	}
 }
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"encoding/json"
+)
+
+// RawJSONGetter provides a way to tell gateways that the response value already contains its
+// fully-encoded JSON, so they can write those bytes as-is rather than encoding the response.
+type RawJSONGetter interface {
+	// Raw returns the already-encoded JSON that should be the body of the response.
+	Raw() json.RawMessage
+}
+
+// RawJSONSetter lets clients hand the raw JSON body of a response to the response value rather
+// than decoding it into individual fields.
+type RawJSONSetter interface {
+	// SetRaw applies the already-encoded JSON that was the body of the response.
+	SetRaw(data json.RawMessage)
+}
+
+// RawJSON lets your response skip the decode/encode round trip when you already have the JSON that you want to
+// send back (e.g. a passthrough endpoint that serves data from a cache). Embed it in your response struct, and
+// the API gateway writes the bytes straight to the response body w/ a Content-Type of "application/json":
+//
+//	type GetProfileResponse struct {
+//		services.RawJSON
+//	}
+//
+//	func (svc ProfileServiceHandler) GetProfile(ctx context.Context, req *GetProfileRequest) (*GetProfileResponse, error) {
+//		cached, err := svc.Cache.Get(ctx, "profile:"+req.ID)
+//		...
+//		res := &GetProfileResponse{}
+//		res.SetRaw(cached)
+//		return res, nil
+//	}
+//
+// The gateway does NOT validate the bytes, so it's on you to make sure that they're actually valid JSON. Since the
+// raw data IS the response, any other fields on your response struct are ignored. On the client side, the generated
+// Go client hands the body to SetRaw() rather than decoding it, so you can call Raw() to get the bytes back out.
+//
+// GATEWAY COMPATABILITY: Only the API gateway skips encoding. Other gateways such as "Events" encode your response
+// like any other value, which still produces the raw JSON since RawJSON implements json.Marshaler.
+type RawJSON struct {
+	data json.RawMessage
+}
+
+// Raw returns the already-encoded JSON that should be the body of the response.
+func (res *RawJSON) Raw() json.RawMessage {
+	return res.data
+}
+
+// SetRaw applies the already-encoded JSON that should be the body of the response.
+func (res *RawJSON) SetRaw(data json.RawMessage) {
+	res.data = data
+}
+
+// MarshalJSON returns the raw bytes as-is, so that encoding the response (e.g. for events or response
+// envelopes) produces the same JSON that the API gateway would have written directly.
+func (res *RawJSON) MarshalJSON() ([]byte, error) {
+	if len(res.data) == 0 {
+		return []byte("null"), nil
+	}
+	return res.data, nil
+}
+
+// UnmarshalJSON captures the raw bytes rather than decoding them, so anything that decodes this
+// response (e.g. clients or event subscribers) can get the original JSON back out using Raw().
+func (res *RawJSON) UnmarshalJSON(data []byte) error {
+	res.data = append(json.RawMessage{}, data...)
+	return nil
+}
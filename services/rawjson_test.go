@@ -0,0 +1,41 @@
+//go:build unit
+
+package services_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/stretchr/testify/require"
+)
+
+type rawProfileResponse struct {
+	services.RawJSON
+}
+
+func TestRawJSON_Marshal(t *testing.T) {
+	assert := require.New(t)
+
+	res := &rawProfileResponse{}
+	data, err := json.Marshal(res)
+	assert.NoError(err)
+	assert.Equal("null", string(data), "Empty raw JSON should encode as null")
+
+	res.SetRaw(json.RawMessage(`{"Name":"Dude","Drinks":["White Russian"]}`))
+	data, err = json.Marshal(res)
+	assert.NoError(err)
+	assert.Equal(`{"Name":"Dude","Drinks":["White Russian"]}`, string(data), "Should encode the raw bytes as the response")
+}
+
+func TestRawJSON_Unmarshal(t *testing.T) {
+	assert := require.New(t)
+
+	input := []byte(`{"Name":"Dude","Drinks":["White Russian"]}`)
+	res := &rawProfileResponse{}
+	assert.NoError(json.Unmarshal(input, res))
+	assert.JSONEq(string(input), string(res.Raw()))
+
+	input[2] = 'X'
+	assert.JSONEq(`{"Name":"Dude","Drinks":["White Russian"]}`, string(res.Raw()), "Should copy the bytes, not reference them")
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -32,6 +34,14 @@ const (
 	GatewayTypeEvents = GatewayType("EVENTS")
 )
 
+// PingPath is the framework-reserved route that the API gateway answers with a 200 so that callers can confirm
+// that a service is reachable without invoking one of its real functions. See clients.Client.Ping().
+const PingPath = "/_frodo/ping"
+
+// BatchPath is the framework-reserved route that the API gateway uses to accept multiple service calls in a single
+// request when you enable batching. See apis.WithBatching() and clients.Batch.
+const BatchPath = "/_frodo/batch"
+
 // Gateway describes a way to execute operations on some underlying service. By
 // default, service methods are closed off to all external processes, but gateways
 // provide a protocol such as HTTP/RPC or PubSub to trigger them. How that actually
@@ -82,6 +92,17 @@ type GatewayMiddleware interface {
 	Middleware() MiddlewareFuncs
 }
 
+// GatewayListening is an optional interface that gateways implement to tell the server exactly when Listen()
+// has started accepting requests. Listen() blocks for the life of the gateway, so without this, the best you can
+// do is call it in a goroutine and hope that it's ready by the time you send it work. The API gateway, for instance,
+// signals once it has bound its port, and the event gateway signals once it has subscribed to all of its routes.
+type GatewayListening interface {
+	Gateway
+	// Listening returns a channel that is closed once the gateway is actually accepting requests. It is never
+	// closed if Listen() fails before getting that far.
+	Listening() <-chan struct{}
+}
+
 // Service encapsulates your hand-implemented service handler and includes all of the
 // endpoint registration information required to power our runtime gateways.
 type Service struct {
@@ -108,6 +129,65 @@ func (svc Service) Endpoint(name string) (Endpoint, bool) {
 	return Endpoint{}, false
 }
 
+// mount returns a copy of the service whose API routes are all prefixed w/ the given path. We copy the endpoints and
+// routes rather than updating them in place, so that you can safely mount the same service in multiple places.
+func (svc Service) mount(prefix string) *Service {
+	prefix = "/" + strings.Trim(strings.TrimSpace(prefix), "/")
+	if prefix == "/" {
+		return &svc
+	}
+
+	endpoints := make([]Endpoint, len(svc.Endpoints))
+	for i, endpoint := range svc.Endpoints {
+		routes := make([]EndpointRoute, len(endpoint.Routes))
+		for j, route := range endpoint.Routes {
+			if route.GatewayType == GatewayTypeAPI {
+				route.Path = prefix + "/" + strings.TrimPrefix(route.Path, "/")
+			}
+			routes[j] = route
+		}
+		endpoint.Routes = routes
+		endpoints[i] = endpoint
+	}
+	svc.Endpoints = endpoints
+	return &svc
+}
+
+// rename returns a copy of the service that runs under a different service name (and optionally version). Every endpoint
+// and route gets the new name, and so do default API paths like "/FooService.Bar". Like mount(), we copy the endpoints and
+// routes rather than updating them in place, so you can register the original service alongside the renamed one.
+func (svc Service) rename(name string, version string) *Service {
+	name = strings.TrimSpace(name)
+	if name == "" || name == svc.Name {
+		if version != "" {
+			svc.Version = version
+		}
+		return &svc
+	}
+
+	endpoints := make([]Endpoint, len(svc.Endpoints))
+	for i, endpoint := range svc.Endpoints {
+		defaultPath := "/" + endpoint.QualifiedName()
+		routes := make([]EndpointRoute, len(endpoint.Routes))
+		for j, route := range endpoint.Routes {
+			if route.GatewayType == GatewayTypeAPI && strings.HasSuffix(route.Path, defaultPath) {
+				route.Path = strings.TrimSuffix(route.Path, defaultPath) + "/" + name + "." + endpoint.Name
+			}
+			route.ServiceName = name
+			routes[j] = route
+		}
+		endpoint.ServiceName = name
+		endpoint.Routes = routes
+		endpoints[i] = endpoint
+	}
+	svc.Name = name
+	if version != "" {
+		svc.Version = version
+	}
+	svc.Endpoints = endpoints
+	return &svc
+}
+
 // NewServer creates a new container that encapsulates one or more gateways and
 // services. It helps set up endpoint routes and manages startup/shutdown routines
 // so that you can start/stop accepting service requests.
@@ -126,12 +206,14 @@ func NewServer(options ...ServerOption) *Server {
 	instance := Server{
 		gateways:          map[GatewayType]Gateway{},
 		shutdownComplete:  &sync.WaitGroup{},
+		listening:         make(chan struct{}),
 		gatewayMiddleware: MiddlewareFuncs{},
 		endpoints:         map[string]Endpoint{},
 		onPanic: func(err error, stack []byte) {
 			fmt.Printf("Panic: %v\n%v\n", err, string(stack))
 		},
-		logger: slog.New(slog.NewJSONHandler(nopWriter{}, nil)),
+		logger:         slog.New(slog.NewJSONHandler(nopWriter{}, nil)),
+		disabledStatus: http.StatusNotImplemented,
 	}
 	for _, option := range options {
 		option(&instance)
@@ -174,14 +256,34 @@ type Server struct {
 	endpoints map[string]Endpoint
 	// shutdownComplete waits for all gateways to be shut down before we exit.
 	shutdownComplete *sync.WaitGroup
+	// listening is closed once every gateway that supports GatewayListening is accepting requests.
+	listening chan struct{}
+	// listeningOnce makes sure that we only close the listening channel once, even if you call Run() again.
+	listeningOnce sync.Once
 	// gatewayMiddleware aggregates all endpoint middleware functions that we want to occur on ALL
 	// endpoints regardless of the gateway that's handling it.
 	gatewayMiddleware MiddlewareFuncs
+	// middleware are the user-defined functions (see WithMiddleware) that run on every endpoint of every service.
+	middleware MiddlewareFuncs
 	// onPanic is a customizable callback that lets you perform custom logging/logic whenever the server
 	// recovers from a panic that occurred during your function calls.
 	onPanic OnPanicFunc
+	// propagatePanics re-panics after invoking onPanic rather than converting the panic into an error.
+	propagatePanics bool
 	// logger customizes how you want low-level frodo logging to be written.
 	logger *slog.Logger
+	// ready is true while the server is running. It flips to false once we actually start shutting down.
+	ready atomic.Bool
+	// drainStarted is when BeginDrain() was called (as Unix nanos), or 0 if we're not draining. While
+	// draining, readiness checks tell the load balancer to stop sending us traffic.
+	drainStarted atomic.Int64
+	// lameDuck is how long we keep serving requests after we start draining before we actually
+	// start shutting down the gateways.
+	lameDuck time.Duration
+	// disabled contains the qualified names of all endpoints that have been switched off at runtime.
+	disabled sync.Map
+	// disabledStatus is the status code of the error we return when you invoke a disabled endpoint.
+	disabledStatus int
 }
 
 func (server *Server) registerEndpoint(endpoint Endpoint) {
@@ -190,8 +292,11 @@ func (server *Server) registerEndpoint(endpoint Endpoint) {
 	// handlers have everything that the framework offers at their disposal. Additionally,
 	// the recovery middleware should always be the outermost handler to clean up
 	// after any crap that happens anywhere else in the pipeline.
-	endpoint.Handler = MiddlewareFuncs{recoverMiddleware(server.onPanic), rolesMiddleware(endpoint)}.
+	// The gateway middleware (e.g. event publishing) must wrap the user's middleware, too. We
+	// promise that in WithMiddleware() so middleware can short-circuit w/o firing an event.
+	endpoint.Handler = MiddlewareFuncs{recoverMiddleware(server.onPanic, server.propagatePanics), server.enabledMiddleware(endpoint), rolesMiddleware(endpoint), loggerMiddleware(server.logger)}.
 		Append(server.gatewayMiddleware...).
+		Append(server.middleware...).
 		Then(endpoint.Handler)
 
 	server.endpoints[endpoint.QualifiedName()] = endpoint
@@ -199,8 +304,27 @@ func (server *Server) registerEndpoint(endpoint Endpoint) {
 	for _, route := range endpoint.Routes {
 		if gw, ok := server.gateways[route.GatewayType]; ok {
 			gw.Register(endpoint, route)
+			continue
 		}
+		server.warnUnroutable(endpoint, route)
+	}
+}
+
+// warnUnroutable logs the fact that we've got a route for a gateway that isn't part of this server, so the route
+// will never be invoked. It's totally valid to run a service as API-only or events-only, but if you forgot to add
+// the event gateway, your "ON FooService.Bar" handlers would silently never fire. Since every function has an API
+// route by default, we only log missing API gateways at the debug level so events-only deployments aren't too noisy.
+func (server *Server) warnUnroutable(endpoint Endpoint, route EndpointRoute) {
+	level := slog.LevelDebug
+	if route.GatewayType == GatewayTypeEvents {
+		level = slog.LevelWarn
 	}
+
+	server.logger.Log(context.Background(), level, "[frodo] no gateway registered for route; it will never be invoked",
+		"endpoint", endpoint.QualifiedName(),
+		"gateway", route.GatewayType.String(),
+		"route", route.Method+" "+route.Path,
+	)
 }
 
 func (server *Server) Routes(gatewayType GatewayType) []EndpointRoute {
@@ -225,6 +349,45 @@ func (server *Server) Routes(gatewayType GatewayType) []EndpointRoute {
 	return routes
 }
 
+// SetEndpointEnabled lets you switch an endpoint off (or back on) at runtime without a deploy. The qualifiedName
+// is the "Service.Function" name of the endpoint. While disabled, every invocation fails w/ a 501 Not Implemented
+// error (see WithDisabledStatus()) without ever reaching your handler. Since this is checked in the endpoint
+// pipeline, it applies the same way to API calls and events. It returns an error if there's no such endpoint.
+//
+//	if err := server.SetEndpointEnabled("OrderService.PlaceOrder", false); err != nil {
+//		...
+//	}
+func (server *Server) SetEndpointEnabled(qualifiedName string, enabled bool) error {
+	if _, ok := server.endpoints[qualifiedName]; !ok {
+		return fail.NotFound("server operation not found: %s", qualifiedName)
+	}
+
+	if enabled {
+		server.disabled.Delete(qualifiedName)
+	} else {
+		server.disabled.Store(qualifiedName, true)
+	}
+	return nil
+}
+
+// EndpointEnabled returns false if the "Service.Function" endpoint was switched off using SetEndpointEnabled().
+func (server *Server) EndpointEnabled(qualifiedName string) bool {
+	_, disabled := server.disabled.Load(qualifiedName)
+	return !disabled
+}
+
+// enabledMiddleware rejects calls to the endpoint while it's disabled. It runs before any other bookkeeping
+// so that a disabled endpoint doesn't do anything at all; it doesn't even publish an event for the failure.
+func (server *Server) enabledMiddleware(endpoint Endpoint) MiddlewareFunc {
+	qualifiedName := endpoint.QualifiedName()
+	return func(ctx context.Context, req any, next HandlerFunc) (any, error) {
+		if !server.EndpointEnabled(qualifiedName) {
+			return nil, fail.New(server.disabledStatus, "operation disabled: %s", qualifiedName)
+		}
+		return next(ctx, req)
+	}
+}
+
 // Invoke allows you to manually trigger any registered service endpoint/function given the name
 // of the service/method. I'd suggest you stick to using the generated clients to invoke functions
 // on your services rather than using this. This primarily exists to aid in testing - it's not really
@@ -236,28 +399,66 @@ func (server *Server) Invoke(ctx context.Context, serviceName string, methodName
 			ServiceName: serviceName,
 			Name:        methodName,
 			Status:      200,
+			Deprecated:  endpoint.Deprecated,
+			Sunset:      endpoint.Sunset,
 		})
 		return endpoint.Handler(ctx, req)
 	}
 	return nil, fail.NotFound("server operation not found: %s", endpointKey)
 }
 
+// Invoke is a type-safe version of Server.Invoke(). It makes sure that you're passing the request type that the
+// function expects, and it hands back the function's response as the type you asked for rather than 'any':
+//
+//	res, err := services.Invoke[calc.AddResponse](server, ctx, "CalculatorService", "Add", &calc.AddRequest{A: 5, B: 2})
+//	...
+//	fmt.Println(res.Result) // no type assertion necessary
+//
+// The response type comes first, so Go can infer the request type from the value you pass in. You get an error
+// if the request or response types don't match what the function actually uses.
+func Invoke[Resp any, Req any](server *Server, ctx context.Context, serviceName string, methodName string, req *Req) (*Resp, error) {
+	endpointKey := serviceName + "." + methodName
+	endpoint, ok := server.endpoints[endpointKey]
+	if !ok {
+		return nil, fail.NotFound("server operation not found: %s", endpointKey)
+	}
+	if _, ok = endpoint.NewInput().(*Req); !ok {
+		return nil, fail.BadRequest("invalid request type for %s: %T is not %T", endpointKey, req, endpoint.NewInput())
+	}
+
+	res, err := server.Invoke(ctx, serviceName, methodName, req)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+	typedRes, ok := res.(*Resp)
+	if !ok {
+		return nil, fail.Unexpected("invalid response type for %s: %T is not %T", endpointKey, res, typedRes)
+	}
+	return typedRes, nil
+}
+
 // Run turns on every gateway currently assigned to this service runtime. Call this
 // once your service setup and registration is complete in order to start accepting
 // incoming requests through your gateway(s).
 func (server *Server) Run(ctx context.Context) error {
 	server.shutdownComplete.Add(1)
+	server.ready.Store(true)
 
-	errs, _ := fail.NewGroup(ctx)
+	errs, errsCtx := fail.NewGroup(ctx)
 	for _, gw := range server.gateways {
 		server.logger.Info("[frodo] starting gateway: " + gw.Type().String())
 		errs.Go(func() error { return gw.Listen(ctx) })
 	}
+	go server.awaitListening(errsCtx)
 
 	// We had an issue starting up the server, so just get out and
 	// let the user determine how to handle the fact that the HTTP
 	// server or event broker didn't work.
 	if err := errs.Wait(); err != nil {
+		server.ready.Store(false)
 		server.shutdownComplete.Done()
 		return err
 	}
@@ -270,12 +471,50 @@ func (server *Server) Run(ctx context.Context) error {
 	return nil
 }
 
+// awaitListening closes the server's Listening() channel once all of the gateways are accepting requests. If any
+// of the gateways fail to start, the context is cancelled, and we give up without ever closing the channel.
+func (server *Server) awaitListening(ctx context.Context) {
+	for _, gw := range server.gateways {
+		notifier, ok := gw.(GatewayListening)
+		if !ok {
+			continue
+		}
+		select {
+		case <-notifier.Listening():
+		case <-ctx.Done():
+			return
+		}
+	}
+	server.listeningOnce.Do(func() { close(server.listening) })
+}
+
+// Listening returns a channel that is closed once Run() has started all of the gateways and they're actually
+// accepting requests. This lets you wait for the server to come up rather than sleeping and hoping for the best:
+//
+//	go server.Run(ctx)
+//	<-server.Listening()
+//
+// Gateways that don't support GatewayListening are assumed to be ready as soon as they've been started. The
+// channel is never closed if any of the gateways fail to start, so you may want to wait on Run()'s error, too.
+func (server *Server) Listening() <-chan struct{} {
+	return server.listening
+}
+
+// Gateway returns the gateway of the given type that you passed to Listen() when creating the server. The
+// 'ok' value is false if the server doesn't have a gateway of that type.
+func (server *Server) Gateway(gatewayType GatewayType) (Gateway, bool) {
+	gw, ok := server.gateways[gatewayType]
+	return gw, ok
+}
+
 // Shutdown attempts to gracefully shut down all of the gateways associated with this
 // service runtime. It should immediately stop accepting new requests and then wait
 // for existing requests to finish before returning. The context should be used to
 // provide a cancellation/timeout to limit how long this will wait for in-flight
 // requests to finish up.
 func (server *Server) Shutdown(ctx context.Context) error {
+	server.enterLameDuck(ctx)
+	server.ready.Store(false)
 	defer server.shutdownComplete.Done()
 
 	errs, _ := fail.NewGroup(ctx)
@@ -291,10 +530,56 @@ func (server *Server) Shutdown(ctx context.Context) error {
 	return errs.Wait()
 }
 
+// Ready returns true while the server is running and happy to accept new requests. This flips to false as
+// soon as the server begins draining (see BeginDrain()), so it's what you want your load balancer's readiness
+// check to look at. See apis.WithReadinessCheck() for an easy way to expose this over HTTP.
+func (server *Server) Ready() bool {
+	return server.ready.Load() && server.drainStarted.Load() == 0
+}
+
+// BeginDrain flips Ready() to false without shutting anything down. Existing and new requests continue to be
+// served normally, but your readiness check starts failing so the load balancer stops sending traffic to this
+// instance. This does not block, and calling it more than once has no additional effect.
+//
+// You usually don't need to call this yourself. Shutdown() and ShutdownOnInterrupt() begin draining for you and
+// then wait out the lame duck period (see WithLameDuck()) before actually shutting down the gateways. Call this
+// when you want to start draining earlier than that, such as from a pre-stop hook.
+func (server *Server) BeginDrain() {
+	if server.drainStarted.CompareAndSwap(0, time.Now().UnixNano()) {
+		server.logger.Info("[frodo] draining; readiness check will now fail", "grace_period", server.lameDuck.String())
+	}
+}
+
+// enterLameDuck begins draining and then keeps serving requests until the lame duck period has elapsed since
+// draining began, giving the load balancer time to notice that we're going away before we actually stop accepting
+// requests. It returns early if the context is canceled. If you called BeginDrain() a while ago or we've already
+// waited once (e.g. ShutdownOnInterrupt() followed by Shutdown()), there's less or nothing left to wait for.
+func (server *Server) enterLameDuck(ctx context.Context) {
+	if !server.ready.Load() {
+		return
+	}
+	server.BeginDrain()
+
+	drainStarted := time.Unix(0, server.drainStarted.Load())
+	remaining := server.lameDuck - time.Since(drainStarted)
+	if remaining <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
 // ShutdownOnInterrupt provides some convenience around shutting down this service.
 // This function will block until the process either receives a SIGTERM or SIGINT
-// signal. At that point, it will invoke Shutdown() whose context will have a
-// deadline of the given duration.
+// signal. At that point, it will BeginDrain(), wait out the lame duck period (if you
+// supplied WithLameDuck()), and then invoke Shutdown() whose context will have a deadline
+// of the given duration.
 //
 // Example:
 //
@@ -313,6 +598,11 @@ func (server *Server) ShutdownOnInterrupt(gracefulTimeout time.Duration) {
 	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM, syscall.SIGINT)
 	<-interrupt
 
+	// Start failing readiness checks right away. If you configured a lame duck period, we keep serving
+	// requests for a while after that. The graceful timeout doesn't start ticking until that's done.
+	server.BeginDrain()
+	server.enterLameDuck(context.Background())
+
 	// This context ensures that we give the gateways some time to finish
 	// up their in-process requests before shutting down.
 	ctx, cancel := context.WithTimeout(context.Background(), gracefulTimeout)
@@ -337,6 +627,23 @@ func Listen(gw Gateway) ServerOption {
 	}
 }
 
+// ListenIf behaves just like Listen(), but it only adds the gateway to the server when 'enabled' is true. This
+// lets you decide at runtime (e.g. based on config/env vars) whether this instance should serve API requests,
+// consume events, or both - all from the same main().
+//
+//	server := services.NewServer(
+//		services.ListenIf(cfg.EnableAPI, apis.NewGateway(":9000")),
+//		services.ListenIf(cfg.EnableEvents, events.NewGateway()),
+//		services.Register(calcServer),
+//	)
+func ListenIf(enabled bool, gw Gateway) ServerOption {
+	return func(server *Server) {
+		if enabled {
+			server.gateways[gw.Type()] = gw
+		}
+	}
+}
+
 // Register adds endpoint handlers for the given service(s) to the appropriate gateways.
 // Typically, you don't create the Service pointer yourself. These are built for you when
 // you use the code generation tools to build the gateways based on your service interfaces.
@@ -349,6 +656,74 @@ func Register(services ...*Service) ServerOption {
 	}
 }
 
+// Mount behaves just like Register(), but it prefixes the path of every API route in the given service(s) with
+// 'prefix'. This lets you compose several services w/ different base paths into a single gateway without editing
+// each service interface's PREFIX doc option:
+//
+//	server := services.NewServer(
+//		services.Listen(apis.NewGateway(":9000")),
+//		services.Mount("/api/v2", userService, groupService),
+//		services.Mount("/internal", adminService),
+//	)
+//
+// Mounting stacks on top of any PREFIX, so "POST /v2/UserService.Create" mounted at "/api" is served from
+// "/api/v2/UserService.Create". Event routes are left alone. Remember to include the prefix in the address you
+// give to your clients (e.g. "http://localhost:9000/internal").
+func Mount(prefix string, services ...*Service) ServerOption {
+	return func(server *Server) {
+		for _, service := range services {
+			server.services = append(server.services, service.mount(prefix))
+		}
+	}
+}
+
+// RegisterAs behaves just like Register(), but it runs the service(s) under a different service name and version than
+// the ones baked into the generated code. This is handy when you're renaming a service, and you need to run the
+// same code under both names until all of your callers have moved over:
+//
+//	server := services.NewServer(
+//		services.Listen(apis.NewGateway(":9000")),
+//		services.Listen(events.NewGateway()),
+//		services.Register(gen.UserServiceServer(userHandler)),
+//		services.RegisterAs("AccountService", "2.0.0", gen.UserServiceServer(userHandler)),
+//	)
+//
+// The renamed functions publish events like "AccountService.Create" and get their own default consumer groups, so
+// both copies receive the events they subscribe to. Default API paths like "/UserService.Create" become
+// "/AccountService.Create", but custom paths (e.g. "GET /user/{ID}") are left alone. Both copies can't serve the same
+// path on one API gateway, so run the renamed copy in its own server if the service has custom paths. Leave the
+// version empty to keep the generated one.
+func RegisterAs(name string, version string, services ...*Service) ServerOption {
+	return func(server *Server) {
+		for _, service := range services {
+			server.services = append(server.services, service.rename(name, version))
+		}
+	}
+}
+
+// WithMiddleware adds middleware that runs on every endpoint of every service registered with the server. This is
+// ideal for cross-cutting concerns like tracing or metrics that you'd otherwise have to pass to every single
+// generated XxxServiceServer() constructor. You can supply this option more than once; the functions run in
+// the order that you supplied them.
+//
+//	server := services.NewServer(
+//		services.Listen(apis.NewGateway(":9000")),
+//		services.Register(userService, groupService),
+//		services.WithMiddleware(TracingMiddleware, MetricsMiddleware),
+//	)
+//
+// These run after the server's built-in bookkeeping (panic recovery, ROLES, etc.) and before any middleware
+// you supplied for an individual service, so a service's own middleware can rely on whatever these set up.
+//
+// Both these and your service's own middleware always run inside of any gateway middleware (see GatewayMiddleware),
+// such as the event gateway's publishing. That means that your middleware can return a response w/o calling 'next'
+// and call metadata.SuppressEvent() to keep the event gateway from publishing (e.g. see CacheMiddleware).
+func WithMiddleware(middleware ...MiddlewareFunc) ServerOption {
+	return func(server *Server) {
+		server.middleware = append(server.middleware, middleware...)
+	}
+}
+
 // OnPanicFunc is the signature for custom callbacks to invoke when a panic occurs in your service code.
 type OnPanicFunc func(err error, stack []byte)
 
@@ -360,9 +735,45 @@ func OnPanic(handler OnPanicFunc) ServerOption {
 	}
 }
 
-// WithLogger customizes the logger used by the server to output various bits of debugging info.
+// WithPanicPropagation controls what happens after the server invokes your OnPanic() callback. By default (false),
+// the server recovers from the panic and your function call simply fails with an error containing the panic's
+// message. When true, the server re-panics with the original value instead, so you get the real stack trace
+// and the same crash you'd get without frodo in the way. This is handy in local/dev environments, but you
+// should leave it off in production.
+//
+//	services.WithPanicPropagation(os.Getenv("ENV") == "local")
+//
+// Keep in mind that other layers may have their own recovery; for instance, net/http recovers panics in HTTP
+// handlers and logs them with their stack before closing the connection.
+func WithPanicPropagation(propagate bool) ServerOption {
+	return func(server *Server) {
+		server.propagatePanics = propagate
+	}
+}
+
+// WithLogger customizes the logger used by the server to output various bits of debugging info. This is
+// also the base for the request-scoped loggers that your handlers get from services.Logger(ctx).
 func WithLogger(logger *slog.Logger) ServerOption {
 	return func(server *Server) {
 		server.logger = logger
 	}
 }
+
+// WithLameDuck gives the server a "lame duck" (drain grace) period when shutting down. As soon as the server begins
+// draining, Ready() will report false, so your load balancer's readiness check starts failing, but we'll keep serving
+// requests normally for the given duration before we actually start shutting down the gateways. This gives the
+// load balancer time to stop routing traffic to this instance, so you don't drop requests during deploys.
+func WithLameDuck(duration time.Duration) ServerOption {
+	return func(server *Server) {
+		server.lameDuck = duration
+	}
+}
+
+// WithDisabledStatus customizes the HTTP-style status code of the error returned when you invoke an endpoint
+// that was switched off using SetEndpointEnabled(). By default, this is 501 Not Implemented, but you might
+// prefer something like 503 Service Unavailable, so your clients know to try again later.
+func WithDisabledStatus(status int) ServerOption {
+	return func(server *Server) {
+		server.disabledStatus = status
+	}
+}
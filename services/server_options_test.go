@@ -0,0 +1,453 @@
+//go:build unit
+
+package services_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bridgekit-io/frodo/fail"
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestServerOptionsSuite(t *testing.T) {
+	suite.Run(t, new(ServerOptionsSuite))
+}
+
+type ServerOptionsSuite struct {
+	suite.Suite
+}
+
+func (suite *ServerOptionsSuite) service() *services.Service {
+	return &services.Service{
+		Name: "FooService",
+		Endpoints: []services.Endpoint{
+			{
+				ServiceName: "FooService",
+				Name:        "Bar",
+				Handler:     func(ctx context.Context, req any) (any, error) { return req, nil },
+				Routes: []services.EndpointRoute{
+					{GatewayType: services.GatewayTypeAPI, Method: "POST", Path: "/FooService.Bar"},
+					{GatewayType: services.GatewayTypeEvents, Method: "ON", Path: "FooService.Baz"},
+				},
+			},
+		},
+	}
+}
+
+func (suite *ServerOptionsSuite) TestListenIf() {
+	apiGateway := &fakeGateway{gatewayType: services.GatewayTypeAPI}
+	eventGateway := &fakeGateway{gatewayType: services.GatewayTypeEvents}
+
+	services.NewServer(
+		services.ListenIf(true, apiGateway),
+		services.ListenIf(false, eventGateway),
+		services.Register(suite.service()),
+	)
+	suite.Equal([]string{"POST /FooService.Bar"}, apiGateway.registered)
+	suite.Empty(eventGateway.registered)
+}
+
+// Ensures that the route on the context has the resolved roles as well as whether the caller needs all of them.
+func (suite *ServerOptionsSuite) TestRoles() {
+	type rolesRequest struct {
+		ID string
+	}
+
+	var route metadata.EndpointRoute
+	service := &services.Service{
+		Name: "FooService",
+		Endpoints: []services.Endpoint{
+			{
+				ServiceName:     "FooService",
+				Name:            "Bar",
+				Roles:           []string{"billing.read", "tenant.{ID}.access"},
+				RequireAllRoles: true,
+				Handler: func(ctx context.Context, req any) (any, error) {
+					route = metadata.Route(ctx)
+					return req, nil
+				},
+			},
+		},
+	}
+
+	server := services.NewServer(services.Register(service))
+	_, err := server.Invoke(context.Background(), "FooService", "Bar", &rolesRequest{ID: "123"})
+	suite.Require().NoError(err)
+	suite.Equal([]string{"billing.read", "tenant.123.access"}, route.Roles)
+	suite.True(route.RequireAllRoles)
+	suite.False(route.AllowsRoles([]string{"billing.read"}))
+	suite.True(route.AllowsRoles([]string{"billing.read", "tenant.123.access"}))
+}
+
+func (suite *ServerOptionsSuite) TestWarnMissingEventGateway() {
+	logs := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(logs, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	services.NewServer(
+		services.WithLogger(logger),
+		services.Listen(&fakeGateway{gatewayType: services.GatewayTypeAPI}),
+		services.Register(suite.service()),
+	)
+	suite.Contains(logs.String(), "level=WARN")
+	suite.Contains(logs.String(), "gateway=EVENTS")
+	suite.Contains(logs.String(), `route="ON FooService.Baz"`)
+	suite.NotContains(logs.String(), "gateway=API")
+}
+
+func (suite *ServerOptionsSuite) TestNoWarningWhenAllGatewaysPresent() {
+	logs := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	services.NewServer(
+		services.WithLogger(logger),
+		services.Listen(&fakeGateway{gatewayType: services.GatewayTypeAPI}),
+		services.Listen(&fakeGateway{gatewayType: services.GatewayTypeEvents}),
+		services.Register(suite.service()),
+	)
+	suite.Empty(logs.String())
+}
+
+func (suite *ServerOptionsSuite) TestMount() {
+	apiGateway := &fakeGateway{gatewayType: services.GatewayTypeAPI}
+	eventGateway := &fakeGateway{gatewayType: services.GatewayTypeEvents}
+	service := suite.service()
+
+	server := services.NewServer(
+		services.Listen(apiGateway),
+		services.Listen(eventGateway),
+		services.Mount("/api/v2/", service),
+		services.Mount("internal", service),
+		services.Mount("/", service),
+	)
+	suite.Equal([]string{
+		"POST /api/v2/FooService.Bar",
+		"POST /internal/FooService.Bar",
+		"POST /FooService.Bar",
+	}, apiGateway.registered)
+	suite.Equal([]string{
+		"ON FooService.Baz",
+		"ON FooService.Baz",
+		"ON FooService.Baz",
+	}, eventGateway.registered, "Should not prefix event routes")
+
+	suite.Len(server.Routes(services.GatewayTypeAPI), 3)
+	suite.Equal("/FooService.Bar", service.Endpoints[0].Routes[0].Path, "Should not modify the original service")
+}
+
+func (suite *ServerOptionsSuite) TestRegisterAs() {
+	apiGateway := &fakeGateway{gatewayType: services.GatewayTypeAPI}
+	eventGateway := &fakeGateway{gatewayType: services.GatewayTypeEvents}
+	service := suite.service()
+	service.Version = "1.0.0"
+	service.Endpoints[0].Routes = append(service.Endpoints[0].Routes, services.EndpointRoute{
+		GatewayType: services.GatewayTypeAPI, Method: "GET", Path: "/bar/{ID}",
+	})
+
+	server := services.NewServer(
+		services.Listen(apiGateway),
+		services.Listen(eventGateway),
+		services.Register(service),
+		services.RegisterAs("BazService", "2.0.0", service),
+		services.RegisterAs("", "", service),
+	)
+	suite.Equal([]string{
+		"POST /FooService.Bar",
+		"GET /bar/{ID}",
+		"POST /BazService.Bar",
+		"GET /bar/{ID}",
+		"POST /FooService.Bar",
+		"GET /bar/{ID}",
+	}, apiGateway.registered, "Should only rename default API paths")
+	suite.Equal([]string{
+		"ON FooService.Baz",
+		"ON FooService.Baz",
+		"ON FooService.Baz",
+	}, eventGateway.registered, "Should still subscribe to the same events")
+
+	res, err := server.Invoke(context.Background(), "BazService", "Bar", &struct{}{})
+	suite.Require().NoError(err)
+	suite.NotNil(res)
+
+	var renamedRoutes int
+	for _, route := range server.Routes(services.GatewayTypeEvents) {
+		if route.ServiceName == "BazService" {
+			renamedRoutes++
+		}
+	}
+	suite.Equal(1, renamedRoutes)
+	suite.Equal("FooService", service.Name, "Should not modify the original service")
+	suite.Equal("1.0.0", service.Version, "Should not modify the original service")
+	suite.Equal("FooService", service.Endpoints[0].ServiceName, "Should not modify the original service")
+}
+
+func (suite *ServerOptionsSuite) TestRegisterAs_metadata() {
+	var route metadata.EndpointRoute
+	service := suite.service()
+	service.Endpoints[0].Handler = func(ctx context.Context, req any) (any, error) {
+		route = metadata.Route(ctx)
+		return req, nil
+	}
+	server := services.NewServer(services.RegisterAs("BazService", "", service))
+
+	_, err := server.Invoke(context.Background(), "BazService", "Bar", &struct{}{})
+	suite.Require().NoError(err)
+	suite.Equal("BazService", route.ServiceName)
+	suite.Equal("BazService.Bar", route.QualifiedName())
+
+	_, err = server.Invoke(context.Background(), "FooService", "Bar", &struct{}{})
+	suite.True(fail.IsNotFound(err), "The original name should not be registered")
+}
+
+// fakeGateway just remembers the routes that the server asked it to register.
+type fakeGateway struct {
+	gatewayType services.GatewayType
+	registered  []string
+}
+
+func (gw *fakeGateway) Type() services.GatewayType {
+	return gw.gatewayType
+}
+
+func (gw *fakeGateway) Register(_ services.Endpoint, route services.EndpointRoute) {
+	gw.registered = append(gw.registered, route.Method+" "+route.Path)
+}
+
+func (gw *fakeGateway) Listen(context.Context) error {
+	return nil
+}
+
+func (gw *fakeGateway) Shutdown(context.Context) error {
+	return nil
+}
+
+// listeningGateway is a fakeGateway that is always listening, and it counts how many times the server checked.
+type listeningGateway struct {
+	fakeGateway
+	checks atomic.Int64
+}
+
+func (gw *listeningGateway) Listening() <-chan struct{} {
+	gw.checks.Add(1)
+	ready := make(chan struct{})
+	close(ready)
+	return ready
+}
+
+func (suite *ServerOptionsSuite) TestLameDuck() {
+	server := services.NewServer(
+		services.Listen(&fakeGateway{gatewayType: services.GatewayTypeAPI}),
+		services.WithLameDuck(50*time.Millisecond),
+	)
+	suite.False(server.Ready(), "Server shouldn't be ready until it's running")
+
+	go func() { _ = server.Run(context.Background()) }()
+	suite.Eventually(server.Ready, time.Second, 5*time.Millisecond)
+
+	start := time.Now()
+	suite.NoError(server.Shutdown(context.Background()))
+	suite.False(server.Ready())
+	suite.GreaterOrEqual(time.Since(start), 50*time.Millisecond, "Shutdown should wait out the lame duck period")
+}
+
+func (suite *ServerOptionsSuite) TestLameDuck_contextCanceled() {
+	server := services.NewServer(
+		services.Listen(&fakeGateway{gatewayType: services.GatewayTypeAPI}),
+		services.WithLameDuck(time.Minute),
+	)
+	go func() { _ = server.Run(context.Background()) }()
+	suite.Eventually(server.Ready, time.Second, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	suite.NoError(server.Shutdown(ctx))
+	suite.Less(time.Since(start), time.Second, "Canceled context should cut the lame duck period short")
+}
+
+func (suite *ServerOptionsSuite) TestListening_runTwice() {
+	gw := &listeningGateway{fakeGateway: fakeGateway{gatewayType: services.GatewayTypeAPI}}
+	server := services.NewServer(services.Listen(gw))
+
+	// Running the same server again (e.g. after a failed start) should not panic by closing Listening() twice.
+	for i := int64(1); i <= 2; i++ {
+		done := make(chan error, 1)
+		go func() { done <- server.Run(context.Background()) }()
+		suite.Eventually(func() bool { return gw.checks.Load() == i }, time.Second, time.Millisecond)
+		<-server.Listening()
+		suite.Require().NoError(server.Shutdown(context.Background()))
+		suite.Require().NoError(<-done)
+	}
+}
+
+func (suite *ServerOptionsSuite) TestLogger() {
+	logs := &bytes.Buffer{}
+	logger := slog.New(slog.NewTextHandler(logs, nil))
+
+	service := suite.service()
+	service.Endpoints[0].Handler = func(ctx context.Context, req any) (any, error) {
+		services.Logger(ctx).Info("hello", "name", "Dude")
+		return req, nil
+	}
+	server := services.NewServer(services.WithLogger(logger), services.Register(service))
+
+	ctx := metadata.WithTraceID(context.Background(), "12345")
+	_, err := server.Invoke(ctx, "FooService", "Bar", "Abide")
+	suite.Require().NoError(err)
+	suite.Contains(logs.String(), "msg=hello")
+	suite.Contains(logs.String(), "trace_id=12345")
+	suite.Contains(logs.String(), "route=FooService.Bar")
+	suite.Contains(logs.String(), "name=Dude")
+}
+
+func (suite *ServerOptionsSuite) TestLogger_outsideServer() {
+	suite.Same(slog.Default(), services.Logger(context.Background()))
+}
+
+func (suite *ServerOptionsSuite) TestBeginDrain() {
+	server := services.NewServer(
+		services.Listen(&fakeGateway{gatewayType: services.GatewayTypeAPI}),
+		services.WithLameDuck(50*time.Millisecond),
+	)
+	go func() { _ = server.Run(context.Background()) }()
+	suite.Eventually(server.Ready, time.Second, 5*time.Millisecond)
+
+	server.BeginDrain()
+	suite.False(server.Ready(), "Draining servers should fail readiness checks")
+	server.BeginDrain()
+	suite.False(server.Ready(), "Draining more than once should be harmless")
+
+	// The grace period started when we began draining, so shutdown only waits for whatever is left.
+	time.Sleep(50 * time.Millisecond)
+	start := time.Now()
+	suite.NoError(server.Shutdown(context.Background()))
+	suite.Less(time.Since(start), 40*time.Millisecond, "Grace period should already be used up")
+}
+
+func (suite *ServerOptionsSuite) TestSetEndpointEnabled() {
+	calls := 0
+	service := suite.service()
+	service.Endpoints[0].Handler = func(ctx context.Context, req any) (any, error) {
+		calls++
+		return req, nil
+	}
+	server := services.NewServer(services.Register(service))
+	suite.True(server.EndpointEnabled("FooService.Bar"))
+
+	suite.Require().NoError(server.SetEndpointEnabled("FooService.Bar", false))
+	suite.False(server.EndpointEnabled("FooService.Bar"))
+	_, err := server.Invoke(context.Background(), "FooService", "Bar", "Abide")
+	suite.Require().Error(err)
+	suite.Equal(http.StatusNotImplemented, fail.Status(err))
+	suite.Equal(0, calls, "Disabled endpoints should never reach the handler")
+
+	suite.Require().NoError(server.SetEndpointEnabled("FooService.Bar", true))
+	suite.True(server.EndpointEnabled("FooService.Bar"))
+	res, err := server.Invoke(context.Background(), "FooService", "Bar", "Abide")
+	suite.Require().NoError(err)
+	suite.Equal("Abide", res)
+	suite.Equal(1, calls)
+}
+
+func (suite *ServerOptionsSuite) TestSetEndpointEnabled_notFound() {
+	server := services.NewServer(services.Register(suite.service()))
+	err := server.SetEndpointEnabled("FooService.Nope", false)
+	suite.Require().Error(err)
+	suite.Equal(http.StatusNotFound, fail.Status(err))
+}
+
+func (suite *ServerOptionsSuite) TestWithDisabledStatus() {
+	server := services.NewServer(
+		services.Register(suite.service()),
+		services.WithDisabledStatus(http.StatusServiceUnavailable),
+	)
+	suite.Require().NoError(server.SetEndpointEnabled("FooService.Bar", false))
+	_, err := server.Invoke(context.Background(), "FooService", "Bar", "Abide")
+	suite.Equal(http.StatusServiceUnavailable, fail.Status(err))
+}
+
+func (suite *ServerOptionsSuite) TestWithMiddleware() {
+	var calls []string
+	record := func(name string) services.MiddlewareFunc {
+		return func(ctx context.Context, req any, next services.HandlerFunc) (any, error) {
+			calls = append(calls, name)
+			return next(ctx, req)
+		}
+	}
+
+	service := suite.service()
+	service.Endpoints[0].Handler = services.MiddlewareFuncs{record("service")}.Then(func(ctx context.Context, req any) (any, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	})
+
+	// The order of the options shouldn't matter; server middleware applies to services registered before/after it.
+	server := services.NewServer(
+		services.WithMiddleware(record("server-a"), record("server-b")),
+		services.Register(service),
+		services.WithMiddleware(record("server-c")),
+	)
+
+	_, err := server.Invoke(context.Background(), "FooService", "Bar", "Abide")
+	suite.Require().NoError(err)
+	suite.Equal([]string{"server-a", "server-b", "server-c", "service", "handler"}, calls)
+}
+
+func (suite *ServerOptionsSuite) TestWithMiddleware_disabledEndpoint() {
+	called := false
+	server := services.NewServer(
+		services.Register(suite.service()),
+		services.WithMiddleware(func(ctx context.Context, req any, next services.HandlerFunc) (any, error) {
+			called = true
+			return next(ctx, req)
+		}),
+	)
+	suite.Require().NoError(server.SetEndpointEnabled("FooService.Bar", false))
+
+	_, err := server.Invoke(context.Background(), "FooService", "Bar", "Abide")
+	suite.Require().Error(err)
+	suite.False(called, "Built-in checks should run before server middleware")
+}
+
+func (suite *ServerOptionsSuite) panicServer(options ...services.ServerOption) (*services.Server, *[]string) {
+	var panics []string
+	service := suite.service()
+	service.Endpoints[0].Handler = func(ctx context.Context, req any) (any, error) {
+		panic("don't")
+	}
+
+	options = append(options,
+		services.Register(service),
+		services.OnPanic(func(err error, stack []byte) {
+			panics = append(panics, err.Error())
+		}),
+	)
+	return services.NewServer(options...), &panics
+}
+
+func (suite *ServerOptionsSuite) TestWithPanicPropagation() {
+	server, panics := suite.panicServer(services.WithPanicPropagation(true))
+
+	suite.PanicsWithValue("don't", func() {
+		_, _ = server.Invoke(context.Background(), "FooService", "Bar", "Abide")
+	})
+	suite.Equal([]string{"don't"}, *panics, "OnPanic should still fire before we re-panic")
+}
+
+func (suite *ServerOptionsSuite) TestWithPanicPropagation_disabled() {
+	server, panics := suite.panicServer(services.WithPanicPropagation(false))
+
+	suite.NotPanics(func() {
+		_, err := server.Invoke(context.Background(), "FooService", "Bar", "Abide")
+		suite.EqualError(err, "don't")
+	})
+	suite.Equal([]string{"don't"}, *panics)
+}
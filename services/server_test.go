@@ -6,15 +6,25 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/bridgekit-io/frodo/eventsource"
+	"github.com/bridgekit-io/frodo/eventsource/local"
+	"github.com/bridgekit-io/frodo/fail"
 	"github.com/bridgekit-io/frodo/internal/quiet"
 	"github.com/bridgekit-io/frodo/internal/testext"
 	gen "github.com/bridgekit-io/frodo/internal/testext/gen"
+	"github.com/bridgekit-io/frodo/metadata"
 	"github.com/bridgekit-io/frodo/services"
+	"github.com/bridgekit-io/frodo/services/clients"
 	"github.com/bridgekit-io/frodo/services/gateways/apis"
 	"github.com/bridgekit-io/frodo/services/gateways/events"
+	"github.com/bridgekit-io/frodo/services/servicetest"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -60,18 +70,8 @@ func (suite *ServerSuite) start() (*services.Server, *testext.Sequence, func())
 			sequence.Append("OnPanic:" + err.Error())
 		}),
 	)
-	go func() { _ = server.Run(context.Background()) }()
-
-	// Kinda crappy, but we need some time to make sure the server is up. Sometimes
-	// this goes so fast that the test case fires before the server is fully running.
-	// As a result the cases fail because the server's not running... duh.
-	time.Sleep(25 * time.Millisecond)
-
-	return server, sequence, func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
-		defer cancel()
-		_ = server.Shutdown(ctx)
-	}
+	_, shutdown := servicetest.Start(suite.T(), server)
+	return server, sequence, shutdown
 }
 
 func (suite *ServerSuite) responseText(res any) string {
@@ -133,6 +133,197 @@ func (suite *ServerSuite) TestBasicExecution() {
 	suite.Equal("Defaults:Hello", suite.responseText(res))
 }
 
+// Ensure that the generic Invoke() hands back the typed response, and rejects requests/responses whose
+// types don't match the function being invoked.
+func (suite *ServerSuite) TestBasicExecution_typed() {
+	server, _, shutdown := suite.start()
+	defer shutdown()
+
+	res, err := services.Invoke[testext.SampleResponse](server, context.Background(), "SampleService", "Defaults", &testext.SampleRequest{
+		Text: "Hello",
+	})
+	suite.Require().NoError(err)
+	suite.Equal("Defaults:Hello", res.Text)
+
+	_, err = services.Invoke[testext.SampleResponse](server, context.Background(), "SampleService", "Defaults", &testext.OtherRequest{
+		Text: "Hello",
+	})
+	suite.Require().Error(err)
+	suite.Equal(400, fail.Status(err))
+
+	_, err = services.Invoke[testext.OtherResponse](server, context.Background(), "SampleService", "Defaults", &testext.SampleRequest{
+		Text: "Hello",
+	})
+	suite.Require().Error(err)
+	suite.Equal(500, fail.Status(err))
+
+	_, err = services.Invoke[testext.SampleResponse](server, context.Background(), "SampleService", "Nope", &testext.SampleRequest{})
+	suite.Require().Error(err)
+	suite.Equal(404, fail.Status(err))
+}
+
+// Ensure that the generated clients can check that the remote service is up without invoking a real function.
+func (suite *ServerSuite) TestPing() {
+	_, calls, shutdown := suite.start()
+	defer shutdown()
+
+	calls.Reset()
+	suite.Require().NoError(suite.client.(clients.Pinger).Ping(context.Background()))
+	suite.assertInvoked(calls, []string{})
+
+	unreachable := gen.SampleServiceClient(suite.addresses.Next())
+	suite.Require().Error(unreachable.(clients.Pinger).Ping(context.Background()))
+}
+
+// Ensure that clients w/ request compression can talk to the gateway, which decompresses the bodies for them.
+func (suite *ServerSuite) TestRequestCompression() {
+	_, _, shutdown := suite.start()
+	defer shutdown()
+
+	encodings := []string{}
+	client := gen.SampleServiceClient(suite.httpAddress,
+		clients.WithRequestCompression(0),
+		clients.WithMiddleware(func(request *http.Request, next clients.RoundTripperFunc) (*http.Response, error) {
+			encodings = append(encodings, request.Header.Get("Content-Encoding"))
+			return next(request)
+		}),
+	)
+	res, err := client.TriggerLowerCase(context.Background(), &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.Equal("abide", res.Text)
+	suite.Equal([]string{"gzip"}, encodings)
+
+	// Junk that claims to be gzip should be rejected before it ever makes it to the handler.
+	req, _ := http.NewRequest(http.MethodPost, "http://"+suite.httpAddress+"/v2/SampleService.TriggerLowerCase", strings.NewReader(`{"Text":"Abide"}`))
+	req.Header.Set("Content-Encoding", "gzip")
+	httpRes, err := suite.httpClient.Do(req)
+	suite.Require().NoError(err)
+	defer quiet.Close(httpRes.Body)
+	suite.Equal(http.StatusBadRequest, httpRes.StatusCode)
+}
+
+// Ensure that the client can unwrap responses from gateways that wrap them in an envelope.
+func (suite *ServerSuite) TestResponseEnvelope() {
+	address := suite.addresses.Next()
+	envelope := func(ctx context.Context, serviceResponse any) any {
+		return map[string]any{"data": serviceResponse, "meta": map[string]any{"traceId": metadata.TraceID(ctx)}}
+	}
+	server := services.NewServer(
+		services.Listen(apis.NewGateway(address, apis.WithResponseEnvelope(envelope))),
+		services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: &testext.Sequence{}})),
+	)
+	_, shutdown := servicetest.Start(suite.T(), server)
+	defer shutdown()
+
+	client := gen.SampleServiceClient(address, clients.WithResponseEnvelope("data"))
+	res, err := client.TriggerLowerCase(context.Background(), &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.Equal("abide", res.Text)
+
+	// Errors aren't wrapped, so the client should still decode them normally.
+	_, err = client.Fail4XX(context.Background(), &testext.SampleRequest{Text: "Abide"})
+	suite.Equal(409, fail.Status(err))
+
+	// And raw HTTP consumers should see the envelope.
+	httpRes, err := suite.httpClient.Post("http://"+address+"/v2/SampleService.TriggerLowerCase", "application/json", strings.NewReader(`{"Text":"Abide"}`))
+	suite.Require().NoError(err)
+	defer quiet.Close(httpRes.Body)
+	body, _ := io.ReadAll(httpRes.Body)
+	suite.Contains(string(body), `"data":{`)
+	suite.Contains(string(body), `"meta":{"traceId":"`)
+}
+
+func (suite *ServerSuite) TestProblemJSON() {
+	address := suite.addresses.Next()
+	server := services.NewServer(
+		services.Listen(apis.NewGateway(address, apis.WithProblemJSON())),
+		services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: &testext.Sequence{}})),
+	)
+	_, shutdown := servicetest.Start(suite.T(), server)
+	defer shutdown()
+
+	// The client should understand problem details just like our standard error format.
+	client := gen.SampleServiceClient(address)
+	_, err := client.Fail4XX(context.Background(), &testext.SampleRequest{Text: "Abide"})
+	suite.Equal(409, fail.Status(err))
+	suite.Contains(err.Error(), "always a conflict")
+
+	httpRes, err := suite.httpClient.Post("http://"+address+"/v2/SampleService.Fail4XX", "application/json", strings.NewReader(`{"Text":"Abide"}`))
+	suite.Require().NoError(err)
+	defer quiet.Close(httpRes.Body)
+	body, _ := io.ReadAll(httpRes.Body)
+	suite.Equal("application/problem+json", httpRes.Header.Get("Content-Type"))
+	suite.Contains(string(body), `"title":"Conflict"`)
+	suite.Contains(string(body), `"instance":"/v2/SampleService.Fail4XX"`)
+}
+
+// Ensure that batched client calls are sent in a single round trip when the gateway supports it and fall
+// back to individual calls when it doesn't. Either way, each call should get its own response/error.
+func (suite *ServerSuite) TestBatch() {
+	run := func(batching bool) (int64, []error, []any) {
+		address := suite.addresses.Next()
+		options := []apis.GatewayOption{}
+		if batching {
+			options = append(options, apis.WithBatching())
+		}
+		server := services.NewServer(
+			services.Listen(apis.NewGateway(address, options...)),
+			services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: &testext.Sequence{}})),
+		)
+		_, shutdown := servicetest.Start(suite.T(), server)
+		defer shutdown()
+
+		// The fallback sends the calls concurrently, so the counter needs to be safe for that.
+		roundTrips := &atomic.Int64{}
+		client := clients.NewClient("SampleService", address, clients.WithMiddleware(
+			func(request *http.Request, next clients.RoundTripperFunc) (*http.Response, error) {
+				roundTrips.Add(1)
+				return next(request)
+			},
+		))
+
+		responses := []any{
+			&testext.SampleResponse{},
+			&testext.SampleResponse{},
+			&testext.SampleResponse{},
+			&testext.SampleDownloadResponse{},
+		}
+		batch := clients.NewBatch(client)
+		batch.Add("GET", "/v2/custom/route/1/{ID}/{Text}", &testext.SampleRequest{ID: "123", Text: "Abide"}, responses[0])
+		batch.Add("POST", "/v2/SampleService.TriggerLowerCase", &testext.SampleRequest{Text: "Abide"}, responses[1])
+		batch.Add("POST", "/v2/SampleService.Fail4XX", &testext.SampleRequest{Text: "Abide"}, responses[2])
+		batch.Add("GET", "/v2/download", &testext.SampleDownloadRequest{Format: "text/csv"}, responses[3])
+		suite.Equal(4, batch.Len())
+		errs := batch.Run(context.Background())
+		return roundTrips.Load(), errs, responses
+	}
+
+	for _, batching := range []bool{true, false} {
+		roundTrips, errs, responses := run(batching)
+		if batching {
+			suite.Equal(int64(1), roundTrips, "Batching should only make a single round trip")
+		} else {
+			suite.Equal(int64(5), roundTrips, "Fallback should make one attempt at batching and then one call each")
+		}
+
+		suite.Require().Len(errs, 4)
+		suite.NoError(errs[0])
+		suite.Equal("123", responses[0].(*testext.SampleResponse).ID)
+		suite.Equal("Route:Abide", responses[0].(*testext.SampleResponse).Text)
+
+		suite.NoError(errs[1])
+		suite.Equal("abide", responses[1].(*testext.SampleResponse).Text)
+
+		suite.Error(errs[2])
+		suite.Equal(409, fail.Status(errs[2]))
+
+		suite.NoError(errs[3])
+		suite.Equal("text/csv", responses[3].(*testext.SampleDownloadResponse).ContentType())
+		suite.Equal("dude.csv", responses[3].(*testext.SampleDownloadResponse).ContentFileName())
+		suite.Equal("ID,Name,Enabled\n1,Dude,true\n2,Walter,false", suite.streamContent(&responses[3].(*testext.SampleDownloadResponse).StreamResponse))
+	}
+}
+
 func (suite *ServerSuite) TestStreamedResponse() {
 	server, _, shutdown := suite.start()
 	defer shutdown()
@@ -165,6 +356,37 @@ func (suite *ServerSuite) TestResumableStreamedResponse() {
 	suite.Equal(50, start)
 	suite.Equal(74, end)
 	suite.Equal(1024, size)
+
+	// The gateway responds w/ a 206 Partial Content, which the client should treat like any other success.
+	res, err = suite.client.DownloadResumable(context.Background(), &testext.SampleDownloadRequest{Format: "text/plain"})
+	suite.Require().NoError(err)
+	stream = suite.responseStream(res)
+	suite.Equal("<h1>The Dude Abides</h1>", suite.streamContent(stream))
+	start, end, size = stream.ContentRange()
+	suite.Equal(50, start)
+	suite.Equal(74, end)
+	suite.Equal(1024, size)
+}
+
+func (suite *ServerSuite) TestDownloadToFile() {
+	_, _, shutdown := suite.start()
+	defer shutdown()
+
+	res, err := suite.client.Download(context.Background(), &testext.SampleDownloadRequest{Format: "text/plain"})
+	suite.Require().NoError(err)
+
+	var written, total int64
+	path := filepath.Join(suite.T().TempDir(), "dude.txt")
+	err = clients.DownloadToFile(context.Background(), res, path, func(w int64, t int64) {
+		written, total = w, t
+	})
+	suite.Require().NoError(err)
+
+	data, err := os.ReadFile(path)
+	suite.Require().NoError(err)
+	suite.Equal("Donny, you're out of your element!", string(data))
+	suite.Equal(int64(34), written)
+	suite.Equal(int64(34), total)
 }
 
 // Ensure that the service still manages an endpoint even if it's not exposed by any gateway.
@@ -200,6 +422,141 @@ func (suite *ServerSuite) TestEvents() {
 	suite.assertInvoked(calls, []string{})
 }
 
+// Ensure that metadata values set by the original HTTP caller are still available to the
+// event handlers triggered by that call, even though the triggering method never read them.
+func (suite *ServerSuite) TestEvents_metadataValues() {
+	_, calls, shutdown := suite.start()
+	defer shutdown()
+
+	calls.Reset()
+	ctx := metadata.WithValue(context.Background(), "Tenant", "42")
+	_, err := suite.client.TriggerLowerCase(ctx, &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.assertInvoked(calls, []string{
+		"TriggerLowerCase:Abide",
+		"ListenerB:abide",
+		"ListenerB.Tenant:42",
+	})
+}
+
+// Ensure that the generated publisher triggers the same subscribers as actually invoking the function, even
+// though the function itself never runs and there's no API gateway involved.
+func (suite *ServerSuite) TestEvents_publisher() {
+	broker := local.Broker()
+	sequence := &testext.Sequence{}
+	server := services.NewServer(
+		services.Listen(events.NewGateway(events.WithBroker(broker))),
+		services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: sequence})),
+	)
+	_, shutdown := servicetest.Start(suite.T(), server)
+	defer shutdown()
+
+	ctx := metadata.WithValue(context.Background(), "Tenant", "42")
+	publisher := gen.NewSampleServicePublisher(broker)
+	err := publisher.PublishTriggerUpperCase(ctx, &testext.SampleResponse{Text: "ABIDE"})
+	suite.Require().NoError(err)
+	suite.assertInvoked(sequence, []string{
+		"ListenerA:ABIDE",
+		"ListenerB:ABIDE",
+		"ListenerB.Tenant:42",
+		"ListenerB:ListenerA:ABIDE",
+		"ListenerB.Tenant:42",
+	})
+}
+
+// Ensure that handlers can subscribe to free-form keys published by systems outside of Frodo. Those payloads
+// aren't wrapped in our event envelope, so the raw JSON should be bound directly to the handler's request.
+func (suite *ServerSuite) TestEvents_externalKey() {
+	broker := local.Broker()
+	sequence := &testext.Sequence{}
+	server := services.NewServer(
+		services.Listen(events.NewGateway(events.WithBroker(broker))),
+		services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: sequence})),
+	)
+	_, shutdown := servicetest.Start(suite.T(), server)
+	defer shutdown()
+
+	err := broker.Publish(context.Background(), "payment.succeeded", []byte(`{"Text":"Paid", "Amount":42}`))
+	suite.Require().NoError(err)
+	suite.assertInvoked(sequence, []string{
+		"ListenerB:Paid",
+	})
+}
+
+// Ensure that custom key naming is applied to both the keys we publish to and the keys that "ON" handlers subscribe
+// to, so event chains still work. External keys like "payment.succeeded" should not be touched.
+func (suite *ServerSuite) TestEvents_keyNaming() {
+	naming := func(serviceName, methodName string) string {
+		return strings.ToLower(serviceName + "_" + methodName)
+	}
+
+	broker := local.Broker()
+	sequence := &testext.Sequence{}
+	server := services.NewServer(
+		services.Listen(events.NewGateway(events.WithBroker(broker), events.WithKeyNaming(naming))),
+		services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: sequence})),
+	)
+	_, shutdown := servicetest.Start(suite.T(), server)
+	defer shutdown()
+
+	keys := &testext.Sequence{}
+	subs, err := broker.Subscribe(context.Background(), "sampleservice_triggeruppercase", func(ctx context.Context, msg *eventsource.EventMessage) error {
+		keys.Append(msg.Key)
+		return nil
+	})
+	suite.Require().NoError(err)
+	defer func() { _ = subs.Close() }()
+
+	_, err = server.Invoke(context.Background(), "SampleService", "TriggerUpperCase", &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.assertInvoked(sequence, []string{
+		"TriggerUpperCase:Abide",
+		"ListenerA:ABIDE",
+		"ListenerB:ABIDE",
+		"ListenerB:ListenerA:ABIDE",
+	})
+	suite.Equal([]string{"sampleservice_triggeruppercase"}, keys.Values())
+
+	sequence.Reset()
+	publisher := gen.NewSampleServicePublisher(broker, events.WithPublisherKeyNaming(naming))
+	err = publisher.PublishTriggerLowerCase(context.Background(), &testext.SampleResponse{Text: "abide"})
+	suite.Require().NoError(err)
+	suite.assertInvoked(sequence, []string{
+		"ListenerB:abide",
+	})
+
+	sequence.Reset()
+	err = broker.Publish(context.Background(), "payment.succeeded", []byte(`{"Text":"Paid"}`))
+	suite.Require().NoError(err)
+	suite.assertInvoked(sequence, []string{
+		"ListenerB:Paid",
+	})
+}
+
+// Ensure that routes excluded by the publish filter don't publish their events, but can still subscribe to others.
+func (suite *ServerSuite) TestEvents_publishFilter() {
+	filter := func(route metadata.EndpointRoute) bool {
+		return route.Name != "ListenerA"
+	}
+
+	sequence := &testext.Sequence{}
+	server := services.NewServer(
+		services.Listen(events.NewGateway(events.WithPublishFilter(filter))),
+		services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: sequence})),
+	)
+	_, shutdown := servicetest.Start(suite.T(), server)
+	defer shutdown()
+
+	_, err := server.Invoke(context.Background(), "SampleService", "TriggerUpperCase", &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.assertInvoked(sequence, []string{
+		"TriggerUpperCase:Abide",
+		"ListenerA:ABIDE",
+		"ListenerB:ABIDE",
+		// No "ListenerB:ListenerA:ABIDE" because ListenerA no longer publishes.
+	})
+}
+
 // Ensure that ServiceA is able to listen to events from ServiceB and that ServiceB
 // can listen to events from ServiceA as well. As a side effect, this one also
 // makes sure that event triggers and cascade and cause others to trigger.
@@ -330,6 +687,43 @@ func (suite *ServerSuite) TestHttpMiddlewareFireOnce() {
 
 // Ensures that you can invoke a method which triggers the event gateway to run another method when some other
 // service method FAILS (i.e. returns a non-nil error).
+// Ensure that a synchronous chain runs every subscriber before the original call returns, so we don't need
+// to sleep, and that subscriber failures are returned to the original caller.
+func (suite *ServerSuite) TestEventChain_synchronous() {
+	sequence := &testext.Sequence{}
+	server := services.NewServer(
+		services.Listen(events.NewGateway(events.WithSynchronousChain())),
+		services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: sequence})),
+		services.Register(gen.OtherServiceServer(testext.OtherServiceHandler{Sequence: sequence})),
+	)
+	_, shutdown := servicetest.Start(suite.T(), server)
+	defer shutdown()
+
+	res, err := server.Invoke(context.Background(), "SampleService", "TriggerUpperCase", &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.Equal("ABIDE", suite.responseText(res))
+	suite.ElementsMatch(sequence.Values(), []string{
+		"TriggerUpperCase:Abide",
+		"ListenerA:ABIDE",
+		"ListenerB:ABIDE",
+		"ListenerB:ListenerA:ABIDE",
+		"ListenWell:ABIDE",
+	})
+
+	// ChainFail runs when ChainOne succeeds, and its failure should make it back to us.
+	sequence.Reset()
+	res, err = server.Invoke(context.Background(), "OtherService", "ChainOne", &testext.OtherRequest{Text: "Abide"})
+	suite.Require().Error(err)
+	suite.Equal("ChainOne:Abide", suite.responseText(res))
+	suite.ElementsMatch(sequence.Values(), []string{
+		"ChainOne:Abide",
+		"ChainTwo:ChainOne:Abide",
+		"ChainFail:ChainOne:Abide",
+		"ChainThree:ChainTwo:ChainOne:Abide",
+		"ChainFour:ChainTwo:ChainOne:Abide",
+	})
+}
+
 func (suite *ServerSuite) TestEventErrorChain() {
 	server, calls, shutdown := suite.start()
 	defer shutdown()
@@ -0,0 +1,92 @@
+// Package servicetest provides utilities for testing your services' event-driven behavior without standing up
+// an API gateway or a real broker.
+package servicetest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/eventsource/local"
+	"github.com/bridgekit-io/frodo/metadata"
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/bridgekit-io/frodo/services/gateways/events"
+)
+
+// NewHarness runs the services you register in an in-memory server, so you can test how your "ON Service.Method"
+// handlers cascade without HTTP or sleeping. Every event is handled synchronously (see events.WithSynchronousChain()),
+// so the entire chain has finished by the time Invoke() returns. Subscribers always fire in the same order, too,
+// so you can assert the exact sequence of calls (except for "GROUP *" subscribers, which each get a random group
+// and can fire in any order). The harness shuts itself down when the test finishes.
+//
+//	harness := servicetest.NewHarness(t,
+//		services.Register(gen.OrderServiceServer(orderHandler)),
+//		services.Register(gen.EmailServiceServer(emailHandler)),
+//	)
+//	_, err := harness.Invoke(ctx, "OrderService", "PlaceOrder", &orders.PlaceOrderRequest{...})
+//	assert.Equal(t, []string{"OrderService.PlaceOrder", "EmailService.SendConfirmation"}, harness.Invoked())
+//
+// You can pass any other server options you like, but don't include your own gateways; the harness supplies
+// the event gateway itself.
+func NewHarness(t testing.TB, options ...services.ServerOption) *Harness {
+	t.Helper()
+
+	harness := &Harness{}
+	broker := local.Broker(local.WithSynchronousDispatch())
+
+	options = append(options,
+		services.Listen(events.NewGateway(events.WithBroker(broker), events.WithSynchronousChain())),
+		services.WithMiddleware(harness.record),
+	)
+	harness.server = services.NewServer(options...)
+
+	// We can't invoke anything until the gateway has subscribed to every "ON" route, otherwise the
+	// first few events might go nowhere. Start() waits for exactly that.
+	Start(t, harness.server)
+	return harness
+}
+
+// Harness is an in-memory server that runs your event chains synchronously. Use NewHarness() to create one.
+type Harness struct {
+	server  *services.Server
+	mutex   sync.Mutex
+	invoked []string
+}
+
+// Invoke calls the service function just like an API call would, and runs all of the event handlers that it
+// triggers before returning. Any errors from those handlers are returned along with the original response.
+func (harness *Harness) Invoke(ctx context.Context, serviceName string, functionName string, req any) (any, error) {
+	return harness.server.Invoke(ctx, serviceName, functionName, req)
+}
+
+// Invoked returns the "Service.Function" name of every function invoked since the harness was created (or
+// the last call to Reset()), in the order that they were invoked.
+func (harness *Harness) Invoked() []string {
+	harness.mutex.Lock()
+	defer harness.mutex.Unlock()
+
+	return append([]string{}, harness.invoked...)
+}
+
+// Reset clears the list of invoked functions, so you can make another call and check just its cascade.
+func (harness *Harness) Reset() {
+	harness.mutex.Lock()
+	defer harness.mutex.Unlock()
+
+	harness.invoked = nil
+}
+
+// Server returns the underlying server that runs your services.
+func (harness *Harness) Server() *services.Server {
+	return harness.server
+}
+
+// record is the middleware that tracks every function invocation. We record the call before it runs, so a
+// function always appears before the handlers that its event triggers.
+func (harness *Harness) record(ctx context.Context, req any, next services.HandlerFunc) (any, error) {
+	harness.mutex.Lock()
+	harness.invoked = append(harness.invoked, metadata.Route(ctx).QualifiedName())
+	harness.mutex.Unlock()
+
+	return next(ctx, req)
+}
@@ -0,0 +1,79 @@
+//go:build unit
+
+package servicetest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/internal/testext"
+	gen "github.com/bridgekit-io/frodo/internal/testext/gen"
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/bridgekit-io/frodo/services/servicetest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestHarnessSuite(t *testing.T) {
+	suite.Run(t, new(HarnessSuite))
+}
+
+type HarnessSuite struct {
+	suite.Suite
+}
+
+func (suite *HarnessSuite) harness() (*servicetest.Harness, *testext.Sequence) {
+	sequence := &testext.Sequence{}
+	harness := servicetest.NewHarness(suite.T(),
+		services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: sequence})),
+		services.Register(gen.OtherServiceServer(testext.OtherServiceHandler{Sequence: sequence})),
+	)
+	return harness, sequence
+}
+
+func (suite *HarnessSuite) TestInvoke() {
+	harness, sequence := suite.harness()
+
+	_, err := harness.Invoke(context.Background(), "SampleService", "TriggerUpperCase", &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+
+	// No sleeping, and the order should be exactly the same every time.
+	suite.Equal([]string{
+		"SampleService.TriggerUpperCase",
+		"OtherService.ListenWell",
+		"SampleService.ListenerA",
+		"SampleService.ListenerB",
+		"SampleService.ListenerB",
+	}, harness.Invoked())
+	suite.Equal([]string{
+		"TriggerUpperCase:Abide",
+		"ListenWell:ABIDE",
+		"ListenerA:ABIDE",
+		"ListenerB:ListenerA:ABIDE",
+		"ListenerB:ABIDE",
+	}, sequence.Values())
+}
+
+func (suite *HarnessSuite) TestInvoke_chainFailure() {
+	harness, _ := suite.harness()
+
+	_, err := harness.Invoke(context.Background(), "OtherService", "ChainOne", &testext.OtherRequest{Text: "Abide"})
+	suite.Error(err, "ChainFail's error should make it back to the caller")
+	suite.Equal([]string{
+		"OtherService.ChainOne",
+		"OtherService.ChainFail",
+		"OtherService.ChainTwo",
+		"OtherService.ChainFour",
+		"OtherService.ChainThree",
+	}, harness.Invoked())
+}
+
+func (suite *HarnessSuite) TestReset() {
+	harness, _ := suite.harness()
+
+	_, err := harness.Invoke(context.Background(), "SampleService", "TriggerLowerCase", &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.Equal([]string{"SampleService.TriggerLowerCase", "SampleService.ListenerB"}, harness.Invoked())
+
+	harness.Reset()
+	suite.Empty(harness.Invoked())
+}
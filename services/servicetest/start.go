@@ -0,0 +1,79 @@
+package servicetest
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/bridgekit-io/frodo/services/gateways/apis"
+)
+
+// Start runs the server in the background and blocks until all of its gateways are actually accepting requests,
+// so your tests don't need to sleep and hope that the server is up. It returns the address that the API gateway
+// is listening on (empty if there isn't one) and a function that shuts the server down. Create the API gateway
+// w/ a port of 0 to have the OS pick a free port, and you'll never have to worry about tests fighting for one:
+//
+//	server := services.NewServer(
+//		services.Listen(apis.NewGateway("localhost:0")),
+//		services.Register(gen.CalculatorServiceServer(calcHandler)),
+//	)
+//	address, shutdown := servicetest.Start(t, server)
+//	defer shutdown()
+//
+//	client := gen.CalculatorServiceClient(address)
+//
+// The test fails immediately if any gateway can't start or if they take more than 5 seconds to do so. You don't
+// technically need to call shutdown(), since Start() also shuts the server down when the test finishes, but it
+// lets you stop the server sooner. It's safe to call more than once.
+func Start(t testing.TB, server *services.Server) (string, func()) {
+	t.Helper()
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- server.Run(context.Background()) }()
+
+	once := sync.Once{}
+	shutdown := func() {
+		once.Do(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = server.Shutdown(ctx)
+		})
+	}
+	t.Cleanup(shutdown)
+
+	select {
+	case <-server.Listening():
+	case err := <-runErr:
+		once.Do(func() {}) // Run() already cleaned up after itself, so there's nothing to shut down.
+		t.Fatalf("servicetest: unable to start server: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("servicetest: timed out waiting for gateways to start listening")
+	}
+	return apiAddress(server), shutdown
+}
+
+// apiAddress returns the address that the server's API gateway is actually listening on. When it's bound to all
+// interfaces (e.g. ":0"), we use "localhost" instead, since that's what a client in your test can connect to.
+func apiAddress(server *services.Server) string {
+	gw, ok := server.Gateway(services.GatewayTypeAPI)
+	if !ok {
+		return ""
+	}
+	apiGateway, ok := gw.(*apis.Gateway)
+	if !ok {
+		return ""
+	}
+
+	address := apiGateway.Addr()
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		return net.JoinHostPort("localhost", port)
+	}
+	return address
+}
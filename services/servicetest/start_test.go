@@ -0,0 +1,86 @@
+//go:build unit
+
+package servicetest_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bridgekit-io/frodo/internal/testext"
+	gen "github.com/bridgekit-io/frodo/internal/testext/gen"
+	"github.com/bridgekit-io/frodo/services"
+	"github.com/bridgekit-io/frodo/services/gateways/apis"
+	"github.com/bridgekit-io/frodo/services/gateways/events"
+	"github.com/bridgekit-io/frodo/services/servicetest"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestStartSuite(t *testing.T) {
+	suite.Run(t, new(StartSuite))
+}
+
+type StartSuite struct {
+	suite.Suite
+}
+
+func (suite *StartSuite) server(address string) *services.Server {
+	return services.NewServer(
+		services.Listen(apis.NewGateway(address)),
+		services.Listen(events.NewGateway()),
+		services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: &testext.Sequence{}})),
+	)
+}
+
+// Ensure that we can call the service as soon as Start() returns, using whatever port the OS picked.
+func (suite *StartSuite) TestStart() {
+	address, shutdown := servicetest.Start(suite.T(), suite.server("localhost:0"))
+	defer shutdown()
+
+	suite.True(strings.HasPrefix(address, "127.0.0.1:") || strings.HasPrefix(address, "[::1]:"), "Address should be the loopback address: %s", address)
+	suite.NotEqual("127.0.0.1:0", address)
+
+	res, err := gen.SampleServiceClient(address).TriggerLowerCase(context.Background(), &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.Equal("abide", res.Text)
+}
+
+// Ensure that gateways bound to all interfaces give us an address that a client can actually connect to.
+func (suite *StartSuite) TestStart_allInterfaces() {
+	address, shutdown := servicetest.Start(suite.T(), suite.server(":0"))
+	defer shutdown()
+
+	suite.True(strings.HasPrefix(address, "localhost:"), "Address should use localhost: %s", address)
+
+	res, err := gen.SampleServiceClient(address).TriggerLowerCase(context.Background(), &testext.SampleRequest{Text: "Abide"})
+	suite.Require().NoError(err)
+	suite.Equal("abide", res.Text)
+}
+
+// Ensure that the shutdown function stops the server and doesn't blow up when the cleanup calls it again.
+func (suite *StartSuite) TestStart_shutdown() {
+	address, shutdown := servicetest.Start(suite.T(), suite.server("localhost:0"))
+	shutdown()
+	shutdown()
+
+	_, err := http.Get("http://" + address + "/")
+	suite.Error(err, "Server should no longer accept connections")
+}
+
+// Ensure that servers without an API gateway still wait for the other gateways, but don't have an address.
+func (suite *StartSuite) TestStart_noAPIGateway() {
+	server := services.NewServer(
+		services.Listen(events.NewGateway()),
+		services.Register(gen.SampleServiceServer(testext.SampleServiceHandler{Sequence: &testext.Sequence{}})),
+	)
+	address, shutdown := servicetest.Start(suite.T(), server)
+	defer shutdown()
+
+	suite.Equal("", address)
+	select {
+	case <-server.Listening():
+	default:
+		suite.Fail("Server should be listening once Start() returns")
+	}
+}
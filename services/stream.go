@@ -2,6 +2,7 @@ package services
 
 import (
 	"io"
+	"net/http"
 )
 
 // ContentGetter provides a way for your service response to indicate that you want to return a
@@ -75,6 +76,32 @@ type ContentFileNameSetter interface {
 	SetContentFileName(string)
 }
 
+// ContentHeadersGetter lets raw response streams include extra headers such as Cache-Control or Last-Modified.
+// The gateway merges these into the response after it has written the standard Content-Type, Content-Length,
+// etc. headers, so only the headers you explicitly include are affected. If you include one of those standard
+// headers, though, your value wins.
+type ContentHeadersGetter interface {
+	// ContentHeaders returns the additional headers to include when responding w/ the raw content stream.
+	ContentHeaders() http.Header
+}
+
+// ContentStatusGetter lets raw response streams pick the HTTP status code of the response themselves. Normally, the
+// gateway uses the route's status (see the HTTP doc option), or 206 Partial Content when the stream includes a valid
+// content range. Returning a non-zero value overrides both of those.
+type ContentStatusGetter interface {
+	// ContentStatus returns the HTTP status code to respond with, or 0 to let the gateway decide.
+	ContentStatus() int
+}
+
+// ContentTypeSniffer lets raw response streams opt into having the gateway detect the Content-Type when you don't
+// supply one via ContentTypeGetter. This is handy when re-serving arbitrary user uploads where you don't know what
+// the data is. The gateway buffers the first 512 bytes of the stream to run them through http.DetectContentType()
+// before writing any headers, so the client won't receive the first bytes until those are read (or the stream ends).
+type ContentTypeSniffer interface {
+	// SniffContentType returns true when the gateway should detect the Content-Type of the stream.
+	SniffContentType() bool
+}
+
 // StreamRequest implements all of the ContentXxx and SetContentXxx methods that we support and look
 // at when we look at streaming/upload style requests.
 //
@@ -114,6 +141,9 @@ type StreamResponse struct {
 	contentRangeEnd   int
 	contentRangeSize  int
 	contentFileName   string
+	contentHeaders    http.Header
+	contentStatus     int
+	sniffContentType  bool
 }
 
 // Content returns the raw byte stream representing the data returned by the endpoint.
@@ -168,6 +198,42 @@ func (res *StreamResponse) SetContentFileName(contentFileName string) {
 	res.contentFileName = contentFileName
 }
 
+// ContentHeaders returns any extra headers that should be included when responding with the content stream.
+func (res *StreamResponse) ContentHeaders() http.Header {
+	return res.contentHeaders
+}
+
+// SetContentHeader adds an extra header (e.g. "Cache-Control") to include when responding with the content
+// stream. Setting the same header again replaces the previous value.
+func (res *StreamResponse) SetContentHeader(name string, value string) {
+	if res.contentHeaders == nil {
+		res.contentHeaders = http.Header{}
+	}
+	res.contentHeaders.Set(name, value)
+}
+
+// ContentStatus returns the HTTP status code that the gateway should respond with, or 0 to let it decide.
+func (res *StreamResponse) ContentStatus() int {
+	return res.contentStatus
+}
+
+// SetContentStatus overrides the HTTP status code that the gateway responds with. By default, it uses the route's
+// status, or 206 Partial Content when you've supplied a content range. Set this to 0 to go back to the default.
+func (res *StreamResponse) SetContentStatus(status int) {
+	res.contentStatus = status
+}
+
+// SniffContentType returns true if the gateway should detect the content type when you haven't set one explicitly.
+func (res *StreamResponse) SniffContentType() bool {
+	return res.sniffContentType
+}
+
+// SetSniffContentType controls whether the gateway should detect the content type by looking at the first 512
+// bytes of the stream. This only applies when you haven't supplied a content type using SetContentType().
+func (res *StreamResponse) SetSniffContentType(sniff bool) {
+	res.sniffContentType = sniff
+}
+
 // Redirector provides a way to tell gateways that the response value doesn't contain the
 // raw byte stream we want to deliver. Instead, you should redirect to that URI to fetch
 // the response data.
@@ -204,3 +270,42 @@ type RedirectorPermanent interface {
 	// we want this endpoint to return.
 	RedirectPermanent() string
 }
+
+// NoContentResponder lets your response value tell gateways that there's nothing meaningful to send back to the
+// caller. The API gateway will respond with a "204 No Content" without a body or Content-Type rather than
+// encoding your (probably empty) struct. The gateway does the same thing when your method returns a nil response.
+//
+// GATEWAY COMPATABILITY: This currently only works with the API gateway. When delivering/receiving
+// responses through other gateways such as "Events", your response will be auto-encoded just
+// like it was a normal struct/value.
+type NoContentResponder interface {
+	// NoContent returns true when the gateway should respond w/ a 204 rather than encoding the response.
+	NoContent() bool
+}
+
+// StatusGetter lets your response pick its HTTP success status at runtime rather than always using the route's
+// status from the "HTTP" doc option (e.g. 201 when you created something, but 200 when it already existed). This
+// applies to normal encoded responses and streams alike. Returning 0 lets the gateway use the route's status as usual:
+//
+//	type SaveFileResponse struct {
+//		services.StreamResponse
+//		created bool
+//	}
+//
+//	func (res SaveFileResponse) Status() int {
+//		if res.created {
+//			return http.StatusCreated
+//		}
+//		return 0
+//	}
+//
+// Redirects and "204 No Content" responses always use their own status codes, and a raw stream's ContentStatus()
+// wins over this since it's the more specific of the two. Values that aren't valid HTTP status codes are ignored.
+//
+// GATEWAY COMPATABILITY: This currently only works with the API gateway. When delivering/receiving
+// responses through other gateways such as "Events", your response will be auto-encoded just
+// like it was a normal struct/value.
+type StatusGetter interface {
+	// Status returns the HTTP status code to respond with, or 0 to use the route's status.
+	Status() int
+}
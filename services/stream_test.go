@@ -152,6 +152,44 @@ func TestStreamResponse_RangedWithLength(t *testing.T) {
 	assert.Equal(2048, size)
 }
 
+func TestStreamResponse_ContentHeaders(t *testing.T) {
+	assert := require.New(t)
+	stream := services.StreamResponse{}
+	assert.Nil(stream.ContentHeaders())
+
+	stream.SetContentHeader("Cache-Control", "no-cache")
+	stream.SetContentHeader("Last-Modified", "Wed, 11 Nov 2020 12:00:00 GMT")
+	assert.Equal("no-cache", stream.ContentHeaders().Get("Cache-Control"))
+	assert.Equal("Wed, 11 Nov 2020 12:00:00 GMT", stream.ContentHeaders().Get("Last-Modified"))
+
+	stream.SetContentHeader("Cache-Control", "max-age=60")
+	assert.Equal([]string{"max-age=60"}, stream.ContentHeaders().Values("Cache-Control"))
+}
+
+func TestStreamResponse_SniffContentType(t *testing.T) {
+	assert := require.New(t)
+	stream := services.StreamResponse{}
+	assert.False(stream.SniffContentType())
+
+	stream.SetSniffContentType(true)
+	assert.True(stream.SniffContentType())
+
+	stream.SetSniffContentType(false)
+	assert.False(stream.SniffContentType())
+}
+
+func TestStreamResponse_ContentStatus(t *testing.T) {
+	assert := require.New(t)
+	stream := services.StreamResponse{}
+	assert.Equal(0, stream.ContentStatus())
+
+	stream.SetContentStatus(206)
+	assert.Equal(206, stream.ContentStatus())
+
+	stream.SetContentStatus(0)
+	assert.Equal(0, stream.ContentStatus())
+}
+
 func newTextStream(value string) io.ReadCloser {
 	return io.NopCloser(bytes.NewBufferString(value))
 }